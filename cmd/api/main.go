@@ -3,21 +3,35 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"anthology/internal/archive"
 	"anthology/internal/auth"
+	"anthology/internal/batches"
 	"anthology/internal/catalog"
 	"anthology/internal/config"
+	"anthology/internal/goals"
 	transporthttp "anthology/internal/http"
+	"anthology/internal/importer"
+	"anthology/internal/imports"
 	"anthology/internal/items"
+	"anthology/internal/loans"
+	"anthology/internal/maintenance"
+	"anthology/internal/mappings"
 	"anthology/internal/platform/database"
 	"anthology/internal/platform/logging"
 	"anthology/internal/platform/migrate"
+	"anthology/internal/queue"
+	"anthology/internal/reading"
+	"anthology/internal/reviews"
+	"anthology/internal/sharing"
 	"anthology/internal/shelves"
+	"anthology/internal/stats"
 )
 
 func main() {
@@ -53,30 +67,119 @@ func main() {
 	// Initialize repositories
 	itemRepo := items.NewPostgresRepository(db)
 	shelfRepo := shelves.NewPostgresRepository(db)
+	loanRepo := loans.NewPostgresRepository(db)
+	readingRepo := reading.NewPostgresRepository(db)
+	statsRepo := stats.NewPostgresRepository(db)
+	goalRepo := goals.NewPostgresRepository(db)
+	queueRepo := queue.NewPostgresRepository(db)
+	reviewRepo := reviews.NewPostgresRepository(db)
+	importJobRepo := imports.NewPostgresRepository(db)
+	mappingRepo := mappings.NewPostgresRepository(db)
+	batchRepo := batches.NewPostgresRepository(db)
+	maintenanceRepo := maintenance.NewPostgresRepository(db)
+	sharingRepo := sharing.NewPostgresRepository(db)
 
 	// Initialize auth (always required)
 	authRepo := auth.NewPostgresRepository(db)
-	authService := auth.NewService(authRepo, 12*time.Hour)
-
-	googleAuth, err := auth.NewGoogleAuthenticator(
-		ctx,
-		cfg.GoogleClientID,
-		cfg.GoogleClientSecret,
-		cfg.GoogleRedirectURL,
-		cfg.GoogleAllowedDomains,
-		cfg.GoogleAllowedEmails,
+	authService := auth.NewService(authRepo, cfg.SessionIdleTimeout, auth.WithSessionMaxLifetime(cfg.SessionMaxLifetime))
+
+	var identityProviders []*auth.OIDCAuthenticator
+	if cfg.GoogleEnabled() {
+		googleAuth, err := auth.NewGoogleAuthenticator(
+			ctx,
+			cfg.GoogleClientID,
+			cfg.GoogleClientSecret,
+			cfg.GoogleRedirectURL,
+			cfg.GoogleAllowedDomains,
+			cfg.GoogleAllowedEmails,
+		)
+		if err != nil {
+			logger.Error("failed to initialize Google OAuth", "error", err)
+			os.Exit(1)
+		}
+		identityProviders = append(identityProviders, googleAuth)
+		logger.Info("Google OAuth enabled", "redirect_url", cfg.GoogleRedirectURL)
+	}
+	if cfg.OIDCEnabled() {
+		oidcAuth, err := auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{
+			Name:           cfg.OIDCName,
+			IssuerURL:      cfg.OIDCIssuerURL,
+			ClientID:       cfg.OIDCClientID,
+			ClientSecret:   cfg.OIDCClientSecret,
+			RedirectURL:    cfg.OIDCRedirectURL,
+			AllowedDomains: cfg.OIDCAllowedDomains,
+			AllowedEmails:  cfg.OIDCAllowedEmails,
+		})
+		if err != nil {
+			logger.Error("failed to initialize OIDC provider", "provider", cfg.OIDCName, "error", err)
+			os.Exit(1)
+		}
+		identityProviders = append(identityProviders, oidcAuth)
+		logger.Info("OIDC provider enabled", "provider", cfg.OIDCName, "issuer", cfg.OIDCIssuerURL, "redirect_url", cfg.OIDCRedirectURL)
+	}
+
+	queueSvc := queue.NewService(queueRepo, itemRepo)
+	reviewSvc := reviews.NewService(reviewRepo, itemRepo)
+	sharingSvc := sharing.NewService(sharingRepo, shelfRepo)
+	svc := items.NewService(itemRepo, items.WithDeleteHook(queueSvc), items.WithDeleteHook(reviewSvc), items.WithGrantSource(sharingSvc), items.WithLogger(logger))
+	lookupClient := &http.Client{Timeout: 12 * time.Second}
+	catalogOpts := []catalog.Option{
+		catalog.WithGoogleBooksAPIKey(cfg.GoogleBooksAPIKey),
+		catalog.WithIGDBCredentials(cfg.IGDBClientID, cfg.IGDBAccessToken),
+		catalog.WithTMDBAPIKey(cfg.TMDBAPIKey),
+		catalog.WithOpenLibraryMerge(),
+		catalog.WithCache(catalog.NewPostgresCache(db), cfg.CatalogCacheTTL, cfg.CatalogNegativeCacheTTL),
+	}
+	for provider, override := range cfg.CatalogRateLimits {
+		limit, err := catalogRateLimit(provider, override)
+		if err != nil {
+			logger.Error("invalid catalog rate limit", "error", err)
+			os.Exit(1)
+		}
+		catalogOpts = append(catalogOpts, catalog.WithRateLimit(provider, limit))
+	}
+	catalogSvc := catalog.NewService(lookupClient, catalogOpts...)
+	if cfg.IGDBClientID == "" {
+		logger.Info("IGDB credentials not configured; game lookups disabled")
+	}
+	if cfg.TMDBAPIKey == "" {
+		logger.Info("TMDB API key not configured; movie lookups disabled")
+	}
+	shelfSvc := shelves.NewService(shelfRepo, itemRepo, catalogSvc, svc, shelves.WithGrantSource(sharingSvc))
+	archiveSvc := archive.NewService(svc, itemRepo, shelfSvc, archive.WithTransactor(database.NewTransactor(db)))
+	loanSvc := loans.NewService(loanRepo, itemRepo)
+	readingSvc := reading.NewService(readingRepo, itemRepo)
+	statsSvc := stats.NewService(statsRepo)
+	goalSvc := goals.NewService(goalRepo, itemRepo, readingRepo)
+	mappingSvc := mappings.NewService(mappingRepo)
+	batchSvc := batches.NewService(batchRepo, svc,
+		batches.WithUsageCheck(batches.KeepReviewed, reviewSvc),
+		batches.WithUsageCheck(batches.KeepShelved, shelfSvc),
+		batches.WithUsageCheck(batches.KeepReadingLogged, readingSvc),
+		batches.WithUsageCheck(batches.KeepQueued, queueSvc),
 	)
-	if err != nil {
-		logger.Error("failed to initialize Google OAuth", "error", err)
+	// One importer serves uploads, previews, and jobs, so previews share one bounded store.
+	bulkImporter := importer.NewCSVImporter(svc, catalogSvc, importer.WithReviewStore(reviewSvc), importer.WithMappingStore(mappingSvc), importer.WithBatchRecorder(batchSvc))
+	importSvc := imports.NewService(importJobRepo, bulkImporter, logger)
+	if err := importSvc.Recover(ctx); err != nil {
+		logger.Error("failed to recover import jobs", "error", err)
 		os.Exit(1)
 	}
-	logger.Info("Google OAuth enabled", "redirect_url", cfg.GoogleRedirectURL)
 
-	svc := items.NewService(itemRepo)
-	lookupClient := &http.Client{Timeout: 12 * time.Second}
-	catalogSvc := catalog.NewService(lookupClient, catalog.WithGoogleBooksAPIKey(cfg.GoogleBooksAPIKey))
-	shelfSvc := shelves.NewService(shelfRepo, itemRepo, catalogSvc, svc)
-	router := transporthttp.NewRouter(cfg, svc, catalogSvc, shelfSvc, authService, googleAuth, logger)
+	scheduler := maintenance.NewScheduler(maintenanceRepo, logger)
+	for _, job := range []maintenance.Job{
+		{Name: "expired-sessions", Interval: cfg.SessionCleanupInterval, Run: authService.CleanupExpiredSessions},
+		{Name: "catalog-cache", Interval: cfg.CachePurgeInterval, Run: catalogSvc.PurgeExpiredCache},
+		{Name: "orphaned-placements", Interval: cfg.PlacementCleanupInterval, Run: shelfSvc.CleanupOrphanedPlacements},
+	} {
+		if err := scheduler.Register(job); err != nil {
+			logger.Error("failed to register maintenance job", "job", job.Name, "error", err)
+			os.Exit(1)
+		}
+	}
+	scheduler.Start(ctx)
+
+	router := transporthttp.NewRouter(cfg, svc, catalogSvc, shelfSvc, loanSvc, readingSvc, statsSvc, goalSvc, queueSvc, reviewSvc, archiveSvc, bulkImporter, importSvc, mappingSvc, batchSvc, sharingSvc, scheduler, authService, identityProviders, logger)
 
 	srv := &http.Server{
 		Addr:              cfg.HTTPAddress(),
@@ -104,4 +207,27 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("graceful shutdown failed", "error", err)
 	}
+	if err := importSvc.Shutdown(shutdownCtx); err != nil {
+		logger.Error("import jobs did not stop in time", "error", err)
+	}
+	if err := scheduler.Wait(shutdownCtx); err != nil {
+		logger.Error("maintenance jobs did not stop in time", "error", err)
+	}
+}
+
+// catalogRateLimit applies a configured override to the provider's default
+// throttling and retry policy.
+func catalogRateLimit(provider string, override config.RateLimit) (catalog.RateLimit, error) {
+	limit, ok := catalog.DefaultRateLimit(provider)
+	if !ok {
+		return catalog.RateLimit{}, fmt.Errorf("unknown CATALOG_RATE_LIMITS provider %q", provider)
+	}
+	limit.RequestsPerSecond = override.RequestsPerSecond
+	if override.Burst != nil {
+		limit.Burst = *override.Burst
+	}
+	if override.MaxRetries != nil {
+		limit.MaxRetries = *override.MaxRetries
+	}
+	return limit, nil
 }
@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"errors"
+	"time"
+
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+// FormatVersion identifies the archive layout. Increment it when fields are
+// renamed or removed; restores reject archives from newer versions.
+const FormatVersion = 1
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// Archive is a complete copy of an owner's catalog: every item (including
+// series and tag fields) and every shelf with its layout and placements.
+// Placements reference items by the IDs recorded in Items.
+type Archive struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exportedAt"`
+	Items      []items.Item       `json:"items"`
+	Shelves    []shelves.Snapshot `json:"shelves"`
+}
+
+// Mode selects how a restore treats data the owner already has.
+type Mode string
+
+const (
+	// ModeMerge keeps existing data and adds whatever the archive has that is
+	// missing. Items match on ID or ISBN and shelves match on ID or name.
+	ModeMerge Mode = "merge"
+	// ModeReplace deletes the owner's items and shelves before restoring.
+	ModeReplace Mode = "replace"
+)
+
+// RestoreSummary reports what a restore changed.
+type RestoreSummary struct {
+	Mode              Mode `json:"mode"`
+	ItemsCreated      int  `json:"itemsCreated"`
+	ItemsMatched      int  `json:"itemsMatched"`
+	ItemsDeleted      int  `json:"itemsDeleted"`
+	ShelvesCreated    int  `json:"shelvesCreated"`
+	ShelvesSkipped    int  `json:"shelvesSkipped"`
+	ShelvesDeleted    int  `json:"shelvesDeleted"`
+	PlacementsCreated int  `json:"placementsCreated"`
+}
@@ -0,0 +1,432 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+// maxReportedProblems caps how many validation problems a failed restore lists.
+const maxReportedProblems = 20
+
+// Service exports and restores whole-catalog archives.
+type Service struct {
+	itemSvc   *items.Service
+	itemsRepo items.Repository
+	shelfSvc  *shelves.Service
+	tx        Transactor
+	now       func() time.Time
+}
+
+// Transactor runs fn so that every repository write made with the context it
+// passes commits or rolls back together.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Option configures optional archive service behaviour.
+type Option func(*Service)
+
+// WithTransactor makes each restore write in a single transaction. Without
+// one, a failed restore is undone by removing what it added and recreating
+// the shelves it deleted.
+func WithTransactor(tx Transactor) Option {
+	return func(s *Service) {
+		s.tx = tx
+	}
+}
+
+// NewService wires an archive service. Items are created and deleted through
+// itemSvc so validation and delete hooks apply as they do for the API.
+func NewService(itemSvc *items.Service, itemsRepo items.Repository, shelfSvc *shelves.Service, opts ...Option) *Service {
+	s := &Service{
+		itemSvc:   itemSvc,
+		itemsRepo: itemsRepo,
+		shelfSvc:  shelfSvc,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Export builds an archive of everything the owner has, oldest items first.
+func (s *Service) Export(ctx context.Context, ownerID uuid.UUID) (Archive, error) {
+	catalog, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		return Archive{}, err
+	}
+	snapshots, err := s.shelfSvc.Snapshots(ctx, ownerID)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	archived := make([]items.Item, 0, len(catalog))
+	for _, item := range catalog {
+		// Placement and loan summaries are derived data; placements travel with shelves.
+		item.ShelfPlacement = nil
+		item.ActiveLoan = nil
+		archived = append(archived, item)
+	}
+	slices.SortStableFunc(archived, func(a, b items.Item) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return Archive{
+		Version:    FormatVersion,
+		ExportedAt: s.now(),
+		Items:      archived,
+		Shelves:    snapshots,
+	}, nil
+}
+
+// Restore validates and stages the whole archive, then writes it for the
+// owner. Nothing is written when validation fails, and a write that fails
+// part way leaves the owner's library as it was. Restored items and shelves
+// get fresh IDs.
+func (s *Service) Restore(ctx context.Context, archive Archive, mode Mode, ownerID uuid.UUID) (RestoreSummary, error) {
+	if mode != ModeMerge && mode != ModeReplace {
+		return RestoreSummary{}, fmt.Errorf("%w: mode must be %q or %q", ErrValidation, ModeMerge, ModeReplace)
+	}
+	if err := s.validate(archive, ownerID); err != nil {
+		return RestoreSummary{}, err
+	}
+	plan, err := s.stage(ctx, archive, mode, ownerID)
+	if err != nil {
+		return RestoreSummary{}, err
+	}
+
+	if s.tx == nil {
+		summary, err := s.write(ctx, plan, ownerID)
+		if err != nil {
+			if undoErr := s.undo(ctx, plan, ownerID); undoErr != nil {
+				return RestoreSummary{}, errors.Join(err, fmt.Errorf("undo restore: %w", undoErr))
+			}
+			return RestoreSummary{}, err
+		}
+		return summary, nil
+	}
+
+	var summary RestoreSummary
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		summary, err = s.write(ctx, plan, ownerID)
+		return err
+	})
+	if err != nil {
+		return RestoreSummary{}, err
+	}
+	return summary, nil
+}
+
+// plan is a validated restore worked out before anything is written.
+type plan struct {
+	mode Mode
+	// existingItems and existingShelves are the owner's library before the
+	// restore; a replace deletes them.
+	existingItems   []items.Item
+	existingShelves []shelves.Snapshot
+	// create lists archived items without a match. matched maps the other
+	// archived item IDs to an existing item or to an earlier archived item.
+	create  []items.Item
+	matched map[uuid.UUID]uuid.UUID
+	shelves []shelves.Snapshot
+	skipped int
+}
+
+// stage reads the owner's library and decides what the restore will create,
+// match, skip, and delete.
+func (s *Service) stage(ctx context.Context, archive Archive, mode Mode, ownerID uuid.UUID) (plan, error) {
+	catalog, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		return plan{}, err
+	}
+	snapshots, err := s.shelfSvc.Snapshots(ctx, ownerID)
+	if err != nil {
+		return plan{}, err
+	}
+
+	p := plan{mode: mode, existingItems: catalog, existingShelves: snapshots, matched: make(map[uuid.UUID]uuid.UUID)}
+	itemIndex, shelfIndex := newItemIndex(nil), newShelfIndex(nil)
+	if mode == ModeMerge {
+		itemIndex, shelfIndex = newItemIndex(catalog), newShelfIndex(snapshots)
+	}
+
+	for _, item := range archive.Items {
+		if match, ok := itemIndex.match(item); ok {
+			p.matched[item.ID] = match
+			continue
+		}
+		p.create = append(p.create, item)
+		itemIndex.add(item)
+	}
+	for _, snapshot := range archive.Shelves {
+		if shelfIndex.has(snapshot.Shelf) {
+			p.skipped++
+			continue
+		}
+		p.shelves = append(p.shelves, snapshot)
+	}
+	return p, nil
+}
+
+// write applies a plan. New items are created before anything is deleted, and
+// old shelves are deleted just before their replacements take their names.
+func (s *Service) write(ctx context.Context, p plan, ownerID uuid.UUID) (RestoreSummary, error) {
+	summary := RestoreSummary{Mode: p.mode, ItemsMatched: len(p.matched), ShelvesSkipped: p.skipped}
+
+	itemIDs := make(map[uuid.UUID]uuid.UUID, len(p.create)+len(p.matched))
+	for _, item := range p.create {
+		created, err := s.itemSvc.Create(ctx, createInput(item, ownerID))
+		if err != nil {
+			return summary, fmt.Errorf("restore item %q: %w", item.Title, err)
+		}
+		itemIDs[item.ID] = created.ID
+		summary.ItemsCreated++
+	}
+	for archivedID, target := range p.matched {
+		if created, ok := itemIDs[target]; ok {
+			target = created
+		}
+		itemIDs[archivedID] = target
+	}
+
+	if p.mode == ModeReplace {
+		for _, snapshot := range p.existingShelves {
+			if err := s.shelfSvc.DeleteShelf(ctx, snapshot.Shelf.ID, ownerID); err != nil {
+				return summary, fmt.Errorf("delete shelf %q: %w", snapshot.Shelf.Name, err)
+			}
+			summary.ShelvesDeleted++
+		}
+	}
+
+	for _, snapshot := range p.shelves {
+		_, placed, err := s.shelfSvc.RestoreSnapshot(ctx, snapshot, itemIDs, ownerID)
+		if err != nil {
+			return summary, fmt.Errorf("restore shelf %q: %w", snapshot.Shelf.Name, err)
+		}
+		summary.PlacementsCreated += placed
+		summary.ShelvesCreated++
+	}
+
+	if p.mode == ModeReplace {
+		for _, item := range p.existingItems {
+			if err := s.itemSvc.Delete(ctx, item.ID, ownerID); err != nil {
+				return summary, fmt.Errorf("delete item %q: %w", item.Title, err)
+			}
+			summary.ItemsDeleted++
+		}
+	}
+	return summary, nil
+}
+
+// undo reverses a failed write when there is no transaction to roll back:
+// items and shelves the restore added are removed, and shelves it deleted are
+// recreated around the owner's original items.
+func (s *Service) undo(ctx context.Context, p plan, ownerID uuid.UUID) error {
+	originalItems := make(map[uuid.UUID]uuid.UUID, len(p.existingItems))
+	for _, item := range p.existingItems {
+		originalItems[item.ID] = item.ID
+	}
+	originalShelves := make(map[uuid.UUID]struct{}, len(p.existingShelves))
+	for _, snapshot := range p.existingShelves {
+		originalShelves[snapshot.Shelf.ID] = struct{}{}
+	}
+
+	current, err := s.shelfSvc.Snapshots(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	remaining := make(map[uuid.UUID]struct{}, len(current))
+	for _, snapshot := range current {
+		if _, ok := originalShelves[snapshot.Shelf.ID]; ok {
+			remaining[snapshot.Shelf.ID] = struct{}{}
+			continue
+		}
+		if err := s.shelfSvc.DeleteShelf(ctx, snapshot.Shelf.ID, ownerID); err != nil {
+			return err
+		}
+	}
+
+	catalog, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		return err
+	}
+	for _, item := range catalog {
+		if _, ok := originalItems[item.ID]; ok {
+			continue
+		}
+		if err := s.itemSvc.Delete(ctx, item.ID, ownerID); err != nil {
+			return err
+		}
+	}
+
+	for _, snapshot := range p.existingShelves {
+		if _, ok := remaining[snapshot.Shelf.ID]; ok {
+			continue
+		}
+		if _, _, err := s.shelfSvc.RestoreSnapshot(ctx, snapshot, originalItems, ownerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the archive version, every item and shelf, and that each
+// placement points at an archived item that is shelved at most once.
+func (s *Service) validate(archive Archive, ownerID uuid.UUID) error {
+	if archive.Version < 1 || archive.Version > FormatVersion {
+		return fmt.Errorf("%w: unsupported archive version %d (expected 1 to %d)", ErrValidation, archive.Version, FormatVersion)
+	}
+
+	var problems []string
+	itemTitles := make(map[uuid.UUID]string, len(archive.Items))
+	for i, item := range archive.Items {
+		label := fmt.Sprintf("item %d (%q)", i+1, item.Title)
+		if item.ID == uuid.Nil {
+			problems = append(problems, label+" has no id")
+			continue
+		}
+		if _, dup := itemTitles[item.ID]; dup {
+			problems = append(problems, fmt.Sprintf("%s reuses id %s", label, item.ID))
+			continue
+		}
+		itemTitles[item.ID] = item.Title
+		if _, err := s.itemSvc.ValidateCreate(createInput(item, ownerID)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", label, err))
+		}
+	}
+
+	shelfIDs := make(map[uuid.UUID]struct{}, len(archive.Shelves))
+	placedOn := make(map[uuid.UUID]string)
+	for _, snapshot := range archive.Shelves {
+		name := snapshot.Shelf.Name
+		if _, dup := shelfIDs[snapshot.Shelf.ID]; dup || snapshot.Shelf.ID == uuid.Nil {
+			problems = append(problems, fmt.Sprintf("shelf %q has a missing or repeated id", name))
+			continue
+		}
+		shelfIDs[snapshot.Shelf.ID] = struct{}{}
+		if err := s.shelfSvc.ValidateSnapshot(snapshot); err != nil {
+			problems = append(problems, strings.TrimPrefix(err.Error(), shelves.ErrValidation.Error()+": "))
+			continue
+		}
+		for _, placement := range snapshot.Placements {
+			if _, ok := itemTitles[placement.ItemID]; !ok {
+				problems = append(problems, fmt.Sprintf("shelf %q places item %s which is not in the archive", name, placement.ItemID))
+				continue
+			}
+			if other, dup := placedOn[placement.ItemID]; dup {
+				problems = append(problems, fmt.Sprintf("item %q is placed on both %q and %q", itemTitles[placement.ItemID], other, name))
+				continue
+			}
+			placedOn[placement.ItemID] = name
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > maxReportedProblems {
+		problems = append(problems[:maxReportedProblems], fmt.Sprintf("and %d more", len(problems)-maxReportedProblems))
+	}
+	return fmt.Errorf("%w: archive rejected: %s", ErrValidation, strings.Join(problems, "; "))
+}
+
+// createInput converts an archived item back into creation input, keeping its timestamps.
+func createInput(item items.Item, ownerID uuid.UUID) items.CreateItemInput {
+	createdAt, updatedAt := item.CreatedAt, item.UpdatedAt
+	return items.CreateItemInput{
+		OwnerID:          ownerID,
+		Title:            item.Title,
+		Creator:          item.Creator,
+		ItemType:         item.ItemType,
+		ReleaseYear:      item.ReleaseYear,
+		PageCount:        item.PageCount,
+		CurrentPage:      item.CurrentPage,
+		ISBN13:           item.ISBN13,
+		ISBN10:           item.ISBN10,
+		Description:      item.Description,
+		CoverImage:       item.CoverImage,
+		Format:           item.Format,
+		Genre:            item.Genre,
+		Rating:           item.Rating,
+		RetailPriceUsd:   item.RetailPriceUsd,
+		GoogleVolumeId:   item.GoogleVolumeId,
+		MetadataSource:   item.MetadataSource,
+		MetadataSourceID: item.MetadataSourceID,
+		Platform:         item.Platform,
+		AgeGroup:         item.AgeGroup,
+		PlayerCount:      item.PlayerCount,
+		ReadingStatus:    item.ReadingStatus,
+		ReadAt:           item.ReadAt,
+		Notes:            item.Notes,
+		SeriesName:       item.SeriesName,
+		VolumeNumber:     item.VolumeNumber,
+		TotalVolumes:     item.TotalVolumes,
+		Tags:             item.Tags,
+		CreatedAt:        &createdAt,
+		UpdatedAt:        &updatedAt,
+	}
+}
+
+// itemIndex finds an owner's existing item for an archived one by ID or ISBN.
+type itemIndex struct {
+	byID         map[uuid.UUID]struct{}
+	byIdentifier map[string]uuid.UUID
+}
+
+func newItemIndex(catalog []items.Item) *itemIndex {
+	index := &itemIndex{byID: make(map[uuid.UUID]struct{}), byIdentifier: make(map[string]uuid.UUID)}
+	for _, item := range catalog {
+		index.add(item)
+	}
+	return index
+}
+
+func (x *itemIndex) add(item items.Item) {
+	x.byID[item.ID] = struct{}{}
+	for _, identifier := range []string{item.ISBN13, item.ISBN10} {
+		if normalized := items.NormalizeIdentifier(identifier); normalized != "" {
+			x.byIdentifier[normalized] = item.ID
+		}
+	}
+}
+
+func (x *itemIndex) match(item items.Item) (uuid.UUID, bool) {
+	if _, ok := x.byID[item.ID]; ok {
+		return item.ID, true
+	}
+	for _, identifier := range []string{item.ISBN13, item.ISBN10} {
+		if id, ok := x.byIdentifier[items.NormalizeIdentifier(identifier)]; ok && identifier != "" {
+			return id, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+// shelfIndex finds shelves the owner already has by ID or case-insensitive name.
+type shelfIndex map[string]struct{}
+
+func newShelfIndex(snapshots []shelves.Snapshot) shelfIndex {
+	index := make(shelfIndex, len(snapshots)*2)
+	for _, snapshot := range snapshots {
+		index[snapshot.Shelf.ID.String()] = struct{}{}
+		index[strings.ToLower(strings.TrimSpace(snapshot.Shelf.Name))] = struct{}{}
+	}
+	return index
+}
+
+func (x shelfIndex) has(shelf shelves.Shelf) bool {
+	if _, ok := x[shelf.ID.String()]; ok {
+		return true
+	}
+	_, ok := x[strings.ToLower(strings.TrimSpace(shelf.Name))]
+	return ok
+}
@@ -0,0 +1,260 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+var (
+	testOwnerID  = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	otherOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+)
+
+func intPtr(v int) *int { return &v }
+
+func newTestService(t *testing.T) (*Service, *items.Service, *shelves.Service) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	itemSvc := items.NewService(itemsRepo)
+	shelfSvc := shelves.NewService(shelves.NewInMemoryRepository(), itemsRepo, nil, itemSvc)
+	return NewService(itemSvc, itemsRepo, shelfSvc), itemSvc, shelfSvc
+}
+
+// seedCatalog creates two series books, one placed in a slot and one left unplaced on the shelf.
+func seedCatalog(t *testing.T, itemSvc *items.Service, shelfSvc *shelves.Service, ownerID uuid.UUID) (items.Item, shelves.ShelfWithLayout) {
+	t.Helper()
+	ctx := context.Background()
+	first, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: ownerID, Title: "Dune", ItemType: items.ItemTypeBook, ISBN13: "9780441172719", SeriesName: "Dune", VolumeNumber: intPtr(1), Tags: []string{"signed"}})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	second, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: ownerID, Title: "Dune Messiah", ItemType: items.ItemTypeBook, SeriesName: "Dune", VolumeNumber: intPtr(2)})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+
+	shelf, err := shelfSvc.CreateShelf(ctx, shelves.CreateShelfInput{Name: "Living Room", PhotoURL: "https://example.com/shelf.jpg"}, ownerID)
+	if err != nil {
+		t.Fatalf("create shelf: %v", err)
+	}
+	shelf, _, err = shelfSvc.UpdateLayout(ctx, shelf.Shelf.ID, ownerID, shelves.UpdateLayoutInput{Slots: []shelves.LayoutSlotInput{
+		{RowIndex: 0, ColIndex: 0, XStartNorm: 0.0, XEndNorm: 0.5, YStartNorm: 0, YEndNorm: 0.5},
+		{RowIndex: 0, ColIndex: 1, XStartNorm: 0.5, XEndNorm: 1.0, YStartNorm: 0, YEndNorm: 0.5},
+	}})
+	if err != nil {
+		t.Fatalf("update layout: %v", err)
+	}
+	if shelf, err = shelfSvc.AssignItem(ctx, shelf.Shelf.ID, shelf.Slots[1].ID, first.ID, ownerID); err != nil {
+		t.Fatalf("assign item: %v", err)
+	}
+	if shelf, err = shelfSvc.AssignItem(ctx, shelf.Shelf.ID, shelf.Slots[0].ID, second.ID, ownerID); err != nil {
+		t.Fatalf("assign item: %v", err)
+	}
+	if shelf, err = shelfSvc.RemoveItem(ctx, shelf.Shelf.ID, shelf.Slots[0].ID, second.ID, ownerID); err != nil {
+		t.Fatalf("remove item: %v", err)
+	}
+	return first, shelf
+}
+
+func TestExportRestoreRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, itemSvc, shelfSvc := newTestService(t)
+	seedCatalog(t, itemSvc, shelfSvc, testOwnerID)
+
+	archive, err := svc.Export(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if archive.Version != FormatVersion || len(archive.Items) != 2 || len(archive.Shelves) != 1 {
+		t.Fatalf("unexpected archive %+v", archive)
+	}
+	if archive.Items[0].Title != "Dune" || archive.Items[0].ShelfPlacement != nil {
+		t.Fatalf("expected oldest item first without derived placement, got %+v", archive.Items[0])
+	}
+	if snapshot := archive.Shelves[0]; len(snapshot.Rows) != 1 || len(snapshot.Columns) != 2 || len(snapshot.Slots) != 2 || len(snapshot.Placements) != 2 {
+		t.Fatalf("unexpected shelf snapshot %+v", snapshot)
+	}
+
+	summary, err := svc.Restore(ctx, archive, ModeReplace, otherOwnerID)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if summary.ItemsCreated != 2 || summary.ShelvesCreated != 1 || summary.PlacementsCreated != 2 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	restored, err := itemSvc.List(ctx, items.ListOptions{OwnerID: otherOwnerID})
+	if err != nil {
+		t.Fatalf("list restored items: %v", err)
+	}
+	byTitle := make(map[string]items.Item)
+	for _, item := range restored {
+		byTitle[item.Title] = item
+	}
+	dune := byTitle["Dune"]
+	if dune.SeriesName != "Dune" || dune.VolumeNumber == nil || *dune.VolumeNumber != 1 || len(dune.Tags) != 1 {
+		t.Fatalf("series and tag fields not restored: %+v", dune)
+	}
+	if dune.ShelfPlacement == nil || dune.ShelfPlacement.ColIndex != 1 {
+		t.Fatalf("expected Dune placed in column 1, got %+v", dune.ShelfPlacement)
+	}
+	if byTitle["Dune Messiah"].ShelfPlacement != nil {
+		t.Fatalf("expected Dune Messiah unplaced, got %+v", byTitle["Dune Messiah"].ShelfPlacement)
+	}
+
+	shelvesAfter, err := shelfSvc.ListShelves(ctx, otherOwnerID)
+	if err != nil {
+		t.Fatalf("list shelves: %v", err)
+	}
+	if len(shelvesAfter) != 1 || shelvesAfter[0].Shelf.ID == archive.Shelves[0].Shelf.ID || shelvesAfter[0].ItemCount != 2 || shelvesAfter[0].PlacedCount != 1 {
+		t.Fatalf("unexpected restored shelves %+v", shelvesAfter)
+	}
+}
+
+func TestRestoreMergeSkipsExistingData(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, itemSvc, shelfSvc := newTestService(t)
+	seedCatalog(t, itemSvc, shelfSvc, testOwnerID)
+
+	archive, err := svc.Export(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	archive.Items = append(archive.Items, items.Item{ID: uuid.New(), Title: "Hades", ItemType: items.ItemTypeGame})
+
+	summary, err := svc.Restore(ctx, archive, ModeMerge, testOwnerID)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if summary.ItemsCreated != 1 || summary.ItemsMatched != 2 || summary.ShelvesSkipped != 1 || summary.ShelvesCreated != 0 || summary.ItemsDeleted != 0 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	summary, err = svc.Restore(ctx, archive, ModeReplace, testOwnerID)
+	if err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if summary.ItemsDeleted != 3 || summary.ShelvesDeleted != 1 || summary.ItemsCreated != 3 || summary.ShelvesCreated != 1 {
+		t.Fatalf("unexpected replace summary %+v", summary)
+	}
+}
+
+func TestRestoreValidatesBeforeWriting(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, itemSvc, shelfSvc := newTestService(t)
+	seedCatalog(t, itemSvc, shelfSvc, testOwnerID)
+
+	archive, err := svc.Export(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	// Drop a placed item so the shelf points at something missing.
+	archive.Items = archive.Items[1:]
+
+	if _, err := svc.Restore(ctx, archive, ModeReplace, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	remaining, err := itemSvc.List(ctx, items.ListOptions{OwnerID: testOwnerID})
+	if err != nil {
+		t.Fatalf("list items: %v", err)
+	}
+	if len(remaining) != 2 {
+		t.Fatalf("expected replace to leave data untouched, got %d items", len(remaining))
+	}
+
+	archive.Version = FormatVersion + 1
+	if _, err := svc.Restore(ctx, archive, ModeMerge, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected version error, got %v", err)
+	}
+	if _, err := svc.Restore(ctx, Archive{Version: FormatVersion}, Mode("overwrite"), testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected mode error, got %v", err)
+	}
+}
+
+// failingItems fails Create once its budget of successful creates runs out.
+type failingItems struct {
+	*items.InMemoryRepository
+	creates int
+}
+
+func (r *failingItems) Create(ctx context.Context, item items.Item) (items.Item, error) {
+	if r.creates == 0 {
+		return items.Item{}, errors.New("disk full")
+	}
+	r.creates--
+	return r.InMemoryRepository.Create(ctx, item)
+}
+
+// failingShelves fails the next CreateShelf once armed.
+type failingShelves struct {
+	shelves.Repository
+	fail bool
+}
+
+func (r *failingShelves) CreateShelf(ctx context.Context, shelf shelves.Shelf, rows []shelves.ShelfRow, columns []shelves.ShelfColumn, slots []shelves.ShelfSlot) (shelves.ShelfWithLayout, error) {
+	if r.fail {
+		r.fail = false
+		return shelves.ShelfWithLayout{}, errors.New("disk full")
+	}
+	return r.Repository.CreateShelf(ctx, shelf, rows, columns, slots)
+}
+
+func TestRestoreReplaceKeepsLibraryWhenWriteFails(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name string
+		arm  func(itemsRepo *failingItems, shelfRepo *failingShelves)
+	}{
+		{name: "item create", arm: func(itemsRepo *failingItems, _ *failingShelves) { itemsRepo.creates = 1 }},
+		{name: "shelf create", arm: func(_ *failingItems, shelfRepo *failingShelves) { shelfRepo.fail = true }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			itemsRepo := &failingItems{InMemoryRepository: items.NewInMemoryRepository(nil), creates: -1}
+			shelfRepo := &failingShelves{Repository: shelves.NewInMemoryRepository()}
+			itemSvc := items.NewService(itemsRepo)
+			shelfSvc := shelves.NewService(shelfRepo, itemsRepo, nil, itemSvc)
+			svc := NewService(itemSvc, itemsRepo, shelfSvc)
+			first, _ := seedCatalog(t, itemSvc, shelfSvc, testOwnerID)
+
+			archive, err := svc.Export(ctx, testOwnerID)
+			if err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			tc.arm(itemsRepo, shelfRepo)
+
+			if _, err := svc.Restore(ctx, archive, ModeReplace, testOwnerID); err == nil {
+				t.Fatal("expected restore to fail")
+			}
+
+			remaining, err := itemSvc.List(ctx, items.ListOptions{OwnerID: testOwnerID})
+			if err != nil {
+				t.Fatalf("list items: %v", err)
+			}
+			if len(remaining) != 2 {
+				t.Fatalf("expected the original 2 items, got %d", len(remaining))
+			}
+			if _, err := itemSvc.Get(ctx, first.ID, testOwnerID); err != nil {
+				t.Fatalf("expected original item to survive: %v", err)
+			}
+			snapshots, err := shelfSvc.Snapshots(ctx, testOwnerID)
+			if err != nil {
+				t.Fatalf("snapshots: %v", err)
+			}
+			if len(snapshots) != 1 || snapshots[0].Shelf.Name != "Living Room" || len(snapshots[0].Placements) != 2 {
+				t.Fatalf("expected the original shelf and placements, got %+v", snapshots)
+			}
+		})
+	}
+}
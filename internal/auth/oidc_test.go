@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestIsEmailAllowedByEmailAllowlist(t *testing.T) {
+	authenticator := &OIDCAuthenticator{
+		allowedEmails: map[string]struct{}{
+			"test@example.com": {},
+		},
+		allowedDomains: map[string]struct{}{},
+	}
+
+	if !authenticator.IsEmailAllowed("Test@Example.com") {
+		t.Fatal("expected email to be allowed")
+	}
+}
+
+func TestIsEmailAllowedByDomainAllowlist(t *testing.T) {
+	authenticator := &OIDCAuthenticator{
+		allowedDomains: map[string]struct{}{
+			"example.com": {},
+		},
+		allowedEmails: map[string]struct{}{},
+	}
+
+	if !authenticator.IsEmailAllowed("user@example.com") {
+		t.Fatal("expected domain to be allowed")
+	}
+}
+
+func TestIsEmailAllowedRejectsUnknown(t *testing.T) {
+	authenticator := &OIDCAuthenticator{
+		allowedDomains: map[string]struct{}{
+			"example.com": {},
+		},
+		allowedEmails: map[string]struct{}{},
+	}
+
+	if authenticator.IsEmailAllowed("user@other.com") {
+		t.Fatal("expected email to be rejected")
+	}
+}
+
+func TestIsEmailAllowedAllowsAllWhenNoAllowlist(t *testing.T) {
+	authenticator := &OIDCAuthenticator{
+		allowedDomains: map[string]struct{}{},
+		allowedEmails:  map[string]struct{}{},
+	}
+
+	if !authenticator.IsEmailAllowed("user@other.com") {
+		t.Fatal("expected email to be allowed when no allowlist is configured")
+	}
+}
+
+func TestHasAllowlist(t *testing.T) {
+	authenticator := &OIDCAuthenticator{
+		allowedDomains: map[string]struct{}{},
+		allowedEmails:  map[string]struct{}{},
+	}
+
+	if authenticator.HasAllowlist() {
+		t.Fatal("expected HasAllowlist to be false")
+	}
+
+	authenticator.allowedDomains["example.com"] = struct{}{}
+	if !authenticator.HasAllowlist() {
+		t.Fatal("expected HasAllowlist to be true")
+	}
+}
+
+func TestGenerateState(t *testing.T) {
+	state1, err := GenerateState()
+	if err != nil {
+		t.Fatalf("GenerateState returned error: %v", err)
+	}
+	state2, err := GenerateState()
+	if err != nil {
+		t.Fatalf("GenerateState returned error: %v", err)
+	}
+	if state1 == "" || state2 == "" {
+		t.Fatal("expected non-empty state")
+	}
+	if state1 == state2 {
+		t.Fatal("expected unique state values")
+	}
+}
+
+func TestGoogleAuthURLIncludesPromptSelectAccount(t *testing.T) {
+	authenticator := &OIDCAuthenticator{
+		config: &oauth2.Config{
+			ClientID:     "client-id",
+			RedirectURL:  "http://localhost/callback",
+			Endpoint:     oauth2.Endpoint{AuthURL: "https://auth.test/oauth"},
+			Scopes:       []string{"openid"},
+			ClientSecret: "secret",
+		},
+		authParams: googleAuthParams,
+	}
+
+	authURL := authenticator.AuthURL("state123")
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("failed to parse auth URL: %v", err)
+	}
+
+	if prompt := parsed.Query().Get("prompt"); prompt != "select_account" {
+		t.Fatalf("expected prompt=select_account, got %q", prompt)
+	}
+}
+
+// testIssuer is a minimal OpenID Connect provider serving discovery, JWKS,
+// and a token endpoint that returns idToken.
+type testIssuer struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	idToken string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	issuer := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		base := issuer.server.URL
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                base,
+			"authorization_endpoint":                base + "/authorize",
+			"token_endpoint":                        base + "/token",
+			"jwks_uri":                              base + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     issuer.idToken,
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// sign returns an RS256 JWT for claims signed with key.
+func (i *testIssuer) sign(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test-key"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (i *testIssuer) claims(audience string) map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":            i.server.URL,
+		"aud":            audience,
+		"sub":            "idp-user-1",
+		"email":          "reader@example.com",
+		"email_verified": true,
+		"name":           "Reader",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestOIDCAuthenticatorDiscoversAndVerifiesIDTokens(t *testing.T) {
+	issuer := newTestIssuer(t)
+	ctx := context.Background()
+	authenticator, err := NewOIDCAuthenticator(ctx, OIDCConfig{
+		Name:          "corp",
+		IssuerURL:     issuer.server.URL,
+		ClientID:      "anthology",
+		ClientSecret:  "secret",
+		RedirectURL:   "http://localhost/api/auth/corp/callback",
+		AllowedEmails: []string{"reader@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("NewOIDCAuthenticator returned error: %v", err)
+	}
+
+	if authURL := authenticator.AuthURL("state123"); !strings.HasPrefix(authURL, issuer.server.URL+"/authorize?") {
+		t.Fatalf("expected discovered authorization endpoint, got %q", authURL)
+	}
+
+	issuer.idToken = issuer.sign(t, issuer.key, issuer.claims("anthology"))
+	claims, err := authenticator.Exchange(ctx, "code")
+	if err != nil {
+		t.Fatalf("Exchange returned error: %v", err)
+	}
+	if claims.Sub != "idp-user-1" || claims.Email != "reader@example.com" || !claims.EmailVerified {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	issuer.idToken = issuer.sign(t, issuer.key, issuer.claims("another-client"))
+	if _, err := authenticator.Exchange(ctx, "code"); err == nil {
+		t.Fatal("expected a token for another audience to be rejected")
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	issuer.idToken = issuer.sign(t, otherKey, issuer.claims("anthology"))
+	if _, err := authenticator.Exchange(ctx, "code"); err == nil {
+		t.Fatal("expected a token with an unknown signature to be rejected")
+	}
+}
@@ -4,10 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolation = "23505"
+	userEmailIndex  = "uq_users_email_lower"
 )
 
 // PostgresRepository implements Repository using PostgreSQL.
@@ -20,12 +27,13 @@ func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
 	return &PostgresRepository{db: db}
 }
 
-// FindUserByOAuth looks up a user by their OAuth provider and provider ID.
+// FindUserByOAuth looks up a user by any identity linked to their account.
 func (r *PostgresRepository) FindUserByOAuth(ctx context.Context, provider, providerID string) (*User, error) {
 	const query = `
-		SELECT id, email, name, avatar_url, oauth_provider, oauth_provider_id, created_at, updated_at, last_login_at
-		FROM users
-		WHERE oauth_provider = $1 AND oauth_provider_id = $2
+		SELECT u.id, u.email, u.name, u.avatar_url, u.oauth_provider, u.oauth_provider_id, u.created_at, u.updated_at, u.last_login_at
+		FROM user_identities i
+		JOIN users u ON i.user_id = u.id
+		WHERE i.provider = $1 AND i.subject = $2
 	`
 
 	var row userRow
@@ -39,12 +47,12 @@ func (r *PostgresRepository) FindUserByOAuth(ctx context.Context, provider, prov
 	return row.toUser(), nil
 }
 
-// FindUserByEmail looks up a user by their email address.
+// FindUserByEmail looks up a user by their email address, ignoring case.
 func (r *PostgresRepository) FindUserByEmail(ctx context.Context, email string) (*User, error) {
 	const query = `
 		SELECT id, email, name, avatar_url, oauth_provider, oauth_provider_id, created_at, updated_at, last_login_at
 		FROM users
-		WHERE email = $1
+		WHERE lower(email) = lower($1)
 	`
 
 	var row userRow
@@ -58,14 +66,20 @@ func (r *PostgresRepository) FindUserByEmail(ctx context.Context, email string)
 	return row.toUser(), nil
 }
 
-// CreateUser inserts a new user into the database.
+// CreateUser inserts a new user and links the identity they signed in with.
 func (r *PostgresRepository) CreateUser(ctx context.Context, user User) (User, error) {
 	const query = `
 		INSERT INTO users (id, email, name, avatar_url, oauth_provider, oauth_provider_id, created_at, updated_at, last_login_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return User{}, fmt.Errorf("begin insert user: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, query,
 		user.ID,
 		user.Email,
 		user.Name,
@@ -76,13 +90,34 @@ func (r *PostgresRepository) CreateUser(ctx context.Context, user User) (User, e
 		user.UpdatedAt,
 		user.LastLoginAt,
 	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == userEmailIndex {
+		return User{}, ErrEmailInUse
+	}
 	if err != nil {
 		return User{}, err
 	}
+	if _, err := tx.ExecContext(ctx, insertIdentityQuery, user.ID, user.OAuthProvider, user.OAuthProviderID, user.CreatedAt); err != nil {
+		return User{}, fmt.Errorf("insert user identity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return User{}, fmt.Errorf("commit insert user: %w", err)
+	}
 
 	return user, nil
 }
 
+const insertIdentityQuery = `
+	INSERT INTO user_identities (user_id, provider, subject, created_at)
+	VALUES ($1, $2, $3, $4)
+`
+
+// LinkIdentity records another provider identity for an existing user.
+func (r *PostgresRepository) LinkIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+	_, err := r.db.ExecContext(ctx, insertIdentityQuery, userID, provider, subject, time.Now())
+	return err
+}
+
 // UpdateUserLogin updates the user's last login time and refreshes profile data.
 func (r *PostgresRepository) UpdateUserLogin(ctx context.Context, id uuid.UUID, name, avatarURL string) error {
 	const query = `
@@ -99,8 +134,8 @@ func (r *PostgresRepository) UpdateUserLogin(ctx context.Context, id uuid.UUID,
 // CreateSession inserts a new session into the database.
 func (r *PostgresRepository) CreateSession(ctx context.Context, session Session, tokenHash string) error {
 	const query = `
-		INSERT INTO user_sessions (id, user_id, session_token_hash, expires_at, created_at, user_agent, ip_address)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO user_sessions (id, user_id, session_token_hash, expires_at, created_at, last_seen_at, user_agent, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -109,6 +144,7 @@ func (r *PostgresRepository) CreateSession(ctx context.Context, session Session,
 		tokenHash,
 		session.ExpiresAt,
 		session.CreatedAt,
+		session.LastSeenAt,
 		session.UserAgent,
 		session.IPAddress,
 	)
@@ -119,7 +155,7 @@ func (r *PostgresRepository) CreateSession(ctx context.Context, session Session,
 func (r *PostgresRepository) FindSessionByTokenHash(ctx context.Context, tokenHash string) (*Session, *User, error) {
 	const query = `
 		SELECT
-			s.id, s.user_id, s.expires_at, s.created_at, s.user_agent, s.ip_address,
+			s.id, s.user_id, s.expires_at, s.created_at, s.last_seen_at, s.user_agent, s.ip_address,
 			u.id AS user_id, u.email, u.name, u.avatar_url, u.oauth_provider, u.oauth_provider_id,
 			u.created_at AS user_created_at, u.updated_at AS user_updated_at, u.last_login_at
 		FROM user_sessions s
@@ -138,6 +174,33 @@ func (r *PostgresRepository) FindSessionByTokenHash(ctx context.Context, tokenHa
 	return row.toSession(), row.toUser(), nil
 }
 
+// TouchSession records session activity and its extended expiry.
+func (r *PostgresRepository) TouchSession(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+	const query = `UPDATE user_sessions SET last_seen_at = $2, expires_at = $3 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, lastSeenAt, expiresAt)
+	return err
+}
+
+// ListSessions returns the user's unexpired sessions, most recently seen first.
+func (r *PostgresRepository) ListSessions(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	const query = `
+		SELECT id, user_id, expires_at, created_at, last_seen_at, user_agent, ip_address
+		FROM user_sessions
+		WHERE user_id = $1 AND expires_at > $2
+		ORDER BY last_seen_at DESC
+	`
+
+	var rows []sessionRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, time.Now()); err != nil {
+		return nil, err
+	}
+	sessions := make([]Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, row.toSession())
+	}
+	return sessions, nil
+}
+
 // DeleteSession removes a session from the database.
 func (r *PostgresRepository) DeleteSession(ctx context.Context, id uuid.UUID) error {
 	const query = `DELETE FROM user_sessions WHERE id = $1`
@@ -145,6 +208,33 @@ func (r *PostgresRepository) DeleteSession(ctx context.Context, id uuid.UUID) er
 	return err
 }
 
+// DeleteUserSession removes one of the user's sessions.
+func (r *PostgresRepository) DeleteUserSession(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	const query = `DELETE FROM user_sessions WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// DeleteUserSessions removes every session belonging to the user.
+func (r *PostgresRepository) DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	const query = `DELETE FROM user_sessions WHERE user_id = $1`
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // DeleteExpiredSessions removes all expired sessions.
 func (r *PostgresRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
 	const query = `DELETE FROM user_sessions WHERE expires_at < $1`
@@ -182,15 +272,39 @@ func (r *userRow) toUser() *User {
 	}
 }
 
+// sessionRow is a database row representation of Session.
+type sessionRow struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastSeenAt time.Time `db:"last_seen_at"`
+	UserAgent  string    `db:"user_agent"`
+	IPAddress  string    `db:"ip_address"`
+}
+
+func (r *sessionRow) toSession() Session {
+	return Session{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		ExpiresAt:  r.ExpiresAt,
+		CreatedAt:  r.CreatedAt,
+		LastSeenAt: r.LastSeenAt,
+		UserAgent:  r.UserAgent,
+		IPAddress:  r.IPAddress,
+	}
+}
+
 // sessionUserRow is a database row for the session + user join query.
 type sessionUserRow struct {
 	// Session fields
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	ExpiresAt time.Time `db:"expires_at"`
-	CreatedAt time.Time `db:"created_at"`
-	UserAgent string    `db:"user_agent"`
-	IPAddress string    `db:"ip_address"`
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastSeenAt time.Time `db:"last_seen_at"`
+	UserAgent  string    `db:"user_agent"`
+	IPAddress  string    `db:"ip_address"`
 
 	// User fields
 	Email           string    `db:"email"`
@@ -205,12 +319,13 @@ type sessionUserRow struct {
 
 func (r *sessionUserRow) toSession() *Session {
 	return &Session{
-		ID:        r.ID,
-		UserID:    r.UserID,
-		ExpiresAt: r.ExpiresAt,
-		CreatedAt: r.CreatedAt,
-		UserAgent: r.UserAgent,
-		IPAddress: r.IPAddress,
+		ID:         r.ID,
+		UserID:     r.UserID,
+		ExpiresAt:  r.ExpiresAt,
+		CreatedAt:  r.CreatedAt,
+		LastSeenAt: r.LastSeenAt,
+		UserAgent:  r.UserAgent,
+		IPAddress:  r.IPAddress,
 	}
 }
 
@@ -227,3 +342,158 @@ func (r *sessionUserRow) toUser() *User {
 		LastLoginAt:     r.LastLoginAt,
 	}
 }
+
+// CreateAPIToken inserts a new API token.
+func (r *PostgresRepository) CreateAPIToken(ctx context.Context, token APIToken, tokenHash string) error {
+	const query = `
+		INSERT INTO api_tokens (id, user_id, name, token_prefix, token_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Name,
+		token.Prefix,
+		tokenHash,
+		pq.Array(scopeStrings(token.Scopes)),
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	return err
+}
+
+// ListAPITokens returns a user's API tokens, newest first.
+func (r *PostgresRepository) ListAPITokens(ctx context.Context, userID uuid.UUID) ([]APIToken, error) {
+	const query = `
+		SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var rows []apiTokenRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, err
+	}
+
+	tokens := make([]APIToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, row.toAPIToken())
+	}
+	return tokens, nil
+}
+
+// FindAPITokenByHash looks up an API token and its user by secret hash.
+func (r *PostgresRepository) FindAPITokenByHash(ctx context.Context, tokenHash string) (*APIToken, *User, error) {
+	const query = `
+		SELECT
+			t.id, t.user_id, t.name, t.token_prefix, t.scopes, t.expires_at, t.last_used_at, t.created_at,
+			u.email, u.name AS user_name, u.avatar_url, u.oauth_provider, u.oauth_provider_id,
+			u.created_at AS user_created_at, u.updated_at AS user_updated_at, u.last_login_at
+		FROM api_tokens t
+		JOIN users u ON t.user_id = u.id
+		WHERE t.token_hash = $1
+	`
+
+	var row apiTokenUserRow
+	if err := r.db.GetContext(ctx, &row, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	token := row.toAPIToken()
+	return &token, row.toUser(), nil
+}
+
+// TouchAPIToken records when a token was last used.
+func (r *PostgresRepository) TouchAPIToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	const query = `UPDATE api_tokens SET last_used_at = $2 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, usedAt)
+	return err
+}
+
+// DeleteAPIToken removes one of a user's API tokens.
+func (r *PostgresRepository) DeleteAPIToken(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	const query = `DELETE FROM api_tokens WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// apiTokenRow is a database row representation of APIToken.
+type apiTokenRow struct {
+	ID         uuid.UUID      `db:"id"`
+	UserID     uuid.UUID      `db:"user_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"token_prefix"`
+	Scopes     pq.StringArray `db:"scopes"`
+	ExpiresAt  *time.Time     `db:"expires_at"`
+	LastUsedAt *time.Time     `db:"last_used_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (r *apiTokenRow) toAPIToken() APIToken {
+	scopes := make([]TokenScope, 0, len(r.Scopes))
+	for _, scope := range r.Scopes {
+		scopes = append(scopes, TokenScope(scope))
+	}
+	return APIToken{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Name:       r.Name,
+		Prefix:     r.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  r.ExpiresAt,
+		LastUsedAt: r.LastUsedAt,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+// apiTokenUserRow is a database row for the API token + user join query.
+type apiTokenUserRow struct {
+	apiTokenRow
+
+	// User fields
+	Email           string    `db:"email"`
+	UserName        string    `db:"user_name"`
+	AvatarURL       string    `db:"avatar_url"`
+	OAuthProvider   string    `db:"oauth_provider"`
+	OAuthProviderID string    `db:"oauth_provider_id"`
+	UserCreatedAt   time.Time `db:"user_created_at"`
+	UserUpdatedAt   time.Time `db:"user_updated_at"`
+	LastLoginAt     time.Time `db:"last_login_at"`
+}
+
+func (r *apiTokenUserRow) toUser() *User {
+	return &User{
+		ID:              r.UserID,
+		Email:           r.Email,
+		Name:            r.UserName,
+		AvatarURL:       r.AvatarURL,
+		OAuthProvider:   r.OAuthProvider,
+		OAuthProviderID: r.OAuthProviderID,
+		CreatedAt:       r.UserCreatedAt,
+		UpdatedAt:       r.UserUpdatedAt,
+		LastLoginAt:     r.LastLoginAt,
+	}
+}
+
+func scopeStrings(scopes []TokenScope) []string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	return values
+}
@@ -2,21 +2,40 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-// Repository defines the interface for user and session persistence.
+// Repository defines the interface for user, session, and API token persistence.
 type Repository interface {
-	// User operations
+	// User operations. Users are found by any identity linked to them;
+	// CreateUser also links the identity in OAuthProvider/OAuthProviderID.
 	FindUserByOAuth(ctx context.Context, provider, providerID string) (*User, error)
 	FindUserByEmail(ctx context.Context, email string) (*User, error)
+	// CreateUser returns ErrEmailInUse when another user has the same email
+	// address, ignoring case.
 	CreateUser(ctx context.Context, user User) (User, error)
 	UpdateUserLogin(ctx context.Context, id uuid.UUID, name, avatarURL string) error
+	LinkIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error
 
 	// Session operations
 	CreateSession(ctx context.Context, session Session, tokenHash string) error
 	FindSessionByTokenHash(ctx context.Context, tokenHash string) (*Session, *User, error)
+	TouchSession(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error
+	// ListSessions returns the user's unexpired sessions, most recently seen first.
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]Session, error)
 	DeleteSession(ctx context.Context, id uuid.UUID) error
+	// DeleteUserSession returns ErrSessionNotFound when the user has no such session.
+	DeleteUserSession(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
 	DeleteExpiredSessions(ctx context.Context) (int64, error)
+
+	// API token operations
+	CreateAPIToken(ctx context.Context, token APIToken, tokenHash string) error
+	ListAPITokens(ctx context.Context, userID uuid.UUID) ([]APIToken, error)
+	FindAPITokenByHash(ctx context.Context, tokenHash string) (*APIToken, *User, error)
+	TouchAPIToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	// DeleteAPIToken returns ErrTokenNotFound when the user has no such token.
+	DeleteAPIToken(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 }
@@ -6,36 +6,98 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrSessionNotFound is returned when a session does not exist for the user.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrEmailInUse is returned when a new identity's email address already
+// belongs to another account and cannot be linked to it, because the provider
+// has not verified the address.
+var ErrEmailInUse = errors.New("email address is already used by another account")
+
+const defaultSessionMaxLifetime = 30 * 24 * time.Hour
+
+// sessionTouchInterval throttles last-seen writes and expiry extensions for busy sessions.
+const sessionTouchInterval = time.Minute
+
 // Service provides authentication business logic.
 type Service struct {
-	repo       Repository
-	sessionTTL time.Duration
+	repo               Repository
+	sessionTTL         time.Duration
+	sessionMaxLifetime time.Duration
+}
+
+// Option configures the auth Service.
+type Option func(*Service)
+
+// WithSessionMaxLifetime caps how long a session may be extended by use.
+func WithSessionMaxLifetime(lifetime time.Duration) Option {
+	return func(s *Service) {
+		if lifetime > 0 {
+			s.sessionMaxLifetime = lifetime
+		}
+	}
 }
 
-// NewService creates a new auth Service.
-func NewService(repo Repository, sessionTTL time.Duration) *Service {
+// NewService creates a new auth Service. Sessions expire after sessionTTL
+// without use, and at the latest after the maximum lifetime.
+func NewService(repo Repository, sessionTTL time.Duration, opts ...Option) *Service {
 	if sessionTTL == 0 {
 		sessionTTL = 12 * time.Hour
 	}
-	return &Service{
-		repo:       repo,
-		sessionTTL: sessionTTL,
+	svc := &Service{
+		repo:               repo,
+		sessionTTL:         sessionTTL,
+		sessionMaxLifetime: defaultSessionMaxLifetime,
 	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	if svc.sessionMaxLifetime < svc.sessionTTL {
+		svc.sessionMaxLifetime = svc.sessionTTL
+	}
+	return svc
+}
+
+// SessionMaxLifetime returns the longest a session can stay valid, which is
+// how long session cookies should be kept by browsers.
+func (s *Service) SessionMaxLifetime() time.Duration {
+	return s.sessionMaxLifetime
 }
 
-// CreateOrUpdateUser finds an existing user by OAuth credentials or creates a new one.
-func (s *Service) CreateOrUpdateUser(ctx context.Context, claims *GoogleClaims) (*User, error) {
-	existing, err := s.repo.FindUserByOAuth(ctx, "google", claims.Sub)
+// CreateOrUpdateUser finds the user linked to the provider identity or creates
+// a new one. Accounts are keyed per provider; an identity seen for the first
+// time is linked to an existing user with the same verified email address.
+// An unverified address that matches an existing user returns ErrEmailInUse.
+func (s *Service) CreateOrUpdateUser(ctx context.Context, provider string, claims *Claims) (*User, error) {
+	existing, err := s.repo.FindUserByOAuth(ctx, provider, claims.Sub)
 	if err != nil {
 		return nil, fmt.Errorf("find user: %w", err)
 	}
 
+	if existing == nil && claims.Email != "" {
+		existing, err = s.repo.FindUserByEmail(ctx, claims.Email)
+		if err != nil {
+			return nil, fmt.Errorf("find user by email: %w", err)
+		}
+		if existing != nil {
+			// Linking on an address the provider has not verified would let
+			// anyone claim the account.
+			if !claims.EmailVerified {
+				return nil, ErrEmailInUse
+			}
+			if err := s.repo.LinkIdentity(ctx, existing.ID, provider, claims.Sub); err != nil {
+				return nil, fmt.Errorf("link identity: %w", err)
+			}
+		}
+	}
+
 	if existing != nil {
 		// Update last login and refresh profile data
 		if err := s.repo.UpdateUserLogin(ctx, existing.ID, claims.Name, claims.Picture); err != nil {
@@ -54,7 +116,7 @@ func (s *Service) CreateOrUpdateUser(ctx context.Context, claims *GoogleClaims)
 		Email:           claims.Email,
 		Name:            claims.Name,
 		AvatarURL:       claims.Picture,
-		OAuthProvider:   "google",
+		OAuthProvider:   provider,
 		OAuthProviderID: claims.Sub,
 		CreatedAt:       now,
 		UpdatedAt:       now,
@@ -62,6 +124,9 @@ func (s *Service) CreateOrUpdateUser(ctx context.Context, claims *GoogleClaims)
 	}
 
 	created, err := s.repo.CreateUser(ctx, newUser)
+	if errors.Is(err, ErrEmailInUse) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("create user: %w", err)
 	}
@@ -81,12 +146,13 @@ func (s *Service) CreateSession(ctx context.Context, userID uuid.UUID, userAgent
 
 	now := time.Now()
 	session := Session{
-		ID:        uuid.New(),
-		UserID:    userID,
-		ExpiresAt: now.Add(s.sessionTTL),
-		CreatedAt: now,
-		UserAgent: truncateString(userAgent, 512),
-		IPAddress: truncateString(ipAddress, 45),
+		ID:         uuid.New(),
+		UserID:     userID,
+		ExpiresAt:  s.sessionExpiry(now, now),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		UserAgent:  truncateString(userAgent, 512),
+		IPAddress:  truncateString(ipAddress, 45),
 	}
 
 	if err := s.repo.CreateSession(ctx, session, tokenHash); err != nil {
@@ -98,27 +164,60 @@ func (s *Service) CreateSession(ctx context.Context, userID uuid.UUID, userAgent
 
 // ValidateSession checks if the token is valid and returns the associated user.
 func (s *Service) ValidateSession(ctx context.Context, token string) (*User, error) {
+	_, user, err := s.AuthenticateSession(ctx, token)
+	return user, err
+}
+
+// AuthenticateSession returns the session and user for a token, or nils when
+// the token is unknown or expired. Each use slides the expiry forward by the
+// session TTL, capped at the maximum lifetime; writes happen at most once a minute.
+func (s *Service) AuthenticateSession(ctx context.Context, token string) (*Session, *User, error) {
 	if token == "" {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	tokenHash := hashToken(token)
 	session, user, err := s.repo.FindSessionByTokenHash(ctx, tokenHash)
 	if err != nil {
-		return nil, fmt.Errorf("find session: %w", err)
+		return nil, nil, fmt.Errorf("find session: %w", err)
 	}
 
 	if session == nil || user == nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// Check expiration
-	if time.Now().After(session.ExpiresAt) {
+	now := time.Now()
+	if now.After(session.ExpiresAt) {
 		_ = s.repo.DeleteSession(ctx, session.ID)
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	return user, nil
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		expiresAt := s.sessionExpiry(session.CreatedAt, now)
+		if err := s.repo.TouchSession(ctx, session.ID, now, expiresAt); err != nil {
+			return nil, nil, fmt.Errorf("touch session: %w", err)
+		}
+		session.LastSeenAt = now
+		session.ExpiresAt = expiresAt
+	}
+
+	return session, user, nil
+}
+
+// ListSessions returns the user's active sessions, most recently seen first.
+func (s *Service) ListSessions(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	return s.repo.ListSessions(ctx, userID)
+}
+
+// RevokeSession ends one of the user's sessions.
+func (s *Service) RevokeSession(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return s.repo.DeleteUserSession(ctx, id, userID)
+}
+
+// RevokeAllSessions ends every session the user has and returns how many were removed.
+func (s *Service) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.repo.DeleteUserSessions(ctx, userID)
 }
 
 // DeleteSession removes the session associated with the given token.
@@ -145,6 +244,15 @@ func (s *Service) CleanupExpiredSessions(ctx context.Context) (int64, error) {
 	return s.repo.DeleteExpiredSessions(ctx)
 }
 
+// sessionExpiry extends a session by the TTL from now without passing its maximum lifetime.
+func (s *Service) sessionExpiry(createdAt, now time.Time) time.Time {
+	expiresAt := now.Add(s.sessionTTL)
+	if limit := createdAt.Add(s.sessionMaxLifetime); expiresAt.After(limit) {
+		return limit
+	}
+	return expiresAt
+}
+
 // hashToken returns the SHA-256 hash of the token as a hex string.
 func hashToken(token string) string {
 	sum := sha256.Sum256([]byte(token))
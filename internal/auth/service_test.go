@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -13,11 +14,22 @@ import (
 type repoStub struct {
 	findUserByOAuth       func(ctx context.Context, provider, providerID string) (*User, error)
 	createUser            func(ctx context.Context, user User) (User, error)
+	findUserByEmail       func(ctx context.Context, email string) (*User, error)
 	updateUserLogin       func(ctx context.Context, id uuid.UUID, name, avatarURL string) error
+	linkIdentity          func(ctx context.Context, userID uuid.UUID, provider, subject string) error
 	createSession         func(ctx context.Context, session Session, tokenHash string) error
 	findSessionByHash     func(ctx context.Context, tokenHash string) (*Session, *User, error)
+	touchSession          func(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error
+	listSessions          func(ctx context.Context, userID uuid.UUID) ([]Session, error)
 	deleteSession         func(ctx context.Context, id uuid.UUID) error
+	deleteUserSession     func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	deleteUserSessions    func(ctx context.Context, userID uuid.UUID) (int64, error)
 	deleteExpiredSessions func(ctx context.Context) (int64, error)
+	createAPIToken        func(ctx context.Context, token APIToken, tokenHash string) error
+	listAPITokens         func(ctx context.Context, userID uuid.UUID) ([]APIToken, error)
+	findAPITokenByHash    func(ctx context.Context, tokenHash string) (*APIToken, *User, error)
+	touchAPIToken         func(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	deleteAPIToken        func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 }
 
 func (r *repoStub) FindUserByOAuth(ctx context.Context, provider, providerID string) (*User, error) {
@@ -28,6 +40,9 @@ func (r *repoStub) FindUserByOAuth(ctx context.Context, provider, providerID str
 }
 
 func (r *repoStub) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	if r.findUserByEmail != nil {
+		return r.findUserByEmail(ctx, email)
+	}
 	return nil, nil
 }
 
@@ -45,6 +60,13 @@ func (r *repoStub) UpdateUserLogin(ctx context.Context, id uuid.UUID, name, avat
 	return nil
 }
 
+func (r *repoStub) LinkIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+	if r.linkIdentity != nil {
+		return r.linkIdentity(ctx, userID, provider, subject)
+	}
+	return nil
+}
+
 func (r *repoStub) CreateSession(ctx context.Context, session Session, tokenHash string) error {
 	if r.createSession != nil {
 		return r.createSession(ctx, session, tokenHash)
@@ -59,6 +81,34 @@ func (r *repoStub) FindSessionByTokenHash(ctx context.Context, tokenHash string)
 	return nil, nil, nil
 }
 
+func (r *repoStub) TouchSession(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+	if r.touchSession != nil {
+		return r.touchSession(ctx, id, lastSeenAt, expiresAt)
+	}
+	return nil
+}
+
+func (r *repoStub) ListSessions(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	if r.listSessions != nil {
+		return r.listSessions(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (r *repoStub) DeleteUserSession(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if r.deleteUserSession != nil {
+		return r.deleteUserSession(ctx, id, userID)
+	}
+	return nil
+}
+
+func (r *repoStub) DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if r.deleteUserSessions != nil {
+		return r.deleteUserSessions(ctx, userID)
+	}
+	return 0, nil
+}
+
 func (r *repoStub) DeleteSession(ctx context.Context, id uuid.UUID) error {
 	if r.deleteSession != nil {
 		return r.deleteSession(ctx, id)
@@ -73,6 +123,41 @@ func (r *repoStub) DeleteExpiredSessions(ctx context.Context) (int64, error) {
 	return 0, nil
 }
 
+func (r *repoStub) CreateAPIToken(ctx context.Context, token APIToken, tokenHash string) error {
+	if r.createAPIToken != nil {
+		return r.createAPIToken(ctx, token, tokenHash)
+	}
+	return nil
+}
+
+func (r *repoStub) ListAPITokens(ctx context.Context, userID uuid.UUID) ([]APIToken, error) {
+	if r.listAPITokens != nil {
+		return r.listAPITokens(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (r *repoStub) FindAPITokenByHash(ctx context.Context, tokenHash string) (*APIToken, *User, error) {
+	if r.findAPITokenByHash != nil {
+		return r.findAPITokenByHash(ctx, tokenHash)
+	}
+	return nil, nil, nil
+}
+
+func (r *repoStub) TouchAPIToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	if r.touchAPIToken != nil {
+		return r.touchAPIToken(ctx, id, usedAt)
+	}
+	return nil
+}
+
+func (r *repoStub) DeleteAPIToken(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if r.deleteAPIToken != nil {
+		return r.deleteAPIToken(ctx, id, userID)
+	}
+	return nil
+}
+
 func TestServiceCreateOrUpdateUserExisting(t *testing.T) {
 	userID := uuid.New()
 	existing := &User{
@@ -100,14 +185,14 @@ func TestServiceCreateOrUpdateUserExisting(t *testing.T) {
 	}
 	svc := NewService(repo, time.Hour)
 
-	claims := &GoogleClaims{
+	claims := &Claims{
 		Sub:     "sub-123",
 		Email:   "user@example.com",
 		Name:    "New Name",
 		Picture: "new.png",
 	}
 
-	user, err := svc.CreateOrUpdateUser(context.Background(), claims)
+	user, err := svc.CreateOrUpdateUser(context.Background(), "google", claims)
 	if err != nil {
 		t.Fatalf("CreateOrUpdateUser returned error: %v", err)
 	}
@@ -132,14 +217,14 @@ func TestServiceCreateOrUpdateUserCreatesNew(t *testing.T) {
 	}
 	svc := NewService(repo, time.Hour)
 
-	claims := &GoogleClaims{
+	claims := &Claims{
 		Sub:     "sub-999",
 		Email:   "new@example.com",
 		Name:    "New User",
 		Picture: "avatar.png",
 	}
 
-	user, err := svc.CreateOrUpdateUser(context.Background(), claims)
+	user, err := svc.CreateOrUpdateUser(context.Background(), "google", claims)
 	if err != nil {
 		t.Fatalf("CreateOrUpdateUser returned error: %v", err)
 	}
@@ -162,12 +247,100 @@ func TestServiceCreateOrUpdateUserFindError(t *testing.T) {
 	}
 	svc := NewService(repo, time.Hour)
 
-	_, err := svc.CreateOrUpdateUser(context.Background(), &GoogleClaims{Sub: "sub"})
+	_, err := svc.CreateOrUpdateUser(context.Background(), "google", &Claims{Sub: "sub"})
 	if err == nil || !strings.Contains(err.Error(), "find user") {
 		t.Fatalf("expected find user error, got %v", err)
 	}
 }
 
+func TestServiceCreateOrUpdateUserLinksVerifiedEmail(t *testing.T) {
+	existing := &User{ID: uuid.New(), Email: "Reader@Example.com", OAuthProvider: "google", OAuthProviderID: "google-sub"}
+	var linkedUser uuid.UUID
+	var linkedProvider, linkedSubject string
+	repo := &repoStub{
+		findUserByEmail: func(ctx context.Context, email string) (*User, error) {
+			return existing, nil
+		},
+		linkIdentity: func(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+			linkedUser, linkedProvider, linkedSubject = userID, provider, subject
+			return nil
+		},
+		createUser: func(ctx context.Context, user User) (User, error) {
+			return User{}, errors.New("unexpected create")
+		},
+	}
+	svc := NewService(repo, time.Hour)
+
+	user, err := svc.CreateOrUpdateUser(context.Background(), "corp", &Claims{Sub: "corp-sub", Email: "reader@example.com", EmailVerified: true, Name: "Reader"})
+	if err != nil {
+		t.Fatalf("CreateOrUpdateUser returned error: %v", err)
+	}
+	if user.ID != existing.ID {
+		t.Fatalf("expected the existing user, got %+v", user)
+	}
+	if linkedUser != existing.ID || linkedProvider != "corp" || linkedSubject != "corp-sub" {
+		t.Fatalf("expected corp identity linked to the existing user, got %s %s %s", linkedUser, linkedProvider, linkedSubject)
+	}
+}
+
+func TestServiceCreateOrUpdateUserRejectsUnverifiedEmailInUse(t *testing.T) {
+	repo := &repoStub{
+		findUserByEmail: func(ctx context.Context, email string) (*User, error) {
+			return &User{ID: uuid.New(), Email: email}, nil
+		},
+		linkIdentity: func(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+			return errors.New("unexpected link")
+		},
+		createUser: func(ctx context.Context, user User) (User, error) {
+			return User{}, errors.New("unexpected create")
+		},
+	}
+	svc := NewService(repo, time.Hour)
+
+	if _, err := svc.CreateOrUpdateUser(context.Background(), "corp", &Claims{Sub: "corp-sub", Email: "Reader@Example.com"}); !errors.Is(err, ErrEmailInUse) {
+		t.Fatalf("expected ErrEmailInUse, got %v", err)
+	}
+}
+
+func TestServiceCreateOrUpdateUserReportsEmailRace(t *testing.T) {
+	repo := &repoStub{
+		createUser: func(ctx context.Context, user User) (User, error) {
+			return User{}, ErrEmailInUse
+		},
+	}
+	svc := NewService(repo, time.Hour)
+
+	if _, err := svc.CreateOrUpdateUser(context.Background(), "corp", &Claims{Sub: "corp-sub", Email: "reader@example.com", EmailVerified: true}); !errors.Is(err, ErrEmailInUse) {
+		t.Fatalf("expected ErrEmailInUse, got %v", err)
+	}
+}
+
+func TestClaimsAcceptStringEmailVerified(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    bool
+	}{
+		{payload: `{"sub":"a","email_verified":true}`, want: true},
+		{payload: `{"sub":"a","email_verified":"true"}`, want: true},
+		{payload: `{"sub":"a","email_verified":"false"}`, want: false},
+		{payload: `{"sub":"a"}`, want: false},
+	}
+	for _, tc := range cases {
+		var claims Claims
+		if err := json.Unmarshal([]byte(tc.payload), &claims); err != nil {
+			t.Fatalf("unmarshal %s: %v", tc.payload, err)
+		}
+		if claims.Sub != "a" || claims.EmailVerified != tc.want {
+			t.Fatalf("unexpected claims from %s: %+v", tc.payload, claims)
+		}
+	}
+
+	var claims Claims
+	if err := json.Unmarshal([]byte(`{"email_verified":"maybe"}`), &claims); err == nil {
+		t.Fatal("expected an unparseable email_verified to be rejected")
+	}
+}
+
 func TestServiceCreateSessionStoresHash(t *testing.T) {
 	var storedHash string
 	var storedSession Session
@@ -259,6 +432,56 @@ func TestServiceValidateSessionValid(t *testing.T) {
 	}
 }
 
+func TestServiceAuthenticateSessionSlidesExpiry(t *testing.T) {
+	createdAt := time.Now().Add(-2 * time.Hour)
+	var touchedExpiry time.Time
+	repo := &repoStub{
+		findSessionByHash: func(ctx context.Context, tokenHash string) (*Session, *User, error) {
+			return &Session{ID: uuid.New(), CreatedAt: createdAt, LastSeenAt: createdAt, ExpiresAt: time.Now().Add(time.Minute)}, &User{ID: uuid.New()}, nil
+		},
+		touchSession: func(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+			touchedExpiry = expiresAt
+			return nil
+		},
+	}
+	svc := NewService(repo, time.Hour, WithSessionMaxLifetime(24*time.Hour))
+
+	session, _, err := svc.AuthenticateSession(context.Background(), "token")
+	if err != nil {
+		t.Fatalf("AuthenticateSession returned error: %v", err)
+	}
+	if until := time.Until(touchedExpiry); until < 59*time.Minute || until > time.Hour {
+		t.Fatalf("expected expiry extended by the TTL, got %s", until)
+	}
+	if !session.ExpiresAt.Equal(touchedExpiry) || time.Since(session.LastSeenAt) > time.Second {
+		t.Fatalf("expected the returned session to reflect the touch, got %+v", session)
+	}
+
+	svc = NewService(repo, time.Hour, WithSessionMaxLifetime(150*time.Minute))
+	if _, _, err := svc.AuthenticateSession(context.Background(), "token"); err != nil {
+		t.Fatalf("AuthenticateSession returned error: %v", err)
+	}
+	if !touchedExpiry.Equal(createdAt.Add(150 * time.Minute)) {
+		t.Fatalf("expected expiry capped at the maximum lifetime, got %s", touchedExpiry)
+	}
+}
+
+func TestServiceAuthenticateSessionSkipsRecentTouch(t *testing.T) {
+	repo := &repoStub{
+		findSessionByHash: func(ctx context.Context, tokenHash string) (*Session, *User, error) {
+			return &Session{ID: uuid.New(), CreatedAt: time.Now(), LastSeenAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}, &User{ID: uuid.New()}, nil
+		},
+		touchSession: func(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+			return errors.New("unexpected touch")
+		},
+	}
+	svc := NewService(repo, time.Hour)
+
+	if _, _, err := svc.AuthenticateSession(context.Background(), "token"); err != nil {
+		t.Fatalf("AuthenticateSession returned error: %v", err)
+	}
+}
+
 func TestServiceValidateSessionRepoError(t *testing.T) {
 	repo := &repoStub{
 		findSessionByHash: func(ctx context.Context, tokenHash string) (*Session, *User, error) {
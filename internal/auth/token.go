@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// ErrTokenNotFound is returned when an API token does not exist for the user.
+var ErrTokenNotFound = errors.New("api token not found")
+
+// TokenScope limits what an API token may do.
+type TokenScope string
+
+const (
+	// ScopeRead allows reading data.
+	ScopeRead TokenScope = "read"
+	// ScopeWrite allows creating, changing, and deleting data other than imports.
+	ScopeWrite TokenScope = "write"
+	// ScopeImport allows uploading CSV imports and managing import jobs.
+	ScopeImport TokenScope = "import"
+)
+
+// apiTokenPrefix marks Anthology tokens so leaked secrets are easy to recognise.
+const apiTokenPrefix = "anth_"
+
+// displayPrefixLength is how much of a secret is kept to help users tell tokens apart.
+const displayPrefixLength = len(apiTokenPrefix) + 6
+
+// lastUsedInterval throttles last-used writes for busy tokens.
+const lastUsedInterval = time.Minute
+
+const maxTokenNameLength = 100
+
+// APIToken is a personal access token for scripts and integrations. Only a
+// hash of the secret is stored; the secret is shown once when created.
+type APIToken struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"-"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	Scopes     []TokenScope `json:"scopes"`
+	ExpiresAt  *time.Time   `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time   `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// Allows reports whether the token carries scope.
+func (t APIToken) Allows(scope TokenScope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// APITokenInput describes a token to create. A nil ExpiresAt never expires.
+type APITokenInput struct {
+	Name      string       `json:"name"`
+	Scopes    []TokenScope `json:"scopes"`
+	ExpiresAt *time.Time   `json:"expiresAt"`
+}
+
+// CreateAPIToken issues a token for the user and returns it with its secret,
+// which cannot be retrieved again.
+func (s *Service) CreateAPIToken(ctx context.Context, userID uuid.UUID, input APITokenInput) (APIToken, string, error) {
+	now := time.Now().UTC()
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return APIToken{}, "", fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if len(name) > maxTokenNameLength {
+		return APIToken{}, "", fmt.Errorf("%w: name must be %d characters or fewer", ErrValidation, maxTokenNameLength)
+	}
+	scopes, err := normalizeScopes(input.Scopes)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return APIToken{}, "", fmt.Errorf("%w: expiresAt must be in the future", ErrValidation)
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return APIToken{}, "", fmt.Errorf("generate api token: %w", err)
+	}
+	secret := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	token := APIToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    secret[:displayPrefixLength],
+		Scopes:    scopes,
+		ExpiresAt: input.ExpiresAt,
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateAPIToken(ctx, token, hashToken(secret)); err != nil {
+		return APIToken{}, "", fmt.Errorf("create api token: %w", err)
+	}
+	return token, secret, nil
+}
+
+// ListAPITokens returns the user's tokens, newest first.
+func (s *Service) ListAPITokens(ctx context.Context, userID uuid.UUID) ([]APIToken, error) {
+	return s.repo.ListAPITokens(ctx, userID)
+}
+
+// RevokeAPIToken deletes one of the user's tokens.
+func (s *Service) RevokeAPIToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return s.repo.DeleteAPIToken(ctx, id, userID)
+}
+
+// ValidateAPIToken returns the token and its user for a presented secret, or
+// nils when the secret is unknown or expired. Last-used times are refreshed
+// at most once a minute.
+func (s *Service) ValidateAPIToken(ctx context.Context, secret string) (*User, *APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, nil, nil
+	}
+
+	token, user, err := s.repo.FindAPITokenByHash(ctx, hashToken(secret))
+	if err != nil {
+		return nil, nil, fmt.Errorf("find api token: %w", err)
+	}
+	if token == nil || user == nil {
+		return nil, nil, nil
+	}
+
+	now := time.Now().UTC()
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, nil, nil
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedInterval {
+		if err := s.repo.TouchAPIToken(ctx, token.ID, now); err != nil {
+			return nil, nil, fmt.Errorf("record api token use: %w", err)
+		}
+		token.LastUsedAt = &now
+	}
+	return user, token, nil
+}
+
+// normalizeScopes checks scopes against the known set and removes repeats.
+func normalizeScopes(scopes []TokenScope) ([]TokenScope, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrValidation)
+	}
+	normalized := make([]TokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = TokenScope(strings.ToLower(strings.TrimSpace(string(scope))))
+		switch scope {
+		case ScopeRead, ScopeWrite, ScopeImport:
+		default:
+			return nil, fmt.Errorf("%w: unknown scope %q (expected read, write, or import)", ErrValidation, scope)
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
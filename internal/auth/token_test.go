@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestServiceCreateAPITokenStoresHash(t *testing.T) {
+	var storedHash string
+	var stored APIToken
+	repo := &repoStub{
+		createAPIToken: func(ctx context.Context, token APIToken, tokenHash string) error {
+			stored = token
+			storedHash = tokenHash
+			return nil
+		},
+	}
+	svc := NewService(repo, time.Hour)
+
+	token, secret, err := svc.CreateAPIToken(context.Background(), uuid.New(), APITokenInput{Name: " Scanner ", Scopes: []TokenScope{"READ", ScopeImport, ScopeRead}})
+	if err != nil {
+		t.Fatalf("CreateAPIToken returned error: %v", err)
+	}
+	if !strings.HasPrefix(secret, apiTokenPrefix) || storedHash != hashToken(secret) {
+		t.Fatalf("expected the secret's hash to be stored, got %q", storedHash)
+	}
+	if token.Name != "Scanner" || len(token.Scopes) != 2 || !token.Allows(ScopeImport) || token.Allows(ScopeWrite) {
+		t.Fatalf("unexpected token %+v", token)
+	}
+	if stored.Prefix != secret[:displayPrefixLength] {
+		t.Fatalf("expected the display prefix to be stored, got %q", stored.Prefix)
+	}
+}
+
+func TestServiceCreateAPITokenValidates(t *testing.T) {
+	svc := NewService(&repoStub{}, time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	inputs := []APITokenInput{
+		{Scopes: []TokenScope{ScopeRead}},
+		{Name: "Script"},
+		{Name: "Script", Scopes: []TokenScope{"admin"}},
+		{Name: "Script", Scopes: []TokenScope{ScopeRead}, ExpiresAt: &past},
+	}
+	for _, input := range inputs {
+		if _, _, err := svc.CreateAPIToken(context.Background(), uuid.New(), input); !errors.Is(err, ErrValidation) {
+			t.Fatalf("expected validation error for %+v, got %v", input, err)
+		}
+	}
+}
+
+func TestServiceValidateAPIToken(t *testing.T) {
+	expected := &User{ID: uuid.New(), Email: "user@example.com"}
+	expired := time.Now().Add(-time.Minute)
+	recent := time.Now().Add(-time.Second)
+	tokens := map[string]*APIToken{
+		hashToken("anth_live"):    {ID: uuid.New(), Scopes: []TokenScope{ScopeRead}},
+		hashToken("anth_expired"): {ID: uuid.New(), Scopes: []TokenScope{ScopeRead}, ExpiresAt: &expired},
+		hashToken("anth_recent"):  {ID: uuid.New(), Scopes: []TokenScope{ScopeRead}, LastUsedAt: &recent},
+	}
+	var touched []uuid.UUID
+	repo := &repoStub{
+		findAPITokenByHash: func(ctx context.Context, tokenHash string) (*APIToken, *User, error) {
+			token, ok := tokens[tokenHash]
+			if !ok {
+				return nil, nil, nil
+			}
+			copied := *token
+			return &copied, expected, nil
+		},
+		touchAPIToken: func(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+			touched = append(touched, id)
+			return nil
+		},
+	}
+	svc := NewService(repo, time.Hour)
+
+	user, token, err := svc.ValidateAPIToken(context.Background(), "anth_live")
+	if err != nil || user != expected || token == nil || token.LastUsedAt == nil {
+		t.Fatalf("expected live token to validate, got %+v %+v (%v)", user, token, err)
+	}
+	if user, _, _ := svc.ValidateAPIToken(context.Background(), "anth_expired"); user != nil {
+		t.Fatal("expected expired token to be rejected")
+	}
+	if user, _, _ := svc.ValidateAPIToken(context.Background(), "anth_unknown"); user != nil {
+		t.Fatal("expected unknown token to be rejected")
+	}
+	if user, _, _ := svc.ValidateAPIToken(context.Background(), "session-token"); user != nil {
+		t.Fatal("expected non-token secrets to be rejected")
+	}
+	if _, _, err := svc.ValidateAPIToken(context.Background(), "anth_recent"); err != nil {
+		t.Fatalf("ValidateAPIToken returned error: %v", err)
+	}
+	if len(touched) != 1 || touched[0] != tokens[hashToken("anth_live")].ID {
+		t.Fatalf("expected only the stale token's last use to be recorded, got %v", touched)
+	}
+}
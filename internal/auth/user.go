@@ -1,6 +1,10 @@
 package auth
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,21 +23,57 @@ type User struct {
 	LastLoginAt     time.Time
 }
 
-// Session represents an authenticated user session.
+// Session represents an authenticated user session. ExpiresAt slides forward
+// while the session is used but never passes CreatedAt plus the service's
+// maximum session lifetime.
 type Session struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	ExpiresAt time.Time
-	CreatedAt time.Time
-	UserAgent string
-	IPAddress string
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"-"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
 }
 
-// GoogleClaims contains the relevant claims from a Google ID token.
-type GoogleClaims struct {
+// Claims contains the relevant claims from an OpenID Connect ID token.
+type Claims struct {
 	Sub           string `json:"sub"`
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
 	Name          string `json:"name"`
 	Picture       string `json:"picture"`
 }
+
+// UnmarshalJSON reads email_verified as a boolean or as the strings "true"
+// and "false", which some providers send instead.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	type plain Claims
+	var raw struct {
+		plain
+		EmailVerified json.RawMessage `json:"email_verified"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = Claims(raw.plain)
+
+	verified := bytes.TrimSpace(raw.EmailVerified)
+	if len(verified) == 0 || bytes.Equal(verified, []byte("null")) {
+		c.EmailVerified = false
+		return nil
+	}
+	if err := json.Unmarshal(verified, &c.EmailVerified); err == nil {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(verified, &text); err != nil {
+		return fmt.Errorf("email_verified: %w", err)
+	}
+	parsed, err := strconv.ParseBool(text)
+	if err != nil {
+		return fmt.Errorf("email_verified: %w", err)
+	}
+	c.EmailVerified = parsed
+	return nil
+}
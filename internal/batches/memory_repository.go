@@ -0,0 +1,71 @@
+package batches
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu      sync.RWMutex
+	batches map[uuid.UUID]Batch
+}
+
+// NewInMemoryRepository seeds an empty batch repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{batches: make(map[uuid.UUID]Batch)}
+}
+
+func (m *inMemoryRepository) List(_ context.Context, ownerID uuid.UUID) ([]Batch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	batches := []Batch{}
+	for _, batch := range m.batches {
+		if batch.OwnerID == ownerID {
+			batches = append(batches, batch)
+		}
+	}
+	slices.SortFunc(batches, func(a, b Batch) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return batches, nil
+}
+
+func (m *inMemoryRepository) Get(_ context.Context, id uuid.UUID, ownerID uuid.UUID) (Batch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	batch, ok := m.batches[id]
+	if !ok || batch.OwnerID != ownerID {
+		return Batch{}, ErrNotFound
+	}
+	return batch, nil
+}
+
+func (m *inMemoryRepository) Create(_ context.Context, batch Batch) (Batch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	batch.Items = slices.Clone(batch.Items)
+	m.batches[batch.ID] = batch
+	return batch, nil
+}
+
+func (m *inMemoryRepository) MarkRolledBack(_ context.Context, id uuid.UUID, ownerID uuid.UUID, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	batch, ok := m.batches[id]
+	if !ok || batch.OwnerID != ownerID {
+		return ErrNotFound
+	}
+	if batch.RolledBackAt == nil {
+		batch.RolledBackAt = &at
+		m.batches[id] = batch
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when an import batch cannot be found.
+var ErrNotFound = errors.New("import batch not found")
+
+// BatchItem is an item an import created. UpdatedAt is its timestamp right
+// after the import; a later one means the item has been edited since.
+type BatchItem struct {
+	ItemID    uuid.UUID `json:"itemId"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Batch records the items created by one import so it can be rolled back.
+type Batch struct {
+	ID           uuid.UUID   `json:"id"`
+	OwnerID      uuid.UUID   `json:"-"`
+	Label        string      `json:"label"`
+	Items        []BatchItem `json:"items"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	RolledBackAt *time.Time  `json:"rolledBackAt,omitempty"`
+}
+
+// KeepReason explains why a rollback left an item in place.
+type KeepReason string
+
+const (
+	// KeepEdited marks items changed after the import.
+	KeepEdited KeepReason = "edited"
+	// KeepOnLoan marks items currently lent out.
+	KeepOnLoan KeepReason = "on_loan"
+	// KeepReviewed marks items reviewed after the import.
+	KeepReviewed KeepReason = "reviewed"
+	// KeepShelved marks items placed on a shelf.
+	KeepShelved KeepReason = "shelved"
+	// KeepReadingLogged marks items with logged reading sessions.
+	KeepReadingLogged KeepReason = "reading_logged"
+	// KeepQueued marks items in the Up Next queue.
+	KeepQueued KeepReason = "queued"
+)
+
+// KeptItem is an item a rollback left in place.
+type KeptItem struct {
+	ItemID uuid.UUID  `json:"itemId"`
+	Title  string     `json:"title"`
+	Reason KeepReason `json:"reason"`
+}
+
+// RollbackResult reports what a rollback deleted and what it kept. Items
+// deleted before the rollback, by hand or by an earlier rollback, are listed
+// under AlreadyDeleted.
+type RollbackResult struct {
+	Batch          Batch       `json:"batch"`
+	Deleted        []BatchItem `json:"deleted"`
+	Kept           []KeptItem  `json:"kept"`
+	AlreadyDeleted []BatchItem `json:"alreadyDeleted"`
+}
+
+// Repository persists import batches.
+type Repository interface {
+	List(ctx context.Context, ownerID uuid.UUID) ([]Batch, error)
+	Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Batch, error)
+	Create(ctx context.Context, batch Batch) (Batch, error)
+	// MarkRolledBack records when a batch was first rolled back; later calls
+	// leave the recorded time as it is.
+	MarkRolledBack(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,125 @@
+package batches
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"anthology/internal/platform/database"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates an import batch repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const batchColumns = `id, owner_id, label, items, created_at, rolled_back_at`
+
+// batchRow mirrors import_batches; the created items are stored as jsonb.
+type batchRow struct {
+	ID           uuid.UUID  `db:"id"`
+	OwnerID      uuid.UUID  `db:"owner_id"`
+	Label        string     `db:"label"`
+	Items        []byte     `db:"items"`
+	CreatedAt    time.Time  `db:"created_at"`
+	RolledBackAt *time.Time `db:"rolled_back_at"`
+}
+
+func newBatchRow(batch Batch) (batchRow, error) {
+	created, err := json.Marshal(batch.Items)
+	if err != nil {
+		return batchRow{}, fmt.Errorf("encode import batch items: %w", err)
+	}
+	return batchRow{
+		ID:           batch.ID,
+		OwnerID:      batch.OwnerID,
+		Label:        batch.Label,
+		Items:        created,
+		CreatedAt:    batch.CreatedAt,
+		RolledBackAt: batch.RolledBackAt,
+	}, nil
+}
+
+func (row batchRow) batch() (Batch, error) {
+	created := []BatchItem{}
+	if len(row.Items) > 0 {
+		if err := json.Unmarshal(row.Items, &created); err != nil {
+			return Batch{}, fmt.Errorf("decode import batch items: %w", err)
+		}
+	}
+	return Batch{
+		ID:           row.ID,
+		OwnerID:      row.OwnerID,
+		Label:        row.Label,
+		Items:        created,
+		CreatedAt:    row.CreatedAt,
+		RolledBackAt: row.RolledBackAt,
+	}, nil
+}
+
+func (r *postgresRepository) List(ctx context.Context, ownerID uuid.UUID) ([]Batch, error) {
+	var rows []batchRow
+	query := `SELECT ` + batchColumns + ` FROM import_batches WHERE owner_id = $1 ORDER BY created_at DESC`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list import batches: %w", err)
+	}
+	batches := make([]Batch, 0, len(rows))
+	for _, row := range rows {
+		batch, err := row.batch()
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Batch, error) {
+	var row batchRow
+	query := `SELECT ` + batchColumns + ` FROM import_batches WHERE id = $1 AND owner_id = $2`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &row, query, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Batch{}, ErrNotFound
+		}
+		return Batch{}, fmt.Errorf("get import batch: %w", err)
+	}
+	return row.batch()
+}
+
+func (r *postgresRepository) Create(ctx context.Context, batch Batch) (Batch, error) {
+	row, err := newBatchRow(batch)
+	if err != nil {
+		return Batch{}, err
+	}
+	query := `INSERT INTO import_batches (` + batchColumns + `)
+VALUES (:id, :owner_id, :label, :items, :created_at, :rolled_back_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, row); err != nil {
+		return Batch{}, fmt.Errorf("insert import batch: %w", err)
+	}
+	return batch, nil
+}
+
+func (r *postgresRepository) MarkRolledBack(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, at time.Time) error {
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, `UPDATE import_batches SET rolled_back_at = COALESCE(rolled_back_at, $3) WHERE id = $1 AND owner_id = $2`, id, ownerID, at)
+	if err != nil {
+		return fmt.Errorf("mark import batch rolled back: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mark import batch rolled back rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
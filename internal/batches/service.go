@@ -0,0 +1,157 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// timestampPrecision absorbs the rounding of timestamps stored in Postgres,
+// which keeps microseconds, so an untouched item is not mistaken for an edited one.
+const timestampPrecision = time.Microsecond
+
+// ItemStore reads and deletes the items a batch created.
+type ItemStore interface {
+	Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (items.Item, error)
+	Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+}
+
+// UsageCheck reports whether an imported item has been used in a way a
+// rollback should not throw away. since is when the batch was recorded, after
+// everything the import itself wrote.
+type UsageCheck interface {
+	ItemUsedSince(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error)
+}
+
+type usageCheck struct {
+	reason KeepReason
+	check  UsageCheck
+}
+
+// Service records import batches and rolls them back.
+type Service struct {
+	repo   Repository
+	items  ItemStore
+	checks []usageCheck
+	now    func() time.Time
+}
+
+// Option configures optional service behaviour.
+type Option func(*Service)
+
+// WithUsageCheck keeps items check reports as used, listed under reason.
+// Checks run in the order they are added, after the edit and loan checks.
+func WithUsageCheck(reason KeepReason, check UsageCheck) Option {
+	return func(s *Service) {
+		s.checks = append(s.checks, usageCheck{reason: reason, check: check})
+	}
+}
+
+// NewService constructs a batch service.
+func NewService(repo Repository, items ItemStore, opts ...Option) *Service {
+	s := &Service{repo: repo, items: items, now: func() time.Time { return time.Now().UTC() }}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// RecordBatch saves the items an import created and returns the batch ID.
+func (s *Service) RecordBatch(ctx context.Context, ownerID uuid.UUID, label string, created []items.Item) (uuid.UUID, error) {
+	entries := make([]BatchItem, 0, len(created))
+	for _, item := range created {
+		entries = append(entries, BatchItem{ItemID: item.ID, Title: item.Title, UpdatedAt: item.UpdatedAt})
+	}
+	batch, err := s.repo.Create(ctx, Batch{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		Label:     label,
+		Items:     entries,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return batch.ID, nil
+}
+
+// List returns the owner's batches, newest first.
+func (s *Service) List(ctx context.Context, ownerID uuid.UUID) ([]Batch, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Get returns a single batch.
+func (s *Service) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Batch, error) {
+	return s.repo.Get(ctx, id, ownerID)
+}
+
+// Rollback deletes the batch's items that are unchanged since the import.
+// Edited items, items out on loan, and items the usage checks report as used
+// are kept. A rollback can be repeated, for example after returning a loaned
+// item; the batch keeps the time of its first rollback.
+func (s *Service) Rollback(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (RollbackResult, error) {
+	batch, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+
+	result := RollbackResult{Deleted: []BatchItem{}, Kept: []KeptItem{}, AlreadyDeleted: []BatchItem{}}
+	for _, entry := range batch.Items {
+		item, err := s.items.Get(ctx, entry.ItemID, ownerID)
+		if errors.Is(err, items.ErrNotFound) {
+			result.AlreadyDeleted = append(result.AlreadyDeleted, entry)
+			continue
+		}
+		if err != nil {
+			return RollbackResult{}, fmt.Errorf("load imported item %s: %w", entry.ItemID, err)
+		}
+
+		reason, keep, err := s.keepReason(ctx, item, entry, batch.CreatedAt)
+		if err != nil {
+			return RollbackResult{}, fmt.Errorf("check imported item %s: %w", item.ID, err)
+		}
+		if keep {
+			result.Kept = append(result.Kept, KeptItem{ItemID: item.ID, Title: item.Title, Reason: reason})
+			continue
+		}
+		if err := s.items.Delete(ctx, item.ID, ownerID); err != nil {
+			return RollbackResult{}, fmt.Errorf("delete imported item %s: %w", item.ID, err)
+		}
+		result.Deleted = append(result.Deleted, entry)
+	}
+
+	now := s.now()
+	if err := s.repo.MarkRolledBack(ctx, id, ownerID, now); err != nil {
+		return RollbackResult{}, err
+	}
+	if batch.RolledBackAt == nil {
+		batch.RolledBackAt = &now
+	}
+	result.Batch = batch
+	return result, nil
+}
+
+// keepReason reports why an imported item should survive a rollback.
+func (s *Service) keepReason(ctx context.Context, item items.Item, entry BatchItem, recordedAt time.Time) (KeepReason, bool, error) {
+	if item.UpdatedAt.Sub(entry.UpdatedAt).Abs() > timestampPrecision {
+		return KeepEdited, true, nil
+	}
+	if item.ActiveLoan != nil {
+		return KeepOnLoan, true, nil
+	}
+	for _, c := range s.checks {
+		used, err := c.check.ItemUsedSince(ctx, item.ID, item.OwnerID, recordedAt)
+		if err != nil {
+			return "", false, err
+		}
+		if used {
+			return c.reason, true, nil
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,181 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/queue"
+	"anthology/internal/reading"
+	"anthology/internal/reviews"
+	"anthology/internal/shelves"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func TestRollbackKeepsEditedAndLoanedItems(t *testing.T) {
+	ctx := context.Background()
+	itemRepo := items.NewInMemoryRepository(nil)
+	itemSvc := items.NewService(itemRepo)
+	svc := NewService(NewInMemoryRepository(), itemSvc)
+
+	var created []items.Item
+	for _, title := range []string{"Dune", "Piranesi", "Hades", "Emma"} {
+		item, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: testOwnerID, Title: title, ItemType: items.ItemTypeBook})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		created = append(created, item)
+	}
+	batchID, err := svc.RecordBatch(ctx, testOwnerID, "library.csv", created)
+	if err != nil {
+		t.Fatalf("record batch: %v", err)
+	}
+
+	edited := created[1]
+	edited.Notes = "signed copy"
+	edited.UpdatedAt = edited.UpdatedAt.Add(time.Minute)
+	if _, err := itemRepo.Update(ctx, edited); err != nil {
+		t.Fatalf("edit item: %v", err)
+	}
+	if err := itemRepo.UpdateActiveLoan(ctx, created[2].ID, &items.ActiveLoan{LoanID: uuid.New(), BorrowerName: "Sam", LentAt: time.Now()}); err != nil {
+		t.Fatalf("lend item: %v", err)
+	}
+	if err := itemSvc.Delete(ctx, created[3].ID, testOwnerID); err != nil {
+		t.Fatalf("delete item: %v", err)
+	}
+
+	result, err := svc.Rollback(ctx, batchID, testOwnerID)
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0].ItemID != created[0].ID {
+		t.Fatalf("expected only the untouched item deleted, got %+v", result.Deleted)
+	}
+	if len(result.Kept) != 2 || result.Kept[0].Reason != KeepEdited || result.Kept[1].Reason != KeepOnLoan {
+		t.Fatalf("unexpected kept items %+v", result.Kept)
+	}
+	if len(result.AlreadyDeleted) != 1 || result.AlreadyDeleted[0].Title != "Emma" {
+		t.Fatalf("unexpected already deleted items %+v", result.AlreadyDeleted)
+	}
+	if result.Batch.RolledBackAt == nil {
+		t.Fatal("expected the batch to be marked rolled back")
+	}
+
+	if _, err := itemSvc.Get(ctx, created[0].ID, testOwnerID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected rolled back item to be gone, got %v", err)
+	}
+	remaining, _ := itemSvc.List(ctx, items.ListOptions{OwnerID: testOwnerID})
+	if len(remaining) != 2 {
+		t.Fatalf("expected the kept items to remain, found %d", len(remaining))
+	}
+
+	if err := itemRepo.UpdateActiveLoan(ctx, created[2].ID, nil); err != nil {
+		t.Fatalf("return item: %v", err)
+	}
+	again, err := svc.Rollback(ctx, batchID, testOwnerID)
+	if err != nil {
+		t.Fatalf("repeat rollback: %v", err)
+	}
+	if len(again.Deleted) != 1 || again.Deleted[0].ItemID != created[2].ID {
+		t.Fatalf("expected the returned item deleted on the second rollback, got %+v", again.Deleted)
+	}
+	if !again.Batch.RolledBackAt.Equal(*result.Batch.RolledBackAt) {
+		t.Fatalf("expected the first rollback time to be kept, got %s", again.Batch.RolledBackAt)
+	}
+	stored, _ := svc.Get(ctx, batchID, testOwnerID)
+	if !stored.RolledBackAt.Equal(*result.Batch.RolledBackAt) {
+		t.Fatalf("expected the stored rollback time to be kept, got %s", stored.RolledBackAt)
+	}
+}
+
+func TestBatchesAreScopedToOwner(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewInMemoryRepository(), items.NewService(items.NewInMemoryRepository(nil)))
+
+	batchID, err := svc.RecordBatch(ctx, testOwnerID, "library.csv", []items.Item{{ID: uuid.New(), Title: "Dune"}})
+	if err != nil {
+		t.Fatalf("record batch: %v", err)
+	}
+	if _, err := svc.Rollback(ctx, batchID, uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected other owners not to roll back the batch, got %v", err)
+	}
+	batches, err := svc.List(ctx, testOwnerID)
+	if err != nil || len(batches) != 1 || batches[0].Label != "library.csv" {
+		t.Fatalf("unexpected batches %+v (%v)", batches, err)
+	}
+}
+
+func TestRollbackKeepsItemsUsedSinceImport(t *testing.T) {
+	ctx := context.Background()
+	itemRepo := items.NewInMemoryRepository(nil)
+	itemSvc := items.NewService(itemRepo)
+	reviewSvc := reviews.NewService(reviews.NewInMemoryRepository(), itemRepo)
+	shelfSvc := shelves.NewService(shelves.NewInMemoryRepository(), itemRepo, nil, itemSvc)
+	readingRepo := reading.NewInMemoryRepository()
+	queueSvc := queue.NewService(queue.NewInMemoryRepository(), itemRepo)
+	svc := NewService(NewInMemoryRepository(), itemSvc,
+		WithUsageCheck(KeepReviewed, reviewSvc),
+		WithUsageCheck(KeepShelved, shelfSvc),
+		WithUsageCheck(KeepReadingLogged, reading.NewService(readingRepo, itemRepo)),
+		WithUsageCheck(KeepQueued, queueSvc),
+	)
+
+	var created []items.Item
+	for _, title := range []string{"Imported Review", "Reviewed", "Shelved", "Logged", "Queued"} {
+		item, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: testOwnerID, Title: title, ItemType: items.ItemTypeBook})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		created = append(created, item)
+	}
+	// Reviews restored by the import keep their original timestamps and do not count.
+	writtenAt := time.Now().UTC().Add(-24 * time.Hour)
+	if _, err := reviewSvc.Create(ctx, created[0].ID, reviews.ReviewInput{Body: "From the export", CreatedAt: &writtenAt, UpdatedAt: &writtenAt}, testOwnerID); err != nil {
+		t.Fatalf("import review: %v", err)
+	}
+	batchID, err := svc.RecordBatch(ctx, testOwnerID, "library.csv", created)
+	if err != nil {
+		t.Fatalf("record batch: %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+	if _, err := reviewSvc.Create(ctx, created[1].ID, reviews.ReviewInput{Body: "Loved it"}, testOwnerID); err != nil {
+		t.Fatalf("review item: %v", err)
+	}
+	shelf, err := shelfSvc.CreateShelf(ctx, shelves.CreateShelfInput{Name: "Living Room", PhotoURL: "https://example.com/shelf.jpg"}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create shelf: %v", err)
+	}
+	if _, err := shelfSvc.AssignItem(ctx, shelf.Shelf.ID, shelf.Slots[0].ID, created[2].ID, testOwnerID); err != nil {
+		t.Fatalf("shelve item: %v", err)
+	}
+	if _, err := readingRepo.CreateSession(ctx, reading.Session{ID: uuid.New(), OwnerID: testOwnerID, ItemID: created[3].ID, Date: time.Now(), EndPage: 40, CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatalf("log session: %v", err)
+	}
+	if _, err := queueSvc.Enqueue(ctx, queue.EnqueueInput{ItemID: created[4].ID}, testOwnerID); err != nil {
+		t.Fatalf("queue item: %v", err)
+	}
+
+	result, err := svc.Rollback(ctx, batchID, testOwnerID)
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0].ItemID != created[0].ID {
+		t.Fatalf("expected only the item with an imported review deleted, got %+v", result.Deleted)
+	}
+	want := []KeepReason{KeepReviewed, KeepShelved, KeepReadingLogged, KeepQueued}
+	if len(result.Kept) != len(want) {
+		t.Fatalf("unexpected kept items %+v", result.Kept)
+	}
+	for i, kept := range result.Kept {
+		if kept.ItemID != created[i+1].ID || kept.Reason != want[i] {
+			t.Fatalf("expected %s kept as %s, got %+v", created[i+1].Title, want[i], kept)
+		}
+	}
+}
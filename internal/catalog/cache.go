@@ -0,0 +1,281 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultCacheTTL is how long successful provider responses are reused.
+	DefaultCacheTTL = 30 * 24 * time.Hour
+	// DefaultNegativeCacheTTL is how long ErrNotFound responses are remembered.
+	DefaultNegativeCacheTTL = time.Hour
+)
+
+// CacheEntry is a provider response stored in a Cache.
+type CacheEntry struct {
+	Provider  string
+	Key       string
+	Results   []Metadata
+	NotFound  bool
+	ExpiresAt time.Time
+}
+
+// Cache persists provider responses keyed by provider name and normalized query.
+// Implementations must not return entries whose ExpiresAt has passed.
+type Cache interface {
+	Get(ctx context.Context, provider, key string) (CacheEntry, bool, error)
+	Set(ctx context.Context, entry CacheEntry) error
+	// Purge removes entries for the provider, or every entry when provider is empty.
+	Purge(ctx context.Context, provider string) (int64, error)
+	// PurgeExpired removes entries whose ExpiresAt has passed.
+	PurgeExpired(ctx context.Context) (int64, error)
+	// Count reports the number of unexpired entries.
+	Count(ctx context.Context) (int64, error)
+}
+
+// CacheStats summarizes lookup cache effectiveness since the service started.
+type CacheStats struct {
+	Enabled      bool  `json:"enabled"`
+	Hits         int64 `json:"hits"`
+	NegativeHits int64 `json:"negativeHits"`
+	Misses       int64 `json:"misses"`
+	Errors       int64 `json:"errors"`
+	Entries      int64 `json:"entries"`
+}
+
+// WithCache caches provider responses. Successful responses are kept for ttl and
+// ErrNotFound responses for negativeTTL; non-positive values fall back to the defaults.
+func WithCache(cache Cache, ttl, negativeTTL time.Duration) Option {
+	return func(s *Service) {
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		if negativeTTL <= 0 {
+			negativeTTL = DefaultNegativeCacheTTL
+		}
+		s.cache = cache
+		s.cacheTTL = ttl
+		s.negativeCacheTTL = negativeTTL
+	}
+}
+
+type cacheCounters struct {
+	hits         atomic.Int64
+	negativeHits atomic.Int64
+	misses       atomic.Int64
+	errors       atomic.Int64
+}
+
+// cachedProvider decorates a Provider with read-through caching. Cache failures
+// are counted but never fail the lookup.
+type cachedProvider struct {
+	Provider
+	cache       Cache
+	ttl         time.Duration
+	negativeTTL time.Duration
+	counters    *cacheCounters
+}
+
+func (p *cachedProvider) Lookup(ctx context.Context, query string) ([]Metadata, error) {
+	key := cacheKey(query)
+	if entry, ok := p.fromCache(ctx, key); ok {
+		if entry.NotFound {
+			return nil, ErrNotFound
+		}
+		return entry.Results, nil
+	}
+
+	results, err := p.Provider.Lookup(ctx, query)
+	p.store(ctx, key, results, err)
+	return results, err
+}
+
+func (p *cachedProvider) LookupByID(ctx context.Context, id string) (Metadata, error) {
+	key := "id:" + strings.TrimSpace(id)
+	if entry, ok := p.fromCache(ctx, key); ok {
+		if entry.NotFound {
+			return Metadata{}, ErrNotFound
+		}
+		if len(entry.Results) > 0 {
+			return entry.Results[0], nil
+		}
+	}
+
+	metadata, err := p.Provider.LookupByID(ctx, id)
+	if err != nil {
+		p.store(ctx, key, nil, err)
+		return Metadata{}, err
+	}
+	p.store(ctx, key, []Metadata{metadata}, nil)
+	return metadata, nil
+}
+
+func (p *cachedProvider) fromCache(ctx context.Context, key string) (CacheEntry, bool) {
+	entry, ok, err := p.cache.Get(ctx, p.Name(), key)
+	switch {
+	case err != nil:
+		p.counters.errors.Add(1)
+		return CacheEntry{}, false
+	case !ok:
+		p.counters.misses.Add(1)
+		return CacheEntry{}, false
+	case entry.NotFound:
+		p.counters.negativeHits.Add(1)
+	default:
+		p.counters.hits.Add(1)
+	}
+	return entry, true
+}
+
+func (p *cachedProvider) store(ctx context.Context, key string, results []Metadata, lookupErr error) {
+	entry := CacheEntry{Provider: p.Name(), Key: key}
+	switch {
+	case lookupErr == nil && len(results) > 0:
+		entry.Results = results
+		entry.ExpiresAt = time.Now().Add(p.ttl)
+	case errors.Is(lookupErr, ErrNotFound):
+		entry.NotFound = true
+		entry.ExpiresAt = time.Now().Add(p.negativeTTL)
+	default:
+		// Upstream failures are transient; don't remember them.
+		return
+	}
+
+	if err := p.cache.Set(ctx, entry); err != nil {
+		p.counters.errors.Add(1)
+	}
+}
+
+// cacheKey normalizes queries so trivially different spellings share an entry.
+func cacheKey(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+// CacheStats reports hit/miss counters along with the number of live cache entries.
+func (s *Service) CacheStats(ctx context.Context) (CacheStats, error) {
+	stats := CacheStats{
+		Enabled:      s.cache != nil,
+		Hits:         s.cacheCounters.hits.Load(),
+		NegativeHits: s.cacheCounters.negativeHits.Load(),
+		Misses:       s.cacheCounters.misses.Load(),
+		Errors:       s.cacheCounters.errors.Load(),
+	}
+	if s.cache == nil {
+		return stats, nil
+	}
+
+	entries, err := s.cache.Count(ctx)
+	if err != nil {
+		return CacheStats{}, err
+	}
+	stats.Entries = entries
+	return stats, nil
+}
+
+// PurgeCache removes cached responses for the provider, or all responses when
+// provider is empty. It returns the number of entries removed.
+func (s *Service) PurgeCache(ctx context.Context, provider string) (int64, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+	provider = strings.TrimSpace(provider)
+	if provider != "" {
+		if _, ok := s.registry.Provider(provider); !ok {
+			return 0, ErrUnknownProvider
+		}
+	}
+	return s.cache.Purge(ctx, provider)
+}
+
+// PurgeExpiredCache removes cached responses that can no longer be served.
+// It returns the number of entries removed.
+func (s *Service) PurgeExpiredCache(ctx context.Context) (int64, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+	return s.cache.PurgeExpired(ctx)
+}
+
+// InMemoryCache is a process-local Cache, primarily for tests and development.
+type InMemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]CacheEntry
+	now     func() time.Time
+}
+
+// NewInMemoryCache constructs an empty InMemoryCache.
+func NewInMemoryCache() *InMemoryCache {
+	return &InMemoryCache{
+		entries: make(map[string]CacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *InMemoryCache) Get(_ context.Context, provider, key string) (CacheEntry, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[provider+"\x00"+key]
+	if !ok || !entry.ExpiresAt.After(c.now()) {
+		return CacheEntry{}, false, nil
+	}
+	entry.Results = append([]Metadata(nil), entry.Results...)
+	return entry, true, nil
+}
+
+func (c *InMemoryCache) Set(_ context.Context, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.Results = append([]Metadata(nil), entry.Results...)
+	c.entries[entry.Provider+"\x00"+entry.Key] = entry
+	return nil
+}
+
+func (c *InMemoryCache) Purge(_ context.Context, provider string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var removed int64
+	for id, entry := range c.entries {
+		if provider == "" || entry.Provider == provider {
+			delete(c.entries, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *InMemoryCache) PurgeExpired(_ context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	var removed int64
+	for id, entry := range c.entries {
+		if !entry.ExpiresAt.After(now) {
+			delete(c.entries, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *InMemoryCache) Count(_ context.Context) (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.now()
+	var count int64
+	for _, entry := range c.entries {
+		if entry.ExpiresAt.After(now) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresCache persists provider responses in the catalog_lookup_cache table.
+type PostgresCache struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCache constructs a cache backed by sqlx.
+func NewPostgresCache(db *sqlx.DB) *PostgresCache {
+	return &PostgresCache{db: db}
+}
+
+type cacheRow struct {
+	Results   []byte    `db:"results"`
+	NotFound  bool      `db:"not_found"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (c *PostgresCache) Get(ctx context.Context, provider, key string) (CacheEntry, bool, error) {
+	var row cacheRow
+	err := c.db.GetContext(ctx, &row, `
+SELECT results, not_found, expires_at
+FROM catalog_lookup_cache
+WHERE provider = $1 AND lookup_key = $2 AND expires_at > now()`, provider, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return CacheEntry{}, false, nil
+		}
+		return CacheEntry{}, false, fmt.Errorf("get cache entry: %w", err)
+	}
+
+	entry := CacheEntry{Provider: provider, Key: key, NotFound: row.NotFound, ExpiresAt: row.ExpiresAt}
+	if err := json.Unmarshal(row.Results, &entry.Results); err != nil {
+		return CacheEntry{}, false, fmt.Errorf("decode cache entry: %w", err)
+	}
+	return entry, true, nil
+}
+
+func (c *PostgresCache) Set(ctx context.Context, entry CacheEntry) error {
+	results := entry.Results
+	if results == nil {
+		results = []Metadata{}
+	}
+	payload, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	_, err = c.db.ExecContext(ctx, `
+INSERT INTO catalog_lookup_cache (provider, lookup_key, results, not_found, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (provider, lookup_key) DO UPDATE
+SET results = EXCLUDED.results,
+    not_found = EXCLUDED.not_found,
+    expires_at = EXCLUDED.expires_at,
+    created_at = EXCLUDED.created_at`,
+		entry.Provider, entry.Key, payload, entry.NotFound, entry.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("set cache entry: %w", err)
+	}
+	return nil
+}
+
+func (c *PostgresCache) Purge(ctx context.Context, provider string) (int64, error) {
+	var (
+		result sql.Result
+		err    error
+	)
+	if provider == "" {
+		result, err = c.db.ExecContext(ctx, `DELETE FROM catalog_lookup_cache`)
+	} else {
+		result, err = c.db.ExecContext(ctx, `DELETE FROM catalog_lookup_cache WHERE provider = $1`, provider)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("purge cache: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (c *PostgresCache) PurgeExpired(ctx context.Context) (int64, error) {
+	result, err := c.db.ExecContext(ctx, `DELETE FROM catalog_lookup_cache WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, fmt.Errorf("purge expired cache entries: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (c *PostgresCache) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := c.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM catalog_lookup_cache WHERE expires_at > now()`); err != nil {
+		return 0, fmt.Errorf("count cache entries: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,142 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCachedLookupServesRepeatQueriesFromCache(t *testing.T) {
+	t.Parallel()
+	provider := &fakeProvider{name: "custom", results: []Metadata{{Title: "Catan"}}}
+	cache := NewInMemoryCache()
+	svc := NewService(nil, WithCache(cache, time.Hour, time.Minute), WithProvider(CategoryGame, provider, 10))
+
+	for _, query := range []string{"Catan Base", "  catan   base "} {
+		results, err := svc.Lookup(context.Background(), query, CategoryGame)
+		if err != nil {
+			t.Fatalf("lookup failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Source != "custom" {
+			t.Fatalf("unexpected results %+v", results)
+		}
+	}
+
+	if provider.calls != 1 {
+		t.Fatalf("expected provider to be called once, got %d", provider.calls)
+	}
+
+	stats, err := svc.CacheStats(context.Background())
+	if err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+	if !stats.Enabled || stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCachedLookupRemembersNotFoundUntilNegativeTTLExpires(t *testing.T) {
+	t.Parallel()
+	provider := &fakeProvider{name: "custom", err: ErrNotFound}
+	cache := NewInMemoryCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	svc := NewService(nil, WithCache(cache, time.Hour, time.Minute), WithProvider(CategoryGame, provider, 10))
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Lookup(context.Background(), "missing", CategoryGame); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Fatalf("expected negative entry to be reused, got %d calls", provider.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := svc.Lookup(context.Background(), "missing", CategoryGame); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if provider.calls != 2 {
+		t.Fatalf("expected expired negative entry to be refreshed, got %d calls", provider.calls)
+	}
+
+	stats, _ := svc.CacheStats(context.Background())
+	if stats.NegativeHits != 1 || stats.Misses != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCachedLookupDoesNotCacheUpstreamErrors(t *testing.T) {
+	t.Parallel()
+	provider := &fakeProvider{name: "custom", err: errors.New("upstream down")}
+	svc := NewService(nil, WithCache(NewInMemoryCache(), time.Hour, time.Minute), WithProvider(CategoryGame, provider, 10))
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Lookup(context.Background(), "anything", CategoryGame); err == nil {
+			t.Fatal("expected upstream error")
+		}
+	}
+	if provider.calls != 2 {
+		t.Fatalf("expected failures to bypass the cache, got %d calls", provider.calls)
+	}
+}
+
+func TestCachedLookupFromProviderAndPurge(t *testing.T) {
+	t.Parallel()
+	provider := &fakeProvider{name: "custom", byID: map[string]Metadata{"42": {Title: "Answer"}}}
+	cache := NewInMemoryCache()
+	svc := NewService(nil, WithCache(cache, time.Hour, time.Minute), WithProvider(CategoryGame, provider, 10))
+
+	if _, err := svc.LookupFromProvider(context.Background(), "custom", "42"); err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	delete(provider.byID, "42")
+	metadata, err := svc.LookupFromProvider(context.Background(), "custom", "42")
+	if err != nil || metadata.Title != "Answer" {
+		t.Fatalf("expected cached record, got %+v, %v", metadata, err)
+	}
+
+	if _, err := svc.PurgeCache(context.Background(), "unknown"); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("expected ErrUnknownProvider, got %v", err)
+	}
+	removed, err := svc.PurgeCache(context.Background(), "custom")
+	if err != nil || removed != 1 {
+		t.Fatalf("expected 1 entry purged, got %d, %v", removed, err)
+	}
+	if _, err := svc.LookupFromProvider(context.Background(), "custom", "42"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected purged entry to be refetched, got %v", err)
+	}
+}
+
+func TestCacheStatsWhenDisabled(t *testing.T) {
+	t.Parallel()
+	svc := NewService(nil)
+
+	stats, err := svc.CacheStats(context.Background())
+	if err != nil || stats.Enabled {
+		t.Fatalf("expected disabled cache stats, got %+v, %v", stats, err)
+	}
+	if removed, err := svc.PurgeCache(context.Background(), ""); err != nil || removed != 0 {
+		t.Fatalf("expected no-op purge, got %d, %v", removed, err)
+	}
+}
+
+func TestPurgeExpiredCacheKeepsLiveEntries(t *testing.T) {
+	t.Parallel()
+	cache := NewInMemoryCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	svc := NewService(nil, WithCache(cache, time.Hour, time.Minute))
+
+	_ = cache.Set(context.Background(), CacheEntry{Provider: "custom", Key: "old", ExpiresAt: now.Add(-time.Second)})
+	_ = cache.Set(context.Background(), CacheEntry{Provider: "custom", Key: "fresh", ExpiresAt: now.Add(time.Hour)})
+
+	removed, err := svc.PurgeExpiredCache(context.Background())
+	if err != nil || removed != 1 {
+		t.Fatalf("expected 1 expired entry purged, got %d, %v", removed, err)
+	}
+	if _, ok, _ := cache.Get(context.Background(), "custom", "fresh"); !ok {
+		t.Fatal("expected the live entry to be kept")
+	}
+}
@@ -0,0 +1,312 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultGoogleBooksURL = "https://www.googleapis.com/books/v1"
+
+// WithGoogleBooksBaseURL overrides the base URL for Google Books requests.
+func WithGoogleBooksBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.googleBooks.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithGoogleBooksAPIKey configures the API key used for Google Books requests.
+func WithGoogleBooksAPIKey(key string) Option {
+	return func(s *Service) {
+		s.googleBooks.apiKey = strings.TrimSpace(key)
+	}
+}
+
+// googleBooksProvider resolves book metadata via the Google Books API.
+type googleBooksProvider struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+func (p *googleBooksProvider) Name() string {
+	return ProviderGoogleBooks
+}
+
+type googleBooksResponse struct {
+	Items []googleVolume `json:"items"`
+}
+
+type googleVolume struct {
+	ID         string           `json:"id"`
+	VolumeInfo googleVolumeInfo `json:"volumeInfo"`
+	SaleInfo   googleSaleInfo   `json:"saleInfo"`
+}
+
+type googleVolumeInfo struct {
+	Title               string                     `json:"title"`
+	Subtitle            string                     `json:"subtitle"`
+	Authors             []string                   `json:"authors"`
+	Description         string                     `json:"description"`
+	PublishedDate       string                     `json:"publishedDate"`
+	PageCount           int                        `json:"pageCount"`
+	Categories          []string                   `json:"categories"`
+	IndustryIdentifiers []googleIndustryIdentifier `json:"industryIdentifiers"`
+	ImageLinks          googleImageLinks           `json:"imageLinks"`
+}
+
+type googleSaleInfo struct {
+	IsEbook     bool             `json:"isEbook"`
+	RetailPrice *googlePriceInfo `json:"retailPrice"`
+}
+
+type googlePriceInfo struct {
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"currencyCode"`
+}
+
+type googleIndustryIdentifier struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+}
+
+type googleImageLinks struct {
+	Thumbnail      string `json:"thumbnail"`
+	SmallThumbnail string `json:"smallThumbnail"`
+}
+
+func (p *googleBooksProvider) Lookup(ctx context.Context, query string) ([]Metadata, error) {
+	if isbn := normalizeISBN(query); isbn != "" {
+		metadata, err := p.lookupByISBN(ctx, isbn)
+		if err == nil {
+			return []Metadata{metadata}, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	// If the query looks like a barcode (digits/X only) but isn't a valid ISBN
+	// length (10 or 13), return not found instead of doing a keyword search.
+	if isInvalidBarcodeQuery(query) {
+		return nil, ErrNotFound
+	}
+
+	return p.lookupByQuery(ctx, query)
+}
+
+func (p *googleBooksProvider) lookupByISBN(ctx context.Context, isbn string) (Metadata, error) {
+	volumes, err := p.search(ctx, "isbn:"+isbn, 1)
+	if err != nil {
+		return Metadata{}, err
+	}
+	if len(volumes) == 0 {
+		return Metadata{}, ErrNotFound
+	}
+
+	metadata, err := metadataFromVolume(volumes[0])
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	if metadata.ISBN13 == "" && len(isbn) == 13 {
+		metadata.ISBN13 = isbn
+	}
+	if metadata.ISBN10 == "" && len(isbn) == 10 {
+		metadata.ISBN10 = isbn
+	}
+
+	return metadata, nil
+}
+
+func (p *googleBooksProvider) lookupByQuery(ctx context.Context, query string) ([]Metadata, error) {
+	volumes, err := p.search(ctx, query, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(volumes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	results := make([]Metadata, 0, len(volumes))
+	for _, volume := range volumes {
+		metadata, err := metadataFromVolume(volume)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, metadata)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return results, nil
+}
+
+// LookupByID fetches metadata for a specific Google Books volume ID.
+func (p *googleBooksProvider) LookupByID(ctx context.Context, volumeID string) (Metadata, error) {
+	if strings.TrimSpace(volumeID) == "" {
+		return Metadata{}, ErrInvalidQuery
+	}
+
+	endpoint, err := url.Parse(p.baseURL + "/volumes/" + url.PathEscape(volumeID))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("build google books url: %w", err)
+	}
+
+	values := url.Values{}
+	if p.apiKey != "" {
+		values.Set("key", p.apiKey)
+	}
+	if len(values) > 0 {
+		endpoint.RawQuery = values.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("create google books request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("call google books: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return Metadata{}, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("google books returned status %d", resp.StatusCode)
+	}
+
+	var volume googleVolume
+	if err := json.NewDecoder(resp.Body).Decode(&volume); err != nil {
+		return Metadata{}, fmt.Errorf("decode google books response: %w", err)
+	}
+
+	return metadataFromVolume(volume)
+}
+
+func (p *googleBooksProvider) search(ctx context.Context, q string, maxResults int) ([]googleVolume, error) {
+	endpoint, err := url.Parse(p.baseURL + "/volumes")
+	if err != nil {
+		return nil, fmt.Errorf("build google books url: %w", err)
+	}
+
+	values := url.Values{}
+	values.Set("q", q)
+	if maxResults > 0 {
+		values.Set("maxResults", strconv.Itoa(maxResults))
+	}
+	values.Set("printType", "books")
+	values.Set("orderBy", "relevance")
+	if p.apiKey != "" {
+		values.Set("key", p.apiKey)
+	}
+	endpoint.RawQuery = values.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create google books request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call google books: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google books returned status %d", resp.StatusCode)
+	}
+
+	var payload googleBooksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode google books response: %w", err)
+	}
+
+	return payload.Items, nil
+}
+
+func metadataFromVolume(volume googleVolume) (Metadata, error) {
+	info := volume.VolumeInfo
+	title := strings.TrimSpace(info.Title)
+	creator := strings.TrimSpace(strings.Join(info.Authors, ", "))
+
+	if title == "" && creator == "" {
+		return Metadata{}, ErrNotFound
+	}
+
+	isbn13, isbn10 := selectISBNs(flattenIdentifiers(info.IndustryIdentifiers))
+	description := strings.TrimSpace(firstNonEmpty(info.Description, info.Subtitle))
+
+	metadata := Metadata{
+		Title:          title,
+		Creator:        creator,
+		ItemType:       "book",
+		PageCount:      nil,
+		ISBN13:         isbn13,
+		ISBN10:         isbn10,
+		Description:    description,
+		CoverImage:     normalizeCoverURL(info.ImageLinks),
+		Notes:          "",
+		GoogleVolumeId: volume.ID,
+		SourceID:       volume.ID,
+		Genre:          MapCategoriesToGenre(info.Categories),
+	}
+
+	if info.PageCount > 0 {
+		pages := info.PageCount
+		metadata.PageCount = &pages
+	}
+
+	if year := parsePublishYear(info.PublishedDate); year != nil {
+		metadata.ReleaseYear = year
+	}
+
+	// Extract retail price (USD only)
+	if volume.SaleInfo.RetailPrice != nil && volume.SaleInfo.RetailPrice.CurrencyCode == "USD" {
+		price := volume.SaleInfo.RetailPrice.Amount
+		metadata.RetailPriceUsd = &price
+	}
+
+	return metadata, nil
+}
+
+func flattenIdentifiers(values []googleIndustryIdentifier) []string {
+	candidates := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value.Identifier)
+		if trimmed == "" {
+			continue
+		}
+		candidates = append(candidates, trimmed)
+	}
+	return candidates
+}
+
+func normalizeCoverURL(links googleImageLinks) string {
+	candidates := []string{links.Thumbnail, links.SmallThumbnail}
+	for _, raw := range candidates {
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "http://") {
+			trimmed = "https://" + strings.TrimPrefix(trimmed, "http://")
+		}
+		return trimmed
+	}
+	return ""
+}
@@ -0,0 +1,295 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultIGDBURL     = "https://api.igdb.com/v4"
+	igdbCoverURLFormat = "https://images.igdb.com/igdb/image/upload/t_cover_big/%s.jpg"
+	igdbGameFields     = "name,summary,first_release_date,cover.image_id,platforms.name," +
+		"involved_companies.company.name,involved_companies.developer,involved_companies.publisher," +
+		"age_ratings.category,age_ratings.rating,game_modes.name," +
+		"multiplayer_modes.offlinemax,multiplayer_modes.onlinemax," +
+		"multiplayer_modes.offlinecoopmax,multiplayer_modes.onlinecoopmax"
+)
+
+// IGDB age rating organisations and their rating enums.
+const (
+	igdbAgeRatingESRB = 1
+	igdbAgeRatingPEGI = 2
+)
+
+var igdbESRBRatings = map[int]string{
+	6:  "RP",
+	7:  "EC",
+	8:  "E",
+	9:  "E10+",
+	10: "T",
+	11: "M",
+	12: "AO",
+}
+
+var igdbPEGIRatings = map[int]string{
+	1: "3",
+	2: "7",
+	3: "12",
+	4: "16",
+	5: "18",
+}
+
+// WithIGDBBaseURL overrides the base URL for IGDB game requests.
+func WithIGDBBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.igdb.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithIGDBCredentials configures the Twitch client ID and app access token used for IGDB requests.
+// Game lookups are disabled until a client ID is supplied.
+func WithIGDBCredentials(clientID, accessToken string) Option {
+	return func(s *Service) {
+		s.igdb.clientID = strings.TrimSpace(clientID)
+		s.igdb.accessToken = strings.TrimSpace(accessToken)
+	}
+}
+
+// igdbProvider resolves board and video game metadata via the IGDB API.
+type igdbProvider struct {
+	client      *http.Client
+	baseURL     string
+	clientID    string
+	accessToken string
+}
+
+func (p *igdbProvider) Name() string {
+	return ProviderIGDB
+}
+
+type igdbGame struct {
+	ID                int64                 `json:"id"`
+	Name              string                `json:"name"`
+	Summary           string                `json:"summary"`
+	FirstReleaseDate  int64                 `json:"first_release_date"`
+	Cover             *igdbCover            `json:"cover"`
+	Platforms         []igdbNamed           `json:"platforms"`
+	InvolvedCompanies []igdbInvolvedCompany `json:"involved_companies"`
+	AgeRatings        []igdbAgeRating       `json:"age_ratings"`
+	GameModes         []igdbNamed           `json:"game_modes"`
+	MultiplayerModes  []igdbMultiplayerMode `json:"multiplayer_modes"`
+}
+
+type igdbCover struct {
+	ImageID string `json:"image_id"`
+}
+
+type igdbNamed struct {
+	Name string `json:"name"`
+}
+
+type igdbInvolvedCompany struct {
+	Company   igdbNamed `json:"company"`
+	Developer bool      `json:"developer"`
+	Publisher bool      `json:"publisher"`
+}
+
+type igdbAgeRating struct {
+	Category int `json:"category"`
+	Rating   int `json:"rating"`
+}
+
+type igdbMultiplayerMode struct {
+	OfflineMax     int `json:"offlinemax"`
+	OnlineMax      int `json:"onlinemax"`
+	OfflineCoopMax int `json:"offlinecoopmax"`
+	OnlineCoopMax  int `json:"onlinecoopmax"`
+}
+
+func (p *igdbProvider) Lookup(ctx context.Context, query string) ([]Metadata, error) {
+	// IGDB has no barcode index, so numeric-only queries cannot be resolved.
+	if normalizeISBN(query) != "" || isInvalidBarcodeQuery(query) {
+		return nil, ErrNotFound
+	}
+
+	escaped := strings.ReplaceAll(query, `"`, `\"`)
+	games, err := p.queryGames(ctx, fmt.Sprintf("search \"%s\"; fields %s; limit 5;", escaped, igdbGameFields))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Metadata, 0, len(games))
+	for _, game := range games {
+		metadata, ok := metadataFromGame(game)
+		if !ok {
+			continue
+		}
+		results = append(results, metadata)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return results, nil
+}
+
+// LookupByID fetches a single game by its IGDB ID.
+func (p *igdbProvider) LookupByID(ctx context.Context, id string) (Metadata, error) {
+	gameID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || gameID <= 0 {
+		return Metadata{}, ErrNotFound
+	}
+
+	games, err := p.queryGames(ctx, fmt.Sprintf("fields %s; where id = %d; limit 1;", igdbGameFields, gameID))
+	if err != nil {
+		return Metadata{}, err
+	}
+	for _, game := range games {
+		if metadata, ok := metadataFromGame(game); ok {
+			return metadata, nil
+		}
+	}
+	return Metadata{}, ErrNotFound
+}
+
+func (p *igdbProvider) queryGames(ctx context.Context, body string) ([]igdbGame, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/games", strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create igdb request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Client-ID", p.clientID)
+	if p.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.accessToken)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call igdb: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("igdb returned status %d", resp.StatusCode)
+	}
+
+	var games []igdbGame
+	if err := json.NewDecoder(resp.Body).Decode(&games); err != nil {
+		return nil, fmt.Errorf("decode igdb response: %w", err)
+	}
+
+	return games, nil
+}
+
+func metadataFromGame(game igdbGame) (Metadata, bool) {
+	title := strings.TrimSpace(game.Name)
+	if title == "" {
+		return Metadata{}, false
+	}
+
+	metadata := Metadata{
+		Title:       title,
+		Creator:     gameCreator(game.InvolvedCompanies),
+		ItemType:    "game",
+		Description: strings.TrimSpace(game.Summary),
+		Platform:    joinNames(game.Platforms),
+		AgeGroup:    gameAgeGroup(game.AgeRatings),
+		PlayerCount: gamePlayerCount(game.GameModes, game.MultiplayerModes),
+		SourceID:    strconv.FormatInt(game.ID, 10),
+	}
+
+	if game.Cover != nil && strings.TrimSpace(game.Cover.ImageID) != "" {
+		metadata.CoverImage = fmt.Sprintf(igdbCoverURLFormat, strings.TrimSpace(game.Cover.ImageID))
+	}
+
+	if game.FirstReleaseDate > 0 {
+		year := time.Unix(game.FirstReleaseDate, 0).UTC().Year()
+		metadata.ReleaseYear = &year
+	}
+
+	return metadata, true
+}
+
+// gameCreator prefers developers and falls back to publishers.
+func gameCreator(companies []igdbInvolvedCompany) string {
+	var developers, publishers []string
+	for _, company := range companies {
+		name := strings.TrimSpace(company.Company.Name)
+		if name == "" {
+			continue
+		}
+		if company.Developer {
+			developers = append(developers, name)
+		} else if company.Publisher {
+			publishers = append(publishers, name)
+		}
+	}
+	if len(developers) > 0 {
+		return strings.Join(developers, ", ")
+	}
+	return strings.Join(publishers, ", ")
+}
+
+// gameAgeGroup prefers an ESRB rating and falls back to PEGI.
+func gameAgeGroup(ratings []igdbAgeRating) string {
+	var pegi string
+	for _, rating := range ratings {
+		switch rating.Category {
+		case igdbAgeRatingESRB:
+			if label, ok := igdbESRBRatings[rating.Rating]; ok {
+				return "ESRB " + label
+			}
+		case igdbAgeRatingPEGI:
+			if label, ok := igdbPEGIRatings[rating.Rating]; ok && pegi == "" {
+				pegi = "PEGI " + label
+			}
+		}
+	}
+	return pegi
+}
+
+// gamePlayerCount summarises the supported player range, e.g. "1-4".
+func gamePlayerCount(modes []igdbNamed, multiplayer []igdbMultiplayerMode) string {
+	singlePlayer := false
+	for _, mode := range modes {
+		if strings.EqualFold(strings.TrimSpace(mode.Name), "single player") {
+			singlePlayer = true
+			break
+		}
+	}
+
+	maxPlayers := 0
+	for _, mode := range multiplayer {
+		maxPlayers = max(maxPlayers, mode.OfflineMax, mode.OnlineMax, mode.OfflineCoopMax, mode.OnlineCoopMax)
+	}
+
+	switch {
+	case maxPlayers > 1 && singlePlayer:
+		return "1-" + strconv.Itoa(maxPlayers)
+	case maxPlayers > 2:
+		return "2-" + strconv.Itoa(maxPlayers)
+	case maxPlayers == 2:
+		return "2"
+	case singlePlayer:
+		return "1"
+	default:
+		return ""
+	}
+}
+
+func joinNames(values []igdbNamed) string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value.Name); trimmed != "" {
+			names = append(names, trimmed)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLookupGameReturnsMetadata(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/games" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Client-ID"); got != "client-id" {
+			t.Fatalf("expected Client-ID header, got %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Fatalf("expected bearer token, got %q", got)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		if !strings.HasPrefix(string(body), `search "Mario \"Kart\"";`) {
+			t.Fatalf("expected escaped search clause, got %q", body)
+		}
+
+		games := []igdbGame{
+			{
+				ID:               1,
+				Name:             "Mario Kart 8 Deluxe",
+				Summary:          "Kart racing.",
+				FirstReleaseDate: 1493337600,
+				Cover:            &igdbCover{ImageID: "co1abc"},
+				Platforms:        []igdbNamed{{Name: "Nintendo Switch"}},
+				InvolvedCompanies: []igdbInvolvedCompany{
+					{Company: igdbNamed{Name: "Nintendo"}, Publisher: true},
+					{Company: igdbNamed{Name: "Nintendo EPD"}, Developer: true},
+				},
+				AgeRatings: []igdbAgeRating{
+					{Category: igdbAgeRatingPEGI, Rating: 1},
+					{Category: igdbAgeRatingESRB, Rating: 8},
+				},
+				GameModes:        []igdbNamed{{Name: "Single player"}, {Name: "Multiplayer"}},
+				MultiplayerModes: []igdbMultiplayerMode{{OfflineMax: 4, OnlineMax: 12}},
+			},
+			{ID: 2},
+		}
+		return jsonResponse(t, http.StatusOK, games), nil
+	})
+	svc := NewService(client, WithIGDBBaseURL("http://igdb.test/"), WithIGDBCredentials("client-id", "token"))
+
+	results, err := svc.Lookup(context.Background(), `Mario "Kart"`, CategoryGame)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	entry := results[0]
+	if entry.Title != "Mario Kart 8 Deluxe" || entry.ItemType != "game" {
+		t.Fatalf("unexpected title/type: %q, %q", entry.Title, entry.ItemType)
+	}
+	if entry.Creator != "Nintendo EPD" {
+		t.Fatalf("expected developer as creator, got %q", entry.Creator)
+	}
+	if entry.Platform != "Nintendo Switch" {
+		t.Fatalf("expected platform, got %q", entry.Platform)
+	}
+	if entry.AgeGroup != "ESRB E" {
+		t.Fatalf("expected ESRB rating to win, got %q", entry.AgeGroup)
+	}
+	if entry.PlayerCount != "1-12" {
+		t.Fatalf("expected player count 1-12, got %q", entry.PlayerCount)
+	}
+	if entry.ReleaseYear == nil || *entry.ReleaseYear != 2017 {
+		t.Fatalf("expected release year 2017, got %+v", entry.ReleaseYear)
+	}
+	if entry.CoverImage != "https://images.igdb.com/igdb/image/upload/t_cover_big/co1abc.jpg" {
+		t.Fatalf("unexpected cover image %q", entry.CoverImage)
+	}
+}
+
+func TestLookupGameRequiresCredentials(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request to %s", r.URL)
+		return nil, nil
+	})
+	svc := NewService(client)
+
+	if _, err := svc.Lookup(context.Background(), "Catan", CategoryGame); !errors.Is(err, ErrUnsupportedCategory) {
+		t.Fatalf("expected ErrUnsupportedCategory, got %v", err)
+	}
+}
+
+func TestLookupGameReturnsNotFoundForBarcodes(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request to %s", r.URL)
+		return nil, nil
+	})
+	svc := NewService(client, WithIGDBCredentials("client-id", "token"))
+
+	if _, err := svc.Lookup(context.Background(), "045496590420", CategoryGame); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGamePlayerCount(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		modes       []igdbNamed
+		multiplayer []igdbMultiplayerMode
+		want        string
+	}{
+		{name: "single only", modes: []igdbNamed{{Name: "Single player"}}, want: "1"},
+		{name: "multiplayer only", multiplayer: []igdbMultiplayerMode{{OfflineCoopMax: 2}}, want: "2"},
+		{name: "unknown", want: ""},
+	}
+	for _, tc := range cases {
+		if got := gamePlayerCount(tc.modes, tc.multiplayer); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultMusicBrainzURL       = "https://musicbrainz.org/ws/2"
+	defaultCoverArtArchiveURL   = "https://coverartarchive.org"
+	defaultMusicBrainzUserAgent = "Anthology/1.0 (https://github.com/drywaters/anthology)"
+	musicBrainzMaxResults       = 5
+)
+
+// WithMusicBrainzBaseURL overrides the base URL for MusicBrainz requests.
+func WithMusicBrainzBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.musicBrainz.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithMusicBrainzUserAgent sets the User-Agent MusicBrainz requires to identify the application.
+func WithMusicBrainzUserAgent(userAgent string) Option {
+	return func(s *Service) {
+		if trimmed := strings.TrimSpace(userAgent); trimmed != "" {
+			s.musicBrainz.userAgent = trimmed
+		}
+	}
+}
+
+// WithCoverArtArchiveBaseURL overrides the base URL used to build album cover links.
+func WithCoverArtArchiveBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.musicBrainz.coverArtBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// musicBrainzProvider resolves album metadata via MusicBrainz and the Cover Art Archive.
+type musicBrainzProvider struct {
+	client          *http.Client
+	baseURL         string
+	userAgent       string
+	coverArtBaseURL string
+}
+
+func (p *musicBrainzProvider) Name() string {
+	return ProviderMusicBrainz
+}
+
+type musicBrainzSearchResponse struct {
+	Releases []musicBrainzReleaseSummary `json:"releases"`
+}
+
+type musicBrainzReleaseSummary struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type musicBrainzRelease struct {
+	ID              string                    `json:"id"`
+	Title           string                    `json:"title"`
+	Date            string                    `json:"date"`
+	Barcode         string                    `json:"barcode"`
+	ArtistCredit    []musicBrainzArtistCredit `json:"artist-credit"`
+	LabelInfo       []musicBrainzLabelInfo    `json:"label-info"`
+	Media           []musicBrainzMedium       `json:"media"`
+	CoverArtArchive musicBrainzCoverArt       `json:"cover-art-archive"`
+}
+
+type musicBrainzArtistCredit struct {
+	Name       string `json:"name"`
+	JoinPhrase string `json:"joinphrase"`
+}
+
+type musicBrainzLabelInfo struct {
+	Label *musicBrainzLabel `json:"label"`
+}
+
+type musicBrainzLabel struct {
+	Name string `json:"name"`
+}
+
+type musicBrainzMedium struct {
+	Tracks []musicBrainzTrack `json:"tracks"`
+}
+
+type musicBrainzTrack struct {
+	Number string `json:"number"`
+	Title  string `json:"title"`
+	Length int    `json:"length"`
+}
+
+type musicBrainzCoverArt struct {
+	Front bool `json:"front"`
+}
+
+func (p *musicBrainzProvider) Lookup(ctx context.Context, query string) ([]Metadata, error) {
+	var search string
+	switch barcode := normalizeBarcode(query); {
+	case barcode != "":
+		search = "barcode:" + barcode
+	case isInvalidBarcodeQuery(query):
+		return nil, ErrNotFound
+	default:
+		if artist, title, ok := splitArtistTitle(query); ok {
+			search = fmt.Sprintf("release:%s AND artist:%s", luceneQuote(title), luceneQuote(artist))
+		} else {
+			search = luceneEscape(query)
+		}
+	}
+
+	values := url.Values{}
+	values.Set("query", search)
+	values.Set("limit", strconv.Itoa(musicBrainzMaxResults))
+
+	var payload musicBrainzSearchResponse
+	if err := p.get(ctx, "/release", values, &payload); err != nil {
+		return nil, err
+	}
+
+	results := make([]Metadata, 0, len(payload.Releases))
+	for _, summary := range payload.Releases {
+		metadata, err := p.LookupByID(ctx, summary.ID)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, metadata)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return results, nil
+}
+
+// LookupByID fetches a single release by its MusicBrainz ID.
+func (p *musicBrainzProvider) LookupByID(ctx context.Context, id string) (Metadata, error) {
+	if strings.TrimSpace(id) == "" {
+		return Metadata{}, ErrNotFound
+	}
+
+	values := url.Values{}
+	values.Set("inc", "recordings+labels+artist-credits")
+
+	var release musicBrainzRelease
+	if err := p.get(ctx, "/release/"+url.PathEscape(id), values, &release); err != nil {
+		return Metadata{}, err
+	}
+
+	return p.metadataFromRelease(release)
+}
+
+func (p *musicBrainzProvider) get(ctx context.Context, path string, values url.Values, dst any) error {
+	endpoint, err := url.Parse(p.baseURL + path)
+	if err != nil {
+		return fmt.Errorf("build musicbrainz url: %w", err)
+	}
+	values.Set("fmt", "json")
+	endpoint.RawQuery = values.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("create musicbrainz request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", p.userAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call musicbrainz: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("musicbrainz returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("decode musicbrainz response: %w", err)
+	}
+	return nil
+}
+
+func (p *musicBrainzProvider) metadataFromRelease(release musicBrainzRelease) (Metadata, error) {
+	title := strings.TrimSpace(release.Title)
+	if title == "" {
+		return Metadata{}, ErrNotFound
+	}
+
+	var artist strings.Builder
+	for _, credit := range release.ArtistCredit {
+		artist.WriteString(credit.Name)
+		artist.WriteString(credit.JoinPhrase)
+	}
+
+	var label string
+	for _, info := range release.LabelInfo {
+		if info.Label != nil && strings.TrimSpace(info.Label.Name) != "" {
+			label = strings.TrimSpace(info.Label.Name)
+			break
+		}
+	}
+
+	var tracklist []string
+	for _, medium := range release.Media {
+		for _, track := range medium.Tracks {
+			tracklist = append(tracklist, formatTrack(track))
+		}
+	}
+
+	metadata := Metadata{
+		Title:       title,
+		Creator:     strings.TrimSpace(artist.String()),
+		ItemType:    "music",
+		ReleaseYear: parsePublishYear(release.Date),
+		Label:       label,
+		Tracklist:   tracklist,
+		Notes:       releaseNotes(label, tracklist),
+		SourceID:    release.ID,
+	}
+
+	if release.CoverArtArchive.Front {
+		metadata.CoverImage = p.coverArtBaseURL + "/release/" + url.PathEscape(release.ID) + "/front-500"
+	}
+
+	if barcode := normalizeBarcode(release.Barcode); len(barcode) == 13 {
+		metadata.ISBN13 = barcode
+	}
+
+	return metadata, nil
+}
+
+func formatTrack(track musicBrainzTrack) string {
+	entry := strings.TrimSpace(track.Title)
+	if number := strings.TrimSpace(track.Number); number != "" {
+		entry = number + ". " + entry
+	}
+	if track.Length > 0 {
+		seconds := track.Length / 1000
+		entry += fmt.Sprintf(" (%d:%02d)", seconds/60, seconds%60)
+	}
+	return entry
+}
+
+// releaseNotes renders the label and tracklist into the free-form notes field
+// so the details survive when the lookup result is saved as an item.
+func releaseNotes(label string, tracklist []string) string {
+	var sections []string
+	if label != "" {
+		sections = append(sections, "Label: "+label)
+	}
+	if len(tracklist) > 0 {
+		sections = append(sections, "Tracklist:\n"+strings.Join(tracklist, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// splitArtistTitle splits "Artist - Album" style queries.
+func splitArtistTitle(query string) (string, string, bool) {
+	for _, separator := range []string{" - ", " – ", " — "} {
+		artist, title, found := strings.Cut(query, separator)
+		if !found {
+			continue
+		}
+		artist = strings.TrimSpace(artist)
+		title = strings.TrimSpace(title)
+		if artist != "" && title != "" {
+			return artist, title, true
+		}
+	}
+	return "", "", false
+}
+
+// luceneEscape escapes Lucene syntax so free-text queries are matched literally.
+func luceneEscape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(`+-&|!(){}[]^"~*?:\/`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func luceneQuote(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(strings.TrimSpace(value))
+	return `"` + escaped + `"`
+}
@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLookupMusicByBarcode(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		if got := r.Header.Get("User-Agent"); got != "AnthologyTest/1.0" {
+			t.Fatalf("expected custom user agent, got %q", got)
+		}
+		if got := r.URL.Query().Get("fmt"); got != "json" {
+			t.Fatalf("expected fmt=json, got %q", got)
+		}
+		switch r.URL.Path {
+		case "/release":
+			if got := r.URL.Query().Get("query"); got != "barcode:0077774644426" {
+				t.Fatalf("expected barcode query, got %q", got)
+			}
+			return jsonResponse(t, http.StatusOK, musicBrainzSearchResponse{
+				Releases: []musicBrainzReleaseSummary{{ID: "abc-123"}},
+			}), nil
+		case "/release/abc-123":
+			return jsonResponse(t, http.StatusOK, musicBrainzRelease{
+				ID:      "abc-123",
+				Title:   "Abbey Road",
+				Date:    "1969-09-26",
+				Barcode: "0077774644426",
+				ArtistCredit: []musicBrainzArtistCredit{
+					{Name: "The Beatles"},
+				},
+				LabelInfo: []musicBrainzLabelInfo{{Label: nil}, {Label: &musicBrainzLabel{Name: "Apple Records"}}},
+				Media: []musicBrainzMedium{{Tracks: []musicBrainzTrack{
+					{Number: "A1", Title: "Come Together", Length: 259000},
+					{Number: "A2", Title: "Something", Length: 182000},
+				}}},
+				CoverArtArchive: musicBrainzCoverArt{Front: true},
+			}), nil
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+			return nil, nil
+		}
+	})
+	svc := NewService(
+		client,
+		WithMusicBrainzBaseURL("http://mb.test/"),
+		WithMusicBrainzUserAgent("AnthologyTest/1.0"),
+		WithCoverArtArchiveBaseURL("https://covers.test"),
+	)
+
+	results, err := svc.Lookup(context.Background(), "0077774644426", CategoryMusic)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	entry := results[0]
+	if entry.Title != "Abbey Road" || entry.Creator != "The Beatles" || entry.ItemType != "music" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if entry.ReleaseYear == nil || *entry.ReleaseYear != 1969 {
+		t.Fatalf("expected release year 1969, got %+v", entry.ReleaseYear)
+	}
+	if entry.Label != "Apple Records" {
+		t.Fatalf("expected label, got %q", entry.Label)
+	}
+	if len(entry.Tracklist) != 2 || entry.Tracklist[0] != "A1. Come Together (4:19)" {
+		t.Fatalf("unexpected tracklist %v", entry.Tracklist)
+	}
+	if !strings.HasPrefix(entry.Notes, "Label: Apple Records\n\nTracklist:\nA1. Come Together") {
+		t.Fatalf("expected notes to include label and tracklist, got %q", entry.Notes)
+	}
+	if entry.CoverImage != "https://covers.test/release/abc-123/front-500" {
+		t.Fatalf("unexpected cover image %q", entry.CoverImage)
+	}
+	if entry.ISBN13 != "0077774644426" {
+		t.Fatalf("expected barcode to be carried as identifier, got %q", entry.ISBN13)
+	}
+}
+
+func TestLookupMusicByArtistAndTitle(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/release":
+			want := `release:"Kind of Blue" AND artist:"Miles Davis"`
+			if got := r.URL.Query().Get("query"); got != want {
+				t.Fatalf("expected %q, got %q", want, got)
+			}
+			return jsonResponse(t, http.StatusOK, musicBrainzSearchResponse{
+				Releases: []musicBrainzReleaseSummary{{ID: "kob"}},
+			}), nil
+		case "/release/kob":
+			return jsonResponse(t, http.StatusOK, musicBrainzRelease{
+				ID:           "kob",
+				Title:        "Kind of Blue",
+				ArtistCredit: []musicBrainzArtistCredit{{Name: "Miles Davis"}},
+			}), nil
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+			return nil, nil
+		}
+	})
+	svc := NewService(client, WithMusicBrainzBaseURL("http://mb.test"))
+
+	results, err := svc.Lookup(context.Background(), "Miles Davis - Kind of Blue", CategoryMusic)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 || results[0].CoverImage != "" {
+		t.Fatalf("expected single result without cover art, got %+v", results)
+	}
+}
+
+func TestLookupMusicReturnsNotFoundWhenEmpty(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		if got := r.URL.Query().Get("query"); got != `AC\/DC` {
+			t.Fatalf("expected escaped free-text query, got %q", got)
+		}
+		return jsonResponse(t, http.StatusOK, musicBrainzSearchResponse{}), nil
+	})
+	svc := NewService(client, WithMusicBrainzBaseURL("http://mb.test"))
+
+	if _, err := svc.Lookup(context.Background(), "AC/DC", CategoryMusic); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultOpenLibraryURL       = "https://openlibrary.org"
+	defaultOpenLibraryCoversURL = "https://covers.openlibrary.org"
+	openLibraryMaxResults       = 5
+	openLibrarySearchFields     = "key,title,author_name,first_publish_year,isbn,cover_i,cover_edition_key,edition_key,number_of_pages_median,subject"
+)
+
+// WithOpenLibraryBaseURL overrides the base URL for Open Library requests.
+func WithOpenLibraryBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.openLibrary.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithOpenLibraryCoversBaseURL overrides the base URL used to build Open Library cover links.
+func WithOpenLibraryCoversBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.openLibrary.coversBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithOpenLibraryMerge fills gaps (cover, description, page count, etc.) in
+// book ISBN results from other providers using Open Library. Values already
+// supplied by the primary provider are never overwritten.
+func WithOpenLibraryMerge() Option {
+	return func(s *Service) {
+		s.mergeOpenLibrary = true
+	}
+}
+
+// openLibraryProvider resolves book metadata via the Open Library books and search APIs.
+// It is registered as a fallback behind Google Books, which often misses older ISBNs.
+type openLibraryProvider struct {
+	client        *http.Client
+	baseURL       string
+	coversBaseURL string
+}
+
+func (p *openLibraryProvider) Name() string {
+	return ProviderOpenLibrary
+}
+
+type openLibraryEdition struct {
+	Key           string                `json:"key"`
+	Title         string                `json:"title"`
+	Subtitle      string                `json:"subtitle"`
+	Authors       []openLibraryNamed    `json:"authors"`
+	PublishDate   string                `json:"publish_date"`
+	NumberOfPages int                   `json:"number_of_pages"`
+	Identifiers   openLibraryIdentifier `json:"identifiers"`
+	Subjects      []openLibraryNamed    `json:"subjects"`
+	Cover         openLibraryCover      `json:"cover"`
+	Excerpts      []openLibraryExcerpt  `json:"excerpts"`
+}
+
+type openLibraryNamed struct {
+	Name string `json:"name"`
+}
+
+type openLibraryIdentifier struct {
+	ISBN13 []string `json:"isbn_13"`
+	ISBN10 []string `json:"isbn_10"`
+}
+
+type openLibraryCover struct {
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+}
+
+type openLibraryExcerpt struct {
+	Text string `json:"text"`
+}
+
+type openLibrarySearchResponse struct {
+	Docs []openLibraryDoc `json:"docs"`
+}
+
+type openLibraryDoc struct {
+	Key                 string   `json:"key"`
+	Title               string   `json:"title"`
+	AuthorName          []string `json:"author_name"`
+	FirstPublishYear    int      `json:"first_publish_year"`
+	ISBN                []string `json:"isbn"`
+	CoverID             int64    `json:"cover_i"`
+	CoverEditionKey     string   `json:"cover_edition_key"`
+	EditionKey          []string `json:"edition_key"`
+	NumberOfPagesMedian int      `json:"number_of_pages_median"`
+	Subject             []string `json:"subject"`
+}
+
+func (p *openLibraryProvider) Lookup(ctx context.Context, query string) ([]Metadata, error) {
+	if isbn := normalizeISBN(query); isbn != "" {
+		metadata, err := p.lookupByISBN(ctx, isbn)
+		if err == nil {
+			return []Metadata{metadata}, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	if isInvalidBarcodeQuery(query) {
+		return nil, ErrNotFound
+	}
+
+	return p.search(ctx, query)
+}
+
+func (p *openLibraryProvider) lookupByISBN(ctx context.Context, isbn string) (Metadata, error) {
+	metadata, err := p.lookupBibKey(ctx, "ISBN:"+isbn)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	if metadata.ISBN13 == "" && len(isbn) == 13 {
+		metadata.ISBN13 = isbn
+	}
+	if metadata.ISBN10 == "" && len(isbn) == 10 {
+		metadata.ISBN10 = isbn
+	}
+
+	return metadata, nil
+}
+
+// LookupByID fetches a single edition by its Open Library edition ID (e.g. OL7353617M).
+func (p *openLibraryProvider) LookupByID(ctx context.Context, id string) (Metadata, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Metadata{}, ErrInvalidQuery
+	}
+	return p.lookupBibKey(ctx, "OLID:"+id)
+}
+
+func (p *openLibraryProvider) lookupBibKey(ctx context.Context, bibKey string) (Metadata, error) {
+	values := url.Values{}
+	values.Set("bibkeys", bibKey)
+	values.Set("format", "json")
+	values.Set("jscmd", "data")
+
+	var payload map[string]openLibraryEdition
+	if err := p.get(ctx, "/api/books", values, &payload); err != nil {
+		return Metadata{}, err
+	}
+
+	edition, ok := payload[bibKey]
+	if !ok {
+		return Metadata{}, ErrNotFound
+	}
+
+	return metadataFromEdition(edition)
+}
+
+func (p *openLibraryProvider) search(ctx context.Context, query string) ([]Metadata, error) {
+	values := url.Values{}
+	values.Set("q", query)
+	values.Set("limit", strconv.Itoa(openLibraryMaxResults))
+	values.Set("fields", openLibrarySearchFields)
+
+	var payload openLibrarySearchResponse
+	if err := p.get(ctx, "/search.json", values, &payload); err != nil {
+		return nil, err
+	}
+
+	results := make([]Metadata, 0, len(payload.Docs))
+	for _, doc := range payload.Docs {
+		metadata, err := p.metadataFromDoc(doc)
+		if err != nil {
+			continue
+		}
+		results = append(results, metadata)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return results, nil
+}
+
+func (p *openLibraryProvider) get(ctx context.Context, path string, values url.Values, dst any) error {
+	endpoint, err := url.Parse(p.baseURL + path)
+	if err != nil {
+		return fmt.Errorf("build open library url: %w", err)
+	}
+	endpoint.RawQuery = values.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("create open library request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call open library: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("open library returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("decode open library response: %w", err)
+	}
+	return nil
+}
+
+func metadataFromEdition(edition openLibraryEdition) (Metadata, error) {
+	title := strings.TrimSpace(edition.Title)
+	authors := make([]string, 0, len(edition.Authors))
+	for _, author := range edition.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	creator := strings.Join(authors, ", ")
+
+	if title == "" && creator == "" {
+		return Metadata{}, ErrNotFound
+	}
+
+	isbn13, isbn10 := selectISBNs(append(edition.Identifiers.ISBN13, edition.Identifiers.ISBN10...))
+
+	var excerpt string
+	if len(edition.Excerpts) > 0 {
+		excerpt = edition.Excerpts[0].Text
+	}
+
+	subjects := make([]string, 0, len(edition.Subjects))
+	for _, subject := range edition.Subjects {
+		subjects = append(subjects, subject.Name)
+	}
+
+	metadata := Metadata{
+		Title:       title,
+		Creator:     creator,
+		ItemType:    "book",
+		ISBN13:      isbn13,
+		ISBN10:      isbn10,
+		Description: strings.TrimSpace(firstNonEmpty(edition.Subtitle, excerpt)),
+		CoverImage:  strings.TrimSpace(firstNonEmpty(edition.Cover.Large, edition.Cover.Medium, edition.Cover.Small)),
+		ReleaseYear: parsePublishYear(edition.PublishDate),
+		Genre:       MapCategoriesToGenre(subjects),
+		SourceID:    strings.TrimPrefix(edition.Key, "/books/"),
+	}
+
+	if edition.NumberOfPages > 0 {
+		pages := edition.NumberOfPages
+		metadata.PageCount = &pages
+	}
+
+	return metadata, nil
+}
+
+func (p *openLibraryProvider) metadataFromDoc(doc openLibraryDoc) (Metadata, error) {
+	title := strings.TrimSpace(doc.Title)
+	creator := strings.TrimSpace(strings.Join(doc.AuthorName, ", "))
+	if title == "" && creator == "" {
+		return Metadata{}, ErrNotFound
+	}
+
+	isbn13, isbn10 := selectISBNs(doc.ISBN)
+
+	metadata := Metadata{
+		Title:    title,
+		Creator:  creator,
+		ItemType: "book",
+		ISBN13:   isbn13,
+		ISBN10:   isbn10,
+		Genre:    MapCategoriesToGenre(doc.Subject),
+		SourceID: doc.CoverEditionKey,
+	}
+
+	if metadata.SourceID == "" && len(doc.EditionKey) > 0 {
+		metadata.SourceID = doc.EditionKey[0]
+	}
+	if doc.CoverID > 0 {
+		metadata.CoverImage = fmt.Sprintf("%s/b/id/%d-L.jpg", p.coversBaseURL, doc.CoverID)
+	}
+	if doc.FirstPublishYear > 0 {
+		year := doc.FirstPublishYear
+		metadata.ReleaseYear = &year
+	}
+	if doc.NumberOfPagesMedian > 0 {
+		pages := doc.NumberOfPagesMedian
+		metadata.PageCount = &pages
+	}
+
+	return metadata, nil
+}
+
+// mergeBookGaps copies fields from fallback into primary only where primary is empty.
+func mergeBookGaps(primary *Metadata, fallback Metadata) {
+	if primary.Creator == "" {
+		primary.Creator = fallback.Creator
+	}
+	if primary.ReleaseYear == nil {
+		primary.ReleaseYear = fallback.ReleaseYear
+	}
+	if primary.PageCount == nil {
+		primary.PageCount = fallback.PageCount
+	}
+	if primary.ISBN13 == "" {
+		primary.ISBN13 = fallback.ISBN13
+	}
+	if primary.ISBN10 == "" {
+		primary.ISBN10 = fallback.ISBN10
+	}
+	if primary.Description == "" {
+		primary.Description = fallback.Description
+	}
+	if primary.CoverImage == "" {
+		primary.CoverImage = fallback.CoverImage
+	}
+	if primary.Genre == "" {
+		primary.Genre = fallback.Genre
+	}
+}
+
+func hasBookGaps(metadata Metadata) bool {
+	return metadata.Creator == "" ||
+		metadata.ReleaseYear == nil ||
+		metadata.PageCount == nil ||
+		metadata.ISBN13 == "" ||
+		metadata.ISBN10 == "" ||
+		metadata.Description == "" ||
+		metadata.CoverImage == ""
+}
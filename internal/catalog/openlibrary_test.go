@@ -0,0 +1,193 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLookupBookFallsBackToOpenLibraryForISBN(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Host + r.URL.Path {
+		case "google.test/volumes":
+			return jsonResponse(t, http.StatusOK, googleBooksResponse{}), nil
+		case "ol.test/api/books":
+			values := r.URL.Query()
+			if values.Get("bibkeys") != "ISBN:0140328726" || values.Get("jscmd") != "data" {
+				t.Fatalf("unexpected books query %s", r.URL.RawQuery)
+			}
+			return jsonResponse(t, http.StatusOK, map[string]openLibraryEdition{
+				"ISBN:0140328726": {
+					Key:           "/books/OL7353617M",
+					Title:         "Fantastic Mr. Fox",
+					Authors:       []openLibraryNamed{{Name: "Roald Dahl"}},
+					PublishDate:   "October 1, 1988",
+					NumberOfPages: 96,
+					Identifiers:   openLibraryIdentifier{ISBN13: []string{"9780140328721"}},
+					Cover:         openLibraryCover{Medium: "https://covers.test/b/id/8739161-M.jpg"},
+				},
+			}), nil
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+			return nil, nil
+		}
+	})
+	svc := NewService(client, WithGoogleBooksBaseURL("http://google.test"), WithOpenLibraryBaseURL("http://ol.test"))
+
+	results, err := svc.Lookup(context.Background(), "0-14-032872-6", CategoryBook)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	entry := results[0]
+	if entry.Title != "Fantastic Mr. Fox" || entry.Creator != "Roald Dahl" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if entry.Source != ProviderOpenLibrary || entry.SourceID != "OL7353617M" {
+		t.Fatalf("expected open library source, got %q/%q", entry.Source, entry.SourceID)
+	}
+	if entry.ISBN10 != "0140328726" || entry.ISBN13 != "9780140328721" {
+		t.Fatalf("expected both ISBNs, got %q, %q", entry.ISBN13, entry.ISBN10)
+	}
+	if entry.ReleaseYear == nil || *entry.ReleaseYear != 1988 {
+		t.Fatalf("expected release year 1988, got %+v", entry.ReleaseYear)
+	}
+	if entry.PageCount == nil || *entry.PageCount != 96 {
+		t.Fatalf("expected page count 96, got %+v", entry.PageCount)
+	}
+	if entry.CoverImage != "https://covers.test/b/id/8739161-M.jpg" {
+		t.Fatalf("unexpected cover image %q", entry.CoverImage)
+	}
+}
+
+func TestLookupBookSearchesOpenLibraryByAuthorAndTitle(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Host + r.URL.Path {
+		case "google.test/volumes":
+			return jsonResponse(t, http.StatusServiceUnavailable, map[string]string{}), nil
+		case "ol.test/search.json":
+			if got := r.URL.Query().Get("q"); got != "dahl fantastic fox" {
+				t.Fatalf("expected free-text query, got %q", got)
+			}
+			return jsonResponse(t, http.StatusOK, openLibrarySearchResponse{
+				Docs: []openLibraryDoc{{
+					Title:            "Fantastic Mr Fox",
+					AuthorName:       []string{"Roald Dahl"},
+					FirstPublishYear: 1970,
+					ISBN:             []string{"0140328726", "9780140328721"},
+					CoverID:          8739161,
+					EditionKey:       []string{"OL7353617M"},
+				}},
+			}), nil
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+			return nil, nil
+		}
+	})
+	svc := NewService(
+		client,
+		WithGoogleBooksBaseURL("http://google.test"),
+		WithOpenLibraryBaseURL("http://ol.test"),
+		WithOpenLibraryCoversBaseURL("https://covers.test"),
+		WithRateLimit(ProviderGoogleBooks, RateLimit{}),
+	)
+
+	results, err := svc.Lookup(context.Background(), "dahl fantastic fox", CategoryBook)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].CoverImage != "https://covers.test/b/id/8739161-L.jpg" {
+		t.Fatalf("unexpected cover image %q", results[0].CoverImage)
+	}
+	if results[0].SourceID != "OL7353617M" {
+		t.Fatalf("expected edition key as source id, got %q", results[0].SourceID)
+	}
+}
+
+func TestLookupBookMergesOpenLibraryGapsWithoutOverwriting(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Host + r.URL.Path {
+		case "google.test/volumes":
+			return jsonResponse(t, http.StatusOK, googleBooksResponse{Items: []googleVolume{{
+				ID: "vol-1",
+				VolumeInfo: googleVolumeInfo{
+					Title:       "Fantastic Mr. Fox",
+					Authors:     []string{"Roald Dahl"},
+					Description: "Google description",
+				},
+			}}}), nil
+		case "ol.test/api/books":
+			return jsonResponse(t, http.StatusOK, map[string]openLibraryEdition{
+				"ISBN:9780140328721": {
+					Title:         "Fantastic Mr Fox (Puffin)",
+					Authors:       []openLibraryNamed{{Name: "R. Dahl"}},
+					Subtitle:      "Open Library subtitle",
+					NumberOfPages: 96,
+					Identifiers:   openLibraryIdentifier{ISBN10: []string{"0140328726"}},
+					Cover:         openLibraryCover{Large: "https://covers.test/b/id/1-L.jpg"},
+				},
+			}), nil
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+			return nil, nil
+		}
+	})
+	svc := NewService(
+		client,
+		WithGoogleBooksBaseURL("http://google.test"),
+		WithOpenLibraryBaseURL("http://ol.test"),
+		WithOpenLibraryMerge(),
+	)
+
+	results, err := svc.Lookup(context.Background(), "9780140328721", CategoryBook)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+
+	entry := results[0]
+	if entry.Source != ProviderGoogleBooks || entry.GoogleVolumeId != "vol-1" {
+		t.Fatalf("expected google result to remain primary, got %+v", entry)
+	}
+	if entry.Title != "Fantastic Mr. Fox" || entry.Creator != "Roald Dahl" || entry.Description != "Google description" {
+		t.Fatalf("expected google values to be preserved, got %+v", entry)
+	}
+	if entry.CoverImage != "https://covers.test/b/id/1-L.jpg" {
+		t.Fatalf("expected cover to be filled from open library, got %q", entry.CoverImage)
+	}
+	if entry.PageCount == nil || *entry.PageCount != 96 {
+		t.Fatalf("expected page count to be filled, got %+v", entry.PageCount)
+	}
+	if entry.ISBN10 != "0140328726" {
+		t.Fatalf("expected ISBN10 to be filled, got %q", entry.ISBN10)
+	}
+}
+
+func TestLookupFromOpenLibraryByEditionID(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		if got := r.URL.Query().Get("bibkeys"); got != "OLID:OL7353617M" {
+			t.Fatalf("expected OLID bibkey, got %q", got)
+		}
+		return jsonResponse(t, http.StatusOK, map[string]openLibraryEdition{
+			"OLID:OL7353617M": {Key: "/books/OL7353617M", Title: "Fantastic Mr. Fox"},
+		}), nil
+	})
+	svc := NewService(client, WithOpenLibraryBaseURL("http://ol.test"))
+
+	metadata, err := svc.LookupFromProvider(context.Background(), ProviderOpenLibrary, "OL7353617M")
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if metadata.Title != "Fantastic Mr. Fox" || metadata.Source != ProviderOpenLibrary {
+		t.Fatalf("unexpected metadata %+v", metadata)
+	}
+}
@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// Built-in provider names recorded on Metadata.Source.
+const (
+	ProviderGoogleBooks = "google_books"
+	ProviderIGDB        = "igdb"
+	ProviderTMDB        = "tmdb"
+	ProviderMusicBrainz = "musicbrainz"
+	ProviderOpenLibrary = "open_library"
+)
+
+// DefaultProviderPriority is the priority assigned to built-in providers.
+// Lower priorities are consulted first.
+const DefaultProviderPriority = 100
+
+// FallbackProviderPriority is the priority assigned to built-in providers that
+// back up the primary source for a category.
+const FallbackProviderPriority = 200
+
+// ErrUnknownProvider is returned when a lookup names a provider that is not registered.
+var ErrUnknownProvider = errors.New("unknown metadata provider")
+
+// Provider resolves metadata for a category from a single upstream source.
+// Implementations return ErrNotFound when the source has no match.
+type Provider interface {
+	// Name uniquely identifies the provider and is recorded on Metadata.Source.
+	Name() string
+	// Lookup searches the source with a trimmed, non-empty query.
+	Lookup(ctx context.Context, query string) ([]Metadata, error)
+	// LookupByID fetches the record previously returned as Metadata.SourceID.
+	LookupByID(ctx context.Context, id string) (Metadata, error)
+}
+
+type registration struct {
+	provider Provider
+	priority int
+}
+
+// Registry holds the providers registered for each category in priority order.
+type Registry struct {
+	mu         sync.RWMutex
+	categories map[Category][]registration
+	byName     map[string]Provider
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		categories: make(map[Category][]registration),
+		byName:     make(map[string]Provider),
+	}
+}
+
+// Register adds a provider for the category. Providers with lower priority values
+// are tried first; equal priorities keep registration order.
+func (r *Registry) Register(category Category, provider Provider, priority int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := append(r.categories[category], registration{provider: provider, priority: priority})
+	slices.SortStableFunc(entries, func(a, b registration) int {
+		return a.priority - b.priority
+	})
+	r.categories[category] = entries
+	r.byName[provider.Name()] = provider
+}
+
+// Providers returns the providers for a category in the order they should be consulted.
+func (r *Registry) Providers(category Category) []Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.categories[category]
+	providers := make([]Provider, 0, len(entries))
+	for _, entry := range entries {
+		providers = append(providers, entry.provider)
+	}
+	return providers
+}
+
+// Provider returns the provider registered under name.
+func (r *Registry) Provider(name string) (Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provider, ok := r.byName[name]
+	return provider, ok
+}
+
+// lookupChain queries each provider in turn until one returns results. Providers
+// that report ErrNotFound or fail are skipped; if none succeed the first upstream
+// failure is returned, or ErrNotFound when every provider simply had no match.
+func lookupChain(ctx context.Context, providers []Provider, query string) ([]Metadata, error) {
+	var firstErr error
+	for _, provider := range providers {
+		results, err := provider.Lookup(ctx, query)
+		if err == nil && len(results) > 0 {
+			return stampSource(provider.Name(), results), nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, ErrNotFound
+}
+
+func stampSource(name string, results []Metadata) []Metadata {
+	for i := range results {
+		if results[i].Source == "" {
+			results[i].Source = name
+		}
+	}
+	return results
+}
@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeProvider struct {
+	name    string
+	results []Metadata
+	err     error
+	byID    map[string]Metadata
+	calls   int
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Lookup(context.Context, string) ([]Metadata, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.results, nil
+}
+
+func (p *fakeProvider) LookupByID(_ context.Context, id string) (Metadata, error) {
+	metadata, ok := p.byID[id]
+	if !ok {
+		return Metadata{}, ErrNotFound
+	}
+	return metadata, nil
+}
+
+func TestRegistryOrdersProvidersByPriority(t *testing.T) {
+	t.Parallel()
+	registry := NewRegistry()
+	registry.Register(CategoryBook, &fakeProvider{name: "late"}, 200)
+	registry.Register(CategoryBook, &fakeProvider{name: "first"}, 10)
+	registry.Register(CategoryBook, &fakeProvider{name: "second"}, 10)
+
+	providers := registry.Providers(CategoryBook)
+	if len(providers) != 3 {
+		t.Fatalf("expected 3 providers, got %d", len(providers))
+	}
+	names := []string{providers[0].Name(), providers[1].Name(), providers[2].Name()}
+	if names[0] != "first" || names[1] != "second" || names[2] != "late" {
+		t.Fatalf("unexpected provider order %v", names)
+	}
+	if len(registry.Providers(CategoryMusic)) != 0 {
+		t.Fatal("expected no providers for an unregistered category")
+	}
+}
+
+func TestLookupFallsBackToNextProvider(t *testing.T) {
+	t.Parallel()
+	missing := &fakeProvider{name: "missing", err: ErrNotFound}
+	failing := &fakeProvider{name: "failing", err: errors.New("upstream down")}
+	fallback := &fakeProvider{name: "fallback", results: []Metadata{{Title: "Found", SourceID: "f-1"}}}
+
+	svc := NewService(nil,
+		WithProvider(CategoryGame, fallback, 30),
+		WithProvider(CategoryGame, failing, 20),
+		WithProvider(CategoryGame, missing, 10),
+	)
+
+	results, err := svc.Lookup(context.Background(), "Catan", CategoryGame)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Found" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if results[0].Source != "fallback" {
+		t.Fatalf("expected source to be stamped, got %q", results[0].Source)
+	}
+	if missing.calls != 1 || failing.calls != 1 || fallback.calls != 1 {
+		t.Fatalf("expected each provider to be consulted once, got %d/%d/%d", missing.calls, failing.calls, fallback.calls)
+	}
+}
+
+func TestLookupReturnsFirstUpstreamErrorWhenAllProvidersFail(t *testing.T) {
+	t.Parallel()
+	upstream := errors.New("upstream down")
+	svc := NewService(nil,
+		WithProvider(CategoryGame, &fakeProvider{name: "missing", err: ErrNotFound}, 10),
+		WithProvider(CategoryGame, &fakeProvider{name: "failing", err: upstream}, 20),
+	)
+
+	if _, err := svc.Lookup(context.Background(), "Catan", CategoryGame); !errors.Is(err, upstream) {
+		t.Fatalf("expected upstream error, got %v", err)
+	}
+}
+
+func TestLookupFromProvider(t *testing.T) {
+	t.Parallel()
+	provider := &fakeProvider{
+		name: "custom",
+		byID: map[string]Metadata{"42": {Title: "Answer", SourceID: "42"}},
+	}
+	svc := NewService(nil, WithProvider(CategoryGame, provider, DefaultProviderPriority))
+
+	metadata, err := svc.LookupFromProvider(context.Background(), "custom", "42")
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if metadata.Title != "Answer" || metadata.Source != "custom" {
+		t.Fatalf("unexpected metadata %+v", metadata)
+	}
+
+	if _, err := svc.LookupFromProvider(context.Background(), "custom", "7"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := svc.LookupFromProvider(context.Background(), "nope", "42"); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("expected ErrUnknownProvider, got %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package catalog
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimit throttles and retries outbound requests to a single provider.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained request rate; zero disables throttling.
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent back to back.
+	Burst int
+	// MaxRetries is how many times a 429/5xx response or network failure is retried.
+	MaxRetries int
+	// BaseBackoff is the delay before the first retry; later retries double it.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed backoff. A Retry-After longer than this is not waited out.
+	MaxBackoff time.Duration
+}
+
+// defaultRateLimits reflect each provider's published limits.
+var defaultRateLimits = map[string]RateLimit{
+	ProviderGoogleBooks: {RequestsPerSecond: 5, Burst: 10, MaxRetries: 3, BaseBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second},
+	ProviderOpenLibrary: {RequestsPerSecond: 3, Burst: 5, MaxRetries: 3, BaseBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second},
+	ProviderIGDB:        {RequestsPerSecond: 4, Burst: 4, MaxRetries: 3, BaseBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second},
+	ProviderTMDB:        {RequestsPerSecond: 20, Burst: 20, MaxRetries: 3, BaseBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second},
+	ProviderMusicBrainz: {RequestsPerSecond: 1, Burst: 6, MaxRetries: 3, BaseBackoff: time.Second, MaxBackoff: 30 * time.Second},
+}
+
+// DefaultRateLimit returns the built-in throttling and retry policy for a
+// provider, so partial overrides can start from it.
+func DefaultRateLimit(provider string) (RateLimit, bool) {
+	limit, ok := defaultRateLimits[provider]
+	return limit, ok
+}
+
+// WithRateLimit overrides the throttling and retry policy for a built-in provider.
+// Zero-valued backoff fields keep the provider's defaults.
+func WithRateLimit(provider string, limit RateLimit) Option {
+	return func(s *Service) {
+		current := s.rateLimits[provider]
+		if limit.BaseBackoff <= 0 {
+			limit.BaseBackoff = current.BaseBackoff
+		}
+		if limit.MaxBackoff <= 0 {
+			limit.MaxBackoff = current.MaxBackoff
+		}
+		s.rateLimits[provider] = limit
+	}
+}
+
+// withTransportOption applies opt to every provider's throttled transport.
+func withTransportOption(opt transportOption) Option {
+	return func(s *Service) {
+		s.transportOpts = append(s.transportOpts, opt)
+	}
+}
+
+// RequestStats accumulates throttling and retry activity for lookups made with
+// a context returned by WithRequestStats. It is safe for concurrent use.
+type RequestStats struct {
+	retries atomic.Int64
+	delay   atomic.Int64
+}
+
+// Retries reports how many upstream requests were retried.
+func (s *RequestStats) Retries() int {
+	return int(s.retries.Load())
+}
+
+// Delay reports the total time spent waiting on rate limits and backoff.
+func (s *RequestStats) Delay() time.Duration {
+	return time.Duration(s.delay.Load())
+}
+
+type requestStatsKey struct{}
+
+// WithRequestStats returns a context that records throttling and retry activity into stats.
+func WithRequestStats(ctx context.Context, stats *RequestStats) context.Context {
+	return context.WithValue(ctx, requestStatsKey{}, stats)
+}
+
+func requestStatsFrom(ctx context.Context) *RequestStats {
+	stats, _ := ctx.Value(requestStatsKey{}).(*RequestStats)
+	return stats
+}
+
+// tokenBucket is a minimal token-bucket limiter shared by every caller of a provider.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// reserve takes a token and reports how long the caller must wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// throttledTransport applies a provider's RateLimit to every outbound request.
+type throttledTransport struct {
+	base   http.RoundTripper
+	bucket *tokenBucket
+	limit  RateLimit
+	sleep  func(ctx context.Context, delay time.Duration) error
+}
+
+// transportOption configures a throttledTransport.
+type transportOption func(*throttledTransport)
+
+// withSleep replaces how the transport waits out throttling and backoff, so
+// tests can record delays without blocking on them.
+func withSleep(sleep func(ctx context.Context, delay time.Duration) error) transportOption {
+	return func(t *throttledTransport) {
+		t.sleep = sleep
+	}
+}
+
+func newThrottledClient(client *http.Client, limit RateLimit, opts ...transportOption) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	transport := &throttledTransport{base: base, limit: limit, sleep: sleep}
+	if limit.RequestsPerSecond > 0 {
+		transport.bucket = newTokenBucket(limit.RequestsPerSecond, limit.Burst)
+	}
+	for _, opt := range opts {
+		opt(transport)
+	}
+
+	wrapped := *client
+	wrapped.Transport = transport
+	return &wrapped
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	stats := requestStatsFrom(ctx)
+
+	for attempt := 0; ; attempt++ {
+		if t.bucket != nil {
+			if err := t.wait(ctx, t.bucket.reserve(), stats); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(ctx)
+			if req.Body != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		canRetry := attempt < t.limit.MaxRetries && (req.Body == nil || req.GetBody != nil)
+		if err != nil {
+			if !canRetry || ctx.Err() != nil {
+				return nil, err
+			}
+		} else if !canRetry || !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if retryAfter > t.limit.MaxBackoff {
+					// The provider wants us to back off longer than we're willing to block a caller.
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if stats != nil {
+			stats.retries.Add(1)
+		}
+		if err := t.wait(ctx, delay, stats); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *throttledTransport) backoff(attempt int) time.Duration {
+	delay := t.limit.BaseBackoff << attempt
+	if delay <= 0 || delay > t.limit.MaxBackoff {
+		delay = t.limit.MaxBackoff
+	}
+	return delay
+}
+
+// sleep blocks for delay or until ctx ends.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *throttledTransport) wait(ctx context.Context, delay time.Duration, stats *RequestStats) error {
+	if delay <= 0 {
+		return nil
+	}
+	if stats != nil {
+		stats.delay.Add(int64(delay))
+	}
+	return t.sleep(ctx, delay)
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter accepts both the delay-seconds and HTTP-date forms.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
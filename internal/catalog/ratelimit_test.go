@@ -0,0 +1,146 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// skipWaits records backoff in RequestStats without blocking on it.
+func skipWaits(ctx context.Context, _ time.Duration) error {
+	return ctx.Err()
+}
+
+func TestLookupRetriesAfterTooManyRequests(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			resp := jsonResponse(t, http.StatusTooManyRequests, map[string]string{})
+			resp.Header.Set("Retry-After", "0")
+			return resp, nil
+		}
+		return jsonResponse(t, http.StatusOK, googleBooksResponse{Items: []googleVolume{{
+			ID:         "vol",
+			VolumeInfo: googleVolumeInfo{Title: "Retried"},
+		}}}), nil
+	})
+	svc := NewService(client, WithGoogleBooksBaseURL("http://google.test"), withTransportOption(withSleep(skipWaits)))
+
+	stats := &RequestStats{}
+	results, err := svc.Lookup(WithRequestStats(context.Background(), stats), "retried book", CategoryBook)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Retried" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if attempts != 2 || stats.Retries() != 1 {
+		t.Fatalf("expected one retry, got %d attempts and %d recorded retries", attempts, stats.Retries())
+	}
+}
+
+func TestLookupGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return jsonResponse(t, http.StatusServiceUnavailable, map[string]string{}), nil
+	})
+	svc := NewService(
+		client,
+		WithTMDBBaseURL("http://tmdb.test"),
+		WithTMDBAPIKey("key"),
+		WithRateLimit(ProviderTMDB, RateLimit{MaxRetries: 2, BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}),
+		withTransportOption(withSleep(skipWaits)),
+	)
+
+	stats := &RequestStats{}
+	_, err := svc.Lookup(WithRequestStats(context.Background(), stats), "Alien", CategoryMovie)
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected upstream error, got %v", err)
+	}
+	if attempts != 3 || stats.Retries() != 2 {
+		t.Fatalf("expected 3 attempts and 2 retries, got %d and %d", attempts, stats.Retries())
+	}
+	if stats.Delay() != 3*time.Second {
+		t.Fatalf("expected backoff delay to be recorded, got %s", stats.Delay())
+	}
+}
+
+func TestLookupDoesNotWaitOutLongRetryAfter(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		attempts++
+		resp := jsonResponse(t, http.StatusTooManyRequests, map[string]string{})
+		resp.Header.Set("Retry-After", "3600")
+		return resp, nil
+	})
+	svc := NewService(client, WithMusicBrainzBaseURL("http://mb.test"))
+
+	if _, err := svc.Lookup(context.Background(), "Kind of Blue", CategoryMusic); err == nil {
+		t.Fatal("expected rate limit error")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retry for an hour-long Retry-After, got %d attempts", attempts)
+	}
+}
+
+func TestLookupReplaysRequestBodyOnRetry(t *testing.T) {
+	t.Parallel()
+	var bodies []string
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return jsonResponse(t, http.StatusBadGateway, map[string]string{}), nil
+		}
+		return jsonResponse(t, http.StatusOK, []igdbGame{{ID: 1, Name: "Hades"}}), nil
+	})
+	svc := NewService(
+		client,
+		WithIGDBBaseURL("http://igdb.test"),
+		WithIGDBCredentials("client", "token"),
+		WithRateLimit(ProviderIGDB, RateLimit{MaxRetries: 1}),
+		withTransportOption(withSleep(skipWaits)),
+	)
+
+	if _, err := svc.Lookup(context.Background(), "Hades", CategoryGame); err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Fatalf("expected identical request bodies, got %q", bodies)
+	}
+}
+
+func TestTokenBucketDelaysOnceBurstIsSpent(t *testing.T) {
+	t.Parallel()
+	bucket := newTokenBucket(10, 2)
+
+	if bucket.reserve() != 0 || bucket.reserve() != 0 {
+		t.Fatal("expected burst requests to proceed immediately")
+	}
+	if delay := bucket.reserve(); delay <= 0 || delay > 100*time.Millisecond {
+		t.Fatalf("expected ~100ms delay, got %s", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	if delay, ok := parseRetryAfter("7"); !ok || delay != 7*time.Second {
+		t.Fatalf("expected 7s, got %s, %v", delay, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 || delay > time.Minute {
+		t.Fatalf("expected delay from HTTP date, got %s, %v", delay, ok)
+	}
+	if _, ok := parseRetryAfter(strings.Repeat("x", 3)); ok {
+		t.Fatal("expected invalid Retry-After to be ignored")
+	}
+}
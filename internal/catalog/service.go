@@ -2,13 +2,11 @@ package catalog
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
-	"net/url"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -18,20 +16,20 @@ import (
 type Category string
 
 const (
-	// CategoryBook resolves metadata for books via Google Books.
+	// CategoryBook resolves metadata for books (Google Books, falling back to Open Library).
 	CategoryBook Category = "book"
-	// CategoryGame is reserved for future expansion.
+	// CategoryGame resolves metadata for board and video games (IGDB by default).
 	CategoryGame Category = "game"
-	// CategoryMovie is reserved for future expansion.
+	// CategoryMovie resolves metadata for movies (TMDB by default).
 	CategoryMovie Category = "movie"
-	// CategoryMusic is reserved for future expansion.
+	// CategoryMusic resolves metadata for albums (MusicBrainz by default).
 	CategoryMusic Category = "music"
 )
 
 var (
 	// ErrInvalidQuery is returned when the lookup query is empty or too short.
 	ErrInvalidQuery = errors.New("query must be at least 3 characters")
-	// ErrUnsupportedCategory is returned when the category is not implemented.
+	// ErrUnsupportedCategory is returned when no provider is registered for the category.
 	ErrUnsupportedCategory = errors.New("unsupported lookup category")
 	// ErrNotFound is returned when no metadata could be located for the query.
 	ErrNotFound = errors.New("no metadata found for the supplied query")
@@ -52,340 +50,206 @@ type Metadata struct {
 	Genre          string   `json:"genre,omitempty"`
 	RetailPriceUsd *float64 `json:"retailPriceUsd,omitempty"`
 	GoogleVolumeId string   `json:"googleVolumeId,omitempty"`
+	Platform       string   `json:"platform,omitempty"`
+	AgeGroup       string   `json:"ageGroup,omitempty"`
+	PlayerCount    string   `json:"playerCount,omitempty"`
+	RuntimeMinutes *int     `json:"runtimeMinutes,omitempty"`
+	Label          string   `json:"label,omitempty"`
+	Tracklist      []string `json:"tracklist,omitempty"`
+	Source         string   `json:"source,omitempty"`
+	SourceID       string   `json:"sourceId,omitempty"`
 }
 
-// Service performs metadata lookups against third-party catalog APIs.
+// Service performs metadata lookups against the providers registered for each category.
 type Service struct {
-	client  *http.Client
-	baseURL string
-	apiKey  string
+	registry *Registry
+
+	googleBooks *googleBooksProvider
+	igdb        *igdbProvider
+	tmdb        *tmdbProvider
+	musicBrainz *musicBrainzProvider
+	openLibrary *openLibraryProvider
+
+	mergeOpenLibrary bool
+
+	cache            Cache
+	cacheTTL         time.Duration
+	negativeCacheTTL time.Duration
+	cacheCounters    cacheCounters
+
+	rateLimits    map[string]RateLimit
+	transportOpts []transportOption
+
+	extra []categoryProvider
 }
 
-const defaultGoogleBooksURL = "https://www.googleapis.com/books/v1"
+type categoryProvider struct {
+	category Category
+	provider Provider
+	priority int
+}
 
 // Option configures the Service during construction.
 type Option func(*Service)
 
-// WithGoogleBooksBaseURL overrides the base URL for Google Books requests.
-func WithGoogleBooksBaseURL(baseURL string) Option {
-	return func(s *Service) {
-		s.baseURL = strings.TrimRight(baseURL, "/")
-	}
-}
-
-// WithGoogleBooksAPIKey configures the API key used for Google Books requests.
-func WithGoogleBooksAPIKey(key string) Option {
+// WithProvider registers an additional provider for the category. Built-in
+// providers use DefaultProviderPriority; use a lower value to consult the
+// provider before them or a higher one to use it as a fallback.
+func WithProvider(category Category, provider Provider, priority int) Option {
 	return func(s *Service) {
-		s.apiKey = strings.TrimSpace(key)
+		s.extra = append(s.extra, categoryProvider{category: category, provider: provider, priority: priority})
 	}
 }
 
-// NewService constructs a Service.
+// NewService constructs a Service with the built-in providers plus any supplied via WithProvider.
+// IGDB and TMDB are only registered once their credentials are configured.
 func NewService(client *http.Client, opts ...Option) *Service {
 	if client == nil {
 		client = &http.Client{Timeout: 10 * time.Second}
 	}
 
 	svc := &Service{
-		client:  client,
-		baseURL: defaultGoogleBooksURL,
+		registry:    NewRegistry(),
+		googleBooks: &googleBooksProvider{client: client, baseURL: defaultGoogleBooksURL},
+		igdb:        &igdbProvider{client: client, baseURL: defaultIGDBURL},
+		tmdb:        &tmdbProvider{client: client, baseURL: defaultTMDBURL, upcBaseURL: defaultUPCLookupURL},
+		musicBrainz: &musicBrainzProvider{
+			client:          client,
+			baseURL:         defaultMusicBrainzURL,
+			userAgent:       defaultMusicBrainzUserAgent,
+			coverArtBaseURL: defaultCoverArtArchiveURL,
+		},
+		openLibrary: &openLibraryProvider{
+			client:        client,
+			baseURL:       defaultOpenLibraryURL,
+			coversBaseURL: defaultOpenLibraryCoversURL,
+		},
+		rateLimits: maps.Clone(defaultRateLimits),
 	}
 
 	for _, opt := range opts {
 		opt(svc)
 	}
 
-	return svc
-}
+	// Each built-in provider gets its own limiter, shared by every caller of the service.
+	svc.googleBooks.client = newThrottledClient(client, svc.rateLimits[ProviderGoogleBooks], svc.transportOpts...)
+	svc.openLibrary.client = newThrottledClient(client, svc.rateLimits[ProviderOpenLibrary], svc.transportOpts...)
+	svc.igdb.client = newThrottledClient(client, svc.rateLimits[ProviderIGDB], svc.transportOpts...)
+	svc.tmdb.client = newThrottledClient(client, svc.rateLimits[ProviderTMDB], svc.transportOpts...)
+	svc.musicBrainz.client = newThrottledClient(client, svc.rateLimits[ProviderMusicBrainz], svc.transportOpts...)
 
-// Lookup attempts to fetch metadata for the supplied query and category.
-func (s *Service) Lookup(ctx context.Context, query string, category Category) ([]Metadata, error) {
-	cleaned := strings.TrimSpace(query)
-	if len(cleaned) < 3 {
-		return nil, ErrInvalidQuery
+	svc.register(CategoryBook, svc.googleBooks, DefaultProviderPriority)
+	svc.register(CategoryBook, svc.openLibrary, FallbackProviderPriority)
+	if svc.igdb.clientID != "" {
+		svc.register(CategoryGame, svc.igdb, DefaultProviderPriority)
 	}
-
-	switch category {
-	case CategoryBook:
-		return s.lookupBook(ctx, cleaned)
-	case CategoryGame, CategoryMovie, CategoryMusic:
-		return nil, ErrUnsupportedCategory
-	default:
-		return nil, ErrUnsupportedCategory
+	if svc.tmdb.apiKey != "" {
+		svc.register(CategoryMovie, svc.tmdb, DefaultProviderPriority)
 	}
-}
-
-type googleBooksResponse struct {
-	Items []googleVolume `json:"items"`
-}
-
-type googleVolume struct {
-	ID         string           `json:"id"`
-	VolumeInfo googleVolumeInfo `json:"volumeInfo"`
-	SaleInfo   googleSaleInfo   `json:"saleInfo"`
-}
-
-type googleVolumeInfo struct {
-	Title               string                     `json:"title"`
-	Subtitle            string                     `json:"subtitle"`
-	Authors             []string                   `json:"authors"`
-	Description         string                     `json:"description"`
-	PublishedDate       string                     `json:"publishedDate"`
-	PageCount           int                        `json:"pageCount"`
-	Categories          []string                   `json:"categories"`
-	IndustryIdentifiers []googleIndustryIdentifier `json:"industryIdentifiers"`
-	ImageLinks          googleImageLinks           `json:"imageLinks"`
-}
-
-type googleSaleInfo struct {
-	IsEbook     bool             `json:"isEbook"`
-	RetailPrice *googlePriceInfo `json:"retailPrice"`
-}
-
-type googlePriceInfo struct {
-	Amount       float64 `json:"amount"`
-	CurrencyCode string  `json:"currencyCode"`
-}
+	svc.register(CategoryMusic, svc.musicBrainz, DefaultProviderPriority)
 
-type googleIndustryIdentifier struct {
-	Type       string `json:"type"`
-	Identifier string `json:"identifier"`
-}
+	for _, entry := range svc.extra {
+		svc.register(entry.category, entry.provider, entry.priority)
+	}
 
-type googleImageLinks struct {
-	Thumbnail      string `json:"thumbnail"`
-	SmallThumbnail string `json:"smallThumbnail"`
+	return svc
 }
 
-func (s *Service) lookupBook(ctx context.Context, query string) ([]Metadata, error) {
-	if isbn := normalizeISBN(query); isbn != "" {
-		metadata, err := s.lookupBookByISBN(ctx, isbn)
-		if err == nil {
-			return []Metadata{metadata}, nil
-		}
-		if !errors.Is(err, ErrNotFound) {
-			return nil, err
+// register adds the provider to the registry, wrapping it with the lookup cache when one is configured.
+func (s *Service) register(category Category, provider Provider, priority int) {
+	if s.cache != nil {
+		provider = &cachedProvider{
+			Provider:    provider,
+			cache:       s.cache,
+			ttl:         s.cacheTTL,
+			negativeTTL: s.negativeCacheTTL,
+			counters:    &s.cacheCounters,
 		}
 	}
-
-	// If the query looks like a barcode (digits/X only) but isn't a valid ISBN
-	// length (10 or 13), return not found instead of doing a keyword search.
-	if isInvalidBarcodeQuery(query) {
-		return nil, ErrNotFound
-	}
-
-	return s.lookupBookByQuery(ctx, query)
+	s.registry.Register(category, provider, priority)
 }
 
-func (s *Service) lookupBookByISBN(ctx context.Context, isbn string) (Metadata, error) {
-	volumes, err := s.searchGoogleBooks(ctx, "isbn:"+isbn, 1)
-	if err != nil {
-		return Metadata{}, err
-	}
-	if len(volumes) == 0 {
-		return Metadata{}, ErrNotFound
+// Lookup attempts to fetch metadata for the supplied query and category, falling
+// back through the category's providers in priority order.
+func (s *Service) Lookup(ctx context.Context, query string, category Category) ([]Metadata, error) {
+	cleaned := strings.TrimSpace(query)
+	if len(cleaned) < 3 {
+		return nil, ErrInvalidQuery
 	}
 
-	metadata, err := s.metadataFromVolume(volumes[0])
-	if err != nil {
-		return Metadata{}, err
+	switch category {
+	case CategoryBook, CategoryGame, CategoryMovie, CategoryMusic:
+	default:
+		return nil, ErrUnsupportedCategory
 	}
 
-	if metadata.ISBN13 == "" && len(isbn) == 13 {
-		metadata.ISBN13 = isbn
-	}
-	if metadata.ISBN10 == "" && len(isbn) == 10 {
-		metadata.ISBN10 = isbn
+	providers := s.registry.Providers(category)
+	if len(providers) == 0 {
+		return nil, ErrUnsupportedCategory
 	}
 
-	return metadata, nil
-}
-
-func (s *Service) lookupBookByQuery(ctx context.Context, query string) ([]Metadata, error) {
-	volumes, err := s.searchGoogleBooks(ctx, query, 5)
+	results, err := lookupChain(ctx, providers, cleaned)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(volumes) == 0 {
-		return nil, ErrNotFound
-	}
-
-	results := make([]Metadata, 0, len(volumes))
-	for _, volume := range volumes {
-		metadata, err := s.metadataFromVolume(volume)
-		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				continue
-			}
-			return nil, err
-		}
-		results = append(results, metadata)
-	}
-
-	if len(results) == 0 {
-		return nil, ErrNotFound
+	if category == CategoryBook && s.mergeOpenLibrary {
+		s.fillBookGaps(ctx, cleaned, results)
 	}
 
 	return results, nil
 }
 
-// LookupByVolumeID fetches metadata for a specific Google Books volume ID.
-// This is used for re-syncing existing items with updated metadata.
-func (s *Service) LookupByVolumeID(ctx context.Context, volumeID string) (Metadata, error) {
-	if strings.TrimSpace(volumeID) == "" {
-		return Metadata{}, ErrInvalidQuery
-	}
-
-	endpoint, err := url.Parse(s.baseURL + "/volumes/" + url.PathEscape(volumeID))
-	if err != nil {
-		return Metadata{}, fmt.Errorf("build google books url: %w", err)
-	}
-
-	values := url.Values{}
-	if s.apiKey != "" {
-		values.Set("key", s.apiKey)
+// fillBookGaps merges Open Library data into the first result of an ISBN lookup
+// answered by another provider. Merging is best effort; failures leave the
+// primary result untouched.
+func (s *Service) fillBookGaps(ctx context.Context, query string, results []Metadata) {
+	isbn := normalizeISBN(query)
+	if isbn == "" || len(results) == 0 {
+		return
 	}
-	if len(values) > 0 {
-		endpoint.RawQuery = values.Encode()
+	primary := &results[0]
+	if primary.Source == ProviderOpenLibrary || !hasBookGaps(*primary) {
+		return
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return Metadata{}, fmt.Errorf("create google books request: %w", err)
-	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return Metadata{}, fmt.Errorf("call google books: %w", err)
+	openLibrary, ok := s.registry.Provider(ProviderOpenLibrary)
+	if !ok {
+		return
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return Metadata{}, ErrNotFound
-	}
-	if resp.StatusCode != http.StatusOK {
-		return Metadata{}, fmt.Errorf("google books returned status %d", resp.StatusCode)
-	}
-
-	var volume googleVolume
-	if err := json.NewDecoder(resp.Body).Decode(&volume); err != nil {
-		return Metadata{}, fmt.Errorf("decode google books response: %w", err)
+	fallback, err := openLibrary.Lookup(ctx, isbn)
+	if err != nil || len(fallback) == 0 {
+		return
 	}
-
-	return s.metadataFromVolume(volume)
+	mergeBookGaps(primary, fallback[0])
 }
 
-func (s *Service) searchGoogleBooks(ctx context.Context, q string, maxResults int) ([]googleVolume, error) {
-	endpoint, err := url.Parse(s.baseURL + "/volumes")
-	if err != nil {
-		return nil, fmt.Errorf("build google books url: %w", err)
-	}
-
-	values := url.Values{}
-	values.Set("q", q)
-	if maxResults > 0 {
-		values.Set("maxResults", strconv.Itoa(maxResults))
-	}
-	values.Set("printType", "books")
-	values.Set("orderBy", "relevance")
-	if s.apiKey != "" {
-		values.Set("key", s.apiKey)
+// LookupFromProvider fetches a record directly from the named provider using the
+// Source and SourceID recorded on an earlier lookup result.
+func (s *Service) LookupFromProvider(ctx context.Context, providerName, id string) (Metadata, error) {
+	if strings.TrimSpace(id) == "" {
+		return Metadata{}, ErrInvalidQuery
 	}
-	endpoint.RawQuery = values.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("create google books request: %w", err)
+	provider, ok := s.registry.Provider(providerName)
+	if !ok {
+		return Metadata{}, ErrUnknownProvider
 	}
 
-	resp, err := s.client.Do(req)
+	metadata, err := provider.LookupByID(ctx, strings.TrimSpace(id))
 	if err != nil {
-		return nil, fmt.Errorf("call google books: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("google books returned status %d", resp.StatusCode)
-	}
-
-	var payload googleBooksResponse
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, fmt.Errorf("decode google books response: %w", err)
-	}
-
-	return payload.Items, nil
-}
-
-func (s *Service) metadataFromVolume(volume googleVolume) (Metadata, error) {
-	info := volume.VolumeInfo
-	title := strings.TrimSpace(info.Title)
-	creator := strings.TrimSpace(strings.Join(info.Authors, ", "))
-
-	if title == "" && creator == "" {
-		return Metadata{}, ErrNotFound
-	}
-
-	isbn13, isbn10 := selectISBNs(flattenIdentifiers(info.IndustryIdentifiers))
-	description := strings.TrimSpace(firstNonEmpty(info.Description, info.Subtitle))
-
-	metadata := Metadata{
-		Title:          title,
-		Creator:        creator,
-		ItemType:       "book",
-		PageCount:      nil,
-		ISBN13:         isbn13,
-		ISBN10:         isbn10,
-		Description:    description,
-		CoverImage:     normalizeCoverURL(info.ImageLinks),
-		Notes:          "",
-		GoogleVolumeId: volume.ID,
-		Genre:          MapCategoriesToGenre(info.Categories),
-	}
-
-	if info.PageCount > 0 {
-		pages := info.PageCount
-		metadata.PageCount = &pages
-	}
-
-	if year := parsePublishYear(info.PublishedDate); year != nil {
-		metadata.ReleaseYear = year
+		return Metadata{}, err
 	}
-
-	// Extract retail price (USD only)
-	if volume.SaleInfo.RetailPrice != nil && volume.SaleInfo.RetailPrice.CurrencyCode == "USD" {
-		price := volume.SaleInfo.RetailPrice.Amount
-		metadata.RetailPriceUsd = &price
+	if metadata.Source == "" {
+		metadata.Source = provider.Name()
 	}
-
 	return metadata, nil
 }
 
-func flattenIdentifiers(values []googleIndustryIdentifier) []string {
-	candidates := make([]string, 0, len(values))
-	for _, value := range values {
-		trimmed := strings.TrimSpace(value.Identifier)
-		if trimmed == "" {
-			continue
-		}
-		candidates = append(candidates, trimmed)
-	}
-	return candidates
-}
-
-func normalizeCoverURL(links googleImageLinks) string {
-	candidates := []string{links.Thumbnail, links.SmallThumbnail}
-	for _, raw := range candidates {
-		trimmed := strings.TrimSpace(raw)
-		if trimmed == "" {
-			continue
-		}
-		if strings.HasPrefix(trimmed, "http://") {
-			trimmed = "https://" + strings.TrimPrefix(trimmed, "http://")
-		}
-		return trimmed
-	}
-	return ""
+// LookupByVolumeID fetches metadata for a specific Google Books volume ID.
+// This is used for re-syncing existing items with updated metadata.
+func (s *Service) LookupByVolumeID(ctx context.Context, volumeID string) (Metadata, error) {
+	return s.LookupFromProvider(ctx, ProviderGoogleBooks, volumeID)
 }
 
 func selectISBNs(values []string) (string, string) {
@@ -452,6 +316,25 @@ func isInvalidBarcodeQuery(query string) bool {
 	return false
 }
 
+// normalizeBarcode returns the digits of a 12-digit UPC or 13-digit EAN query,
+// or an empty string when the query is not purely a barcode.
+func normalizeBarcode(value string) string {
+	digits := make([]rune, 0, len(value))
+	for _, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			digits = append(digits, r)
+		case unicode.IsSpace(r) || r == '-':
+		default:
+			return ""
+		}
+	}
+	if len(digits) != 12 && len(digits) != 13 {
+		return ""
+	}
+	return string(digits)
+}
+
 func normalizeISBN(value string) string {
 	cleaned := make([]rune, 0, len(value))
 	for _, r := range value {
@@ -0,0 +1,304 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultTMDBURL      = "https://api.themoviedb.org/3"
+	defaultUPCLookupURL = "https://api.upcitemdb.com/prod/trial"
+	tmdbPosterURLPrefix = "https://image.tmdb.org/t/p/w500"
+	tmdbMaxResults      = 5
+)
+
+// WithTMDBBaseURL overrides the base URL for TMDB movie requests.
+func WithTMDBBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.tmdb.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithTMDBAPIKey configures the API key used for TMDB requests.
+// Movie lookups are disabled until a key is supplied.
+func WithTMDBAPIKey(key string) Option {
+	return func(s *Service) {
+		s.tmdb.apiKey = strings.TrimSpace(key)
+	}
+}
+
+// WithUPCLookupBaseURL overrides the UPCitemdb-compatible service used to turn
+// movie barcodes into titles before searching TMDB.
+func WithUPCLookupBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.tmdb.upcBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// tmdbProvider resolves movie metadata via TMDB, using a UPC lookup service to
+// translate barcodes into searchable titles.
+type tmdbProvider struct {
+	client     *http.Client
+	baseURL    string
+	apiKey     string
+	upcBaseURL string
+}
+
+func (p *tmdbProvider) Name() string {
+	return ProviderTMDB
+}
+
+type tmdbSearchResponse struct {
+	Results []tmdbMovieSummary `json:"results"`
+}
+
+type tmdbMovieSummary struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+}
+
+type tmdbMovie struct {
+	ID          int64       `json:"id"`
+	Title       string      `json:"title"`
+	Overview    string      `json:"overview"`
+	ReleaseDate string      `json:"release_date"`
+	PosterPath  string      `json:"poster_path"`
+	Runtime     int         `json:"runtime"`
+	Credits     tmdbCredits `json:"credits"`
+}
+
+type tmdbCredits struct {
+	Crew []tmdbCrewMember `json:"crew"`
+}
+
+type tmdbCrewMember struct {
+	Name string `json:"name"`
+	Job  string `json:"job"`
+}
+
+type upcLookupResponse struct {
+	Items []upcLookupItem `json:"items"`
+}
+
+type upcLookupItem struct {
+	Title string `json:"title"`
+}
+
+var (
+	// trailingYearPattern splits "Alien (1979)" or "Alien 1979" into title and year.
+	trailingYearPattern = regexp.MustCompile(`^(.+?)[\s(\[]+((?:18|19|20)[0-9]{2})[)\]]?$`)
+	// bracketedPattern strips retailer annotations such as "(DVD, 1999)" or "[Blu-ray]".
+	bracketedPattern = regexp.MustCompile(`\s*[(\[][^)\]]*[)\]]`)
+	// mediaFormatPattern strips disc format words that retailers append to titles.
+	mediaFormatPattern = regexp.MustCompile(`(?i)\b(blu-?ray|dvd|4k|ultra hd|uhd|digital copy|widescreen|full ?screen|special edition|collector'?s edition)\b`)
+)
+
+func (p *tmdbProvider) Lookup(ctx context.Context, query string) ([]Metadata, error) {
+	if barcode := normalizeBarcode(query); barcode != "" {
+		title, err := p.lookupUPCTitle(ctx, barcode)
+		if err != nil {
+			return nil, err
+		}
+		return p.searchMovies(ctx, title, nil)
+	}
+
+	// Barcode-shaped queries of any other length cannot be resolved.
+	if isInvalidBarcodeQuery(query) {
+		return nil, ErrNotFound
+	}
+
+	if match := trailingYearPattern.FindStringSubmatch(query); match != nil {
+		year, _ := strconv.Atoi(match[2])
+		results, err := p.searchMovies(ctx, strings.TrimSpace(match[1]), &year)
+		if err == nil {
+			return results, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		// Titles such as "Blade Runner 2049" end in a year-like number, so retry verbatim.
+	}
+
+	return p.searchMovies(ctx, query, nil)
+}
+
+func (p *tmdbProvider) searchMovies(ctx context.Context, title string, year *int) ([]Metadata, error) {
+	values := url.Values{}
+	values.Set("query", title)
+	values.Set("include_adult", "false")
+	if year != nil {
+		values.Set("year", strconv.Itoa(*year))
+	}
+
+	var payload tmdbSearchResponse
+	if err := p.get(ctx, "/search/movie", values, &payload); err != nil {
+		return nil, err
+	}
+
+	summaries := payload.Results
+	if len(summaries) > tmdbMaxResults {
+		summaries = summaries[:tmdbMaxResults]
+	}
+
+	results := make([]Metadata, 0, len(summaries))
+	for _, summary := range summaries {
+		metadata, err := p.lookupMovieByID(ctx, summary.ID)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, metadata)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return results, nil
+}
+
+// LookupByID fetches a single movie by its TMDB ID.
+func (p *tmdbProvider) LookupByID(ctx context.Context, id string) (Metadata, error) {
+	movieID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || movieID <= 0 {
+		return Metadata{}, ErrNotFound
+	}
+	return p.lookupMovieByID(ctx, movieID)
+}
+
+func (p *tmdbProvider) lookupMovieByID(ctx context.Context, id int64) (Metadata, error) {
+	values := url.Values{}
+	values.Set("append_to_response", "credits")
+
+	var movie tmdbMovie
+	if err := p.get(ctx, "/movie/"+strconv.FormatInt(id, 10), values, &movie); err != nil {
+		return Metadata{}, err
+	}
+
+	return metadataFromMovie(movie)
+}
+
+func (p *tmdbProvider) get(ctx context.Context, path string, values url.Values, dst any) error {
+	endpoint, err := url.Parse(p.baseURL + path)
+	if err != nil {
+		return fmt.Errorf("build tmdb url: %w", err)
+	}
+	values.Set("api_key", p.apiKey)
+	endpoint.RawQuery = values.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("create tmdb request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call tmdb: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tmdb returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("decode tmdb response: %w", err)
+	}
+	return nil
+}
+
+func (p *tmdbProvider) lookupUPCTitle(ctx context.Context, barcode string) (string, error) {
+	endpoint, err := url.Parse(p.upcBaseURL + "/lookup")
+	if err != nil {
+		return "", fmt.Errorf("build upc lookup url: %w", err)
+	}
+	endpoint.RawQuery = url.Values{"upc": []string{barcode}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("create upc lookup request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("call upc lookup: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("upc lookup returned status %d", resp.StatusCode)
+	}
+
+	var payload upcLookupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("decode upc lookup response: %w", err)
+	}
+
+	for _, item := range payload.Items {
+		if title := cleanRetailTitle(item.Title); title != "" {
+			return title, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+func metadataFromMovie(movie tmdbMovie) (Metadata, error) {
+	title := strings.TrimSpace(movie.Title)
+	if title == "" {
+		return Metadata{}, ErrNotFound
+	}
+
+	var directors []string
+	for _, member := range movie.Credits.Crew {
+		if member.Job == "Director" && strings.TrimSpace(member.Name) != "" {
+			directors = append(directors, strings.TrimSpace(member.Name))
+		}
+	}
+
+	metadata := Metadata{
+		Title:       title,
+		Creator:     strings.Join(directors, ", "),
+		ItemType:    "movie",
+		Description: strings.TrimSpace(movie.Overview),
+		ReleaseYear: parsePublishYear(movie.ReleaseDate),
+		SourceID:    strconv.FormatInt(movie.ID, 10),
+	}
+
+	if poster := strings.TrimSpace(movie.PosterPath); poster != "" {
+		metadata.CoverImage = tmdbPosterURLPrefix + poster
+	}
+
+	if movie.Runtime > 0 {
+		runtime := movie.Runtime
+		metadata.RuntimeMinutes = &runtime
+	}
+
+	return metadata, nil
+}
+
+// cleanRetailTitle strips packaging noise from retailer product titles so they
+// can be used as a search query.
+func cleanRetailTitle(raw string) string {
+	cleaned := bracketedPattern.ReplaceAllString(raw, "")
+	cleaned = mediaFormatPattern.ReplaceAllString(cleaned, "")
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+	return strings.Trim(cleaned, " -:,/+")
+}
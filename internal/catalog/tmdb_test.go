@@ -0,0 +1,158 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestLookupMovieByTitleAndYear(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		if got := r.URL.Query().Get("api_key"); got != "tmdb-key" {
+			t.Fatalf("expected api_key to be set, got %q", got)
+		}
+		switch r.URL.Path {
+		case "/search/movie":
+			values := r.URL.Query()
+			if values.Get("query") != "Alien" || values.Get("year") != "1979" {
+				t.Fatalf("expected title/year search, got %s", r.URL.RawQuery)
+			}
+			return jsonResponse(t, http.StatusOK, tmdbSearchResponse{
+				Results: []tmdbMovieSummary{{ID: 348, Title: "Alien"}},
+			}), nil
+		case "/movie/348":
+			if got := r.URL.Query().Get("append_to_response"); got != "credits" {
+				t.Fatalf("expected credits to be appended, got %q", got)
+			}
+			return jsonResponse(t, http.StatusOK, tmdbMovie{
+				ID:          348,
+				Title:       "Alien",
+				Overview:    "In space no one can hear you scream.",
+				ReleaseDate: "1979-05-25",
+				PosterPath:  "/poster.jpg",
+				Runtime:     117,
+				Credits: tmdbCredits{Crew: []tmdbCrewMember{
+					{Name: "Walter Hill", Job: "Producer"},
+					{Name: "Ridley Scott", Job: "Director"},
+				}},
+			}), nil
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+			return nil, nil
+		}
+	})
+	svc := NewService(client, WithTMDBBaseURL("http://tmdb.test"), WithTMDBAPIKey("tmdb-key"))
+
+	results, err := svc.Lookup(context.Background(), "Alien (1979)", CategoryMovie)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	entry := results[0]
+	if entry.Title != "Alien" || entry.ItemType != "movie" {
+		t.Fatalf("unexpected title/type: %q, %q", entry.Title, entry.ItemType)
+	}
+	if entry.Creator != "Ridley Scott" {
+		t.Fatalf("expected director as creator, got %q", entry.Creator)
+	}
+	if entry.ReleaseYear == nil || *entry.ReleaseYear != 1979 {
+		t.Fatalf("expected release year 1979, got %+v", entry.ReleaseYear)
+	}
+	if entry.RuntimeMinutes == nil || *entry.RuntimeMinutes != 117 {
+		t.Fatalf("expected runtime 117, got %+v", entry.RuntimeMinutes)
+	}
+	if entry.CoverImage != "https://image.tmdb.org/t/p/w500/poster.jpg" {
+		t.Fatalf("unexpected poster %q", entry.CoverImage)
+	}
+	if entry.Description == "" {
+		t.Fatal("expected synopsis to be populated")
+	}
+}
+
+func TestLookupMovieRetriesWithoutYear(t *testing.T) {
+	t.Parallel()
+	var searches []string
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/search/movie":
+			searches = append(searches, r.URL.Query().Get("query"))
+			if r.URL.Query().Get("year") != "" {
+				return jsonResponse(t, http.StatusOK, tmdbSearchResponse{}), nil
+			}
+			return jsonResponse(t, http.StatusOK, tmdbSearchResponse{
+				Results: []tmdbMovieSummary{{ID: 1}},
+			}), nil
+		case "/movie/1":
+			return jsonResponse(t, http.StatusOK, tmdbMovie{ID: 1, Title: "Blade Runner 2049"}), nil
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+			return nil, nil
+		}
+	})
+	svc := NewService(client, WithTMDBBaseURL("http://tmdb.test"), WithTMDBAPIKey("tmdb-key"))
+
+	results, err := svc.Lookup(context.Background(), "Blade Runner 2049", CategoryMovie)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Blade Runner 2049" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if len(searches) != 2 || searches[1] != "Blade Runner 2049" {
+		t.Fatalf("expected verbatim retry, got %v", searches)
+	}
+}
+
+func TestLookupMovieByBarcode(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Host + r.URL.Path {
+		case "upc.test/lookup":
+			if got := r.URL.Query().Get("upc"); got != "085391163121" {
+				t.Fatalf("expected upc query, got %q", got)
+			}
+			return jsonResponse(t, http.StatusOK, upcLookupResponse{
+				Items: []upcLookupItem{{Title: "The Matrix (DVD, 1999) [Widescreen]"}},
+			}), nil
+		case "tmdb.test/search/movie":
+			if got := r.URL.Query().Get("query"); got != "The Matrix" {
+				t.Fatalf("expected cleaned title, got %q", got)
+			}
+			return jsonResponse(t, http.StatusOK, tmdbSearchResponse{
+				Results: []tmdbMovieSummary{{ID: 603}},
+			}), nil
+		case "tmdb.test/movie/603":
+			return jsonResponse(t, http.StatusOK, tmdbMovie{ID: 603, Title: "The Matrix"}), nil
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+			return nil, nil
+		}
+	})
+	svc := NewService(
+		client,
+		WithTMDBBaseURL("http://tmdb.test"),
+		WithTMDBAPIKey("tmdb-key"),
+		WithUPCLookupBaseURL("http://upc.test"),
+	)
+
+	results, err := svc.Lookup(context.Background(), "0 85391-16312 1", CategoryMovie)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "The Matrix" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+}
+
+func TestLookupMovieRequiresAPIKey(t *testing.T) {
+	t.Parallel()
+	svc := NewService(nil)
+	if _, err := svc.Lookup(context.Background(), "Alien", CategoryMovie); !errors.Is(err, ErrUnsupportedCategory) {
+		t.Fatalf("expected ErrUnsupportedCategory, got %v", err)
+	}
+}
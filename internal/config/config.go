@@ -6,8 +6,17 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// RateLimit is a CATALOG_RATE_LIMITS override for one catalog provider. Nil
+// fields keep the provider's default.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             *int
+	MaxRetries        *int
+}
+
 // Config aggregates runtime configuration for the Anthology services.
 type Config struct {
 	Environment       string
@@ -17,6 +26,32 @@ type Config struct {
 	AllowedOrigins    []string
 	GoogleBooksAPIKey string
 
+	// IGDB game metadata (optional)
+	IGDBClientID    string
+	IGDBAccessToken string
+
+	// TMDB movie metadata (optional)
+	TMDBAPIKey string
+
+	// Catalog lookup cache lifetimes
+	CatalogCacheTTL         time.Duration
+	CatalogNegativeCacheTTL time.Duration
+
+	// CatalogRateLimits overrides outbound throttling per catalog provider name.
+	CatalogRateLimits map[string]RateLimit
+
+	// Sessions expire after SessionIdleTimeout without use and never outlive SessionMaxLifetime.
+	SessionIdleTimeout time.Duration
+	SessionMaxLifetime time.Duration
+
+	// Maintenance job intervals
+	SessionCleanupInterval   time.Duration
+	CachePurgeInterval       time.Duration
+	PlacementCleanupInterval time.Duration
+
+	// AdminEmails may use operational endpoints such as the catalog cache purge.
+	AdminEmails []string
+
 	// Google OAuth
 	GoogleClientID       string
 	GoogleClientSecret   string
@@ -24,13 +59,23 @@ type Config struct {
 	GoogleAllowedDomains []string
 	GoogleAllowedEmails  []string
 	FrontendURL          string
+
+	// Generic OpenID Connect provider (optional), served at /api/auth/{OIDCName}
+	OIDCName           string
+	OIDCIssuerURL      string
+	OIDCClientID       string
+	OIDCClientSecret   string
+	OIDCRedirectURL    string
+	OIDCAllowedDomains []string
+	OIDCAllowedEmails  []string
 }
 
 // Load reads configuration from environment variables, supporting secret-file fallbacks and sensible defaults for local development.
 // 
 // It validates and returns a Config populated from environment values (including parsing CSV lists and determining the HTTP port).
 // Load enforces APP_ENV to be either "development" or "production", requires DATABASE_URL and GOOGLE_BOOKS_API_KEY, requires Google OAuth
-// client ID and secret, and requires at least one of AUTH_GOOGLE_ALLOWED_DOMAINS or AUTH_GOOGLE_ALLOWED_EMAILS. It also sanitizes and
+// client ID and secret, and requires at least one of AUTH_GOOGLE_ALLOWED_DOMAINS or AUTH_GOOGLE_ALLOWED_EMAILS unless a generic OIDC
+// provider (AUTH_OIDC_ISSUER_URL plus its client credentials and allowlist) is configured instead. It also sanitizes and
 // deduplicates allowed origins and returns an error for invalid port values or other validation/read failures.
 func Load() (Config, error) {
 	databaseURL, err := getEnvOrFile("DATABASE_URL", "/run/secrets/anthology_database_url")
@@ -43,6 +88,21 @@ func Load() (Config, error) {
 		return Config{}, err
 	}
 
+	igdbClientID, err := getEnvOrFile("IGDB_CLIENT_ID", "/run/secrets/anthology_igdb_client_id")
+	if err != nil {
+		return Config{}, err
+	}
+
+	igdbAccessToken, err := getEnvOrFile("IGDB_ACCESS_TOKEN", "/run/secrets/anthology_igdb_access_token")
+	if err != nil {
+		return Config{}, err
+	}
+
+	tmdbAPIKey, err := getEnvOrFile("TMDB_API_KEY", "/run/secrets/anthology_tmdb_api_key")
+	if err != nil {
+		return Config{}, err
+	}
+
 	googleClientID, err := getEnvOrFile("AUTH_GOOGLE_CLIENT_ID", "/run/secrets/anthology_google_client_id")
 	if err != nil {
 		return Config{}, err
@@ -53,6 +113,16 @@ func Load() (Config, error) {
 		return Config{}, err
 	}
 
+	oidcClientID, err := getEnvOrFile("AUTH_OIDC_CLIENT_ID", "/run/secrets/anthology_oidc_client_id")
+	if err != nil {
+		return Config{}, err
+	}
+
+	oidcClientSecret, err := getEnvOrFile("AUTH_OIDC_CLIENT_SECRET", "/run/secrets/anthology_oidc_client_secret")
+	if err != nil {
+		return Config{}, err
+	}
+
 	trimmedGoogleClientID := strings.TrimSpace(googleClientID)
 	trimmedGoogleClientSecret := strings.TrimSpace(googleClientSecret)
 
@@ -71,6 +141,9 @@ func Load() (Config, error) {
 		LogLevel:          strings.ToLower(getEnv("LOG_LEVEL", "info")),
 		AllowedOrigins:    parseCSV(getEnv("ALLOWED_ORIGINS", "http://localhost:4200,http://localhost:8080")),
 		GoogleBooksAPIKey: strings.TrimSpace(googleBooksAPIKey),
+		IGDBClientID:      strings.TrimSpace(igdbClientID),
+		IGDBAccessToken:   strings.TrimSpace(igdbAccessToken),
+		TMDBAPIKey:        strings.TrimSpace(tmdbAPIKey),
 
 		// Google OAuth
 		GoogleClientID:       trimmedGoogleClientID,
@@ -79,6 +152,52 @@ func Load() (Config, error) {
 		GoogleAllowedDomains: parseCSV(getEnv("AUTH_GOOGLE_ALLOWED_DOMAINS", "")),
 		GoogleAllowedEmails:  parseCSV(getEnv("AUTH_GOOGLE_ALLOWED_EMAILS", "")),
 		FrontendURL:          getEnv("FRONTEND_URL", "http://localhost:4200"),
+
+		// Generic OpenID Connect
+		OIDCName:           strings.ToLower(strings.TrimSpace(getEnv("AUTH_OIDC_NAME", "oidc"))),
+		OIDCIssuerURL:      strings.TrimSpace(getEnv("AUTH_OIDC_ISSUER_URL", "")),
+		OIDCClientID:       strings.TrimSpace(oidcClientID),
+		OIDCClientSecret:   strings.TrimSpace(oidcClientSecret),
+		OIDCAllowedDomains: parseCSV(getEnv("AUTH_OIDC_ALLOWED_DOMAINS", "")),
+		OIDCAllowedEmails:  parseCSV(getEnv("AUTH_OIDC_ALLOWED_EMAILS", "")),
+
+		AdminEmails: parseCSV(getEnv("AUTH_ADMIN_EMAILS", "")),
+	}
+
+	cfg.CatalogCacheTTL, err = parseDuration("CATALOG_CACHE_TTL", "720h")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.CatalogNegativeCacheTTL, err = parseDuration("CATALOG_CACHE_NEGATIVE_TTL", "1h")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.SessionIdleTimeout, err = parseDuration("SESSION_IDLE_TIMEOUT", "12h")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.SessionMaxLifetime, err = parseDuration("SESSION_MAX_LIFETIME", "720h")
+	if err != nil {
+		return Config{}, err
+	}
+	if cfg.SessionMaxLifetime < cfg.SessionIdleTimeout {
+		return Config{}, fmt.Errorf("SESSION_MAX_LIFETIME must be at least SESSION_IDLE_TIMEOUT")
+	}
+	cfg.SessionCleanupInterval, err = parseDuration("MAINTENANCE_SESSION_CLEANUP_INTERVAL", "1h")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.CachePurgeInterval, err = parseDuration("MAINTENANCE_CACHE_PURGE_INTERVAL", "6h")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.PlacementCleanupInterval, err = parseDuration("MAINTENANCE_PLACEMENT_CLEANUP_INTERVAL", "24h")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.CatalogRateLimits, err = parseRateLimits(getEnv("CATALOG_RATE_LIMITS", ""))
+	if err != nil {
+		return Config{}, err
 	}
 
 	portValue := getEnv("PORT", getEnv("HTTP_PORT", "8080"))
@@ -96,15 +215,33 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("GOOGLE_BOOKS_API_KEY is required")
 	}
 
-	// Google OAuth is required in all environments
-	if cfg.GoogleClientID == "" {
-		return Config{}, fmt.Errorf("AUTH_GOOGLE_CLIENT_ID is required")
-	}
-	if cfg.GoogleClientSecret == "" {
-		return Config{}, fmt.Errorf("AUTH_GOOGLE_CLIENT_SECRET is required")
+	// Google OAuth is required in all environments unless a generic OIDC provider is configured
+	if !cfg.OIDCEnabled() || cfg.GoogleEnabled() || cfg.GoogleClientSecret != "" {
+		if cfg.GoogleClientID == "" {
+			return Config{}, fmt.Errorf("AUTH_GOOGLE_CLIENT_ID is required")
+		}
+		if cfg.GoogleClientSecret == "" {
+			return Config{}, fmt.Errorf("AUTH_GOOGLE_CLIENT_SECRET is required")
+		}
+		if len(cfg.GoogleAllowedDomains) == 0 && len(cfg.GoogleAllowedEmails) == 0 {
+			return Config{}, fmt.Errorf("AUTH_GOOGLE_ALLOWED_DOMAINS or AUTH_GOOGLE_ALLOWED_EMAILS is required")
+		}
 	}
-	if len(cfg.GoogleAllowedDomains) == 0 && len(cfg.GoogleAllowedEmails) == 0 {
-		return Config{}, fmt.Errorf("AUTH_GOOGLE_ALLOWED_DOMAINS or AUTH_GOOGLE_ALLOWED_EMAILS is required")
+
+	if cfg.OIDCEnabled() {
+		if !isValidProviderName(cfg.OIDCName) || cfg.OIDCName == "google" {
+			return Config{}, fmt.Errorf("AUTH_OIDC_NAME must use lowercase letters, digits, and hyphens and must not be \"google\"")
+		}
+		if cfg.OIDCClientID == "" {
+			return Config{}, fmt.Errorf("AUTH_OIDC_CLIENT_ID is required when AUTH_OIDC_ISSUER_URL is set")
+		}
+		if cfg.OIDCClientSecret == "" {
+			return Config{}, fmt.Errorf("AUTH_OIDC_CLIENT_SECRET is required when AUTH_OIDC_ISSUER_URL is set")
+		}
+		if len(cfg.OIDCAllowedDomains) == 0 && len(cfg.OIDCAllowedEmails) == 0 {
+			return Config{}, fmt.Errorf("AUTH_OIDC_ALLOWED_DOMAINS or AUTH_OIDC_ALLOWED_EMAILS is required")
+		}
+		cfg.OIDCRedirectURL = getEnv("AUTH_OIDC_REDIRECT_URL", "http://localhost:8080/api/auth/"+cfg.OIDCName+"/callback")
 	}
 
 	allowedOrigins, err := sanitizeAllowedOrigins(cfg.AllowedOrigins, cfg.Environment)
@@ -116,6 +253,16 @@ func Load() (Config, error) {
 	return cfg, nil
 }
 
+// GoogleEnabled reports whether Google sign-in is configured.
+func (c Config) GoogleEnabled() bool {
+	return c.GoogleClientID != ""
+}
+
+// OIDCEnabled reports whether a generic OpenID Connect provider is configured.
+func (c Config) OIDCEnabled() bool {
+	return c.OIDCIssuerURL != ""
+}
+
 // HTTPAddress returns the address the HTTP server should bind to.
 func (c Config) HTTPAddress() string {
 	return fmt.Sprintf(":%d", c.HTTPPort)
@@ -128,6 +275,59 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func parseDuration(key, fallback string) (time.Duration, error) {
+	value := getEnv(key, fallback)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s must be positive", key)
+	}
+	return duration, nil
+}
+
+// parseRateLimits parses "provider=rps:burst:retries" entries, e.g.
+// "google_books=2:5:4,musicbrainz=1:1:2". Burst and retries are optional and
+// keep the provider's defaults when left out.
+func parseRateLimits(value string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for _, entry := range parseCSV(value) {
+		provider, spec, ok := strings.Cut(entry, "=")
+		provider = strings.TrimSpace(provider)
+		if !ok || provider == "" {
+			return nil, fmt.Errorf("invalid CATALOG_RATE_LIMITS entry %q", entry)
+		}
+
+		var limit RateLimit
+		parts := strings.Split(spec, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid CATALOG_RATE_LIMITS entry %q", entry)
+		}
+		rps, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil || rps < 0 {
+			return nil, fmt.Errorf("invalid CATALOG_RATE_LIMITS rate for %s: %q", provider, parts[0])
+		}
+		limit.RequestsPerSecond = rps
+		if len(parts) > 1 {
+			burst, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil || burst < 1 {
+				return nil, fmt.Errorf("invalid CATALOG_RATE_LIMITS burst for %s: %q", provider, parts[1])
+			}
+			limit.Burst = &burst
+		}
+		if len(parts) > 2 {
+			retries, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+			if err != nil || retries < 0 {
+				return nil, fmt.Errorf("invalid CATALOG_RATE_LIMITS retries for %s: %q", provider, parts[2])
+			}
+			limit.MaxRetries = &retries
+		}
+		limits[provider] = limit
+	}
+	return limits, nil
+}
+
 func parseCSV(value string) []string {
 	parts := strings.Split(value, ",")
 	out := make([]string, 0, len(parts))
@@ -151,6 +351,19 @@ func isValidEnvironment(value string) bool {
 	}
 }
 
+// isValidProviderName reports whether name is usable as an identity provider path segment.
+func isValidProviderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
 func sanitizeAllowedOrigins(origins []string, env string) ([]string, error) {
 	cleaned := make([]string, 0, len(origins))
 	seen := make(map[string]struct{}, len(origins))
@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadRequiresDatabaseURL(t *testing.T) {
@@ -99,6 +100,96 @@ func TestLoadAcceptsValidConfig(t *testing.T) {
 	}
 }
 
+func TestLoadReadsOptionalIGDBCredentials(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "client-id")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "client-secret")
+	t.Setenv("AUTH_GOOGLE_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("IGDB_CLIENT_ID", " igdb-client ")
+	t.Setenv("IGDB_ACCESS_TOKEN", "igdb-token")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.IGDBClientID != "igdb-client" || cfg.IGDBAccessToken != "igdb-token" {
+		t.Fatalf("expected IGDB credentials to be loaded, got %q, %q", cfg.IGDBClientID, cfg.IGDBAccessToken)
+	}
+}
+
+func TestLoadReadsCatalogCacheAndAdminSettings(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "client-id")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "client-secret")
+	t.Setenv("AUTH_GOOGLE_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("CATALOG_CACHE_TTL", "48h")
+	t.Setenv("AUTH_ADMIN_EMAILS", "admin@example.com, ops@example.com")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.CatalogCacheTTL != 48*time.Hour {
+		t.Fatalf("expected cache TTL 48h, got %s", cfg.CatalogCacheTTL)
+	}
+	if cfg.CatalogNegativeCacheTTL != time.Hour {
+		t.Fatalf("expected default negative cache TTL 1h, got %s", cfg.CatalogNegativeCacheTTL)
+	}
+	if len(cfg.AdminEmails) != 2 || cfg.AdminEmails[1] != "ops@example.com" {
+		t.Fatalf("expected admin emails to be parsed, got %v", cfg.AdminEmails)
+	}
+}
+
+func TestLoadRejectsInvalidCatalogCacheTTL(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "client-id")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "client-secret")
+	t.Setenv("AUTH_GOOGLE_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("CATALOG_CACHE_NEGATIVE_TTL", "soon")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for invalid negative cache TTL")
+	}
+}
+
+func TestLoadParsesCatalogRateLimits(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "client-id")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "client-secret")
+	t.Setenv("AUTH_GOOGLE_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("CATALOG_RATE_LIMITS", "google_books=2.5:5:4, musicbrainz=1")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	google := cfg.CatalogRateLimits["google_books"]
+	if google.RequestsPerSecond != 2.5 || google.Burst == nil || *google.Burst != 5 || google.MaxRetries == nil || *google.MaxRetries != 4 {
+		t.Fatalf("unexpected google_books limit %+v", google)
+	}
+	musicBrainz := cfg.CatalogRateLimits["musicbrainz"]
+	if musicBrainz.RequestsPerSecond != 1 || musicBrainz.Burst != nil || musicBrainz.MaxRetries != nil {
+		t.Fatalf("expected omitted fields to be left unset, got %+v", musicBrainz)
+	}
+
+	t.Setenv("CATALOG_RATE_LIMITS", "google_books=fast")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for invalid rate")
+	}
+}
+
 func TestLoadRejectsWildcardOriginsOutsideDevelopment(t *testing.T) {
 	t.Setenv("APP_ENV", "production")
 	t.Setenv("PORT", "8080")
@@ -155,3 +246,87 @@ func TestLoadDefaultsToProduction(t *testing.T) {
 		t.Fatalf("expected APP_ENV to default to production, got %q", cfg.Environment)
 	}
 }
+
+func TestLoadAcceptsOIDCProviderWithoutGoogle(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("AUTH_OIDC_NAME", "Corp")
+	t.Setenv("AUTH_OIDC_ISSUER_URL", "https://sso.example.com/realms/main")
+	t.Setenv("AUTH_OIDC_CLIENT_ID", "anthology")
+	t.Setenv("AUTH_OIDC_CLIENT_SECRET", "oidc-secret")
+	t.Setenv("AUTH_OIDC_ALLOWED_DOMAINS", "example.com")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.GoogleEnabled() || !cfg.OIDCEnabled() {
+		t.Fatalf("expected only the OIDC provider to be enabled, got %+v", cfg)
+	}
+	if cfg.OIDCName != "corp" || cfg.OIDCRedirectURL != "http://localhost:8080/api/auth/corp/callback" {
+		t.Fatalf("unexpected OIDC settings name=%q redirect=%q", cfg.OIDCName, cfg.OIDCRedirectURL)
+	}
+
+	t.Setenv("AUTH_OIDC_ALLOWED_DOMAINS", "")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "AUTH_OIDC_ALLOWED_DOMAINS or AUTH_OIDC_ALLOWED_EMAILS is required") {
+		t.Fatalf("expected OIDC allowlist error, got %v", err)
+	}
+
+	t.Setenv("AUTH_OIDC_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("AUTH_OIDC_NAME", "google")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "AUTH_OIDC_NAME") {
+		t.Fatalf("expected provider name error, got %v", err)
+	}
+}
+
+func TestLoadReadsSessionLifetimes(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "client-id")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "client-secret")
+	t.Setenv("AUTH_GOOGLE_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.SessionIdleTimeout != 12*time.Hour || cfg.SessionMaxLifetime != 720*time.Hour {
+		t.Fatalf("unexpected session defaults idle=%s max=%s", cfg.SessionIdleTimeout, cfg.SessionMaxLifetime)
+	}
+
+	t.Setenv("SESSION_IDLE_TIMEOUT", "48h")
+	t.Setenv("SESSION_MAX_LIFETIME", "24h")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "SESSION_MAX_LIFETIME") {
+		t.Fatalf("expected a lifetime shorter than the idle timeout to be rejected, got %v", err)
+	}
+}
+
+func TestLoadReadsMaintenanceIntervals(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PORT", "8080")
+	t.Setenv("GOOGLE_BOOKS_API_KEY", "test-key")
+	t.Setenv("AUTH_GOOGLE_CLIENT_ID", "client-id")
+	t.Setenv("AUTH_GOOGLE_CLIENT_SECRET", "client-secret")
+	t.Setenv("AUTH_GOOGLE_ALLOWED_DOMAINS", "example.com")
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("MAINTENANCE_CACHE_PURGE_INTERVAL", "30m")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.SessionCleanupInterval != time.Hour || cfg.CachePurgeInterval != 30*time.Minute || cfg.PlacementCleanupInterval != 24*time.Hour {
+		t.Fatalf("unexpected maintenance intervals sessions=%s cache=%s placements=%s", cfg.SessionCleanupInterval, cfg.CachePurgeInterval, cfg.PlacementCleanupInterval)
+	}
+
+	t.Setenv("MAINTENANCE_SESSION_CLEANUP_INTERVAL", "0s")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "MAINTENANCE_SESSION_CLEANUP_INTERVAL") {
+		t.Fatalf("expected a zero interval to be rejected, got %v", err)
+	}
+}
@@ -2,17 +2,22 @@ package exporter
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"anthology/internal/items"
+	"anthology/internal/reviews"
 )
 
 // SchemaVersion identifies the CSV export format version.
 // This version should be incremented when adding new columns or changing the format.
-const SchemaVersion = "1"
+const SchemaVersion = "3"
 
 // csvColumns defines the column order for export. These columns are a superset
 // of the import format to ensure round-trip compatibility.
@@ -41,8 +46,21 @@ var csvColumns = []string{
 	"readingStatus",
 	"readAt",
 	"notes",
+	"tags",
 	"createdAt",
 	"updatedAt",
+	"review",
+}
+
+// csvReview is the portable form of a review stored in the review column.
+// Each cell holds a JSON array of these, newest first.
+type csvReview struct {
+	Title     string            `json:"title,omitempty"`
+	Body      string            `json:"body,omitempty"`
+	Sections  []reviews.Section `json:"sections,omitempty"`
+	ReadOn    string            `json:"readOn,omitempty"`
+	CreatedAt string            `json:"createdAt,omitempty"`
+	UpdatedAt string            `json:"updatedAt,omitempty"`
 }
 
 // CSVExporter exports items to CSV format.
@@ -53,9 +71,10 @@ func NewCSVExporter() *CSVExporter {
 	return &CSVExporter{}
 }
 
-// Export writes items to the given writer in CSV format.
+// Export writes items to the given writer in CSV format, with each item's
+// reviews from itemReviews (which may be nil) in the review column.
 // The export format is designed to be compatible with the CSV import feature.
-func (e *CSVExporter) Export(w io.Writer, itemList []items.Item) error {
+func (e *CSVExporter) Export(w io.Writer, itemList []items.Item, itemReviews map[uuid.UUID][]reviews.Review) error {
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
@@ -66,7 +85,10 @@ func (e *CSVExporter) Export(w io.Writer, itemList []items.Item) error {
 
 	// Write item rows
 	for _, item := range itemList {
-		row := e.itemToRow(item)
+		row, err := e.itemToRow(item, itemReviews[item.ID])
+		if err != nil {
+			return err
+		}
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -76,7 +98,7 @@ func (e *CSVExporter) Export(w io.Writer, itemList []items.Item) error {
 }
 
 // itemToRow converts an item to a CSV row following the column order.
-func (e *CSVExporter) itemToRow(item items.Item) []string {
+func (e *CSVExporter) itemToRow(item items.Item, itemReviews []reviews.Review) ([]string, error) {
 	row := make([]string, len(csvColumns))
 
 	row[0] = SchemaVersion
@@ -101,14 +123,46 @@ func (e *CSVExporter) itemToRow(item items.Item) []string {
 	row[19] = string(item.ReadingStatus)
 	row[20] = formatOptionalTime(item.ReadAt)
 	row[21] = item.Notes
-	row[22] = formatTime(item.CreatedAt)
-	row[23] = formatTime(item.UpdatedAt)
+	row[22] = strings.Join(item.Tags, ", ")
+	row[23] = formatTime(item.CreatedAt)
+	row[24] = formatTime(item.UpdatedAt)
+	review, err := formatReviews(itemReviews)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reviews for %q: %w", item.Title, err)
+	}
+	row[25] = review
 
 	for i := range row {
 		row[i] = sanitizeCSVCell(row[i])
 	}
 
-	return row
+	return row, nil
+}
+
+// formatReviews encodes reviews as a JSON array, or an empty cell when there are none.
+func formatReviews(itemReviews []reviews.Review) (string, error) {
+	if len(itemReviews) == 0 {
+		return "", nil
+	}
+	encoded := make([]csvReview, 0, len(itemReviews))
+	for _, review := range itemReviews {
+		entry := csvReview{
+			Title:     review.Title,
+			Body:      review.Body,
+			Sections:  review.Sections,
+			CreatedAt: formatTime(review.CreatedAt),
+			UpdatedAt: formatTime(review.UpdatedAt),
+		}
+		if review.ReadOn != nil {
+			entry.ReadOn = review.ReadOn.Format(time.DateOnly)
+		}
+		encoded = append(encoded, entry)
+	}
+	raw, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
 }
 
 func sanitizeCSVCell(value string) string {
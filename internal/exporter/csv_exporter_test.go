@@ -10,13 +10,14 @@ import (
 	"github.com/google/uuid"
 
 	"anthology/internal/items"
+	"anthology/internal/reviews"
 )
 
 func TestCSVExporter_ExportEmpty(t *testing.T) {
 	exporter := NewCSVExporter()
 	var buf bytes.Buffer
 
-	err := exporter.Export(&buf, []items.Item{})
+	err := exporter.Export(&buf, []items.Item{}, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -75,7 +76,7 @@ func TestCSVExporter_ExportBook(t *testing.T) {
 		},
 	}
 
-	err := exporter.Export(&buf, testItems)
+	err := exporter.Export(&buf, testItems, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -135,7 +136,7 @@ func TestCSVExporter_ExportGame(t *testing.T) {
 		},
 	}
 
-	err := exporter.Export(&buf, testItems)
+	err := exporter.Export(&buf, testItems, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -173,7 +174,7 @@ func TestCSVExporter_ExportMultipleItems(t *testing.T) {
 		{ID: uuid.New(), Title: "Music 1", Creator: "Artist 1", ItemType: items.ItemTypeMusic, CreatedAt: createdAt, UpdatedAt: updatedAt},
 	}
 
-	err := exporter.Export(&buf, testItems)
+	err := exporter.Export(&buf, testItems, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -193,7 +194,7 @@ func TestCSVExporter_HeaderMatchesColumnOrder(t *testing.T) {
 	exporter := NewCSVExporter()
 	var buf bytes.Buffer
 
-	err := exporter.Export(&buf, []items.Item{})
+	err := exporter.Export(&buf, []items.Item{}, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -234,7 +235,7 @@ func TestCSVExporter_ZeroValuesExportAsEmpty(t *testing.T) {
 		},
 	}
 
-	err := exporter.Export(&buf, testItems)
+	err := exporter.Export(&buf, testItems, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -276,7 +277,7 @@ func TestCSVExporter_SpecialCharactersInFields(t *testing.T) {
 		},
 	}
 
-	err := exporter.Export(&buf, testItems)
+	err := exporter.Export(&buf, testItems, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -319,7 +320,7 @@ func TestCSVExporter_EscapesFormulaCells(t *testing.T) {
 		},
 	}
 
-	err := exporter.Export(&buf, testItems)
+	err := exporter.Export(&buf, testItems, nil)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -344,3 +345,66 @@ func TestCSVExporter_EscapesFormulaCells(t *testing.T) {
 		t.Errorf("expected formula-escaped notes, got %q", row[21])
 	}
 }
+
+func TestCSVExporter_ExportsTags(t *testing.T) {
+	exporter := NewCSVExporter()
+	var buf bytes.Buffer
+
+	testItems := []items.Item{
+		{ID: uuid.New(), Title: "Signed Copy", ItemType: items.ItemTypeBook, Tags: items.TagList{"first edition", "signed"}},
+	}
+
+	if err := exporter.Export(&buf, testItems, nil); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	if records[0][22] != "tags" {
+		t.Fatalf("expected tags column, got %q", records[0][22])
+	}
+	if records[1][22] != "first edition, signed" {
+		t.Errorf("expected comma-separated tags, got %q", records[1][22])
+	}
+}
+
+func TestCSVExporter_ExportsReviews(t *testing.T) {
+	exporter := NewCSVExporter()
+	var buf bytes.Buffer
+
+	withReview := items.Item{ID: uuid.New(), Title: "Dune", ItemType: items.ItemTypeBook}
+	without := items.Item{ID: uuid.New(), Title: "Emma", ItemType: items.ItemTypeBook}
+	readOn := time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC)
+	itemReviews := map[uuid.UUID][]reviews.Review{
+		withReview.ID: {{
+			Title:     "Spice",
+			Body:      "Slow start, great finish.",
+			Sections:  reviews.SectionList{{Heading: "Ending", Body: "He drinks the Water of Life.", Spoiler: true}},
+			ReadOn:    &readOn,
+			CreatedAt: time.Date(2026, time.April, 21, 9, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	if err := exporter.Export(&buf, []items.Item{withReview, without}, itemReviews); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	if records[0][25] != "review" {
+		t.Fatalf("expected review column, got %q", records[0][25])
+	}
+	want := `[{"title":"Spice","body":"Slow start, great finish.","sections":[{"heading":"Ending","body":"He drinks the Water of Life.","spoiler":true}],"readOn":"2026-04-20","createdAt":"2026-04-21T09:00:00Z"}]`
+	if records[1][25] != want {
+		t.Errorf("unexpected review cell %q", records[1][25])
+	}
+	if records[2][25] != "" {
+		t.Errorf("expected empty review cell, got %q", records[2][25])
+	}
+}
@@ -0,0 +1,84 @@
+package goals
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu    sync.RWMutex
+	goals map[uuid.UUID]Goal
+}
+
+// NewInMemoryRepository seeds an empty goal repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{goals: make(map[uuid.UUID]Goal)}
+}
+
+func (m *inMemoryRepository) List(_ context.Context, ownerID uuid.UUID, year *int) ([]Goal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	goals := []Goal{}
+	for _, goal := range m.goals {
+		if goal.OwnerID != ownerID || (year != nil && goal.Year != *year) {
+			continue
+		}
+		goals = append(goals, goal)
+	}
+	slices.SortFunc(goals, func(a, b Goal) int {
+		if c := cmp.Compare(b.Year, a.Year); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.Metric), string(b.Metric))
+	})
+	return goals, nil
+}
+
+func (m *inMemoryRepository) Get(_ context.Context, id uuid.UUID, ownerID uuid.UUID) (Goal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	goal, ok := m.goals[id]
+	if !ok || goal.OwnerID != ownerID {
+		return Goal{}, ErrNotFound
+	}
+	return goal, nil
+}
+
+func (m *inMemoryRepository) Create(_ context.Context, goal Goal) (Goal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.goals[goal.ID] = goal
+	return goal, nil
+}
+
+func (m *inMemoryRepository) Update(_ context.Context, goal Goal) (Goal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.goals[goal.ID]
+	if !ok || existing.OwnerID != goal.OwnerID {
+		return Goal{}, ErrNotFound
+	}
+	m.goals[goal.ID] = goal
+	return goal, nil
+}
+
+func (m *inMemoryRepository) Delete(_ context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	goal, ok := m.goals[id]
+	if !ok || goal.OwnerID != ownerID {
+		return ErrNotFound
+	}
+	delete(m.goals, id)
+	return nil
+}
@@ -0,0 +1,80 @@
+package goals
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when a goal cannot be found.
+var ErrNotFound = errors.New("goal not found")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// Metric selects what a goal counts.
+type Metric string
+
+const (
+	// MetricBooks counts books marked read.
+	MetricBooks Metric = "books"
+	// MetricPages sums the page counts of books marked read.
+	MetricPages Metric = "pages"
+)
+
+// Goal is a yearly reading target such as "24 books in 2026".
+type Goal struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	OwnerID   uuid.UUID `db:"owner_id" json:"-"`
+	Year      int       `db:"year" json:"year"`
+	Metric    Metric    `db:"metric" json:"metric"`
+	Target    int       `db:"target" json:"target"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// GoalInput captures the editable fields of a goal.
+type GoalInput struct {
+	Year   int    `json:"year"`
+	Metric Metric `json:"metric"`
+	Target int    `json:"target"`
+}
+
+// Progress reports how far along a goal is, counted from books with
+// ReadingStatus read whose ReadAt falls in the goal's year.
+//
+// ExpectedByNow is the share of the target due by today at an even pace.
+// ProjectedTotal and ProjectedCompletion extrapolate the pace so far; the
+// completion date is omitted once the goal is met or while nothing has been read.
+type Progress struct {
+	Goal                Goal       `json:"goal"`
+	Current             int        `json:"current"`
+	Remaining           int        `json:"remaining"`
+	Percent             float64    `json:"percent"`
+	ExpectedByNow       int        `json:"expectedByNow"`
+	OnTrack             bool       `json:"onTrack"`
+	ProjectedTotal      int        `json:"projectedTotal"`
+	ProjectedCompletion *time.Time `json:"projectedCompletion,omitempty"`
+	CompletedAt         *time.Time `json:"completedAt,omitempty"`
+}
+
+// Streaks reports consecutive days with reading activity, counted in UTC.
+// A day is active when a reading session was logged or a book was finished.
+// The current streak stays alive until a full day passes without activity.
+type Streaks struct {
+	Current    int        `json:"current"`
+	Longest    int        `json:"longest"`
+	ActiveDays int        `json:"activeDays"`
+	LastActive *time.Time `json:"lastActive,omitempty"`
+}
+
+// Repository defines persistence for goals.
+type Repository interface {
+	List(ctx context.Context, ownerID uuid.UUID, year *int) ([]Goal, error)
+	Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Goal, error)
+	Create(ctx context.Context, goal Goal) (Goal, error)
+	Update(ctx context.Context, goal Goal) (Goal, error)
+	Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+}
@@ -0,0 +1,91 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a goal repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const goalColumns = `id, owner_id, year, metric, target, created_at, updated_at`
+
+func (r *postgresRepository) List(ctx context.Context, ownerID uuid.UUID, year *int) ([]Goal, error) {
+	query := `SELECT ` + goalColumns + ` FROM reading_goals WHERE owner_id = $1`
+	args := []any{ownerID}
+	if year != nil {
+		query += ` AND year = $2`
+		args = append(args, *year)
+	}
+	query += ` ORDER BY year DESC, metric`
+
+	goals := []Goal{}
+	if err := r.db.SelectContext(ctx, &goals, query, args...); err != nil {
+		return nil, fmt.Errorf("list goals: %w", err)
+	}
+	return goals, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Goal, error) {
+	var goal Goal
+	query := `SELECT ` + goalColumns + ` FROM reading_goals WHERE id = $1 AND owner_id = $2`
+	if err := r.db.GetContext(ctx, &goal, query, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Goal{}, ErrNotFound
+		}
+		return Goal{}, fmt.Errorf("get goal: %w", err)
+	}
+	return goal, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, goal Goal) (Goal, error) {
+	query := `INSERT INTO reading_goals (` + goalColumns + `)
+VALUES (:id, :owner_id, :year, :metric, :target, :created_at, :updated_at)`
+	if _, err := r.db.NamedExecContext(ctx, query, goal); err != nil {
+		return Goal{}, fmt.Errorf("insert goal: %w", err)
+	}
+	return goal, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, goal Goal) (Goal, error) {
+	query := `UPDATE reading_goals
+SET year = :year,
+    metric = :metric,
+    target = :target,
+    updated_at = :updated_at
+WHERE id = :id AND owner_id = :owner_id`
+	res, err := r.db.NamedExecContext(ctx, query, goal)
+	if err != nil {
+		return Goal{}, fmt.Errorf("update goal: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return Goal{}, ErrNotFound
+	}
+	return r.Get(ctx, goal.ID, goal.OwnerID)
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM reading_goals WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("delete goal: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete goal rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
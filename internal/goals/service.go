@@ -0,0 +1,278 @@
+package goals
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/reading"
+)
+
+const (
+	minGoalYear = 1900
+	maxGoalYear = 2200
+	maxTarget   = 1_000_000
+)
+
+const day = 24 * time.Hour
+
+// Service manages reading goals and computes progress and streaks.
+type Service struct {
+	repo        Repository
+	itemsRepo   items.Repository
+	readingRepo reading.Repository
+	now         func() time.Time
+}
+
+// NewService wires a goal service. Progress is read from the items repository
+// and streaks additionally use reading sessions.
+func NewService(repo Repository, itemsRepo items.Repository, readingRepo reading.Repository) *Service {
+	return &Service{
+		repo:        repo,
+		itemsRepo:   itemsRepo,
+		readingRepo: readingRepo,
+		now:         func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// List returns the owner's goals with progress, newest year first.
+func (s *Service) List(ctx context.Context, ownerID uuid.UUID, year *int) ([]Progress, error) {
+	goals, err := s.repo.List(ctx, ownerID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := s.readBooks(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]Progress, 0, len(goals))
+	for _, goal := range goals {
+		progress = append(progress, s.progress(goal, read))
+	}
+	return progress, nil
+}
+
+// Get returns a single goal with progress.
+func (s *Service) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Progress, error) {
+	goal, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return Progress{}, err
+	}
+	return s.withProgress(ctx, goal)
+}
+
+// Create validates and stores a goal. Each owner may have one goal per metric and year.
+func (s *Service) Create(ctx context.Context, input GoalInput, ownerID uuid.UUID) (Progress, error) {
+	if err := validateInput(input); err != nil {
+		return Progress{}, err
+	}
+	if err := s.ensureUnique(ctx, input, uuid.Nil, ownerID); err != nil {
+		return Progress{}, err
+	}
+
+	now := s.now()
+	goal, err := s.repo.Create(ctx, Goal{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		Year:      input.Year,
+		Metric:    input.Metric,
+		Target:    input.Target,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return Progress{}, err
+	}
+	return s.withProgress(ctx, goal)
+}
+
+// Update replaces a goal's year, metric, and target.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input GoalInput, ownerID uuid.UUID) (Progress, error) {
+	existing, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return Progress{}, err
+	}
+	if err := validateInput(input); err != nil {
+		return Progress{}, err
+	}
+	if err := s.ensureUnique(ctx, input, id, ownerID); err != nil {
+		return Progress{}, err
+	}
+
+	existing.Year = input.Year
+	existing.Metric = input.Metric
+	existing.Target = input.Target
+	existing.UpdatedAt = s.now()
+	goal, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		return Progress{}, err
+	}
+	return s.withProgress(ctx, goal)
+}
+
+// Delete removes a goal.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// Streaks computes current and longest runs of consecutive reading days.
+func (s *Service) Streaks(ctx context.Context, ownerID uuid.UUID) (Streaks, error) {
+	sessionDates, err := s.readingRepo.ListSessionDates(ctx, ownerID)
+	if err != nil {
+		return Streaks{}, err
+	}
+	read, err := s.readBooks(ctx, ownerID)
+	if err != nil {
+		return Streaks{}, err
+	}
+
+	activity := make(map[time.Time]struct{}, len(sessionDates)+len(read))
+	for _, date := range sessionDates {
+		activity[truncateDay(date)] = struct{}{}
+	}
+	for _, item := range read {
+		activity[truncateDay(*item.ReadAt)] = struct{}{}
+	}
+
+	days := make([]time.Time, 0, len(activity))
+	for date := range activity {
+		days = append(days, date)
+	}
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+
+	streaks := Streaks{ActiveDays: len(days)}
+	if len(days) == 0 {
+		return streaks, nil
+	}
+
+	run := 0
+	for i, date := range days {
+		if i > 0 && date.Sub(days[i-1]) == day {
+			run++
+		} else {
+			run = 1
+		}
+		streaks.Longest = max(streaks.Longest, run)
+	}
+
+	last := days[len(days)-1]
+	streaks.LastActive = &last
+	// run now holds the length of the streak ending on the last active day; it is
+	// only current if that day is today or yesterday.
+	if truncateDay(s.now()).Sub(last) <= day {
+		streaks.Current = run
+	}
+	return streaks, nil
+}
+
+func (s *Service) withProgress(ctx context.Context, goal Goal) (Progress, error) {
+	read, err := s.readBooks(ctx, goal.OwnerID)
+	if err != nil {
+		return Progress{}, err
+	}
+	return s.progress(goal, read), nil
+}
+
+// readBooks returns the owner's finished books with a ReadAt, oldest first.
+func (s *Service) readBooks(ctx context.Context, ownerID uuid.UUID) ([]items.Item, error) {
+	bookType := items.ItemTypeBook
+	readStatus := items.BookStatusRead
+	all, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID, ItemType: &bookType, ReadingStatus: &readStatus})
+	if err != nil {
+		return nil, err
+	}
+
+	read := make([]items.Item, 0, len(all))
+	for _, item := range all {
+		if item.ReadAt != nil {
+			read = append(read, item)
+		}
+	}
+	slices.SortFunc(read, func(a, b items.Item) int { return a.ReadAt.Compare(*b.ReadAt) })
+	return read, nil
+}
+
+func (s *Service) progress(goal Goal, read []items.Item) Progress {
+	progress := Progress{Goal: goal}
+	for _, item := range read {
+		if item.ReadAt.UTC().Year() != goal.Year {
+			continue
+		}
+		switch goal.Metric {
+		case MetricPages:
+			if item.PageCount != nil {
+				progress.Current += *item.PageCount
+			}
+		default:
+			progress.Current++
+		}
+		if progress.CompletedAt == nil && progress.Current >= goal.Target {
+			completedAt := item.ReadAt.UTC()
+			progress.CompletedAt = &completedAt
+		}
+	}
+
+	progress.Remaining = max(goal.Target-progress.Current, 0)
+	progress.Percent = math.Round(float64(progress.Current)/float64(goal.Target)*1000) / 10
+
+	yearStart := time.Date(goal.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	yearLength := yearEnd.Sub(yearStart)
+	elapsed := min(max(s.now().Sub(yearStart), 0), yearLength)
+	share := float64(elapsed) / float64(yearLength)
+
+	progress.ExpectedByNow = int(math.Round(float64(goal.Target) * share))
+	progress.OnTrack = progress.Current >= progress.ExpectedByNow
+
+	progress.ProjectedTotal = progress.Current
+	if elapsed > 0 {
+		progress.ProjectedTotal = int(math.Round(float64(progress.Current) / share))
+	}
+
+	if progress.CompletedAt == nil && progress.Current > 0 && elapsed > 0 && elapsed < yearLength {
+		untilTarget := time.Duration(float64(elapsed) * float64(goal.Target) / float64(progress.Current))
+		projected := truncateDay(yearStart.Add(untilTarget))
+		progress.ProjectedCompletion = &projected
+	}
+	return progress
+}
+
+func (s *Service) ensureUnique(ctx context.Context, input GoalInput, selfID uuid.UUID, ownerID uuid.UUID) error {
+	existing, err := s.repo.List(ctx, ownerID, &input.Year)
+	if err != nil {
+		return err
+	}
+	for _, goal := range existing {
+		if goal.ID != selfID && goal.Metric == input.Metric {
+			return fmt.Errorf("%w: a %s goal for %d already exists", ErrValidation, input.Metric, input.Year)
+		}
+	}
+	return nil
+}
+
+func validateInput(input GoalInput) error {
+	switch input.Metric {
+	case MetricBooks, MetricPages:
+	default:
+		return fmt.Errorf("%w: metric must be %q or %q", ErrValidation, MetricBooks, MetricPages)
+	}
+	if input.Year < minGoalYear || input.Year > maxGoalYear {
+		return fmt.Errorf("%w: year must be between %d and %d", ErrValidation, minGoalYear, maxGoalYear)
+	}
+	if input.Target <= 0 || input.Target > maxTarget {
+		return fmt.Errorf("%w: target must be between 1 and %d", ErrValidation, maxTarget)
+	}
+	return nil
+}
+
+func truncateDay(ts time.Time) time.Time {
+	ts = ts.UTC()
+	return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,149 @@
+package goals
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/reading"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func intPtr(v int) *int { return &v }
+
+func readBook(title string, pages int, readAt time.Time) items.Item {
+	return items.Item{
+		ID:            uuid.New(),
+		OwnerID:       testOwnerID,
+		Title:         title,
+		ItemType:      items.ItemTypeBook,
+		PageCount:     intPtr(pages),
+		ReadingStatus: items.BookStatusRead,
+		ReadAt:        &readAt,
+	}
+}
+
+func newTestService(t *testing.T, now time.Time, catalog []items.Item) (*Service, reading.Repository) {
+	t.Helper()
+	readingRepo := reading.NewInMemoryRepository()
+	svc := NewService(NewInMemoryRepository(), items.NewInMemoryRepository(catalog), readingRepo)
+	svc.now = func() time.Time { return now }
+	return svc, readingRepo
+}
+
+func TestProgressProjectsCompletion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2026, time.April, 2, 0, 0, 0, 0, time.UTC) // 91 days into the year
+	svc, _ := newTestService(t, now, []items.Item{
+		readBook("Dune", 600, time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)),
+		readBook("Emma", 400, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)),
+		readBook("Old", 999, time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)),
+	})
+
+	books, err := svc.Create(ctx, GoalInput{Year: 2026, Metric: MetricBooks, Target: 8}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create books goal: %v", err)
+	}
+	if books.Current != 2 || books.Remaining != 6 || books.Percent != 25 {
+		t.Fatalf("unexpected books progress %+v", books)
+	}
+	if books.ExpectedByNow != 2 || !books.OnTrack || books.ProjectedTotal != 8 {
+		t.Fatalf("unexpected pace %+v", books)
+	}
+	if books.ProjectedCompletion == nil || books.ProjectedCompletion.Month() != time.December {
+		t.Fatalf("expected completion projected for December, got %v", books.ProjectedCompletion)
+	}
+
+	pages, err := svc.Create(ctx, GoalInput{Year: 2026, Metric: MetricPages, Target: 1000}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create pages goal: %v", err)
+	}
+	if pages.Current != 1000 || pages.CompletedAt == nil || pages.CompletedAt.Month() != time.March || pages.ProjectedCompletion != nil {
+		t.Fatalf("expected pages goal completed in March, got %+v", pages)
+	}
+
+	list, err := svc.List(ctx, testOwnerID, nil)
+	if err != nil {
+		t.Fatalf("list goals: %v", err)
+	}
+	if len(list) != 2 || list[0].Goal.Metric != MetricBooks {
+		t.Fatalf("unexpected goals %+v", list)
+	}
+}
+
+func TestCreateValidatesAndRejectsDuplicates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _ := newTestService(t, time.Now().UTC(), nil)
+
+	invalid := map[string]GoalInput{
+		"unknown metric": {Year: 2026, Metric: "hours", Target: 10},
+		"zero target":    {Year: 2026, Metric: MetricBooks},
+		"bad year":       {Year: 26, Metric: MetricBooks, Target: 10},
+	}
+	for name, input := range invalid {
+		if _, err := svc.Create(ctx, input, testOwnerID); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+
+	goal, err := svc.Create(ctx, GoalInput{Year: 2026, Metric: MetricBooks, Target: 10}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create goal: %v", err)
+	}
+	if _, err := svc.Create(ctx, GoalInput{Year: 2026, Metric: MetricBooks, Target: 20}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected duplicate goal to be rejected, got %v", err)
+	}
+
+	updated, err := svc.Update(ctx, goal.Goal.ID, GoalInput{Year: 2026, Metric: MetricBooks, Target: 12}, testOwnerID)
+	if err != nil || updated.Goal.Target != 12 {
+		t.Fatalf("expected target update, got %+v, %v", updated, err)
+	}
+
+	if err := svc.Delete(ctx, goal.Goal.ID, testOwnerID); err != nil {
+		t.Fatalf("delete goal: %v", err)
+	}
+	if _, err := svc.Get(ctx, goal.Goal.ID, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestStreaksCombineSessionsAndFinishes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2026, time.May, d, 21, 30, 0, 0, time.UTC) }
+	svc, readingRepo := newTestService(t, day(10).Add(3*time.Hour), []items.Item{
+		readBook("Dune", 600, day(4)),
+	})
+
+	// Activity on May 1-4 (longest run) and May 8-10, with today being May 11.
+	for _, d := range []int{1, 2, 3, 3, 8, 9, 10} {
+		if _, err := readingRepo.CreateSession(ctx, reading.Session{ID: uuid.New(), OwnerID: testOwnerID, ItemID: uuid.New(), Date: day(d)}); err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+	}
+
+	streaks, err := svc.Streaks(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("streaks: %v", err)
+	}
+	if streaks.Current != 3 || streaks.Longest != 4 || streaks.ActiveDays != 7 {
+		t.Fatalf("unexpected streaks %+v", streaks)
+	}
+
+	svc.now = func() time.Time { return day(13) }
+	streaks, err = svc.Streaks(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("streaks: %v", err)
+	}
+	if streaks.Current != 0 || streaks.Longest != 4 {
+		t.Fatalf("expected broken streak, got %+v", streaks)
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"anthology/internal/archive"
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+// maxArchiveBodyBytes bounds restore uploads. Archives embed cover images and
+// shelf photos, so they are allowed to be much larger than other JSON bodies.
+const maxArchiveBodyBytes int64 = 256 << 20 // 256 MiB
+
+// ArchiveHandler exposes full-catalog export and restore endpoints.
+type ArchiveHandler struct {
+	svc    *archive.Service
+	logger *slog.Logger
+}
+
+// NewArchiveHandler constructs an ArchiveHandler.
+func NewArchiveHandler(svc *archive.Service, logger *slog.Logger) *ArchiveHandler {
+	return &ArchiveHandler{svc: svc, logger: logger}
+}
+
+func (h *ArchiveHandler) handleArchiveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, archive.ErrValidation),
+		errors.Is(err, items.ErrValidation),
+		errors.Is(err, shelves.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("archive operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// Export downloads the owner's whole catalog as a JSON archive.
+func (h *ArchiveHandler) Export(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	data, err := h.svc.Export(r.Context(), user.ID)
+	if err != nil {
+		h.handleArchiveError(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf("anthology-archive-%s.json", data.ExportedAt.Format(time.DateOnly))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	writeJSON(w, http.StatusOK, data)
+}
+
+// Restore loads a JSON archive. The mode query parameter selects "merge"
+// (default) or "replace".
+func (h *ArchiveHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	mode := archive.ModeMerge
+	if raw := strings.TrimSpace(r.URL.Query().Get("mode")); raw != "" {
+		mode = archive.Mode(strings.ToLower(raw))
+	}
+
+	var payload archive.Archive
+	if err := decodeArchiveBody(w, r, &payload); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	summary, err := h.svc.Restore(r.Context(), payload, mode, user.ID)
+	if err != nil {
+		h.handleArchiveError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func decodeArchiveBody(w http.ResponseWriter, r *http.Request, dst *archive.Archive) error {
+	limited := http.MaxBytesReader(w, r.Body, maxArchiveBodyBytes)
+	defer func() {
+		_ = limited.Close()
+	}()
+
+	decoder := json.NewDecoder(limited)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return fmt.Errorf("%w (max %d bytes)", errPayloadTooLarge, maxErr.Limit)
+		}
+		return err
+	}
+	return nil
+}
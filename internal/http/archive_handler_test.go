@@ -0,0 +1,85 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/archive"
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+func newArchiveTestRouter(t *testing.T) (http.Handler, *items.Service) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	itemSvc := items.NewService(itemsRepo)
+	shelfSvc := shelves.NewService(shelves.NewInMemoryRepository(), itemsRepo, nil, itemSvc)
+	if _, err := itemSvc.Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook}); err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+
+	handler := NewArchiveHandler(archive.NewService(itemSvc, itemsRepo, shelfSvc), newTestLogger())
+	r := chi.NewRouter()
+	r.Get("/archive", handler.Export)
+	r.Post("/archive/restore", handler.Restore)
+	return r, itemSvc
+}
+
+func TestArchiveHandlerExportAndRestore(t *testing.T) {
+	router, itemSvc := newArchiveTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/archive", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if disposition := rec.Header().Get("Content-Disposition"); !strings.Contains(disposition, "anthology-archive-") {
+		t.Fatalf("unexpected content disposition %q", disposition)
+	}
+	exported := rec.Body.Bytes()
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/archive/restore?mode=replace", bytes.NewReader(exported))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary archive.RestoreSummary
+	if err := json.NewDecoder(rec.Body).Decode(&summary); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if summary.Mode != archive.ModeReplace || summary.ItemsDeleted != 1 || summary.ItemsCreated != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	list, err := itemSvc.List(context.Background(), items.ListOptions{OwnerID: testOwnerID})
+	if err != nil {
+		t.Fatalf("list items: %v", err)
+	}
+	if len(list) != 1 || list[0].Title != "Dune" {
+		t.Fatalf("unexpected items after restore %+v", list)
+	}
+}
+
+func TestArchiveHandlerRestoreRejectsInvalidArchive(t *testing.T) {
+	router, _ := newArchiveTestRouter(t)
+
+	cases := map[string]string{
+		"/archive/restore":                `{"version":99,"items":[],"shelves":[]}`,
+		"/archive/restore?mode=overwrite": `{"version":1,"items":[],"shelves":[]}`,
+		"/archive/restore?mode=merge":     `{"version":1,"items":[{"id":"00000000-0000-0000-0000-0000000000aa","title":"","itemType":"book"}],"shelves":[]}`,
+	}
+	for target, body := range cases {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected status 400, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"anthology/internal/auth"
 
@@ -11,11 +12,22 @@ import (
 type authRepoStub struct {
 	findUserByOAuth       func(ctx context.Context, provider, providerID string) (*auth.User, error)
 	createUser            func(ctx context.Context, user auth.User) (auth.User, error)
+	findUserByEmail       func(ctx context.Context, email string) (*auth.User, error)
 	updateUserLogin       func(ctx context.Context, id uuid.UUID, name, avatarURL string) error
+	linkIdentity          func(ctx context.Context, userID uuid.UUID, provider, subject string) error
 	createSession         func(ctx context.Context, session auth.Session, tokenHash string) error
 	findSessionByHash     func(ctx context.Context, tokenHash string) (*auth.Session, *auth.User, error)
+	touchSession          func(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error
+	listSessions          func(ctx context.Context, userID uuid.UUID) ([]auth.Session, error)
 	deleteSession         func(ctx context.Context, id uuid.UUID) error
+	deleteUserSession     func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	deleteUserSessions    func(ctx context.Context, userID uuid.UUID) (int64, error)
 	deleteExpiredSessions func(ctx context.Context) (int64, error)
+	createAPIToken        func(ctx context.Context, token auth.APIToken, tokenHash string) error
+	listAPITokens         func(ctx context.Context, userID uuid.UUID) ([]auth.APIToken, error)
+	findAPITokenByHash    func(ctx context.Context, tokenHash string) (*auth.APIToken, *auth.User, error)
+	touchAPIToken         func(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	deleteAPIToken        func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 }
 
 func (r *authRepoStub) FindUserByOAuth(ctx context.Context, provider, providerID string) (*auth.User, error) {
@@ -26,6 +38,9 @@ func (r *authRepoStub) FindUserByOAuth(ctx context.Context, provider, providerID
 }
 
 func (r *authRepoStub) FindUserByEmail(ctx context.Context, email string) (*auth.User, error) {
+	if r.findUserByEmail != nil {
+		return r.findUserByEmail(ctx, email)
+	}
 	return nil, nil
 }
 
@@ -43,6 +58,13 @@ func (r *authRepoStub) UpdateUserLogin(ctx context.Context, id uuid.UUID, name,
 	return nil
 }
 
+func (r *authRepoStub) LinkIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+	if r.linkIdentity != nil {
+		return r.linkIdentity(ctx, userID, provider, subject)
+	}
+	return nil
+}
+
 func (r *authRepoStub) CreateSession(ctx context.Context, session auth.Session, tokenHash string) error {
 	if r.createSession != nil {
 		return r.createSession(ctx, session, tokenHash)
@@ -57,6 +79,34 @@ func (r *authRepoStub) FindSessionByTokenHash(ctx context.Context, tokenHash str
 	return nil, nil, nil
 }
 
+func (r *authRepoStub) TouchSession(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+	if r.touchSession != nil {
+		return r.touchSession(ctx, id, lastSeenAt, expiresAt)
+	}
+	return nil
+}
+
+func (r *authRepoStub) ListSessions(ctx context.Context, userID uuid.UUID) ([]auth.Session, error) {
+	if r.listSessions != nil {
+		return r.listSessions(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (r *authRepoStub) DeleteUserSession(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if r.deleteUserSession != nil {
+		return r.deleteUserSession(ctx, id, userID)
+	}
+	return nil
+}
+
+func (r *authRepoStub) DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if r.deleteUserSessions != nil {
+		return r.deleteUserSessions(ctx, userID)
+	}
+	return 0, nil
+}
+
 func (r *authRepoStub) DeleteSession(ctx context.Context, id uuid.UUID) error {
 	if r.deleteSession != nil {
 		return r.deleteSession(ctx, id)
@@ -70,3 +120,38 @@ func (r *authRepoStub) DeleteExpiredSessions(ctx context.Context) (int64, error)
 	}
 	return 0, nil
 }
+
+func (r *authRepoStub) CreateAPIToken(ctx context.Context, token auth.APIToken, tokenHash string) error {
+	if r.createAPIToken != nil {
+		return r.createAPIToken(ctx, token, tokenHash)
+	}
+	return nil
+}
+
+func (r *authRepoStub) ListAPITokens(ctx context.Context, userID uuid.UUID) ([]auth.APIToken, error) {
+	if r.listAPITokens != nil {
+		return r.listAPITokens(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (r *authRepoStub) FindAPITokenByHash(ctx context.Context, tokenHash string) (*auth.APIToken, *auth.User, error) {
+	if r.findAPITokenByHash != nil {
+		return r.findAPITokenByHash(ctx, tokenHash)
+	}
+	return nil, nil, nil
+}
+
+func (r *authRepoStub) TouchAPIToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	if r.touchAPIToken != nil {
+		return r.touchAPIToken(ctx, id, usedAt)
+	}
+	return nil
+}
+
+func (r *authRepoStub) DeleteAPIToken(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if r.deleteAPIToken != nil {
+		return r.deleteAPIToken(ctx, id, userID)
+	}
+	return nil
+}
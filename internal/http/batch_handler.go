@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/batches"
+)
+
+// BatchHandler exposes recorded import batches and their rollback.
+type BatchHandler struct {
+	svc    *batches.Service
+	logger *slog.Logger
+}
+
+// NewBatchHandler constructs a BatchHandler.
+func NewBatchHandler(svc *batches.Service, logger *slog.Logger) *BatchHandler {
+	return &BatchHandler{svc: svc, logger: logger}
+}
+
+func (h *BatchHandler) handleBatchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, batches.ErrNotFound):
+		writeError(w, http.StatusNotFound, "import batch not found")
+	default:
+		h.logger.Error("import batch operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns the owner's import batches, newest first.
+func (h *BatchHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	list, err := h.svc.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleBatchError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"batches": list})
+}
+
+// Get returns a batch with the items it created.
+func (h *BatchHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "batchId")
+	if !ok {
+		return
+	}
+
+	batch, err := h.svc.Get(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleBatchError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, batch)
+}
+
+// Rollback deletes the batch's unedited items and reports the ones it kept.
+func (h *BatchHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "batchId")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.Rollback(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleBatchError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"anthology/internal/batches"
+	"anthology/internal/importer"
+	"anthology/internal/items"
+)
+
+func newBatchTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	batchSvc := batches.NewService(batches.NewInMemoryRepository(), itemSvc)
+	itemHandler := NewItemHandler(itemSvc, nil, nil, importer.NewCSVImporter(itemSvc, nil, importer.WithBatchRecorder(batchSvc)), newTestLogger())
+	handler := NewBatchHandler(batchSvc, newTestLogger())
+
+	r := chi.NewRouter()
+	r.Post("/items/import", itemHandler.ImportCSV)
+	r.Route("/import-batches", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Get("/{batchId}", handler.Get)
+		r.Post("/{batchId}/rollback", handler.Rollback)
+	})
+	return r
+}
+
+func TestBatchHandlerRollsBackImport(t *testing.T) {
+	router := newBatchTestRouter(t)
+
+	req := newMultipartCSVRequest(t, "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\nDune,Frank Herbert,book,1965,,,,,,\nHades,,game,,,,,,,\n")
+	req.URL.Path = "/items/import"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(req))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary importer.Summary
+	if err := json.NewDecoder(rec.Body).Decode(&summary); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if summary.BatchID == nil {
+		t.Fatalf("expected a batch id in %+v", summary)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/import-batches", nil)))
+	var listed struct {
+		Batches []batches.Batch `json:"batches"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(listed.Batches) != 1 || listed.Batches[0].Label != "import.csv" || len(listed.Batches[0].Items) != 2 {
+		t.Fatalf("unexpected batches %+v", listed.Batches)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/import-batches/"+summary.BatchID.String()+"/rollback", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result batches.RollbackResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(result.Deleted) != 2 || len(result.Kept) != 0 || result.Batch.RolledBackAt == nil {
+		t.Fatalf("unexpected rollback result %+v", result)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/import-batches/"+uuid.NewString()+"/rollback", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for an unknown batch, got %d", rec.Code)
+	}
+}
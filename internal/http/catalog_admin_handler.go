@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"log/slog"
+
+	"anthology/internal/catalog"
+)
+
+// CatalogCacheAdmin describes the catalog cache operations exposed to admins.
+type CatalogCacheAdmin interface {
+	CacheStats(ctx context.Context) (catalog.CacheStats, error)
+	PurgeCache(ctx context.Context, provider string) (int64, error)
+}
+
+// CatalogAdminHandler exposes operational endpoints for the catalog lookup cache.
+type CatalogAdminHandler struct {
+	service CatalogCacheAdmin
+	logger  *slog.Logger
+}
+
+// NewCatalogAdminHandler constructs a handler for catalog cache administration.
+func NewCatalogAdminHandler(service CatalogCacheAdmin, logger *slog.Logger) *CatalogAdminHandler {
+	return &CatalogAdminHandler{service: service, logger: logger}
+}
+
+// CacheStats reports lookup cache hit/miss counters and the number of live entries.
+func (h *CatalogAdminHandler) CacheStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.CacheStats(r.Context())
+	if err != nil {
+		h.logger.Error("catalog cache stats failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to load cache stats")
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// PurgeCache removes cached lookups, optionally limited to a single provider.
+func (h *CatalogAdminHandler) PurgeCache(w http.ResponseWriter, r *http.Request) {
+	provider := strings.TrimSpace(r.URL.Query().Get("provider"))
+
+	removed, err := h.service.PurgeCache(r.Context(), provider)
+	if err != nil {
+		if errors.Is(err, catalog.ErrUnknownProvider) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("catalog cache purge failed", "error", err, "provider", provider)
+		writeError(w, http.StatusInternalServerError, "failed to purge cache")
+		return
+	}
+
+	if user := UserFromContext(r.Context()); user != nil {
+		h.logger.Info("catalog cache purged", "provider", provider, "removed", removed, "user_id", user.ID)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"removed": removed})
+}
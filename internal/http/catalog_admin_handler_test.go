@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"anthology/internal/auth"
+	"anthology/internal/catalog"
+)
+
+type mockCatalogCacheAdmin struct {
+	stats        catalog.CacheStats
+	removed      int64
+	err          error
+	lastProvider string
+}
+
+func (m *mockCatalogCacheAdmin) CacheStats(context.Context) (catalog.CacheStats, error) {
+	return m.stats, m.err
+}
+
+func (m *mockCatalogCacheAdmin) PurgeCache(_ context.Context, provider string) (int64, error) {
+	m.lastProvider = provider
+	return m.removed, m.err
+}
+
+func newCatalogAdminRouter(service CatalogCacheAdmin, email string) http.Handler {
+	handler := NewCatalogAdminHandler(service, newTestLogger())
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := &auth.User{ID: uuid.New(), Email: email}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+		})
+	})
+	r.Use(newAdminMiddleware([]string{"Admin@Example.com"}))
+	r.Get("/cache", handler.CacheStats)
+	r.Delete("/cache", handler.PurgeCache)
+	return r
+}
+
+func TestCatalogAdminRejectsNonAdmins(t *testing.T) {
+	router := newCatalogAdminRouter(&mockCatalogCacheAdmin{}, "user@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/cache", nil))
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rr.Code)
+	}
+}
+
+func TestCatalogAdminReturnsStats(t *testing.T) {
+	service := &mockCatalogCacheAdmin{stats: catalog.CacheStats{Enabled: true, Hits: 3, Misses: 1, Entries: 2}}
+	router := newCatalogAdminRouter(service, "admin@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/cache", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	var stats catalog.CacheStats
+	if err := json.NewDecoder(rr.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if stats.Hits != 3 || stats.Entries != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCatalogAdminPurgesByProvider(t *testing.T) {
+	service := &mockCatalogCacheAdmin{removed: 4}
+	router := newCatalogAdminRouter(service, "admin@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/cache?provider=google_books", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastProvider != "google_books" {
+		t.Fatalf("expected provider to be forwarded, got %q", service.lastProvider)
+	}
+	var payload map[string]int64
+	if err := json.NewDecoder(rr.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload["removed"] != 4 {
+		t.Fatalf("expected removed count, got %v", payload)
+	}
+}
+
+func TestCatalogAdminPurgeRejectsUnknownProvider(t *testing.T) {
+	router := newCatalogAdminRouter(&mockCatalogCacheAdmin{err: catalog.ErrUnknownProvider}, "admin@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/cache?provider=nope", nil))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"anthology/internal/goals"
+)
+
+// GoalHandler exposes reading goal and streak endpoints.
+type GoalHandler struct {
+	svc    *goals.Service
+	logger *slog.Logger
+}
+
+// NewGoalHandler constructs a GoalHandler.
+func NewGoalHandler(svc *goals.Service, logger *slog.Logger) *GoalHandler {
+	return &GoalHandler{svc: svc, logger: logger}
+}
+
+func (h *GoalHandler) handleGoalError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, goals.ErrNotFound):
+		writeError(w, http.StatusNotFound, "goal not found")
+	case errors.Is(err, goals.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("goal operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns goals with progress, optionally for a single year.
+func (h *GoalHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var year *int
+	if rawYear := strings.TrimSpace(r.URL.Query().Get("year")); rawYear != "" {
+		value, err := strconv.Atoi(rawYear)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid year")
+			return
+		}
+		year = &value
+	}
+
+	progress, err := h.svc.List(r.Context(), user.ID, year)
+	if err != nil {
+		h.handleGoalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"goals": progress})
+}
+
+// Get returns a single goal with progress.
+func (h *GoalHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "goalId")
+	if !ok {
+		return
+	}
+
+	progress, err := h.svc.Get(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleGoalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, progress)
+}
+
+// Create stores a new goal.
+func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var input goals.GoalInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	progress, err := h.svc.Create(r.Context(), input, user.ID)
+	if err != nil {
+		h.handleGoalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, progress)
+}
+
+// Update edits a goal.
+func (h *GoalHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "goalId")
+	if !ok {
+		return
+	}
+
+	var input goals.GoalInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	progress, err := h.svc.Update(r.Context(), id, input, user.ID)
+	if err != nil {
+		h.handleGoalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, progress)
+}
+
+// Delete removes a goal.
+func (h *GoalHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "goalId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), id, user.ID); err != nil {
+		h.handleGoalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Streaks returns the current and longest reading streaks.
+func (h *GoalHandler) Streaks(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	streaks, err := h.svc.Streaks(r.Context(), user.ID)
+	if err != nil {
+		h.handleGoalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, streaks)
+}
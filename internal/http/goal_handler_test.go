@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/goals"
+	"anthology/internal/items"
+	"anthology/internal/reading"
+)
+
+func newGoalTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	handler := NewGoalHandler(goals.NewService(goals.NewInMemoryRepository(), items.NewInMemoryRepository(nil), reading.NewInMemoryRepository()), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/goals", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Create)
+		r.Get("/streaks", handler.Streaks)
+		r.Route("/{goalId}", func(r chi.Router) {
+			r.Get("/", handler.Get)
+			r.Put("/", handler.Update)
+			r.Delete("/", handler.Delete)
+		})
+	})
+	return r
+}
+
+func TestGoalHandlerCRUD(t *testing.T) {
+	router := newGoalTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/goals", strings.NewReader(`{"year":2026,"metric":"books","target":24}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created goals.Progress
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if created.Goal.Target != 24 || created.Remaining != 24 {
+		t.Fatalf("unexpected goal %+v", created)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPut, "/goals/"+created.Goal.ID.String(), strings.NewReader(`{"year":2026,"metric":"books","target":0}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for zero target, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/goals/"+created.Goal.ID.String(), nil)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/goals/"+created.Goal.ID.String(), nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestGoalHandlerStreaks(t *testing.T) {
+	router := newGoalTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/goals/streaks", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var streaks goals.Streaks
+	if err := json.NewDecoder(rec.Body).Decode(&streaks); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if streaks.Current != 0 || streaks.Longest != 0 || streaks.LastActive != nil {
+		t.Fatalf("expected empty streaks, got %+v", streaks)
+	}
+}
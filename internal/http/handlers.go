@@ -19,22 +19,25 @@ import (
 	"anthology/internal/exporter"
 	"anthology/internal/importer"
 	"anthology/internal/items"
+	"anthology/internal/reviews"
 )
 
 // ItemHandler exposes item CRUD endpoints.
 type ItemHandler struct {
 	service    *items.Service
 	catalogSvc *catalog.Service
+	reviewSvc  *reviews.Service
 	importer   *importer.CSVImporter
 	exporter   *exporter.CSVExporter
 	logger     *slog.Logger
 }
 
 // NewItemHandler creates a handler.
-func NewItemHandler(service *items.Service, catalogSvc *catalog.Service, importer *importer.CSVImporter, logger *slog.Logger) *ItemHandler {
+func NewItemHandler(service *items.Service, catalogSvc *catalog.Service, reviewSvc *reviews.Service, importer *importer.CSVImporter, logger *slog.Logger) *ItemHandler {
 	return &ItemHandler{
 		service:    service,
 		catalogSvc: catalogSvc,
+		reviewSvc:  reviewSvc,
 		importer:   importer,
 		exporter:   exporter.NewCSVExporter(),
 		logger:     logger,
@@ -98,6 +101,18 @@ func parseListOptions(values url.Values) (items.ListOptions, error) {
 		}
 	}
 
+	if rawLoanStatus := strings.TrimSpace(values.Get("loan_status")); rawLoanStatus != "" {
+		loanStatus := items.LoanStatus(rawLoanStatus)
+		switch loanStatus {
+		case items.LoanStatusOnLoan, items.LoanStatusAvailable:
+			opts.LoanStatus = &loanStatus
+		case items.LoanStatusAll:
+			// "all" means no filter - leave LoanStatus nil
+		default:
+			return items.ListOptions{}, fmt.Errorf("invalid loan_status filter")
+		}
+	}
+
 	if rawLetter := strings.TrimSpace(values.Get("letter")); rawLetter != "" {
 		letter := strings.ToUpper(rawLetter)
 		if letter == "#" || (len(letter) == 1 && letter[0] >= 'A' && letter[0] <= 'Z') {
@@ -115,6 +130,20 @@ func parseListOptions(values url.Values) (items.ListOptions, error) {
 		opts.Query = &query
 	}
 
+	if rawTags := strings.TrimSpace(values.Get("tags")); rawTags != "" {
+		opts.Tags = strings.Split(rawTags, ",")
+	}
+
+	if rawMatch := strings.TrimSpace(values.Get("tag_match")); rawMatch != "" {
+		match := items.TagMatch(rawMatch)
+		switch match {
+		case items.TagMatchAny, items.TagMatchAll:
+			opts.TagMatch = match
+		default:
+			return items.ListOptions{}, fmt.Errorf("invalid tag_match filter")
+		}
+	}
+
 	if rawLimit := strings.TrimSpace(values.Get("limit")); rawLimit != "" {
 		value, err := strconv.Atoi(rawLimit)
 		if err != nil || value <= 0 || value > maxListLimit {
@@ -123,6 +152,14 @@ func parseListOptions(values url.Values) (items.ListOptions, error) {
 		opts.Limit = &value
 	}
 
+	if rawShared := strings.TrimSpace(values.Get("include_shared")); rawShared != "" {
+		includeShared, err := strconv.ParseBool(rawShared)
+		if err != nil {
+			return items.ListOptions{}, fmt.Errorf("invalid include_shared filter")
+		}
+		opts.IncludeShared = includeShared
+	}
+
 	return opts, nil
 }
 
@@ -131,30 +168,33 @@ func (h *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
 	user := UserFromContext(r.Context())
 
 	var payload struct {
-		Title          string     `json:"title"`
-		Creator        string     `json:"creator"`
-		ItemType       string     `json:"itemType"`
-		ReleaseYear    *int       `json:"releaseYear"`
-		PageCount      *int       `json:"pageCount"`
-		CurrentPage    *int       `json:"currentPage"`
-		ISBN13         string     `json:"isbn13"`
-		ISBN10         string     `json:"isbn10"`
-		Description    string     `json:"description"`
-		CoverImage     string     `json:"coverImage"`
-		Format         string     `json:"format"`
-		Genre          string     `json:"genre"`
-		Rating         *int       `json:"rating"`
-		RetailPriceUsd *float64   `json:"retailPriceUsd"`
-		GoogleVolumeId string     `json:"googleVolumeId"`
-		Platform       string     `json:"platform"`
-		AgeGroup       string     `json:"ageGroup"`
-		PlayerCount    string     `json:"playerCount"`
-		ReadingStatus  string     `json:"readingStatus"`
-		ReadAt         *time.Time `json:"readAt"`
-		Notes          string     `json:"notes"`
-		SeriesName     string     `json:"seriesName"`
-		VolumeNumber   *int       `json:"volumeNumber"`
-		TotalVolumes   *int       `json:"totalVolumes"`
+		Title            string     `json:"title"`
+		Creator          string     `json:"creator"`
+		ItemType         string     `json:"itemType"`
+		ReleaseYear      *int       `json:"releaseYear"`
+		PageCount        *int       `json:"pageCount"`
+		CurrentPage      *int       `json:"currentPage"`
+		ISBN13           string     `json:"isbn13"`
+		ISBN10           string     `json:"isbn10"`
+		Description      string     `json:"description"`
+		CoverImage       string     `json:"coverImage"`
+		Format           string     `json:"format"`
+		Genre            string     `json:"genre"`
+		Rating           *int       `json:"rating"`
+		RetailPriceUsd   *float64   `json:"retailPriceUsd"`
+		GoogleVolumeId   string     `json:"googleVolumeId"`
+		MetadataSource   string     `json:"metadataSource"`
+		MetadataSourceID string     `json:"metadataSourceId"`
+		Platform         string     `json:"platform"`
+		AgeGroup         string     `json:"ageGroup"`
+		PlayerCount      string     `json:"playerCount"`
+		ReadingStatus    string     `json:"readingStatus"`
+		ReadAt           *time.Time `json:"readAt"`
+		Notes            string     `json:"notes"`
+		SeriesName       string     `json:"seriesName"`
+		VolumeNumber     *int       `json:"volumeNumber"`
+		TotalVolumes     *int       `json:"totalVolumes"`
+		Tags             []string   `json:"tags"`
 	}
 
 	if err := decodeJSONBody(w, r, &payload); err != nil {
@@ -163,31 +203,34 @@ func (h *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	item, err := h.service.Create(r.Context(), items.CreateItemInput{
-		OwnerID:        user.ID,
-		Title:          payload.Title,
-		Creator:        payload.Creator,
-		ItemType:       items.ItemType(payload.ItemType),
-		ReleaseYear:    payload.ReleaseYear,
-		PageCount:      payload.PageCount,
-		CurrentPage:    payload.CurrentPage,
-		ISBN13:         payload.ISBN13,
-		ISBN10:         payload.ISBN10,
-		Description:    payload.Description,
-		CoverImage:     payload.CoverImage,
-		Format:         items.Format(payload.Format),
-		Genre:          items.Genre(payload.Genre),
-		Rating:         payload.Rating,
-		RetailPriceUsd: payload.RetailPriceUsd,
-		GoogleVolumeId: payload.GoogleVolumeId,
-		Platform:       payload.Platform,
-		AgeGroup:       payload.AgeGroup,
-		PlayerCount:    payload.PlayerCount,
-		ReadingStatus:  items.BookStatus(payload.ReadingStatus),
-		ReadAt:         payload.ReadAt,
-		Notes:          payload.Notes,
-		SeriesName:     payload.SeriesName,
-		VolumeNumber:   payload.VolumeNumber,
-		TotalVolumes:   payload.TotalVolumes,
+		OwnerID:          user.ID,
+		Title:            payload.Title,
+		Creator:          payload.Creator,
+		ItemType:         items.ItemType(payload.ItemType),
+		ReleaseYear:      payload.ReleaseYear,
+		PageCount:        payload.PageCount,
+		CurrentPage:      payload.CurrentPage,
+		ISBN13:           payload.ISBN13,
+		ISBN10:           payload.ISBN10,
+		Description:      payload.Description,
+		CoverImage:       payload.CoverImage,
+		Format:           items.Format(payload.Format),
+		Genre:            items.Genre(payload.Genre),
+		Rating:           payload.Rating,
+		RetailPriceUsd:   payload.RetailPriceUsd,
+		GoogleVolumeId:   payload.GoogleVolumeId,
+		MetadataSource:   payload.MetadataSource,
+		MetadataSourceID: payload.MetadataSourceID,
+		Platform:         payload.Platform,
+		AgeGroup:         payload.AgeGroup,
+		PlayerCount:      payload.PlayerCount,
+		ReadingStatus:    items.BookStatus(payload.ReadingStatus),
+		ReadAt:           payload.ReadAt,
+		Notes:            payload.Notes,
+		SeriesName:       payload.SeriesName,
+		VolumeNumber:     payload.VolumeNumber,
+		TotalVolumes:     payload.TotalVolumes,
+		Tags:             payload.Tags,
 	})
 	if err != nil {
 		if errors.Is(err, items.ErrValidation) {
@@ -236,30 +279,33 @@ func (h *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var payload struct {
-		Title          *string    `json:"title"`
-		Creator        *string    `json:"creator"`
-		ItemType       *string    `json:"itemType"`
-		ReleaseYear    *int       `json:"releaseYear"`
-		PageCount      *int       `json:"pageCount"`
-		CurrentPage    *int       `json:"currentPage"`
-		ISBN13         *string    `json:"isbn13"`
-		ISBN10         *string    `json:"isbn10"`
-		Description    *string    `json:"description"`
-		CoverImage     *string    `json:"coverImage"`
-		Format         *string    `json:"format"`
-		Genre          *string    `json:"genre"`
-		Rating         *int       `json:"rating"`
-		RetailPriceUsd *float64   `json:"retailPriceUsd"`
-		GoogleVolumeId *string    `json:"googleVolumeId"`
-		Platform       *string    `json:"platform"`
-		AgeGroup       *string    `json:"ageGroup"`
-		PlayerCount    *string    `json:"playerCount"`
-		ReadingStatus  *string    `json:"readingStatus"`
-		ReadAt         *time.Time `json:"readAt"`
-		Notes          *string    `json:"notes"`
-		SeriesName     *string    `json:"seriesName"`
-		VolumeNumber   *int       `json:"volumeNumber"`
-		TotalVolumes   *int       `json:"totalVolumes"`
+		Title            *string    `json:"title"`
+		Creator          *string    `json:"creator"`
+		ItemType         *string    `json:"itemType"`
+		ReleaseYear      *int       `json:"releaseYear"`
+		PageCount        *int       `json:"pageCount"`
+		CurrentPage      *int       `json:"currentPage"`
+		ISBN13           *string    `json:"isbn13"`
+		ISBN10           *string    `json:"isbn10"`
+		Description      *string    `json:"description"`
+		CoverImage       *string    `json:"coverImage"`
+		Format           *string    `json:"format"`
+		Genre            *string    `json:"genre"`
+		Rating           *int       `json:"rating"`
+		RetailPriceUsd   *float64   `json:"retailPriceUsd"`
+		GoogleVolumeId   *string    `json:"googleVolumeId"`
+		MetadataSource   *string    `json:"metadataSource"`
+		MetadataSourceID *string    `json:"metadataSourceId"`
+		Platform         *string    `json:"platform"`
+		AgeGroup         *string    `json:"ageGroup"`
+		PlayerCount      *string    `json:"playerCount"`
+		ReadingStatus    *string    `json:"readingStatus"`
+		ReadAt           *time.Time `json:"readAt"`
+		Notes            *string    `json:"notes"`
+		SeriesName       *string    `json:"seriesName"`
+		VolumeNumber     *int       `json:"volumeNumber"`
+		TotalVolumes     *int       `json:"totalVolumes"`
+		Tags             []string   `json:"tags"`
 	}
 
 	if err := decodeInto(raw, &payload); err != nil {
@@ -336,6 +382,12 @@ func (h *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if _, ok := raw["googleVolumeId"]; ok {
 		input.GoogleVolumeId = payload.GoogleVolumeId
 	}
+	if _, ok := raw["metadataSource"]; ok {
+		input.MetadataSource = payload.MetadataSource
+	}
+	if _, ok := raw["metadataSourceId"]; ok {
+		input.MetadataSourceID = payload.MetadataSourceID
+	}
 	if _, ok := raw["platform"]; ok {
 		input.Platform = payload.Platform
 	}
@@ -371,6 +423,10 @@ func (h *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
 		value := payload.TotalVolumes
 		input.TotalVolumes = &value
 	}
+	if _, ok := raw["tags"]; ok {
+		value := payload.Tags
+		input.Tags = &value
+	}
 
 	item, err := h.service.Update(r.Context(), id, user.ID, input)
 	if err != nil {
@@ -506,7 +562,8 @@ func parseHistogramOptions(values url.Values) (items.HistogramOptions, error) {
 	return opts, nil
 }
 
-// ImportCSV ingests a CSV file of catalog items.
+// ImportCSV ingests a CSV file of catalog items. With ?preview=true nothing is
+// written; the response lists what would be imported and a token for CommitImport.
 func (h *ItemHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
 	user := UserFromContext(r.Context())
 
@@ -515,6 +572,16 @@ func (h *ItemHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	preview := false
+	if raw := r.URL.Query().Get("preview"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "preview must be true or false")
+			return
+		}
+		preview = parsed
+	}
+
 	r.Body = http.MaxBytesReader(w, r.Body, maxCSVUploadBytes)
 	if err := r.ParseMultipartForm(maxCSVUploadBytes); err != nil {
 		var maxErr *http.MaxBytesError
@@ -531,27 +598,107 @@ func (h *ItemHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	file, _, err := r.FormFile("file")
+	file, fileHeader, err := r.FormFile("file")
 	if err != nil {
 		writeError(w, http.StatusBadRequest, "CSV file is required")
 		return
 	}
 	defer func() { _ = file.Close() }()
 
-	summary, err := h.importer.Import(r.Context(), file, user.ID)
+	opts, err := importOptionsFromForm(r)
 	if err != nil {
-		if errors.Is(err, importer.ErrInvalidCSV) {
-			writeError(w, http.StatusBadRequest, err.Error())
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts.Label = fileHeader.Filename
+
+	if preview {
+		result, err := h.importer.Preview(r.Context(), file, user.ID, opts)
+		if err != nil {
+			h.handleImportError(w, err)
 			return
 		}
-		h.logger.Error("csv import failed", "error", err)
-		writeError(w, http.StatusInternalServerError, "bulk import failed")
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+
+	summary, err := h.importer.ImportWithOptions(r.Context(), file, user.ID, opts)
+	if err != nil {
+		h.handleImportError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// importOptionsFromForm reads an optional column mapping from the upload form:
+// "mapping" holds an inline mapping as JSON and "mappingId" names a saved one.
+func importOptionsFromForm(r *http.Request) (importer.Options, error) {
+	var opts importer.Options
+	if raw := strings.TrimSpace(r.FormValue("mapping")); raw != "" {
+		decoder := json.NewDecoder(strings.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		var mapping importer.Mapping
+		if err := decoder.Decode(&mapping); err != nil {
+			return importer.Options{}, errors.New("mapping must be a JSON column mapping")
+		}
+		opts.Mapping = &mapping
+	}
+	if raw := strings.TrimSpace(r.FormValue("mappingId")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return importer.Options{}, errors.New("mappingId must be a UUID")
+		}
+		opts.MappingID = id
+	}
+	return opts, nil
+}
+
+type commitImportRequest struct {
+	Token       string `json:"token"`
+	ExcludeRows []int  `json:"excludeRows"`
+}
+
+// CommitImport creates the items from an earlier preview, skipping excluded rows.
+func (h *ItemHandler) CommitImport(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	if h.importer == nil {
+		writeError(w, http.StatusNotImplemented, "CSV import is not available")
+		return
+	}
+
+	var req commitImportRequest
+	if err := decodeJSONBody(w, r, &req); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		writeError(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	summary, err := h.importer.Commit(r.Context(), strings.TrimSpace(req.Token), req.ExcludeRows, user.ID)
+	if err != nil {
+		h.handleImportError(w, err)
 		return
 	}
 
 	writeJSON(w, http.StatusOK, summary)
 }
 
+func (h *ItemHandler) handleImportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, importer.ErrInvalidCSV):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, importer.ErrPreviewNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		h.logger.Error("csv import failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "bulk import failed")
+	}
+}
+
 // ExportCSV exports all items matching the given filters to CSV format.
 func (h *ItemHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 	user := UserFromContext(r.Context())
@@ -573,6 +720,16 @@ func (h *ItemHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var itemReviews map[uuid.UUID][]reviews.Review
+	if h.reviewSvc != nil {
+		itemReviews, err = h.reviewSvc.ByItem(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Error("export reviews", "error", err)
+			writeError(w, http.StatusInternalServerError, "failed to export items")
+			return
+		}
+	}
+
 	// Generate filename with timestamp
 	timestamp := time.Now().UTC().Format("2006-01-02")
 	filename := fmt.Sprintf("anthology-export-%s.csv", timestamp)
@@ -580,7 +737,7 @@ func (h *ItemHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
 	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
 
-	if err := h.exporter.Export(w, itemList); err != nil {
+	if err := h.exporter.Export(w, itemList, itemReviews); err != nil {
 		h.logger.Error("write csv export", "error", err)
 		// Response headers already sent, can't change status code
 		return
@@ -606,6 +763,10 @@ func handleServiceError(w http.ResponseWriter, err error, logger *slog.Logger) {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, items.ErrForbidden) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
 	logger.Error("service error", "error", err)
 	writeError(w, http.StatusInternalServerError, "unexpected error")
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -65,7 +66,7 @@ func TestItemHandlerImportCSVSuccess(t *testing.T) {
 	store := &csvStoreStub{}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	importerSvc := importer.NewCSVImporter(store, nil)
-	handler := NewItemHandler(nil, nil, importerSvc, logger)
+	handler := NewItemHandler(nil, nil, nil, importerSvc, logger)
 	req := newMultipartCSVRequest(t, strings.Join([]string{
 		"title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes",
 		"Title A,Creator,book,2020,300,9780000000001,0000000001,Desc,,Notes",
@@ -98,7 +99,7 @@ func TestItemHandlerImportCSVValidationError(t *testing.T) {
 	store := &csvStoreStub{}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	importerSvc := importer.NewCSVImporter(store, nil)
-	handler := NewItemHandler(nil, nil, importerSvc, logger)
+	handler := NewItemHandler(nil, nil, nil, importerSvc, logger)
 	req := newMultipartCSVRequest(t, "title,itemType\nbad,csv\n")
 	req = reqWithUser(req)
 	rec := httptest.NewRecorder()
@@ -110,8 +111,54 @@ func TestItemHandlerImportCSVValidationError(t *testing.T) {
 	}
 }
 
+func TestItemHandlerImportCSVPreviewAndCommit(t *testing.T) {
+	store := &csvStoreStub{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := NewItemHandler(nil, nil, nil, importer.NewCSVImporter(store, nil), logger)
+	req := newMultipartCSVRequest(t, strings.Join([]string{
+		"title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes",
+		"Title A,Creator,book,2020,300,,,,,",
+		"Title B,Director,movie,,,,,,,",
+	}, "\n"))
+	req.URL.RawQuery = "preview=true"
+	rec := httptest.NewRecorder()
+
+	handler.ImportCSV(rec, reqWithUser(req))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var preview importer.Preview
+	if err := json.Unmarshal(rec.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("response should decode: %v", err)
+	}
+	if preview.Token == "" || len(preview.Items) != 2 || len(store.items) != 0 {
+		t.Fatalf("expected a preview without writes, got %+v (%d stored)", preview, len(store.items))
+	}
+
+	body := fmt.Sprintf(`{"token":%q,"excludeRows":[3]}`, preview.Token)
+	rec = httptest.NewRecorder()
+	handler.CommitImport(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/api/items/import/commit", strings.NewReader(body))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary importer.Summary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("response should decode: %v", err)
+	}
+	if summary.Imported != 1 || summary.ExcludedRows != 1 || len(store.items) != 1 || store.items[0].Title != "Title A" {
+		t.Fatalf("unexpected commit summary %+v", summary)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.CommitImport(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/api/items/import/commit", strings.NewReader(body))))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected a used token to be rejected with 404, got %d", rec.Code)
+	}
+}
+
 func TestItemHandlerImportCSVUnavailable(t *testing.T) {
-	handler := NewItemHandler(nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := NewItemHandler(nil, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	req := newMultipartCSVRequest(t, "title\nA\n")
 	req = reqWithUser(req)
 	rec := httptest.NewRecorder()
@@ -147,7 +194,7 @@ func TestItemHandlerExportCSV(t *testing.T) {
 		items: []items.Item{itemOld, itemNew},
 	}
 	service := items.NewService(repo)
-	handler := NewItemHandler(service, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := NewItemHandler(service, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
 
 	req := httptest.NewRequest(
 		http.MethodGet,
@@ -229,6 +276,16 @@ func reqWithUser(req *http.Request) *http.Request {
 	return req.WithContext(ctx)
 }
 
+// reqWithStranger attaches a signed-in user who owns nothing in the fixtures.
+func reqWithStranger(req *http.Request) *http.Request {
+	user := &auth.User{
+		ID:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		Email: "stranger@example.com",
+	}
+	ctx := context.WithValue(req.Context(), userContextKey, user)
+	return req.WithContext(ctx)
+}
+
 type csvStoreStub struct {
 	items []items.Item
 }
@@ -300,3 +357,23 @@ func (s *exportRepoStub) UpdateSeriesName(ctx context.Context, oldName, newName
 func (s *exportRepoStub) ClearSeriesName(ctx context.Context, seriesName string, ownerID uuid.UUID) (int64, error) {
 	return 0, nil
 }
+
+func (s *exportRepoStub) ListTags(ctx context.Context, ownerID uuid.UUID) ([]items.Tag, error) {
+	return nil, nil
+}
+
+func (s *exportRepoStub) CreateTag(ctx context.Context, name string, ownerID uuid.UUID) (items.Tag, error) {
+	return items.Tag{Name: name}, nil
+}
+
+func (s *exportRepoStub) RenameTag(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (s *exportRepoStub) MergeTags(ctx context.Context, sources []string, target string, ownerID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (s *exportRepoStub) DeleteTag(ctx context.Context, name string, ownerID uuid.UUID) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/importer"
+	"anthology/internal/imports"
+)
+
+// maxImportJobUploadBytes bounds background import uploads, which may hold far
+// more rows than the synchronous /items/import endpoint accepts.
+const maxImportJobUploadBytes int64 = 50 << 20
+
+// ImportJobHandler exposes background CSV import jobs.
+type ImportJobHandler struct {
+	svc    *imports.Service
+	logger *slog.Logger
+}
+
+// NewImportJobHandler constructs an ImportJobHandler.
+func NewImportJobHandler(svc *imports.Service, logger *slog.Logger) *ImportJobHandler {
+	return &ImportJobHandler{svc: svc, logger: logger}
+}
+
+func (h *ImportJobHandler) handleImportJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, imports.ErrNotFound):
+		writeError(w, http.StatusNotFound, "import job not found")
+	case errors.Is(err, imports.ErrFinished):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, importer.ErrInvalidCSV):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("import job operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// Start accepts a CSV upload and queues it for background import.
+func (h *ImportJobHandler) Start(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportJobUploadBytes)
+	if err := r.ParseMultipartForm(maxCSVUploadBytes); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("CSV upload is too large (max %d bytes)", maxErr.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid CSV upload")
+		return
+	}
+	defer func() {
+		if r.MultipartForm != nil {
+			_ = r.MultipartForm.RemoveAll()
+		}
+	}()
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "CSV file is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid CSV upload")
+		return
+	}
+
+	opts, err := importOptionsFromForm(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := h.svc.Start(r.Context(), user.ID, fileHeader.Filename, data, opts)
+	if err != nil {
+		h.handleImportJobError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// List returns the owner's import jobs, newest first.
+func (h *ImportJobHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	jobs, err := h.svc.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleImportJobError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"imports": jobs})
+}
+
+// Get returns a job's status, progress, and summary so far.
+func (h *ImportJobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	job, err := h.svc.Get(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleImportJobError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// Cancel stops a queued or running job.
+func (h *ImportJobHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	job, err := h.svc.Cancel(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleImportJobError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/importer"
+	"anthology/internal/imports"
+	"anthology/internal/items"
+)
+
+func newImportJobTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	svc := imports.NewService(imports.NewInMemoryRepository(), importer.NewCSVImporter(itemSvc, nil), newTestLogger())
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	handler := NewImportJobHandler(svc, newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/imports", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Start)
+		r.Get("/{id}", handler.Get)
+		r.Post("/{id}/cancel", handler.Cancel)
+	})
+	return r
+}
+
+func TestImportJobHandlerStartAndPoll(t *testing.T) {
+	router := newImportJobTestRouter(t)
+
+	req := newMultipartCSVRequest(t, "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\nDune,Frank Herbert,book,1965,,,,,,\n")
+	req.URL.Path = "/imports"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(req))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var job imports.Job
+	if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if job.Filename != "import.csv" || job.TotalRows != 1 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !job.Status.Finished() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/imports/"+job.ID.String(), nil)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	if job.Status != imports.StatusCompleted || job.Summary.Imported != 1 {
+		t.Fatalf("unexpected finished job %+v", job)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/imports/"+job.ID.String()+"/cancel", nil)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 cancelling a finished job, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestImportJobHandlerRejectsInvalidCSV(t *testing.T) {
+	router := newImportJobTestRouter(t)
+
+	req := newMultipartCSVRequest(t, "title\nDune\n")
+	req.URL.Path = "/imports"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(req))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/imports/00000000-0000-0000-0000-00000000abcd", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
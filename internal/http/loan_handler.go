@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/items"
+	"anthology/internal/loans"
+)
+
+// LoanHandler exposes lending and borrower endpoints.
+type LoanHandler struct {
+	svc    *loans.Service
+	logger *slog.Logger
+}
+
+// NewLoanHandler constructs a LoanHandler.
+func NewLoanHandler(svc *loans.Service, logger *slog.Logger) *LoanHandler {
+	return &LoanHandler{svc: svc, logger: logger}
+}
+
+func (h *LoanHandler) handleLoanError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, loans.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item is not on loan")
+	case errors.Is(err, loans.ErrBorrowerNotFound):
+		writeError(w, http.StatusNotFound, "borrower not found")
+	case errors.Is(err, items.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item not found")
+	case errors.Is(err, loans.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("loan operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// ListItemLoans returns the loan history for an item.
+func (h *LoanHandler) ListItemLoans(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	history, err := h.svc.ListItemLoans(r.Context(), itemID, user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"loans": history})
+}
+
+// Lend records a new loan for an item.
+func (h *LoanHandler) Lend(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var input loans.LendInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	loan, err := h.svc.Lend(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, loan)
+}
+
+// Return closes the active loan for an item.
+func (h *LoanHandler) Return(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var input loans.ReturnInput
+	if r.ContentLength != 0 {
+		if err := decodeJSONBody(w, r, &input); err != nil {
+			writeJSONError(w, err)
+			return
+		}
+	}
+
+	loan, err := h.svc.Return(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, loan)
+}
+
+// ListActive returns every outstanding loan.
+func (h *LoanHandler) ListActive(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	active, err := h.svc.ListActiveLoans(r.Context(), user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"loans": active})
+}
+
+// ListOverdue returns outstanding loans past their due date.
+func (h *LoanHandler) ListOverdue(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	overdue, err := h.svc.ListOverdue(r.Context(), user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"loans": overdue})
+}
+
+// ListBorrowers returns the user's borrowers.
+func (h *LoanHandler) ListBorrowers(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	borrowers, err := h.svc.ListBorrowers(r.Context(), user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"borrowers": borrowers})
+}
+
+// CreateBorrower registers a borrower.
+func (h *LoanHandler) CreateBorrower(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var input loans.BorrowerInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	borrower, err := h.svc.CreateBorrower(r.Context(), input, user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, borrower)
+}
+
+// UpdateBorrower edits a borrower.
+func (h *LoanHandler) UpdateBorrower(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "borrowerId")
+	if !ok {
+		return
+	}
+
+	var input loans.BorrowerInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	borrower, err := h.svc.UpdateBorrower(r.Context(), id, input, user.ID)
+	if err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, borrower)
+}
+
+// DeleteBorrower removes a borrower with no outstanding loans.
+func (h *LoanHandler) DeleteBorrower(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "borrowerId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteBorrower(r.Context(), id, user.ID); err != nil {
+		h.handleLoanError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
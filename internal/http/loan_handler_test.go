@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/items"
+	"anthology/internal/loans"
+)
+
+func newLoanTestRouter(t *testing.T) (http.Handler, items.Item) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	item, err := items.NewService(itemsRepo).Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+
+	handler := NewLoanHandler(loans.NewService(loans.NewInMemoryRepository(), itemsRepo), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/items/{id}/loans", func(r chi.Router) {
+		r.Get("/", handler.ListItemLoans)
+		r.Post("/", handler.Lend)
+		r.Post("/return", handler.Return)
+	})
+	return r, item
+}
+
+func TestLoanHandlerLendAndReturn(t *testing.T) {
+	router, item := newLoanTestRouter(t)
+	base := "/items/" + item.ID.String() + "/loans"
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base, strings.NewReader(`{"borrowerName":"Sam","dueAt":"2999-01-01T00:00:00Z"}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base, strings.NewReader(`{"borrowerName":"Alex"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for double loan, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/return", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, base, nil)))
+	var payload struct {
+		Loans []loans.Loan `json:"loans"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Loans) != 1 || payload.Loans[0].ReturnedAt == nil {
+		t.Fatalf("unexpected loan history %+v", payload.Loans)
+	}
+}
+
+func TestLoanHandlerErrors(t *testing.T) {
+	router, item := newLoanTestRouter(t)
+	base := "/items/" + item.ID.String() + "/loans"
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/return", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 without a loan, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base, strings.NewReader(`{"dueAt":"2999-01-01T00:00:00Z"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without a borrower, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base, strings.NewReader(`{"borrowerName":`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a malformed body, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithStranger(httptest.NewRequest(http.MethodPost, base, strings.NewReader(`{"borrowerName":"Sam"}`))))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 on another user's item, got %d", rec.Code)
+	}
+}
+
+func TestParseListOptionsLoanStatus(t *testing.T) {
+	opts, err := parseListOptions(url.Values{"loan_status": {"on_loan"}})
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	if opts.LoanStatus == nil || *opts.LoanStatus != items.LoanStatusOnLoan {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+
+	if _, err := parseListOptions(url.Values{"loan_status": {"lost"}}); err == nil {
+		t.Fatal("expected invalid loan_status to be rejected")
+	}
+}
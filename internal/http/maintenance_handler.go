@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"log/slog"
+
+	"anthology/internal/maintenance"
+)
+
+const (
+	defaultMaintenanceRunLimit = 50
+	maxMaintenanceRunLimit     = 500
+)
+
+// MaintenanceAdmin describes the maintenance scheduler state exposed to admins.
+type MaintenanceAdmin interface {
+	Jobs(ctx context.Context) ([]maintenance.JobStatus, error)
+	Runs(ctx context.Context, job string, limit int) ([]maintenance.Run, error)
+}
+
+// MaintenanceHandler exposes background maintenance jobs and their run history.
+type MaintenanceHandler struct {
+	service MaintenanceAdmin
+	logger  *slog.Logger
+}
+
+// NewMaintenanceHandler constructs a handler for maintenance administration.
+func NewMaintenanceHandler(service MaintenanceAdmin, logger *slog.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{service: service, logger: logger}
+}
+
+// Jobs lists the scheduled jobs with their last run and next run time.
+func (h *MaintenanceHandler) Jobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.Jobs(r.Context())
+	if err != nil {
+		h.logger.Error("list maintenance jobs failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to load maintenance jobs")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"jobs": jobs})
+}
+
+// Runs lists recent runs, newest first, optionally filtered by ?job=.
+func (h *MaintenanceHandler) Runs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := defaultMaintenanceRunLimit
+	if rawLimit := strings.TrimSpace(query.Get("limit")); rawLimit != "" {
+		value, err := strconv.Atoi(rawLimit)
+		if err != nil || value <= 0 || value > maxMaintenanceRunLimit {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = value
+	}
+
+	job := strings.TrimSpace(query.Get("job"))
+	runs, err := h.service.Runs(r.Context(), job, limit)
+	if err != nil {
+		h.logger.Error("list maintenance runs failed", "error", err, "job", job)
+		writeError(w, http.StatusInternalServerError, "failed to load maintenance runs")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"runs": runs})
+}
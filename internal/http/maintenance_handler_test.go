@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"anthology/internal/auth"
+	"anthology/internal/maintenance"
+)
+
+type mockMaintenanceAdmin struct {
+	jobs      []maintenance.JobStatus
+	runs      []maintenance.Run
+	lastJob   string
+	lastLimit int
+}
+
+func (m *mockMaintenanceAdmin) Jobs(context.Context) ([]maintenance.JobStatus, error) {
+	return m.jobs, nil
+}
+
+func (m *mockMaintenanceAdmin) Runs(_ context.Context, job string, limit int) ([]maintenance.Run, error) {
+	m.lastJob = job
+	m.lastLimit = limit
+	return m.runs, nil
+}
+
+func newMaintenanceRouter(service MaintenanceAdmin, email string) http.Handler {
+	handler := NewMaintenanceHandler(service, newTestLogger())
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := &auth.User{ID: uuid.New(), Email: email}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+		})
+	})
+	r.Use(newAdminMiddleware([]string{"admin@example.com"}))
+	r.Get("/jobs", handler.Jobs)
+	r.Get("/runs", handler.Runs)
+	return r
+}
+
+func TestMaintenanceRejectsNonAdmins(t *testing.T) {
+	router := newMaintenanceRouter(&mockMaintenanceAdmin{}, "user@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/runs", nil))
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rr.Code)
+	}
+}
+
+func TestMaintenanceListsJobs(t *testing.T) {
+	service := &mockMaintenanceAdmin{jobs: []maintenance.JobStatus{{Name: "expired-sessions", Interval: "1h0m0s"}}}
+	router := newMaintenanceRouter(service, "admin@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	var body struct {
+		Jobs []maintenance.JobStatus `json:"jobs"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(body.Jobs) != 1 || body.Jobs[0].Name != "expired-sessions" {
+		t.Fatalf("unexpected jobs %+v", body.Jobs)
+	}
+}
+
+func TestMaintenanceListsRunsWithFilters(t *testing.T) {
+	service := &mockMaintenanceAdmin{runs: []maintenance.Run{{ID: uuid.New(), Job: "catalog-cache", Status: maintenance.StatusSucceeded, Affected: 7}}}
+	router := newMaintenanceRouter(service, "admin@example.com")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/runs?job=catalog-cache&limit=5", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastJob != "catalog-cache" || service.lastLimit != 5 {
+		t.Fatalf("expected filters to be forwarded, got job=%q limit=%d", service.lastJob, service.lastLimit)
+	}
+	var body struct {
+		Runs []maintenance.Run `json:"runs"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(body.Runs) != 1 || body.Runs[0].Affected != 7 {
+		t.Fatalf("unexpected runs %+v", body.Runs)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/runs?limit=0", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for invalid limit, got %d", rr.Code)
+	}
+}
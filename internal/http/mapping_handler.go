@@ -0,0 +1,123 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/mappings"
+)
+
+// MappingHandler exposes saved CSV import mappings.
+type MappingHandler struct {
+	svc    *mappings.Service
+	logger *slog.Logger
+}
+
+// NewMappingHandler constructs a MappingHandler.
+func NewMappingHandler(svc *mappings.Service, logger *slog.Logger) *MappingHandler {
+	return &MappingHandler{svc: svc, logger: logger}
+}
+
+func (h *MappingHandler) handleMappingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, mappings.ErrNotFound):
+		writeError(w, http.StatusNotFound, "mapping not found")
+	case errors.Is(err, mappings.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("mapping operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns the owner's saved mappings.
+func (h *MappingHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	saved, err := h.svc.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleMappingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"mappings": saved})
+}
+
+// Get returns a single saved mapping.
+func (h *MappingHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "mappingId")
+	if !ok {
+		return
+	}
+
+	saved, err := h.svc.Get(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleMappingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// Create saves a new mapping.
+func (h *MappingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var input mappings.MappingInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	saved, err := h.svc.Create(r.Context(), input, user.ID)
+	if err != nil {
+		h.handleMappingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, saved)
+}
+
+// Update replaces a saved mapping.
+func (h *MappingHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "mappingId")
+	if !ok {
+		return
+	}
+
+	var input mappings.MappingInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	saved, err := h.svc.Update(r.Context(), id, input, user.ID)
+	if err != nil {
+		h.handleMappingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// Delete removes a saved mapping.
+func (h *MappingHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "mappingId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), id, user.ID); err != nil {
+		h.handleMappingError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/importer"
+	"anthology/internal/items"
+	"anthology/internal/mappings"
+)
+
+func newMappingTestRouter(t *testing.T) (http.Handler, *items.Service) {
+	t.Helper()
+	mappingSvc := mappings.NewService(mappings.NewInMemoryRepository())
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	itemHandler := NewItemHandler(itemSvc, nil, nil, importer.NewCSVImporter(itemSvc, nil, importer.WithMappingStore(mappingSvc)), newTestLogger())
+	handler := NewMappingHandler(mappingSvc, newTestLogger())
+
+	r := chi.NewRouter()
+	r.Post("/items/import", itemHandler.ImportCSV)
+	r.Route("/import-mappings", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Create)
+		r.Get("/{mappingId}", handler.Get)
+		r.Put("/{mappingId}", handler.Update)
+		r.Delete("/{mappingId}", handler.Delete)
+	})
+	return r, itemSvc
+}
+
+// newMappedCSVRequest builds an import upload with extra form fields.
+func newMappedCSVRequest(t *testing.T, csv string, fields map[string]string) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatalf("failed to write field: %v", err)
+		}
+	}
+	part, err := writer.CreateFormFile("file", "import.csv")
+	if err != nil {
+		t.Fatalf("failed to create multipart form: %v", err)
+	}
+	_, _ = part.Write([]byte(csv))
+	_ = writer.Close()
+	req := httptest.NewRequest(http.MethodPost, "/items/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestMappingHandlerSavedMappingImport(t *testing.T) {
+	router, itemSvc := newMappingTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	body := `{"name":"Game log","mapping":{"columns":[{"column":"Game","field":"title"},{"column":"System","field":"platform"}],"defaults":{"itemType":"game"}}}`
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/import-mappings", strings.NewReader(body))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var saved mappings.SavedMapping
+	if err := json.NewDecoder(rec.Body).Decode(&saved); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/import-mappings", strings.NewReader(body))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a duplicate name, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(newMappedCSVRequest(t, "Game,System\nHades,Switch\n", map[string]string{"mappingId": saved.ID.String()})))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary importer.Summary
+	if err := json.NewDecoder(rec.Body).Decode(&summary); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if summary.Imported != 1 || summary.Adapter != importer.AdapterMapping {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	list, _ := itemSvc.List(context.Background(), items.ListOptions{OwnerID: testOwnerID})
+	if len(list) != 1 || list[0].ItemType != items.ItemTypeGame || list[0].Platform != "Switch" {
+		t.Fatalf("unexpected imported items %+v", list)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/import-mappings/"+saved.ID.String(), nil)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(newMappedCSVRequest(t, "Game,System\nHades,Switch\n", map[string]string{"mappingId": saved.ID.String()})))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a deleted mapping, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestImportCSVAcceptsInlineMapping(t *testing.T) {
+	router, _ := newMappingTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	mapping := `{"columns":[{"column":"Name","field":"title"},{"column":"Owned","field":"notes","transform":{"kind":"boolean","true":"owned"}}],"defaults":{"itemType":"book"}}`
+	router.ServeHTTP(rec, reqWithUser(newMappedCSVRequest(t, "Name,Owned\nDune,yes\n", map[string]string{"mapping": mapping})))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(newMappedCSVRequest(t, "Name\nDune\n", map[string]string{"mapping": "{not json"})))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a malformed mapping, got %d", rec.Code)
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -35,7 +36,11 @@ func newSlogMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 // contextKey is a custom type for context keys to avoid collisions.
 type contextKey string
 
-const userContextKey contextKey = "user"
+const (
+	userContextKey     contextKey = "user"
+	apiTokenContextKey contextKey = "apiToken"
+	sessionContextKey  contextKey = "session"
+)
 
 // UserFromContext extracts the authenticated user from the request context.
 // Returns nil if the auth middleware hasn't populated the context.
@@ -44,9 +49,54 @@ func UserFromContext(ctx context.Context) *auth.User {
 	return user
 }
 
+// APITokenFromContext returns the personal access token that authenticated
+// the request, or nil when it was authenticated by a session cookie.
+func APITokenFromContext(ctx context.Context) *auth.APIToken {
+	token, _ := ctx.Value(apiTokenContextKey).(*auth.APIToken)
+	return token
+}
+
+// SessionFromContext returns the session that authenticated the request, or
+// nil when it was authenticated by an API token.
+func SessionFromContext(ctx context.Context) *auth.Session {
+	session, _ := ctx.Value(sessionContextKey).(*auth.Session)
+	return session
+}
+
+// newAuthMiddleware accepts a session cookie or a personal access token sent
+// as "Authorization: Bearer <token>". Token requests must carry the scope the
+// request needs (see requiredScope).
 func newAuthMiddleware(authService *auth.Service, logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if header := r.Header.Get("Authorization"); header != "" {
+				scheme, secret, ok := strings.Cut(header, " ")
+				if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(secret) == "" {
+					unauthorized(w)
+					return
+				}
+
+				user, token, err := authService.ValidateAPIToken(r.Context(), strings.TrimSpace(secret))
+				if err != nil {
+					logger.Error("api token validation error", "error", err)
+					unauthorized(w)
+					return
+				}
+				if user == nil || token == nil {
+					unauthorized(w)
+					return
+				}
+				if scope := requiredScope(r); !token.Allows(scope) {
+					writeError(w, http.StatusForbidden, fmt.Sprintf("api token lacks the %q scope", scope))
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), userContextKey, user)
+				ctx = context.WithValue(ctx, apiTokenContextKey, token)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			// Check session cookie
 			cookie, err := r.Cookie(sessionCookieName)
 			if err != nil || cookie.Value == "" {
@@ -55,25 +105,83 @@ func newAuthMiddleware(authService *auth.Service, logger *slog.Logger) func(http
 			}
 
 			// Validate session
-			user, err := authService.ValidateSession(r.Context(), cookie.Value)
+			session, user, err := authService.AuthenticateSession(r.Context(), cookie.Value)
 			if err != nil {
 				logger.Error("session validation error", "error", err)
 				unauthorized(w)
 				return
 			}
 
-			if user == nil {
+			if session == nil || user == nil {
 				unauthorized(w)
 				return
 			}
 
-			// Inject user into context
+			// Inject user and session into context
 			ctx := context.WithValue(r.Context(), userContextKey, user)
+			ctx = context.WithValue(ctx, sessionContextKey, session)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// importPaths are the endpoints covered by the import scope.
+var importPaths = []string{"/api/items/import", "/api/imports"}
+
+// requiredScope maps a request to the token scope it needs: reads need read,
+// CSV imports and import jobs need import, and other changes need write.
+func requiredScope(r *http.Request) auth.TokenScope {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return auth.ScopeRead
+	}
+	for _, prefix := range importPaths {
+		if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+			return auth.ScopeImport
+		}
+	}
+	return auth.ScopeWrite
+}
+
+// newSessionOnlyMiddleware rejects requests authenticated by an API token, so
+// a token cannot be used to manage credentials. It must run after the auth
+// middleware.
+func newSessionOnlyMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if APITokenFromContext(r.Context()) != nil {
+				writeError(w, http.StatusForbidden, "this endpoint requires a signed-in session")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newAdminMiddleware restricts routes to authenticated users whose email is in
+// adminEmails. It must run after the auth middleware.
+func newAdminMiddleware(adminEmails []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]struct{}, len(adminEmails))
+	for _, email := range adminEmails {
+		allowed[strings.ToLower(strings.TrimSpace(email))] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := UserFromContext(r.Context())
+			if user == nil {
+				unauthorized(w)
+				return
+			}
+			if _, ok := allowed[strings.ToLower(strings.TrimSpace(user.Email))]; !ok {
+				writeError(w, http.StatusForbidden, "admin access required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func unauthorized(w http.ResponseWriter) {
 	w.Header().Set("WWW-Authenticate", "Bearer")
 	writeError(w, http.StatusUnauthorized, "authentication required")
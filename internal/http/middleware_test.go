@@ -83,3 +83,79 @@ func TestAuthMiddlewareRejectsInvalidSession(t *testing.T) {
 		t.Fatalf("expected status 401, got %d", rec.Code)
 	}
 }
+
+func newTokenAuthTestHandler(token *auth.APIToken) http.Handler {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &authRepoStub{
+		findAPITokenByHash: func(ctx context.Context, tokenHash string) (*auth.APIToken, *auth.User, error) {
+			return token, &auth.User{ID: uuid.New(), Email: "script@example.com"}, nil
+		},
+	}
+	return newAuthMiddleware(auth.NewService(repo, time.Hour), logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if UserFromContext(r.Context()) == nil || APITokenFromContext(r.Context()) == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestAuthMiddlewareEnforcesTokenScopes(t *testing.T) {
+	handler := newTokenAuthTestHandler(&auth.APIToken{ID: uuid.New(), Scopes: []auth.TokenScope{auth.ScopeRead, auth.ScopeImport}})
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/api/items", http.StatusOK},
+		{http.MethodPost, "/api/items/import", http.StatusOK},
+		{http.MethodPost, "/api/imports/abc/cancel", http.StatusOK},
+		{http.MethodPost, "/api/items", http.StatusForbidden},
+		{http.MethodDelete, "/api/items/abc", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set("Authorization", "Bearer anth_secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Fatalf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.want, rec.Code)
+		}
+	}
+}
+
+func TestAuthMiddlewareRejectsBadBearer(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	handler := newTokenAuthTestHandler(&auth.APIToken{ID: uuid.New(), Scopes: []auth.TokenScope{auth.ScopeRead}, ExpiresAt: &expired})
+
+	for _, header := range []string{"Bearer anth_secret", "Basic dXNlcg==", "Bearer "} {
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("%q: expected status 401, got %d", header, rec.Code)
+		}
+	}
+}
+
+func TestSessionOnlyMiddlewareRejectsTokens(t *testing.T) {
+	next := newSessionOnlyMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tokens", nil)
+	ctx := context.WithValue(req.Context(), apiTokenContextKey, &auth.APIToken{ID: uuid.New()})
+	rec := httptest.NewRecorder()
+	next.ServeHTTP(rec, req.WithContext(ctx))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for token requests, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	next.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for session requests, got %d", rec.Code)
+	}
+}
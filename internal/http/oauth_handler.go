@@ -5,18 +5,23 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+
 	"anthology/internal/auth"
 )
 
-// oauthStatePayload holds the CSRF state and optional redirect path.
+// oauthStatePayload holds the CSRF state, the provider that issued it, and
+// the optional redirect path.
 type oauthStatePayload struct {
 	State      string `json:"s"`
+	Provider   string `json:"p"`
 	RedirectTo string `json:"r,omitempty"`
 }
 
@@ -60,15 +65,15 @@ const (
 	oauthStateCookieTTL  = 10 * time.Minute
 )
 
-type googleAuthenticator interface {
+type identityProvider interface {
 	AuthURL(state string) string
-	Exchange(ctx context.Context, code string) (*auth.GoogleClaims, error)
+	Exchange(ctx context.Context, code string) (*auth.Claims, error)
 	IsEmailAllowed(email string) bool
 }
 
-// OAuthHandler handles OAuth authentication endpoints.
+// OAuthHandler handles OAuth authentication endpoints for each configured identity provider.
 type OAuthHandler struct {
-	google       googleAuthenticator
+	providers    map[string]identityProvider
 	authService  *auth.Service
 	logger       *slog.Logger
 	secureCookie bool
@@ -76,9 +81,9 @@ type OAuthHandler struct {
 }
 
 // NewOAuthHandler creates a new OAuthHandler.
-func NewOAuthHandler(google googleAuthenticator, authService *auth.Service, frontendURL, env string, logger *slog.Logger) *OAuthHandler {
+func NewOAuthHandler(providers map[string]identityProvider, authService *auth.Service, frontendURL, env string, logger *slog.Logger) *OAuthHandler {
 	return &OAuthHandler{
-		google:       google,
+		providers:    providers,
 		authService:  authService,
 		logger:       logger,
 		secureCookie: !strings.EqualFold(env, "development"),
@@ -86,9 +91,16 @@ func NewOAuthHandler(google googleAuthenticator, authService *auth.Service, fron
 	}
 }
 
-// InitiateGoogle handles GET /api/auth/google
-// Redirects the user to Google's OAuth consent screen.
-func (h *OAuthHandler) InitiateGoogle(w http.ResponseWriter, r *http.Request) {
+// Initiate handles GET /api/auth/{provider}
+// Redirects the user to the provider's consent screen.
+func (h *OAuthHandler) Initiate(w http.ResponseWriter, r *http.Request) {
+	providerName := chi.URLParam(r, "provider")
+	provider, ok := h.providers[providerName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown identity provider")
+		return
+	}
+
 	state, err := auth.GenerateState()
 	if err != nil {
 		h.logger.Error("failed to generate state", "error", err)
@@ -109,7 +121,7 @@ func (h *OAuthHandler) InitiateGoogle(w http.ResponseWriter, r *http.Request) {
 
 	// Preserve redirectTo query param in state payload
 	redirectTo := r.URL.Query().Get("redirectTo")
-	payload := oauthStatePayload{State: state}
+	payload := oauthStatePayload{State: state, Provider: providerName}
 	if redirectTo != "" && isValidRedirectPath(redirectTo) {
 		payload.RedirectTo = redirectTo
 	}
@@ -118,13 +130,20 @@ func (h *OAuthHandler) InitiateGoogle(w http.ResponseWriter, r *http.Request) {
 	stateJSON, _ := json.Marshal(payload)
 	fullState := base64.RawURLEncoding.EncodeToString(stateJSON)
 
-	authURL := h.google.AuthURL(fullState)
+	authURL := provider.AuthURL(fullState)
 	http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
 }
 
-// CallbackGoogle handles GET /api/auth/google/callback
+// Callback handles GET /api/auth/{provider}/callback
 // Exchanges the authorization code for tokens, creates/updates user, issues session.
-func (h *OAuthHandler) CallbackGoogle(w http.ResponseWriter, r *http.Request) {
+func (h *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	providerName := chi.URLParam(r, "provider")
+	provider, ok := h.providers[providerName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown identity provider")
+		return
+	}
+
 	// Verify state (CSRF protection)
 	stateCookie, err := r.Cookie(oauthStateCookieName)
 	if err != nil {
@@ -157,8 +176,8 @@ func (h *OAuthHandler) CallbackGoogle(w http.ResponseWriter, r *http.Request) {
 		redirectTo = statePayload.RedirectTo
 	}
 
-	if subtle.ConstantTimeCompare([]byte(statePayload.State), []byte(expectedState)) != 1 {
-		h.logger.Warn("oauth callback: state mismatch")
+	if subtle.ConstantTimeCompare([]byte(statePayload.State), []byte(expectedState)) != 1 || statePayload.Provider != providerName {
+		h.logger.Warn("oauth callback: state mismatch", "provider", providerName)
 		h.redirectWithError(w, r, "invalid_request", "Invalid state. Please try again.")
 		return
 	}
@@ -173,9 +192,9 @@ func (h *OAuthHandler) CallbackGoogle(w http.ResponseWriter, r *http.Request) {
 		Secure:   h.secureCookie,
 	})
 
-	// Check for OAuth error from Google
+	// Check for OAuth error from the provider
 	if errParam := r.URL.Query().Get("error"); errParam != "" {
-		h.logger.Warn("oauth callback: provider error", "error", errParam)
+		h.logger.Warn("oauth callback: provider error", "provider", providerName, "error", errParam)
 		h.redirectWithError(w, r, errParam, r.URL.Query().Get("error_description"))
 		return
 	}
@@ -187,9 +206,9 @@ func (h *OAuthHandler) CallbackGoogle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims, err := h.google.Exchange(r.Context(), code)
+	claims, err := provider.Exchange(r.Context(), code)
 	if err != nil {
-		h.logger.Error("oauth callback: exchange failed", "error", err)
+		h.logger.Error("oauth callback: exchange failed", "provider", providerName, "error", err)
 		h.redirectWithError(w, r, "exchange_error", "Failed to complete authentication.")
 		return
 	}
@@ -197,19 +216,24 @@ func (h *OAuthHandler) CallbackGoogle(w http.ResponseWriter, r *http.Request) {
 	// Verify email is verified
 	if !claims.EmailVerified {
 		h.logger.Warn("oauth callback: email not verified", "email", claims.Email)
-		h.redirectWithError(w, r, "email_not_verified", "Please verify your Google email address.")
+		h.redirectWithError(w, r, "email_not_verified", "Please verify your email address with your identity provider.")
 		return
 	}
 
 	// Check allowlist
-	if !h.google.IsEmailAllowed(claims.Email) {
+	if !provider.IsEmailAllowed(claims.Email) {
 		h.logger.Warn("oauth callback: email not allowed", "email", claims.Email)
 		h.redirectWithError(w, r, "access_denied", "Your account is not authorized to access this application.")
 		return
 	}
 
 	// Create or update user
-	user, err := h.authService.CreateOrUpdateUser(r.Context(), claims)
+	user, err := h.authService.CreateOrUpdateUser(r.Context(), providerName, claims)
+	if errors.Is(err, auth.ErrEmailInUse) {
+		h.logger.Warn("oauth callback: email belongs to another account", "provider", providerName, "email", claims.Email)
+		h.redirectWithError(w, r, "account_exists", "An account already uses this email address. Sign in with the provider you used before.")
+		return
+	}
 	if err != nil {
 		h.logger.Error("oauth callback: user creation failed", "error", err)
 		h.redirectWithError(w, r, "internal_error", "Failed to create user account.")
@@ -232,10 +256,10 @@ func (h *OAuthHandler) CallbackGoogle(w http.ResponseWriter, r *http.Request) {
 		HttpOnly: true,
 		Secure:   h.secureCookie,
 		SameSite: http.SameSiteLaxMode,
-		MaxAge:   int(sessionCookieTTL.Seconds()),
+		MaxAge:   int(h.authService.SessionMaxLifetime().Seconds()),
 	})
 
-	h.logger.Info("oauth login successful", "user_id", user.ID, "email", user.Email)
+	h.logger.Info("oauth login successful", "provider", providerName, "user_id", user.ID, "email", user.Email)
 
 	// Redirect to frontend
 	http.Redirect(w, r, h.frontendURL+redirectTo, http.StatusTemporaryRedirect)
@@ -14,25 +14,32 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+
 	"anthology/internal/auth"
 )
 
 // encodeOAuthState creates a base64-encoded JSON state payload for testing
 func encodeOAuthState(state, redirectTo string) string {
-	payload := oauthStatePayload{State: state, RedirectTo: redirectTo}
+	return encodeProviderOAuthState(state, "google", redirectTo)
+}
+
+// encodeProviderOAuthState creates a state payload issued for the named provider.
+func encodeProviderOAuthState(state, provider, redirectTo string) string {
+	payload := oauthStatePayload{State: state, Provider: provider, RedirectTo: redirectTo}
 	data, _ := json.Marshal(payload)
 	return base64.RawURLEncoding.EncodeToString(data)
 }
 
-type fakeGoogleAuthenticator struct {
+type fakeIdentityProvider struct {
 	authURLBase    string
 	lastState      string
-	exchangeClaims *auth.GoogleClaims
+	exchangeClaims *auth.Claims
 	exchangeErr    error
 	allowEmail     bool
 }
 
-func (f *fakeGoogleAuthenticator) AuthURL(state string) string {
+func (f *fakeIdentityProvider) AuthURL(state string) string {
 	f.lastState = state
 	if f.authURLBase == "" {
 		f.authURLBase = "https://accounts.google.com/auth?state="
@@ -40,26 +47,33 @@ func (f *fakeGoogleAuthenticator) AuthURL(state string) string {
 	return f.authURLBase + state
 }
 
-func (f *fakeGoogleAuthenticator) Exchange(ctx context.Context, code string) (*auth.GoogleClaims, error) {
+func (f *fakeIdentityProvider) Exchange(ctx context.Context, code string) (*auth.Claims, error) {
 	if f.exchangeErr != nil {
 		return nil, f.exchangeErr
 	}
 	return f.exchangeClaims, nil
 }
 
-func (f *fakeGoogleAuthenticator) IsEmailAllowed(email string) bool {
+func (f *fakeIdentityProvider) IsEmailAllowed(email string) bool {
 	return f.allowEmail
 }
 
+func newOAuthTestRouter(handler *OAuthHandler) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/api/auth/{provider}", handler.Initiate)
+	r.Get("/api/auth/{provider}/callback", handler.Callback)
+	return r
+}
+
 func TestOAuthInitiateGoogleSetsStateCookieAndRedirects(t *testing.T) {
-	google := &fakeGoogleAuthenticator{allowEmail: true}
+	google := &fakeIdentityProvider{allowEmail: true}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google?redirectTo=/items", nil)
 	rec := httptest.NewRecorder()
 
-	handler.InitiateGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusTemporaryRedirect {
 		t.Fatalf("expected status 307, got %d", rec.Code)
@@ -99,14 +113,14 @@ func TestOAuthInitiateGoogleSetsStateCookieAndRedirects(t *testing.T) {
 }
 
 func TestOAuthCallbackRejectsMissingStateCookie(t *testing.T) {
-	google := &fakeGoogleAuthenticator{}
+	google := &fakeIdentityProvider{}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state=abc", nil)
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusTemporaryRedirect {
 		t.Fatalf("expected status 307, got %d", rec.Code)
@@ -117,9 +131,9 @@ func TestOAuthCallbackRejectsMissingStateCookie(t *testing.T) {
 }
 
 func TestOAuthCallbackRejectsStateMismatch(t *testing.T) {
-	google := &fakeGoogleAuthenticator{}
+	google := &fakeIdentityProvider{}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	// Encode state with wrong value
 	encodedState := encodeOAuthState("other", "")
@@ -127,7 +141,7 @@ func TestOAuthCallbackRejectsStateMismatch(t *testing.T) {
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "expected"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=invalid_request") {
 		t.Fatalf("expected invalid_request redirect, got %q", rec.Header().Get("Location"))
@@ -135,16 +149,16 @@ func TestOAuthCallbackRejectsStateMismatch(t *testing.T) {
 }
 
 func TestOAuthCallbackPropagatesProviderError(t *testing.T) {
-	google := &fakeGoogleAuthenticator{}
+	google := &fakeIdentityProvider{}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&error=access_denied&error_description=Denied", nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	location := rec.Header().Get("Location")
 	if !strings.Contains(location, "/login?error=access_denied") || !strings.Contains(location, "message=Denied") {
@@ -153,16 +167,16 @@ func TestOAuthCallbackPropagatesProviderError(t *testing.T) {
 }
 
 func TestOAuthCallbackRequiresCode(t *testing.T) {
-	google := &fakeGoogleAuthenticator{}
+	google := &fakeIdentityProvider{}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState), nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=invalid_request") {
 		t.Fatalf("expected invalid_request redirect, got %q", rec.Header().Get("Location"))
@@ -170,16 +184,16 @@ func TestOAuthCallbackRequiresCode(t *testing.T) {
 }
 
 func TestOAuthCallbackHandlesExchangeError(t *testing.T) {
-	google := &fakeGoogleAuthenticator{exchangeErr: errors.New("boom")}
+	google := &fakeIdentityProvider{exchangeErr: errors.New("boom")}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=exchange_error") {
 		t.Fatalf("expected exchange_error redirect, got %q", rec.Header().Get("Location"))
@@ -187,19 +201,19 @@ func TestOAuthCallbackHandlesExchangeError(t *testing.T) {
 }
 
 func TestOAuthCallbackRequiresVerifiedEmail(t *testing.T) {
-	google := &fakeGoogleAuthenticator{
-		exchangeClaims: &auth.GoogleClaims{Email: "user@example.com", EmailVerified: false},
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: false},
 		allowEmail:     true,
 	}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=email_not_verified") {
 		t.Fatalf("expected email_not_verified redirect, got %q", rec.Header().Get("Location"))
@@ -207,19 +221,19 @@ func TestOAuthCallbackRequiresVerifiedEmail(t *testing.T) {
 }
 
 func TestOAuthCallbackRejectsUnauthorizedEmail(t *testing.T) {
-	google := &fakeGoogleAuthenticator{
-		exchangeClaims: &auth.GoogleClaims{Email: "user@example.com", EmailVerified: true},
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: true},
 		allowEmail:     false,
 	}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, nil, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=access_denied") {
 		t.Fatalf("expected access_denied redirect, got %q", rec.Header().Get("Location"))
@@ -227,8 +241,8 @@ func TestOAuthCallbackRejectsUnauthorizedEmail(t *testing.T) {
 }
 
 func TestOAuthCallbackHandlesUserCreationError(t *testing.T) {
-	google := &fakeGoogleAuthenticator{
-		exchangeClaims: &auth.GoogleClaims{Email: "user@example.com", EmailVerified: true, Sub: "sub"},
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: true, Sub: "sub"},
 		allowEmail:     true,
 	}
 	repo := &authRepoStub{
@@ -238,23 +252,49 @@ func TestOAuthCallbackHandlesUserCreationError(t *testing.T) {
 	}
 	authService := auth.NewService(repo, time.Hour)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, authService, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, authService, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=internal_error") {
 		t.Fatalf("expected internal_error redirect, got %q", rec.Header().Get("Location"))
 	}
 }
 
+func TestOAuthCallbackReportsEmailInUse(t *testing.T) {
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: true, Sub: "sub"},
+		allowEmail:     true,
+	}
+	repo := &authRepoStub{
+		createUser: func(ctx context.Context, user auth.User) (auth.User, error) {
+			return auth.User{}, auth.ErrEmailInUse
+		},
+	}
+	authService := auth.NewService(repo, time.Hour)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, authService, "http://frontend.test", "development", logger)
+
+	encodedState := encodeOAuthState("abc", "")
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
+	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
+	rec := httptest.NewRecorder()
+
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Header().Get("Location"), "/login?error=account_exists") {
+		t.Fatalf("expected account_exists redirect, got %q", rec.Header().Get("Location"))
+	}
+}
+
 func TestOAuthCallbackHandlesSessionCreationError(t *testing.T) {
-	google := &fakeGoogleAuthenticator{
-		exchangeClaims: &auth.GoogleClaims{Email: "user@example.com", EmailVerified: true, Sub: "sub"},
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: true, Sub: "sub"},
 		allowEmail:     true,
 	}
 	repo := &authRepoStub{
@@ -267,14 +307,14 @@ func TestOAuthCallbackHandlesSessionCreationError(t *testing.T) {
 	}
 	authService := auth.NewService(repo, time.Hour)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, authService, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, authService, "http://frontend.test", "development", logger)
 
 	encodedState := encodeOAuthState("abc", "")
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "abc"})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if !strings.Contains(rec.Header().Get("Location"), "/login?error=internal_error") {
 		t.Fatalf("expected internal_error redirect, got %q", rec.Header().Get("Location"))
@@ -282,8 +322,8 @@ func TestOAuthCallbackHandlesSessionCreationError(t *testing.T) {
 }
 
 func TestOAuthCallbackSuccessRedirectsToFrontend(t *testing.T) {
-	google := &fakeGoogleAuthenticator{
-		exchangeClaims: &auth.GoogleClaims{Email: "user@example.com", EmailVerified: true, Sub: "sub", Name: "User"},
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: true, Sub: "sub", Name: "User"},
 		allowEmail:     true,
 	}
 	repo := &authRepoStub{
@@ -293,7 +333,7 @@ func TestOAuthCallbackSuccessRedirectsToFrontend(t *testing.T) {
 	}
 	authService := auth.NewService(repo, time.Hour)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, authService, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, authService, "http://frontend.test", "development", logger)
 
 	state := "state123"
 	encodedState := encodeOAuthState(state, "/items")
@@ -301,7 +341,7 @@ func TestOAuthCallbackSuccessRedirectsToFrontend(t *testing.T) {
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: state})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusTemporaryRedirect {
 		t.Fatalf("expected status 307, got %d", rec.Code)
@@ -324,8 +364,8 @@ func TestOAuthCallbackSuccessRedirectsToFrontend(t *testing.T) {
 }
 
 func TestOAuthCallbackSanitizesRedirectTo(t *testing.T) {
-	google := &fakeGoogleAuthenticator{
-		exchangeClaims: &auth.GoogleClaims{Email: "user@example.com", EmailVerified: true, Sub: "sub", Name: "User"},
+	google := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "user@example.com", EmailVerified: true, Sub: "sub", Name: "User"},
 		allowEmail:     true,
 	}
 	repo := &authRepoStub{
@@ -335,7 +375,7 @@ func TestOAuthCallbackSanitizesRedirectTo(t *testing.T) {
 	}
 	authService := auth.NewService(repo, time.Hour)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewOAuthHandler(google, authService, "http://frontend.test", "development", logger)
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, authService, "http://frontend.test", "development", logger)
 
 	state := "state123"
 	// The evil redirect URL should be rejected by isValidRedirectPath
@@ -344,7 +384,7 @@ func TestOAuthCallbackSanitizesRedirectTo(t *testing.T) {
 	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: state})
 	rec := httptest.NewRecorder()
 
-	handler.CallbackGoogle(rec, req)
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
 
 	location := rec.Header().Get("Location")
 	if location != "http://frontend.test/" {
@@ -352,6 +392,72 @@ func TestOAuthCallbackSanitizesRedirectTo(t *testing.T) {
 	}
 }
 
+func TestOAuthRejectsUnknownProvider(t *testing.T) {
+	google := &fakeIdentityProvider{allowEmail: true}
+	handler := NewOAuthHandler(map[string]identityProvider{"google": google}, nil, "http://frontend.test", "development", newTestLogger())
+
+	for _, path := range []string{"/api/auth/corp", "/api/auth/corp/callback?state=abc"} {
+		rec := httptest.NewRecorder()
+		newOAuthTestRouter(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected status 404, got %d", path, rec.Code)
+		}
+	}
+}
+
+func TestOAuthCallbackRejectsStateFromAnotherProvider(t *testing.T) {
+	providers := map[string]identityProvider{
+		"google": &fakeIdentityProvider{allowEmail: true},
+		"corp":   &fakeIdentityProvider{allowEmail: true},
+	}
+	handler := NewOAuthHandler(providers, nil, "http://frontend.test", "development", newTestLogger())
+
+	encodedState := encodeProviderOAuthState("state123", "google", "")
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/corp/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
+	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "state123"})
+	rec := httptest.NewRecorder()
+
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Header().Get("Location"), "/login?error=invalid_request") {
+		t.Fatalf("expected invalid_request redirect, got %q", rec.Header().Get("Location"))
+	}
+}
+
+func TestOAuthCallbackCreatesUserForOIDCProvider(t *testing.T) {
+	corp := &fakeIdentityProvider{
+		exchangeClaims: &auth.Claims{Email: "reader@example.com", EmailVerified: true, Sub: "corp-sub", Name: "Reader"},
+		allowEmail:     true,
+	}
+	var lookedUp, created string
+	repo := &authRepoStub{
+		findUserByOAuth: func(ctx context.Context, provider, providerID string) (*auth.User, error) {
+			lookedUp = provider + ":" + providerID
+			return nil, nil
+		},
+		createUser: func(ctx context.Context, user auth.User) (auth.User, error) {
+			created = user.OAuthProvider + ":" + user.OAuthProviderID
+			return user, nil
+		},
+	}
+	providers := map[string]identityProvider{"google": &fakeIdentityProvider{}, "corp": corp}
+	handler := NewOAuthHandler(providers, auth.NewService(repo, time.Hour), "http://frontend.test", "development", newTestLogger())
+
+	encodedState := encodeProviderOAuthState("state123", "corp", "/items")
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/corp/callback?state="+url.QueryEscape(encodedState)+"&code=123", nil)
+	req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: "state123"})
+	rec := httptest.NewRecorder()
+
+	newOAuthTestRouter(handler).ServeHTTP(rec, req)
+
+	if location := rec.Header().Get("Location"); location != "http://frontend.test/items" {
+		t.Fatalf("expected redirect to frontend, got %q", location)
+	}
+	if lookedUp != "corp:corp-sub" || created != "corp:corp-sub" {
+		t.Fatalf("expected the user to be keyed by the corp identity, looked up %q and created %q", lookedUp, created)
+	}
+}
+
 func TestIsValidRedirectPath(t *testing.T) {
 	tests := []struct {
 		name  string
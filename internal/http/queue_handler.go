@@ -0,0 +1,123 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/items"
+	"anthology/internal/queue"
+)
+
+// QueueHandler exposes the "Up Next" queue endpoints.
+type QueueHandler struct {
+	svc    *queue.Service
+	logger *slog.Logger
+}
+
+// NewQueueHandler constructs a QueueHandler.
+func NewQueueHandler(svc *queue.Service, logger *slog.Logger) *QueueHandler {
+	return &QueueHandler{svc: svc, logger: logger}
+}
+
+func (h *QueueHandler) handleQueueError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, queue.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item is not queued")
+	case errors.Is(err, queue.ErrEmpty):
+		writeError(w, http.StatusNotFound, "queue is empty")
+	case errors.Is(err, items.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item not found")
+	case errors.Is(err, queue.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("queue operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns in-progress entries and the ordered queue.
+func (h *QueueHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	result, err := h.svc.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleQueueError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Enqueue adds an item to the queue.
+func (h *QueueHandler) Enqueue(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var input queue.EnqueueInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	result, err := h.svc.Enqueue(r.Context(), input, user.ID)
+	if err != nil {
+		h.handleQueueError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// Move reorders a queued item.
+func (h *QueueHandler) Move(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "itemId")
+	if !ok {
+		return
+	}
+
+	var input queue.MoveInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	result, err := h.svc.Move(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleQueueError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Dequeue removes an item from the queue.
+func (h *QueueHandler) Dequeue(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "itemId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Dequeue(r.Context(), itemID, user.ID); err != nil {
+		h.handleQueueError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PopNext starts the first queued item.
+func (h *QueueHandler) PopNext(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	entry, err := h.svc.PopNext(r.Context(), user.ID)
+	if err != nil {
+		h.handleQueueError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/items"
+	"anthology/internal/queue"
+)
+
+func newQueueTestRouter(t *testing.T) (http.Handler, items.Item) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	item, err := items.NewService(itemsRepo).Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: "Hades", ItemType: items.ItemTypeGame})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+
+	handler := NewQueueHandler(queue.NewService(queue.NewInMemoryRepository(), itemsRepo), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/queue", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Enqueue)
+		r.Post("/next", handler.PopNext)
+		r.Put("/{itemId}", handler.Move)
+		r.Delete("/{itemId}", handler.Dequeue)
+	})
+	return r, item
+}
+
+func TestQueueHandlerEnqueueAndPop(t *testing.T) {
+	router, item := newQueueTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/queue", strings.NewReader(`{"itemId":"`+item.ID.String()+`"}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result queue.Queue
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(result.UpNext) != 1 || result.UpNext[0].Position != 1 || result.UpNext[0].Item == nil {
+		t.Fatalf("unexpected queue %+v", result)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/queue/next", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/queue/next", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for empty queue, got %d", rec.Code)
+	}
+}
+
+func TestQueueHandlerMoveAndDequeueErrors(t *testing.T) {
+	router, item := newQueueTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPut, "/queue/"+item.ID.String(), strings.NewReader(`{"position":1}`))))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for unqueued item, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/queue/not-a-uuid", nil)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for invalid id, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/queue", strings.NewReader(`{"itemId":"`+item.ID.String()+`","position":"first"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a malformed body, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithStranger(httptest.NewRequest(http.MethodPost, "/queue", strings.NewReader(`{"itemId":"`+item.ID.String()+`"}`))))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 when queueing another user's item, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/items"
+	"anthology/internal/reading"
+)
+
+// ReadingHandler exposes per-item reading history endpoints.
+type ReadingHandler struct {
+	svc    *reading.Service
+	logger *slog.Logger
+}
+
+// NewReadingHandler constructs a ReadingHandler.
+func NewReadingHandler(svc *reading.Service, logger *slog.Logger) *ReadingHandler {
+	return &ReadingHandler{svc: svc, logger: logger}
+}
+
+func (h *ReadingHandler) handleReadingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, reading.ErrNotFound):
+		writeError(w, http.StatusNotFound, "no read-through in progress")
+	case errors.Is(err, items.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item not found")
+	case errors.Is(err, reading.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("reading log operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// History returns every read-through and session for an item.
+func (h *ReadingHandler) History(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	history, err := h.svc.History(r.Context(), itemID, user.ID)
+	if err != nil {
+		h.handleReadingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+}
+
+// LogSession records a reading session and updates the item's progress.
+func (h *ReadingHandler) LogSession(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var input reading.LogSessionInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	result, err := h.svc.LogSession(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleReadingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// Start opens a new read-through for an item.
+func (h *ReadingHandler) Start(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	input, ok := decodeReadThroughInput(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.StartReadThrough(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleReadingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// Finish closes the open read-through and marks the item as read.
+func (h *ReadingHandler) Finish(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	input, ok := decodeReadThroughInput(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.FinishReadThrough(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleReadingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func decodeReadThroughInput(w http.ResponseWriter, r *http.Request) (reading.ReadThroughInput, bool) {
+	var input reading.ReadThroughInput
+	if r.ContentLength != 0 {
+		if err := decodeJSONBody(w, r, &input); err != nil {
+			writeJSONError(w, err)
+			return input, false
+		}
+	}
+	return input, true
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/items"
+	"anthology/internal/reading"
+)
+
+func newReadingTestRouter(t *testing.T) (http.Handler, items.Item) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	pageCount := 200
+	item, err := items.NewService(itemsRepo).Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook, PageCount: &pageCount})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+
+	handler := NewReadingHandler(reading.NewService(reading.NewInMemoryRepository(), itemsRepo), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/items/{id}/reading", func(r chi.Router) {
+		r.Get("/", handler.History)
+		r.Post("/sessions", handler.LogSession)
+		r.Post("/start", handler.Start)
+		r.Post("/finish", handler.Finish)
+	})
+	return r, item
+}
+
+func TestReadingHandlerLogSessionAndHistory(t *testing.T) {
+	router, item := newReadingTestRouter(t)
+	base := "/items/" + item.ID.String() + "/reading"
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/sessions", strings.NewReader(`{"date":"2024-03-01T20:00:00Z","endPage":80,"minutes":30}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result reading.SessionResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.Item.CurrentPage == nil || *result.Item.CurrentPage != 80 || result.Item.ReadingStatus != items.BookStatusReading {
+		t.Fatalf("unexpected item %+v", result.Item)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/finish", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, base, nil)))
+	var history reading.History
+	if err := json.NewDecoder(rec.Body).Decode(&history); err != nil {
+		t.Fatalf("decode history: %v", err)
+	}
+	if history.ReadCount != 1 || len(history.ReadThroughs) != 1 || len(history.ReadThroughs[0].Sessions) != 1 {
+		t.Fatalf("unexpected history %+v", history)
+	}
+}
+
+func TestReadingHandlerErrors(t *testing.T) {
+	router, item := newReadingTestRouter(t)
+	base := "/items/" + item.ID.String() + "/reading"
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/finish", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 without an open read-through, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/sessions", strings.NewReader(`{"endPage":500}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 past the page count, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, base+"/sessions", strings.NewReader(`{"endPage":"eighty"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a malformed body, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithStranger(httptest.NewRequest(http.MethodPost, base+"/sessions", strings.NewReader(`{"endPage":20}`))))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 on another user's item, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithStranger(httptest.NewRequest(http.MethodGet, base, nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for another user's history, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"anthology/internal/items"
+	"anthology/internal/reviews"
+)
+
+// ReviewHandler exposes review endpoints.
+type ReviewHandler struct {
+	svc    *reviews.Service
+	logger *slog.Logger
+}
+
+// NewReviewHandler constructs a ReviewHandler.
+func NewReviewHandler(svc *reviews.Service, logger *slog.Logger) *ReviewHandler {
+	return &ReviewHandler{svc: svc, logger: logger}
+}
+
+func (h *ReviewHandler) handleReviewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, reviews.ErrNotFound):
+		writeError(w, http.StatusNotFound, "review not found")
+	case errors.Is(err, items.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item not found")
+	case errors.Is(err, reviews.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("review operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns reviews across the catalog, optionally narrowed by item type.
+func (h *ReviewHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	opts := reviews.ListOptions{}
+	if rawType := strings.TrimSpace(r.URL.Query().Get("type")); rawType != "" {
+		typeValue := items.ItemType(rawType)
+		switch typeValue {
+		case items.ItemTypeBook, items.ItemTypeGame, items.ItemTypeMovie, items.ItemTypeMusic:
+			opts.ItemType = &typeValue
+		default:
+			writeError(w, http.StatusBadRequest, "invalid type filter")
+			return
+		}
+	}
+
+	list, err := h.svc.List(r.Context(), user.ID, opts)
+	if err != nil {
+		h.handleReviewError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"reviews": list})
+}
+
+// ListForItem returns an item's reviews.
+func (h *ReviewHandler) ListForItem(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.svc.ListForItem(r.Context(), itemID, user.ID)
+	if err != nil {
+		h.handleReviewError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"reviews": list})
+}
+
+// Create adds a review to an item.
+func (h *ReviewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	itemID, ok := parseUUIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var input reviews.ReviewInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	review, err := h.svc.Create(r.Context(), itemID, input, user.ID)
+	if err != nil {
+		h.handleReviewError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, review)
+}
+
+// Get returns a single review.
+func (h *ReviewHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "reviewId")
+	if !ok {
+		return
+	}
+
+	review, err := h.svc.Get(r.Context(), id, user.ID)
+	if err != nil {
+		h.handleReviewError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, review)
+}
+
+// Update edits a review.
+func (h *ReviewHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "reviewId")
+	if !ok {
+		return
+	}
+
+	var input reviews.ReviewInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	review, err := h.svc.Update(r.Context(), id, input, user.ID)
+	if err != nil {
+		h.handleReviewError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, review)
+}
+
+// Delete removes a review.
+func (h *ReviewHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "reviewId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), id, user.ID); err != nil {
+		h.handleReviewError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
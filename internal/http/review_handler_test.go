@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"anthology/internal/items"
+	"anthology/internal/reviews"
+)
+
+func newReviewTestRouter(t *testing.T) (http.Handler, items.Item) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	item, err := items.NewService(itemsRepo).Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+
+	handler := NewReviewHandler(reviews.NewService(reviews.NewInMemoryRepository(), itemsRepo), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/items/{id}/reviews", func(r chi.Router) {
+		r.Get("/", handler.ListForItem)
+		r.Post("/", handler.Create)
+	})
+	r.Route("/reviews", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Get("/{reviewId}", handler.Get)
+		r.Put("/{reviewId}", handler.Update)
+		r.Delete("/{reviewId}", handler.Delete)
+	})
+	return r, item
+}
+
+func TestReviewHandlerCreateEditAndList(t *testing.T) {
+	router, item := newReviewTestRouter(t)
+
+	body := `{"title":"Spice","body":"Slow start.","sections":[{"heading":"Ending","body":"Twist","spoiler":true}],"readOn":"2026-04-20T00:00:00Z"}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/items/"+item.ID.String()+"/reviews", strings.NewReader(body))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created reviews.Review
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if created.Title != "Spice" || len(created.Sections) != 1 || !created.Sections[0].Spoiler || created.ReadOn == nil {
+		t.Fatalf("unexpected review %+v", created)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPut, "/reviews/"+created.ID.String(), strings.NewReader(`{"title":"Spice","body":"Better on reread."}`))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/reviews?type=book", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var list struct {
+		Reviews []reviews.Review `json:"reviews"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(list.Reviews) != 1 || list.Reviews[0].Body != "Better on reread." || list.Reviews[0].Item == nil {
+		t.Fatalf("unexpected reviews %+v", list.Reviews)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/reviews/"+created.ID.String(), nil)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+}
+
+func TestReviewHandlerValidation(t *testing.T) {
+	router, item := newReviewTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/items/"+item.ID.String()+"/reviews", strings.NewReader(`{"title":"Empty"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for empty review, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/reviews?type=comic", nil)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown type, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/items/"+item.ID.String()+"/reviews", strings.NewReader(`{"body":["not","text"]}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a malformed body, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithStranger(httptest.NewRequest(http.MethodPost, "/items/"+item.ID.String()+"/reviews", strings.NewReader(`{"body":"Mine now"}`))))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 when reviewing another user's item, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithStranger(httptest.NewRequest(http.MethodGet, "/items/"+item.ID.String()+"/reviews", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for another user's reviews, got %d", rec.Code)
+	}
+}
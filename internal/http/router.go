@@ -10,16 +10,28 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
+	"anthology/internal/archive"
 	"anthology/internal/auth"
+	"anthology/internal/batches"
 	"anthology/internal/catalog"
 	"anthology/internal/config"
+	"anthology/internal/goals"
 	"anthology/internal/importer"
+	"anthology/internal/imports"
 	"anthology/internal/items"
+	"anthology/internal/loans"
+	"anthology/internal/maintenance"
+	"anthology/internal/mappings"
+	"anthology/internal/queue"
+	"anthology/internal/reading"
+	"anthology/internal/reviews"
+	"anthology/internal/sharing"
 	"anthology/internal/shelves"
+	"anthology/internal/stats"
 )
 
 // NewRouter wires application routes and middleware using chi.
-func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Service, shelfSvc *shelves.Service, authService *auth.Service, googleAuth *auth.GoogleAuthenticator, logger *slog.Logger) http.Handler {
+func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Service, shelfSvc *shelves.Service, loanSvc *loans.Service, readingSvc *reading.Service, statsSvc *stats.Service, goalSvc *goals.Service, queueSvc *queue.Service, reviewSvc *reviews.Service, archiveSvc *archive.Service, bulkImporter *importer.CSVImporter, importSvc *imports.Service, mappingSvc *mappings.Service, batchSvc *batches.Service, sharingSvc *sharing.Service, scheduler *maintenance.Scheduler, authService *auth.Service, identityProviders []*auth.OIDCAuthenticator, logger *slog.Logger) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -44,19 +56,37 @@ func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Servic
 	})
 
 	sessionHandler := NewSessionHandler(authService, cfg.Environment, logger)
-	bulkImporter := importer.NewCSVImporter(svc, catalogSvc)
-	handler := NewItemHandler(svc, catalogSvc, bulkImporter, logger)
+	tokenHandler := NewTokenHandler(authService, logger)
+	handler := NewItemHandler(svc, catalogSvc, reviewSvc, bulkImporter, logger)
 	catalogHandler := NewCatalogHandler(catalogSvc, logger)
+	catalogAdminHandler := NewCatalogAdminHandler(catalogSvc, logger)
+	maintenanceHandler := NewMaintenanceHandler(scheduler, logger)
 	shelfHandler := NewShelfHandler(shelfSvc, logger)
 	seriesHandler := NewSeriesHandler(svc, logger)
+	tagHandler := NewTagHandler(svc, logger)
+	loanHandler := NewLoanHandler(loanSvc, logger)
+	readingHandler := NewReadingHandler(readingSvc, logger)
+	statsHandler := NewStatsHandler(statsSvc, logger)
+	goalHandler := NewGoalHandler(goalSvc, logger)
+	queueHandler := NewQueueHandler(queueSvc, logger)
+	reviewHandler := NewReviewHandler(reviewSvc, logger)
+	archiveHandler := NewArchiveHandler(archiveSvc, logger)
+	importJobHandler := NewImportJobHandler(importSvc, logger)
+	mappingHandler := NewMappingHandler(mappingSvc, logger)
+	batchHandler := NewBatchHandler(batchSvc, logger)
+	shareHandler := NewShareHandler(sharingSvc, logger)
 
 	r.Route("/api", func(r chi.Router) {
 		// OAuth routes (unauthenticated)
-		if googleAuth != nil {
-			oauthHandler := NewOAuthHandler(googleAuth, authService, cfg.FrontendURL, cfg.Environment, logger)
+		if len(identityProviders) > 0 {
+			providers := make(map[string]identityProvider, len(identityProviders))
+			for _, provider := range identityProviders {
+				providers[provider.Name()] = provider
+			}
+			oauthHandler := NewOAuthHandler(providers, authService, cfg.FrontendURL, cfg.Environment, logger)
 			r.Route("/auth", func(r chi.Router) {
-				r.Get("/google", oauthHandler.InitiateGoogle)
-				r.Get("/google/callback", oauthHandler.CallbackGoogle)
+				r.Get("/{provider}", oauthHandler.Initiate)
+				r.Get("/{provider}/callback", oauthHandler.Callback)
 			})
 		}
 
@@ -73,6 +103,34 @@ func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Servic
 			// User info endpoint
 			r.Get("/session/user", sessionHandler.CurrentUser)
 
+			// Sessions can only be listed and revoked from a signed-in session.
+			r.Group(func(r chi.Router) {
+				r.Use(newSessionOnlyMiddleware())
+				r.Get("/session/all", sessionHandler.ListAll)
+				r.Delete("/session/all", sessionHandler.RevokeAll)
+				r.Delete("/session/{sessionId}", sessionHandler.Revoke)
+			})
+
+			// Personal API tokens can only be managed from a signed-in session.
+			r.Route("/tokens", func(r chi.Router) {
+				r.Use(newSessionOnlyMiddleware())
+				r.Get("/", tokenHandler.List)
+				r.Post("/", tokenHandler.Create)
+				r.Delete("/{tokenId}", tokenHandler.Revoke)
+			})
+
+			// Library shares can only be managed from a signed-in session.
+			r.Route("/shares", func(r chi.Router) {
+				r.Use(newSessionOnlyMiddleware())
+				r.Get("/", shareHandler.List)
+				r.Post("/", shareHandler.Invite)
+				r.Put("/{shareId}", shareHandler.UpdateRole)
+				r.Delete("/{shareId}", shareHandler.Revoke)
+				r.Get("/incoming", shareHandler.ListIncoming)
+				r.Post("/incoming/{shareId}/accept", shareHandler.Accept)
+				r.Delete("/incoming/{shareId}", shareHandler.Decline)
+			})
+
 			r.Route("/items", func(r chi.Router) {
 				r.Get("/", handler.List)
 				r.Get("/histogram", handler.Histogram)
@@ -80,11 +138,27 @@ func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Servic
 				r.Get("/export", handler.ExportCSV)
 				r.Post("/", handler.Create)
 				r.Post("/import", handler.ImportCSV)
+				r.Post("/import/commit", handler.CommitImport)
 				r.Route("/{id}", func(r chi.Router) {
 					r.Get("/", handler.Get)
 					r.Put("/", handler.Update)
 					r.Delete("/", handler.Delete)
 					r.Post("/resync", handler.Resync)
+					r.Route("/loans", func(r chi.Router) {
+						r.Get("/", loanHandler.ListItemLoans)
+						r.Post("/", loanHandler.Lend)
+						r.Post("/return", loanHandler.Return)
+					})
+					r.Route("/reading", func(r chi.Router) {
+						r.Get("/", readingHandler.History)
+						r.Post("/sessions", readingHandler.LogSession)
+						r.Post("/start", readingHandler.Start)
+						r.Post("/finish", readingHandler.Finish)
+					})
+					r.Route("/reviews", func(r chi.Router) {
+						r.Get("/", reviewHandler.ListForItem)
+						r.Post("/", reviewHandler.Create)
+					})
 				})
 			})
 			r.Route("/series", func(r chi.Router) {
@@ -93,6 +167,75 @@ func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Servic
 				r.Put("/detail", seriesHandler.Update)
 				r.Delete("/detail", seriesHandler.Delete)
 			})
+			r.Route("/reviews", func(r chi.Router) {
+				r.Get("/", reviewHandler.List)
+				r.Get("/{reviewId}", reviewHandler.Get)
+				r.Put("/{reviewId}", reviewHandler.Update)
+				r.Delete("/{reviewId}", reviewHandler.Delete)
+			})
+			r.Route("/imports", func(r chi.Router) {
+				r.Get("/", importJobHandler.List)
+				r.Post("/", importJobHandler.Start)
+				r.Get("/{id}", importJobHandler.Get)
+				r.Post("/{id}/cancel", importJobHandler.Cancel)
+			})
+			r.Route("/import-batches", func(r chi.Router) {
+				r.Get("/", batchHandler.List)
+				r.Get("/{batchId}", batchHandler.Get)
+				r.Post("/{batchId}/rollback", batchHandler.Rollback)
+			})
+			r.Route("/import-mappings", func(r chi.Router) {
+				r.Get("/", mappingHandler.List)
+				r.Post("/", mappingHandler.Create)
+				r.Get("/{mappingId}", mappingHandler.Get)
+				r.Put("/{mappingId}", mappingHandler.Update)
+				r.Delete("/{mappingId}", mappingHandler.Delete)
+			})
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/", archiveHandler.Export)
+				r.Post("/restore", archiveHandler.Restore)
+			})
+			r.Route("/queue", func(r chi.Router) {
+				r.Get("/", queueHandler.List)
+				r.Post("/", queueHandler.Enqueue)
+				r.Post("/next", queueHandler.PopNext)
+				r.Put("/{itemId}", queueHandler.Move)
+				r.Delete("/{itemId}", queueHandler.Dequeue)
+			})
+			r.Route("/loans", func(r chi.Router) {
+				r.Get("/", loanHandler.ListActive)
+				r.Get("/overdue", loanHandler.ListOverdue)
+			})
+			r.Route("/borrowers", func(r chi.Router) {
+				r.Get("/", loanHandler.ListBorrowers)
+				r.Post("/", loanHandler.CreateBorrower)
+				r.Put("/{borrowerId}", loanHandler.UpdateBorrower)
+				r.Delete("/{borrowerId}", loanHandler.DeleteBorrower)
+			})
+			r.Route("/tags", func(r chi.Router) {
+				r.Get("/", tagHandler.List)
+				r.Post("/", tagHandler.Create)
+				r.Post("/merge", tagHandler.Merge)
+				r.Put("/detail", tagHandler.Update)
+				r.Delete("/detail", tagHandler.Delete)
+			})
+			r.Route("/stats", func(r chi.Router) {
+				r.Get("/", statsHandler.Dashboard)
+				r.Get("/reading", statsHandler.Reading)
+				r.Get("/additions", statsHandler.Additions)
+				r.Get("/collection", statsHandler.Collection)
+				r.Get("/shelves", statsHandler.Shelves)
+			})
+			r.Route("/goals", func(r chi.Router) {
+				r.Get("/", goalHandler.List)
+				r.Post("/", goalHandler.Create)
+				r.Get("/streaks", goalHandler.Streaks)
+				r.Route("/{goalId}", func(r chi.Router) {
+					r.Get("/", goalHandler.Get)
+					r.Put("/", goalHandler.Update)
+					r.Delete("/", goalHandler.Delete)
+				})
+			})
 			r.Route("/shelves", func(r chi.Router) {
 				r.Get("/", shelfHandler.List)
 				r.Post("/", shelfHandler.Create)
@@ -111,6 +254,17 @@ func NewRouter(cfg config.Config, svc *items.Service, catalogSvc *catalog.Servic
 			r.Route("/catalog", func(r chi.Router) {
 				r.Get("/lookup", catalogHandler.Lookup)
 			})
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(newAdminMiddleware(cfg.AdminEmails))
+				r.Route("/catalog/cache", func(r chi.Router) {
+					r.Get("/", catalogAdminHandler.CacheStats)
+					r.Delete("/", catalogAdminHandler.PurgeCache)
+				})
+				r.Route("/maintenance", func(r chi.Router) {
+					r.Get("/jobs", maintenanceHandler.Jobs)
+					r.Get("/runs", maintenanceHandler.Runs)
+				})
+			})
 		})
 	})
 
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"log/slog"
 	"net"
 	"net/http"
@@ -10,10 +11,7 @@ import (
 	"anthology/internal/auth"
 )
 
-const (
-	sessionCookieName = "anthology_session"
-	sessionCookieTTL  = 12 * time.Hour
-)
+const sessionCookieName = "anthology_session"
 
 // SessionHandler manages OAuth-authenticated sessions using HttpOnly cookies.
 type SessionHandler struct {
@@ -80,7 +78,83 @@ func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Clear the cookie
+	h.clearCookie(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionResponse describes one of the user's sessions and whether it made the request.
+type sessionResponse struct {
+	auth.Session
+	Current bool `json:"current"`
+}
+
+func (h *SessionHandler) handleSessionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrSessionNotFound):
+		writeError(w, http.StatusNotFound, "session not found")
+	default:
+		h.logger.Error("session operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// ListAll returns the user's active sessions with device details, most recently seen first.
+func (h *SessionHandler) ListAll(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+	current := SessionFromContext(r.Context())
+
+	sessions, err := h.authService.ListSessions(r.Context(), user.ID)
+	if err != nil {
+		h.handleSessionError(w, err)
+		return
+	}
+
+	response := make([]sessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, sessionResponse{
+			Session: session,
+			Current: current != nil && session.ID == current.ID,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"sessions": response})
+}
+
+// Revoke ends one of the user's sessions. Revoking the current session also clears its cookie.
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "sessionId")
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeSession(r.Context(), user.ID, id); err != nil {
+		h.handleSessionError(w, err)
+		return
+	}
+
+	if current := SessionFromContext(r.Context()); current != nil && current.ID == id {
+		h.clearCookie(w)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAll signs the user out everywhere, including the current session.
+func (h *SessionHandler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	revoked, err := h.authService.RevokeAllSessions(r.Context(), user.ID)
+	if err != nil {
+		h.handleSessionError(w, err)
+		return
+	}
+
+	h.clearCookie(w)
+	writeJSON(w, http.StatusOK, map[string]any{"revoked": revoked})
+}
+
+// clearCookie tells the browser to drop the session cookie.
+func (h *SessionHandler) clearCookie(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     sessionCookieName,
 		Value:    "",
@@ -91,8 +165,6 @@ func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		MaxAge:   -1,
 		Expires:  time.Unix(0, 0),
 	})
-
-	w.WriteHeader(http.StatusNoContent)
 }
 
 // CurrentUser returns the authenticated user's information.
@@ -13,6 +13,7 @@ import (
 
 	"anthology/internal/auth"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
@@ -131,6 +132,100 @@ func TestSessionHandlerLogoutDeletesSession(t *testing.T) {
 	}
 }
 
+func TestSessionHandlerListsAndRevokesSessions(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	current := auth.Session{ID: uuid.New(), UserID: userID, CreatedAt: now, LastSeenAt: now, ExpiresAt: now.Add(time.Hour), UserAgent: "Firefox"}
+	other := auth.Session{ID: uuid.New(), UserID: userID, CreatedAt: now, LastSeenAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour), UserAgent: "Safari"}
+	sessions := []auth.Session{current, other}
+	repo := &authRepoStub{
+		findSessionByHash: func(ctx context.Context, tokenHash string) (*auth.Session, *auth.User, error) {
+			session := current
+			return &session, &auth.User{ID: userID}, nil
+		},
+		listSessions: func(ctx context.Context, id uuid.UUID) ([]auth.Session, error) {
+			return sessions, nil
+		},
+		deleteUserSession: func(ctx context.Context, id uuid.UUID, owner uuid.UUID) error {
+			for i, session := range sessions {
+				if session.ID == id && owner == userID {
+					sessions = append(sessions[:i], sessions[i+1:]...)
+					return nil
+				}
+			}
+			return auth.ErrSessionNotFound
+		},
+		deleteUserSessions: func(ctx context.Context, owner uuid.UUID) (int64, error) {
+			revoked := int64(len(sessions))
+			sessions = nil
+			return revoked, nil
+		},
+	}
+	authService := auth.NewService(repo, time.Hour)
+	handler := NewSessionHandler(authService, "development", newTestLogger())
+
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		r.Route("/session", func(r chi.Router) {
+			r.Get("/", handler.Status)
+			r.Delete("/", handler.Logout)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(newAuthMiddleware(authService, newTestLogger()))
+			r.Get("/session/all", handler.ListAll)
+			r.Delete("/session/all", handler.RevokeAll)
+			r.Delete("/session/{sessionId}", handler.Revoke)
+		})
+	})
+	withCookie := func(req *http.Request) *http.Request {
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "token"})
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, withCookie(httptest.NewRequest(http.MethodGet, "/api/session/all", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var listed struct {
+		Sessions []struct {
+			ID        uuid.UUID `json:"id"`
+			UserAgent string    `json:"userAgent"`
+			Current   bool      `json:"current"`
+		} `json:"sessions"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(listed.Sessions) != 2 || !listed.Sessions[0].Current || listed.Sessions[1].Current || listed.Sessions[1].UserAgent != "Safari" {
+		t.Fatalf("unexpected sessions %+v", listed.Sessions)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, withCookie(httptest.NewRequest(http.MethodDelete, "/api/session/"+other.ID.String(), nil)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(rec.Result().Cookies()) != 0 {
+		t.Fatal("revoking another session must keep the current cookie")
+	}
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, withCookie(httptest.NewRequest(http.MethodDelete, "/api/session/"+other.ID.String(), nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a revoked session, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, withCookie(httptest.NewRequest(http.MethodDelete, "/api/session/all", nil)))
+	if rec.Code != http.StatusOK || len(sessions) != 0 {
+		t.Fatalf("expected every session revoked, got %d with %d left", rec.Code, len(sessions))
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != sessionCookieName || cookies[0].MaxAge >= 0 {
+		t.Fatalf("expected the session cookie to be cleared, got %+v", cookies)
+	}
+}
+
 func TestClientIPFromRequestRemoteAddr(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.RemoteAddr = "192.0.2.1:12345"
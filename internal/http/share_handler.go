@@ -0,0 +1,156 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/items"
+	"anthology/internal/sharing"
+)
+
+// ShareHandler manages invitations to view or edit another user's library.
+type ShareHandler struct {
+	svc    *sharing.Service
+	logger *slog.Logger
+}
+
+// NewShareHandler constructs a ShareHandler.
+func NewShareHandler(svc *sharing.Service, logger *slog.Logger) *ShareHandler {
+	return &ShareHandler{svc: svc, logger: logger}
+}
+
+func (h *ShareHandler) handleShareError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sharing.ErrNotFound):
+		writeError(w, http.StatusNotFound, "share not found")
+	case errors.Is(err, sharing.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("share operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns the shares the user has made.
+func (h *ShareHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	shares, err := h.svc.ListOutgoing(r.Context(), user.ID)
+	if err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"shares": shares})
+}
+
+// Invite shares the user's catalog or one of their shelves with an email address.
+func (h *ShareHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var input sharing.InviteInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	share, err := h.svc.Invite(r.Context(), user, input)
+	if err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, share)
+}
+
+// UpdateRole changes whether the invitee may view or edit.
+func (h *ShareHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "shareId")
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Role items.Role `json:"role"`
+	}
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	share, err := h.svc.UpdateRole(r.Context(), id, user.ID, input.Role)
+	if err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, share)
+}
+
+// Revoke ends a share the user made.
+func (h *ShareHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "shareId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Revoke(r.Context(), id, user.ID); err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListIncoming returns libraries shared with the user and invitations waiting for them.
+func (h *ShareHandler) ListIncoming(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	shares, err := h.svc.ListIncoming(r.Context(), user)
+	if err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"shares": shares})
+}
+
+// Accept takes up an invitation sent to the user's email address.
+func (h *ShareHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "shareId")
+	if !ok {
+		return
+	}
+
+	share, err := h.svc.Accept(r.Context(), id, user)
+	if err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, share)
+}
+
+// Decline turns down an invitation or leaves a library shared with the user.
+func (h *ShareHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "shareId")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Decline(r.Context(), id, user); err != nil {
+		h.handleShareError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"anthology/internal/auth"
+	"anthology/internal/items"
+	"anthology/internal/sharing"
+	"anthology/internal/shelves"
+)
+
+func TestShareHandlerInviteAcceptAndRevoke(t *testing.T) {
+	svc := sharing.NewService(sharing.NewInMemoryRepository(), shelves.NewInMemoryRepository())
+	handler := NewShareHandler(svc, newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/shares", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Invite)
+		r.Put("/{shareId}", handler.UpdateRole)
+		r.Delete("/{shareId}", handler.Revoke)
+		r.Get("/incoming", handler.ListIncoming)
+		r.Post("/incoming/{shareId}/accept", handler.Accept)
+		r.Delete("/incoming/{shareId}", handler.Decline)
+	})
+	partner := &auth.User{ID: uuid.New(), Email: "partner@example.com"}
+	asPartner := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), userContextKey, partner))
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/shares", strings.NewReader(`{"email":"partner@example.com","role":"superuser"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown role, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/shares", strings.NewReader(`{"email":"partner@example.com","role":"viewer"}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var share sharing.Share
+	if err := json.NewDecoder(rec.Body).Decode(&share); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if share.Status != sharing.StatusPending || share.Scope != sharing.ScopeCatalog {
+		t.Fatalf("unexpected share %+v", share)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/shares/incoming/"+share.ID.String()+"/accept", nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the owner to be unable to accept, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, asPartner(httptest.NewRequest(http.MethodPost, "/shares/incoming/"+share.ID.String()+"/accept", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPut, "/shares/"+share.ID.String(), strings.NewReader(`{"role":"editor"}`))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	grants, err := svc.AccessGrants(context.Background(), partner.ID)
+	if err != nil || len(grants) != 1 || grants[0].Role != items.RoleEditor || grants[0].OwnerID != testOwnerID {
+		t.Fatalf("expected an editor grant, got %+v (%v)", grants, err)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, asPartner(httptest.NewRequest(http.MethodDelete, "/shares/"+share.ID.String(), nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected only the owner to revoke, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/shares/"+share.ID.String(), nil)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, asPartner(httptest.NewRequest(http.MethodGet, "/shares/incoming", nil)))
+	var incoming struct {
+		Shares []sharing.Share `json:"shares"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&incoming); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(incoming.Shares) != 0 {
+		t.Fatalf("expected revoked share to disappear, got %+v", incoming.Shares)
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"anthology/internal/stats"
+)
+
+// StatsHandler exposes aggregate statistics about the owner's catalog.
+type StatsHandler struct {
+	svc    *stats.Service
+	logger *slog.Logger
+}
+
+// NewStatsHandler constructs a StatsHandler.
+func NewStatsHandler(svc *stats.Service, logger *slog.Logger) *StatsHandler {
+	return &StatsHandler{svc: svc, logger: logger}
+}
+
+func (h *StatsHandler) handleStatsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, stats.ErrValidation) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.logger.Error("stats", "error", err)
+	writeError(w, http.StatusInternalServerError, "failed to compute statistics")
+}
+
+// Dashboard returns every statistic in one payload.
+func (h *StatsHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.parseOptions(w, r)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.svc.Dashboard(r.Context(), opts)
+	if err != nil {
+		h.handleStatsError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dashboard)
+}
+
+// Reading returns books and pages read per year and month.
+func (h *StatsHandler) Reading(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.parseOptions(w, r)
+	if !ok {
+		return
+	}
+
+	reading, err := h.svc.Reading(r.Context(), opts)
+	if err != nil {
+		h.handleStatsError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reading)
+}
+
+// Additions returns items added to the collection per month.
+func (h *StatsHandler) Additions(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.parseOptions(w, r)
+	if !ok {
+		return
+	}
+
+	additions, err := h.svc.Additions(r.Context(), opts)
+	if err != nil {
+		h.handleStatsError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, additions)
+}
+
+// Collection returns type, genre, and format distribution with retail value.
+func (h *StatsHandler) Collection(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	collection, err := h.svc.Collection(r.Context(), user.ID)
+	if err != nil {
+		h.handleStatsError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, collection)
+}
+
+// Shelves returns slot fill rates per shelf.
+func (h *StatsHandler) Shelves(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	shelfStats, err := h.svc.Shelves(r.Context(), user.ID)
+	if err != nil {
+		h.handleStatsError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, shelfStats)
+}
+
+func (h *StatsHandler) parseOptions(w http.ResponseWriter, r *http.Request) (stats.Options, bool) {
+	user := UserFromContext(r.Context())
+	opts := stats.Options{OwnerID: user.ID}
+
+	if rawYear := strings.TrimSpace(r.URL.Query().Get("year")); rawYear != "" {
+		year, err := strconv.Atoi(rawYear)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid year")
+			return stats.Options{}, false
+		}
+		opts.Year = &year
+	}
+
+	return opts, true
+}
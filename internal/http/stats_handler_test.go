@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+	"anthology/internal/stats"
+)
+
+func newStatsTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	pages := 320
+	readAt := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	itemsRepo := items.NewInMemoryRepository([]items.Item{
+		{ID: uuid.New(), OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook, PageCount: &pages, ReadingStatus: items.BookStatusRead, ReadAt: &readAt, CreatedAt: readAt},
+	})
+
+	handler := NewStatsHandler(stats.NewService(stats.NewInMemoryRepository(itemsRepo, shelves.NewInMemoryRepository())), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/stats", func(r chi.Router) {
+		r.Get("/", handler.Dashboard)
+		r.Get("/reading", handler.Reading)
+	})
+	return r
+}
+
+func TestStatsHandlerDashboard(t *testing.T) {
+	router := newStatsTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/stats", nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var dashboard stats.Dashboard
+	if err := json.NewDecoder(rec.Body).Decode(&dashboard); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if dashboard.Reading.TotalBooks != 1 || dashboard.Reading.TotalPages != 320 || dashboard.Collection.TotalItems != 1 {
+		t.Fatalf("unexpected dashboard %+v", dashboard)
+	}
+}
+
+func TestStatsHandlerRejectsInvalidYear(t *testing.T) {
+	router := newStatsTestRouter(t)
+
+	for _, year := range []string{"soon", "99"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/stats/reading?year="+year, nil)))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("year %q: expected status 400, got %d", year, rec.Code)
+		}
+	}
+}
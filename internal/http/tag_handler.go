@@ -0,0 +1,149 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"anthology/internal/items"
+)
+
+// TagHandler exposes tag management endpoints.
+type TagHandler struct {
+	service *items.Service
+	logger  *slog.Logger
+}
+
+// NewTagHandler creates a handler.
+func NewTagHandler(service *items.Service, logger *slog.Logger) *TagHandler {
+	return &TagHandler{service: service, logger: logger}
+}
+
+// List returns the user's tags with item counts.
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	tags, err := h.service.ListTags(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("list tags", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to list tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"tags": tags})
+}
+
+// Create defines a new tag.
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := decodeJSONBody(w, r, &body); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	tag, err := h.service.CreateTag(r.Context(), body.Name, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, items.ErrValidation):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.Error("create tag", "error", err)
+			writeError(w, http.StatusInternalServerError, "failed to create tag")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tag)
+}
+
+// Update renames a tag on every item that carries it.
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "tag name is required")
+		return
+	}
+
+	var body struct {
+		NewName string `json:"newName"`
+	}
+	if err := decodeJSONBody(w, r, &body); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	if strings.TrimSpace(body.NewName) == "" {
+		writeError(w, http.StatusBadRequest, "new tag name is required")
+		return
+	}
+
+	tag, err := h.service.RenameTag(r.Context(), name, body.NewName, user.ID)
+	if err != nil {
+		h.writeTagError(w, err, "rename tag", "failed to rename tag")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tag)
+}
+
+// Merge folds one or more tags into a target tag.
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var body struct {
+		Sources []string `json:"sources"`
+		Target  string   `json:"target"`
+	}
+	if err := decodeJSONBody(w, r, &body); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	tag, err := h.service.MergeTags(r.Context(), body.Sources, body.Target, user.ID)
+	if err != nil {
+		h.writeTagError(w, err, "merge tags", "failed to merge tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tag)
+}
+
+// Delete removes a tag from all items and deletes its definition.
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "tag name is required")
+		return
+	}
+
+	count, err := h.service.DeleteTag(r.Context(), name, user.ID)
+	if err != nil {
+		h.writeTagError(w, err, "delete tag", "failed to delete tag")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"itemsUpdated": count,
+	})
+}
+
+func (h *TagHandler) writeTagError(w http.ResponseWriter, err error, logMsg, clientMsg string) {
+	switch {
+	case errors.Is(err, items.ErrNotFound):
+		writeError(w, http.StatusNotFound, "tag not found")
+	case errors.Is(err, items.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(logMsg, "error", err)
+		writeError(w, http.StatusInternalServerError, clientMsg)
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"anthology/internal/items"
+)
+
+func TestTagHandlerMergeRetagsItems(t *testing.T) {
+	repo := items.NewInMemoryRepository(nil)
+	service := items.NewService(repo)
+	for _, tag := range []string{"autographed", "signed"} {
+		if _, err := service.Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: tag, ItemType: items.ItemTypeBook, Tags: []string{tag}}); err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+	}
+	handler := NewTagHandler(service, newTestLogger())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/tags/merge", strings.NewReader(`{"sources":["autographed"],"target":"signed"}`))
+	rec := httptest.NewRecorder()
+	handler.Merge(rec, reqWithUser(req))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var tag items.Tag
+	if err := json.NewDecoder(rec.Body).Decode(&tag); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if tag.Name != "signed" || tag.ItemCount != 2 {
+		t.Fatalf("unexpected tag %+v", tag)
+	}
+}
+
+func TestTagHandlerUpdateReturnsNotFound(t *testing.T) {
+	service := items.NewService(items.NewInMemoryRepository(nil))
+	handler := NewTagHandler(service, newTestLogger())
+
+	req := httptest.NewRequest(http.MethodPut, "/api/tags/detail?name=missing", strings.NewReader(`{"newName":"other"}`))
+	rec := httptest.NewRecorder()
+	handler.Update(rec, reqWithUser(req))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestTagHandlerCreateRejectsInvalidName(t *testing.T) {
+	service := items.NewService(items.NewInMemoryRepository(nil))
+	handler := NewTagHandler(service, newTestLogger())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/tags", strings.NewReader(`{"name":"signed, gift"}`))
+	rec := httptest.NewRecorder()
+	handler.Create(rec, reqWithUser(req))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestParseListOptionsTagFilters(t *testing.T) {
+	opts, err := parseListOptions(url.Values{"tags": {"signed,gift"}, "tag_match": {"all"}})
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	if len(opts.Tags) != 2 || opts.TagMatch != items.TagMatchAll {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+
+	if _, err := parseListOptions(url.Values{"tag_match": {"some"}}); err == nil {
+		t.Fatal("expected invalid tag_match to be rejected")
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"anthology/internal/auth"
+)
+
+// TokenHandler manages personal API tokens.
+type TokenHandler struct {
+	authService *auth.Service
+	logger      *slog.Logger
+}
+
+// NewTokenHandler constructs a TokenHandler.
+func NewTokenHandler(authService *auth.Service, logger *slog.Logger) *TokenHandler {
+	return &TokenHandler{authService: authService, logger: logger}
+}
+
+func (h *TokenHandler) handleTokenError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrTokenNotFound):
+		writeError(w, http.StatusNotFound, "api token not found")
+	case errors.Is(err, auth.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("api token operation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "unexpected error")
+	}
+}
+
+// List returns the user's tokens without their secrets.
+func (h *TokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	tokens, err := h.authService.ListAPITokens(r.Context(), user.ID)
+	if err != nil {
+		h.handleTokenError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"tokens": tokens})
+}
+
+// Create issues a token. The secret is only included in this response.
+func (h *TokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	var input auth.APITokenInput
+	if err := decodeJSONBody(w, r, &input); err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	token, secret, err := h.authService.CreateAPIToken(r.Context(), user.ID, input)
+	if err != nil {
+		h.handleTokenError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{"token": token, "secret": secret})
+}
+
+// Revoke deletes a token so it can no longer be used.
+func (h *TokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	user := UserFromContext(r.Context())
+
+	id, ok := parseUUIDParam(w, r, "tokenId")
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeAPIToken(r.Context(), user.ID, id); err != nil {
+		h.handleTokenError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"anthology/internal/auth"
+)
+
+func TestTokenHandlerCreateAndRevoke(t *testing.T) {
+	var stored []auth.APIToken
+	repo := &authRepoStub{
+		createAPIToken: func(ctx context.Context, token auth.APIToken, tokenHash string) error {
+			stored = append(stored, token)
+			return nil
+		},
+		listAPITokens: func(ctx context.Context, userID uuid.UUID) ([]auth.APIToken, error) {
+			return stored, nil
+		},
+		deleteAPIToken: func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+			for i, token := range stored {
+				if token.ID == id && token.UserID == userID {
+					stored = append(stored[:i], stored[i+1:]...)
+					return nil
+				}
+			}
+			return auth.ErrTokenNotFound
+		},
+	}
+	handler := NewTokenHandler(auth.NewService(repo, time.Hour), newTestLogger())
+	r := chi.NewRouter()
+	r.Route("/tokens", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Create)
+		r.Delete("/{tokenId}", handler.Revoke)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/tokens", strings.NewReader(`{"name":"Home Assistant","scopes":["read"]}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created struct {
+		Token  auth.APIToken `json:"token"`
+		Secret string        `json:"secret"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if created.Secret == "" || !strings.HasPrefix(created.Secret, created.Token.Prefix) {
+		t.Fatalf("expected the secret once on creation, got %+v", created)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodGet, "/tokens", nil)))
+	if strings.Contains(rec.Body.String(), created.Secret) {
+		t.Fatal("listing tokens must not reveal secrets")
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodPost, "/tokens", strings.NewReader(`{"name":"Bad","scopes":["admin"]}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown scope, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/tokens/"+created.Token.ID.String(), nil)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, reqWithUser(httptest.NewRequest(http.MethodDelete, "/tokens/"+created.Token.ID.String(), nil)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after revoking, got %d", rec.Code)
+	}
+}
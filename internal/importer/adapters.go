@@ -0,0 +1,220 @@
+package importer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"anthology/internal/items"
+)
+
+// Adapter names reported in Summary.Adapter.
+const (
+	AdapterAnthology  = "anthology"
+	AdapterGoodreads  = "goodreads"
+	AdapterStoryGraph = "storygraph"
+	// AdapterMapping is reported when a column mapping was supplied.
+	AdapterMapping = "mapping"
+)
+
+// adapter recognises an export layout by its header and translates each row
+// into Anthology's own columns so buildInput can treat every source alike.
+type adapter struct {
+	name      string
+	signature []string
+	translate func(values map[string]string) (map[string]string, error)
+}
+
+var anthologyAdapter = adapter{name: AdapterAnthology, signature: requiredColumns}
+
+// foreignAdapters are tried in order when a header lacks Anthology's required columns.
+var foreignAdapters = []adapter{
+	{
+		name:      AdapterGoodreads,
+		signature: []string{"book id", "title", "author", "exclusive shelf", "my rating"},
+		translate: translateGoodreads,
+	},
+	{
+		name:      AdapterStoryGraph,
+		signature: []string{"title", "authors", "isbn/upc", "read status", "star rating"},
+		translate: translateStoryGraph,
+	},
+}
+
+// matches reports whether every signature column is present.
+func (a adapter) matches(seen map[string]bool) bool {
+	for _, column := range a.signature {
+		if !seen[column] {
+			return false
+		}
+	}
+	return true
+}
+
+// goodreadsExclusiveShelves are the built-in shelves; any other shelf name becomes a tag.
+var goodreadsExclusiveShelves = map[string]items.BookStatus{
+	"read":              items.BookStatusRead,
+	"currently-reading": items.BookStatusReading,
+	"to-read":           items.BookStatusWantToRead,
+}
+
+// translateGoodreads maps a Goodreads "Export Library" row. Books on the read
+// shelf without a Date Read fall back to Date Added so they still import as read.
+func translateGoodreads(values map[string]string) (map[string]string, error) {
+	dateRead, err := parseExportDate(values["date read"], "Date Read")
+	if err != nil {
+		return nil, err
+	}
+	dateAdded, err := parseExportDate(values["date added"], "Date Added")
+	if err != nil {
+		return nil, err
+	}
+
+	shelf := strings.ToLower(strings.TrimSpace(values["exclusive shelf"]))
+	status := goodreadsExclusiveShelves[shelf]
+	if status == items.BookStatusRead && dateRead == "" {
+		dateRead = dateAdded
+	}
+
+	var tags []string
+	for _, name := range strings.Split(values["bookshelves"], ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, builtIn := goodreadsExclusiveShelves[strings.ToLower(name)]; builtIn {
+			continue
+		}
+		tags = append(tags, name)
+	}
+
+	rating, err := starsToRating(values["my rating"], "My Rating")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"title":         values["title"],
+		"creator":       values["author"],
+		"itemtype":      string(items.ItemTypeBook),
+		"releaseyear":   firstNonEmpty(values["original publication year"], values["year published"]),
+		"pagecount":     values["number of pages"],
+		"isbn13":        cleanExportISBN(values["isbn13"]),
+		"isbn10":        cleanExportISBN(values["isbn"]),
+		"format":        string(exportFormat(values["binding"])),
+		"rating":        rating,
+		"readingstatus": string(status),
+		"readat":        dateRead,
+		"notes":         values["private notes"],
+		"tags":          strings.Join(tags, ","),
+		"createdat":     dateAdded,
+	}, nil
+}
+
+var storyGraphStatuses = map[string]items.BookStatus{
+	"read":              items.BookStatusRead,
+	"currently-reading": items.BookStatusReading,
+	"to-read":           items.BookStatusWantToRead,
+}
+
+// translateStoryGraph maps a StoryGraph export row. Statuses without an
+// Anthology equivalent, such as did-not-finish, import without a status.
+func translateStoryGraph(values map[string]string) (map[string]string, error) {
+	lastRead, err := parseExportDate(values["last date read"], "Last Date Read")
+	if err != nil {
+		return nil, err
+	}
+	dateAdded, err := parseExportDate(values["date added"], "Date Added")
+	if err != nil {
+		return nil, err
+	}
+
+	status := storyGraphStatuses[strings.ToLower(strings.TrimSpace(values["read status"]))]
+	if status == items.BookStatusRead && lastRead == "" {
+		lastRead = dateAdded
+	}
+
+	rating, err := starsToRating(values["star rating"], "Star Rating")
+	if err != nil {
+		return nil, err
+	}
+
+	translated := map[string]string{
+		"title":         values["title"],
+		"creator":       values["authors"],
+		"itemtype":      string(items.ItemTypeBook),
+		"format":        string(exportFormat(values["format"])),
+		"rating":        rating,
+		"readingstatus": string(status),
+		"readat":        lastRead,
+		"tags":          values["tags"],
+		"createdat":     dateAdded,
+	}
+	isbn := cleanExportISBN(values["isbn/upc"])
+	if len(normalizeIdentifier(isbn)) == 10 {
+		translated["isbn10"] = isbn
+	} else {
+		translated["isbn13"] = isbn
+	}
+	return translated, nil
+}
+
+// cleanExportISBN strips the ="..." spreadsheet quoting Goodreads wraps around identifiers.
+func cleanExportISBN(value string) string {
+	cleaned := strings.TrimSpace(value)
+	cleaned = strings.TrimPrefix(cleaned, "=")
+	return strings.TrimSpace(strings.Trim(cleaned, `"`))
+}
+
+// parseExportDate converts the YYYY/MM/DD dates used by both services into
+// the RFC3339 form buildInput expects.
+func parseExportDate(value string, field string) (string, error) {
+	cleaned := strings.TrimSpace(value)
+	if cleaned == "" {
+		return "", nil
+	}
+	for _, layout := range []string{"2006/01/02", time.DateOnly, "2006/1/2"} {
+		if parsed, err := time.Parse(layout, cleaned); err == nil {
+			return parsed.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("%s must be a YYYY/MM/DD date", field)
+}
+
+// starsToRating turns a 0-5 star rating into Anthology's 1-10 scale, rounding
+// quarter stars to the nearest point. Zero means unrated.
+func starsToRating(value string, field string) (string, error) {
+	cleaned := strings.TrimSpace(value)
+	if cleaned == "" {
+		return "", nil
+	}
+	stars, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil || stars < 0 || stars > 5 {
+		return "", fmt.Errorf("%s must be between 0 and 5 stars", field)
+	}
+	if stars == 0 {
+		return "", nil
+	}
+	return strconv.Itoa(int(math.Round(stars * 2))), nil
+}
+
+// exportFormat maps binding names from other services onto item formats.
+func exportFormat(value string) items.Format {
+	binding := strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case binding == "":
+		return ""
+	case strings.Contains(binding, "hardcover"):
+		return items.FormatHardcover
+	case strings.Contains(binding, "paperback"):
+		return items.FormatPaperback
+	case strings.Contains(binding, "kindle"), strings.Contains(binding, "ebook"), binding == "digital":
+		return items.FormatEbook
+	case strings.Contains(binding, "magazine"):
+		return items.FormatMagazine
+	default:
+		return items.FormatUnknown
+	}
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"anthology/internal/items"
+)
+
+func TestCSVImporter_GoodreadsExport(t *testing.T) {
+	store := &stubStore{}
+	importer := NewCSVImporter(store, &stubCatalog{})
+	csv := "Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating,Average Rating,Publisher,Binding,Number of Pages,Year Published,Original Publication Year,Date Read,Date Added,Bookshelves,Bookshelves with positions,Exclusive Shelf,My Review,Spoiler,Private Notes,Read Count,Owned Copies\n" +
+		`234225,Dune,Frank Herbert,"Herbert, Frank",,"=""0441172717""","=""9780441172719""",4,4.27,Ace,Mass Market Paperback,661,1990,1965,2023/05/14,2023/01/02,"sci-fi, favorites","sci-fi (#1), favorites (#3)",read,,,Signed copy,1,1` + "\n" +
+		`1,Piranesi,Susanna Clarke,"Clarke, Susanna",,"=""""","=""""",0,4.2,Bloomsbury,Hardcover,272,2020,2020,,2024/02/10,to-read,to-read (#4),to-read,,,,0,0` + "\n"
+
+	summary, err := importer.Import(context.Background(), strings.NewReader(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Adapter != AdapterGoodreads || summary.Imported != 2 || len(summary.Failed) != 0 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	dune := store.createdInputs[0]
+	if dune.ISBN10 != "0441172717" || dune.ISBN13 != "9780441172719" {
+		t.Fatalf("expected cleaned identifiers, got %q / %q", dune.ISBN10, dune.ISBN13)
+	}
+	if dune.ReadingStatus != items.BookStatusRead || dune.ReadAt == nil || dune.ReadAt.Format("2006-01-02") != "2023-05-14" {
+		t.Fatalf("unexpected reading fields %q %v", dune.ReadingStatus, dune.ReadAt)
+	}
+	if dune.Rating == nil || *dune.Rating != 8 || dune.Format != items.FormatPaperback || dune.Notes != "Signed copy" {
+		t.Fatalf("unexpected mapped fields %+v", dune)
+	}
+	if dune.ReleaseYear == nil || *dune.ReleaseYear != 1965 || len(dune.Tags) != 2 {
+		t.Fatalf("expected original year and custom shelves as tags, got %+v", dune)
+	}
+
+	piranesi := store.createdInputs[1]
+	if piranesi.ReadingStatus != items.BookStatusWantToRead || piranesi.Rating != nil || piranesi.ISBN13 != "" || len(piranesi.Tags) != 0 {
+		t.Fatalf("unexpected to-read row %+v", piranesi)
+	}
+}
+
+func TestCSVImporter_StoryGraphExport(t *testing.T) {
+	store := &stubStore{}
+	importer := NewCSVImporter(store, &stubCatalog{})
+	csv := "Title,Authors,Contributors,ISBN/UPC,Format,Read Status,Date Added,Last Date Read,Dates Read,Read Count,Moods,Pace,Star Rating,Review,Content Warnings,Tags,Owned?\n" +
+		"The Fifth Season,N. K. Jemisin,,9780316229296,paperback,read,2023/01/02,2023/03/04,2023/03/01-2023/03/04,1,dark,medium,4.25,,,\"award winners\",Yes\n" +
+		"Babel,R. F. Kuang,,0063021420,digital,did-not-finish,2023/06/01,,,0,,,,,,,No\n" +
+		"Bad Date,Someone,,,,read,yesterday,,,,,,,,,,\n"
+
+	summary, err := importer.Import(context.Background(), strings.NewReader(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Adapter != AdapterStoryGraph || summary.Imported != 2 || len(summary.Failed) != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.Failed[0].Title != "Bad Date" {
+		t.Fatalf("expected bad date row to fail, got %+v", summary.Failed[0])
+	}
+
+	season := store.createdInputs[0]
+	if season.ISBN13 != "9780316229296" || season.ReadingStatus != items.BookStatusRead || season.ReadAt == nil {
+		t.Fatalf("unexpected read row %+v", season)
+	}
+	if season.Rating == nil || *season.Rating != 9 || len(season.Tags) != 1 {
+		t.Fatalf("unexpected rating or tags %+v", season)
+	}
+
+	babel := store.createdInputs[1]
+	if babel.ISBN10 != "0063021420" || babel.ReadingStatus != "" || babel.Format != items.FormatEbook {
+		t.Fatalf("unexpected did-not-finish row %+v", babel)
+	}
+}
+
+func TestCSVImporter_ReportsAnthologyAdapter(t *testing.T) {
+	importer := NewCSVImporter(&stubStore{}, &stubCatalog{})
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n" +
+		"New Book,Author,book,2020,320,,,,,\n"
+	summary, err := importer.Import(context.Background(), strings.NewReader(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Adapter != AdapterAnthology {
+		t.Fatalf("expected anthology adapter, got %q", summary.Adapter)
+	}
+}
@@ -3,6 +3,7 @@ package importer
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 
 	"anthology/internal/catalog"
 	"anthology/internal/items"
+	"anthology/internal/reviews"
 )
 
 type ItemStore interface {
@@ -25,12 +27,28 @@ type CatalogLookup interface {
 	Lookup(ctx context.Context, query string, category catalog.Category) ([]catalog.Metadata, error)
 }
 
+// ReviewStore saves reviews carried in the review column of exported CSVs.
+type ReviewStore interface {
+	Create(ctx context.Context, itemID uuid.UUID, input reviews.ReviewInput, ownerID uuid.UUID) (reviews.Review, error)
+}
+
+// BatchRecorder records the items an import created so it can be undone later.
+type BatchRecorder interface {
+	RecordBatch(ctx context.Context, ownerID uuid.UUID, label string, created []items.Item) (uuid.UUID, error)
+}
+
 type Summary struct {
+	Adapter           string          `json:"adapter"`
 	TotalRows         int             `json:"totalRows"`
 	Imported          int             `json:"imported"`
 	SkippedDuplicates []SkippedRecord `json:"skippedDuplicates"`
 	Failed            []FailedRecord  `json:"failed"`
+	Delayed           []DelayedRecord `json:"delayed,omitempty"`
 	TruncatedRecords  bool            `json:"truncatedRecords,omitempty"`
+	// ExcludedRows counts previewed rows left out when the preview was committed.
+	ExcludedRows int `json:"excludedRows,omitempty"`
+	// BatchID identifies the recorded import batch when any items were created.
+	BatchID *uuid.UUID `json:"batchId,omitempty"`
 }
 
 type SkippedRecord struct {
@@ -47,6 +65,15 @@ type FailedRecord struct {
 	Error      string `json:"error"`
 }
 
+// DelayedRecord reports a row whose metadata lookups were throttled or retried.
+type DelayedRecord struct {
+	Row        int    `json:"row"`
+	Title      string `json:"title,omitempty"`
+	Identifier string `json:"identifier,omitempty"`
+	Retries    int    `json:"retries"`
+	WaitedMs   int64  `json:"waitedMs"`
+}
+
 var ErrInvalidCSV = errors.New("invalid csv upload")
 
 // MaxImportRows limits the number of data rows processed per CSV import to
@@ -71,15 +98,74 @@ var requiredColumns = []string{
 }
 
 type CSVImporter struct {
-	items   ItemStore
-	catalog CatalogLookup
+	items    ItemStore
+	catalog  CatalogLookup
+	reviews  ReviewStore
+	mappings MappingStore
+	batches  BatchRecorder
+	previews *previewStore
 }
 
-func NewCSVImporter(items ItemStore, catalog CatalogLookup) *CSVImporter {
-	return &CSVImporter{items: items, catalog: catalog}
+// Option configures optional importer dependencies.
+type Option func(*CSVImporter)
+
+// WithReviewStore restores reviews from the review column. Without it the
+// column is ignored.
+func WithReviewStore(store ReviewStore) Option {
+	return func(i *CSVImporter) {
+		i.reviews = store
+	}
+}
+
+// WithBatchRecorder records each import's created items as an undoable batch.
+func WithBatchRecorder(recorder BatchRecorder) Option {
+	return func(i *CSVImporter) {
+		i.batches = recorder
+	}
+}
+
+func NewCSVImporter(items ItemStore, catalog CatalogLookup, opts ...Option) *CSVImporter {
+	importer := &CSVImporter{items: items, catalog: catalog, previews: newPreviewStore()}
+	for _, opt := range opts {
+		opt(importer)
+	}
+	return importer
+}
+
+// Options tune a single import run.
+type Options struct {
+	// MaxRows caps the data rows accepted; zero means MaxImportRows.
+	MaxRows int
+	// Progress, when set, receives the running summary after each row along
+	// with the number of rows processed so far.
+	Progress func(summary Summary, processed int)
+	// Mapping reads a custom spreadsheet layout; MappingID uses a saved one instead.
+	Mapping   *Mapping
+	MappingID uuid.UUID
+	// Label names the recorded batch, usually after the uploaded file.
+	Label string
 }
 
 func (i *CSVImporter) Import(ctx context.Context, reader io.Reader, ownerID uuid.UUID) (Summary, error) {
+	return i.ImportWithOptions(ctx, reader, ownerID, Options{})
+}
+
+// CountRows validates the header, and any mapping in opts, and counts the
+// non-empty data rows without importing anything, so callers can reject bad
+// uploads up front.
+func (i *CSVImporter) CountRows(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts Options) (int, error) {
+	mapping, err := i.resolveMapping(ctx, opts, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	_, rows, err := readRows(reader, opts.MaxRows, mapping)
+	return len(rows), err
+}
+
+// ImportWithOptions imports rows until the reader is exhausted or ctx is
+// cancelled. On cancellation it returns the summary of the rows processed so
+// far together with the context error.
+func (i *CSVImporter) ImportWithOptions(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts Options) (Summary, error) {
 	if i.items == nil {
 		return Summary{}, fmt.Errorf("%w: item store is not configured", ErrInvalidCSV)
 	}
@@ -91,6 +177,180 @@ func (i *CSVImporter) Import(ctx context.Context, reader io.Reader, ownerID uuid
 
 	tracker := newDuplicateTracker(existing)
 
+	mapping, err := i.resolveMapping(ctx, opts, ownerID)
+	if err != nil {
+		return Summary{}, err
+	}
+	format, rows, err := readRows(reader, opts.MaxRows, mapping)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary := Summary{Adapter: format.name, TotalRows: len(rows)}
+	var created []items.Item
+
+	for processed, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return summary, errors.Join(err, i.recordBatch(ctx, ownerID, opts.Label, created, &summary))
+		}
+		if opts.Progress != nil && processed > 0 {
+			opts.Progress(summary, processed)
+		}
+
+		plan, ok := i.planRow(ctx, row, tracker, &summary, ownerID)
+		if !ok {
+			continue
+		}
+		if item, ok := i.createRow(ctx, plan, tracker, &summary, ownerID); ok {
+			created = append(created, item)
+		}
+	}
+
+	if err := i.recordBatch(ctx, ownerID, opts.Label, created, &summary); err != nil {
+		return summary, err
+	}
+	if opts.Progress != nil {
+		opts.Progress(summary, len(rows))
+	}
+	return summary, nil
+}
+
+// recordBatch saves the created items as a batch and notes its ID in the
+// summary. It still records after ctx is cancelled so partial imports can be
+// undone too.
+func (i *CSVImporter) recordBatch(ctx context.Context, ownerID uuid.UUID, label string, created []items.Item, summary *Summary) error {
+	if i.batches == nil || len(created) == 0 {
+		return nil
+	}
+	id, err := i.batches.RecordBatch(context.WithoutCancel(ctx), ownerID, label, created)
+	if err != nil {
+		return fmt.Errorf("items imported but the batch was not recorded: %w", err)
+	}
+	summary.BatchID = &id
+	return nil
+}
+
+// plannedRow is a row that parsed, enriched, and passed duplicate checks.
+type plannedRow struct {
+	number  int
+	input   items.CreateItemInput
+	reviews []reviews.ReviewInput
+}
+
+// planRow builds a row's item input, recording delayed lookups, failures, and
+// duplicates in the summary. It reports false when the row should not be created.
+func (i *CSVImporter) planRow(ctx context.Context, row parsedRow, tracker *duplicateTracker, summary *Summary, ownerID uuid.UUID) (plannedRow, bool) {
+	values := row.values
+	lookupStats := &catalog.RequestStats{}
+	input, meta, rowErr := items.CreateItemInput{}, rowMeta{title: values["title"]}, row.err
+	if rowErr == nil {
+		input, meta, rowErr = i.buildInput(catalog.WithRequestStats(ctx, lookupStats), values, ownerID)
+	}
+	var rowReviews []reviews.ReviewInput
+	if rowErr == nil && i.reviews != nil {
+		rowReviews, rowErr = parseReviews(values["review"])
+	}
+	if lookupStats.Retries() > 0 || lookupStats.Delay() > 0 {
+		summary.addDelayed(DelayedRecord{
+			Row:        row.number,
+			Title:      firstNonEmpty(input.Title, meta.title),
+			Identifier: firstNonEmpty(firstIdentifier(input), meta.identifier),
+			Retries:    lookupStats.Retries(),
+			WaitedMs:   lookupStats.Delay().Milliseconds(),
+		})
+	}
+	if rowErr != nil {
+		summary.addFailed(FailedRecord{
+			Row:        row.number,
+			Title:      meta.title,
+			Identifier: meta.identifier,
+			Error:      rowErr.Error(),
+		})
+		return plannedRow{}, false
+	}
+
+	if reason, ok := tracker.Check(input); ok {
+		summary.addSkipped(SkippedRecord{
+			Row:        row.number,
+			Title:      input.Title,
+			Identifier: firstIdentifier(input),
+			Reason:     reason,
+		})
+		return plannedRow{}, false
+	}
+
+	return plannedRow{number: row.number, input: input, reviews: rowReviews}, true
+}
+
+// createRow saves a planned row and its reviews, reporting the created item.
+func (i *CSVImporter) createRow(ctx context.Context, plan plannedRow, tracker *duplicateTracker, summary *Summary, ownerID uuid.UUID) (items.Item, bool) {
+	input := plan.input
+	created, err := i.items.Create(ctx, input)
+	if err != nil {
+		summary.addFailed(FailedRecord{
+			Row:        plan.number,
+			Title:      input.Title,
+			Identifier: firstIdentifier(input),
+			Error:      err.Error(),
+		})
+		return items.Item{}, false
+	}
+
+	tracker.Add(input)
+	summary.Imported++
+
+	// The item itself is kept when a review is rejected; the row is reported
+	// as failed so the review can be re-entered by hand.
+	for _, review := range plan.reviews {
+		if _, err := i.reviews.Create(ctx, created.ID, review, ownerID); err != nil {
+			summary.addFailed(FailedRecord{
+				Row:        plan.number,
+				Title:      input.Title,
+				Identifier: firstIdentifier(input),
+				Error:      fmt.Sprintf("item imported but review was not: %v", err),
+			})
+		}
+	}
+	return created, true
+}
+
+func (s *Summary) addFailed(record FailedRecord) {
+	if len(s.Failed) >= MaxFailedRecords {
+		s.TruncatedRecords = true
+		return
+	}
+	s.Failed = append(s.Failed, record)
+}
+
+func (s *Summary) addSkipped(record SkippedRecord) {
+	if len(s.SkippedDuplicates) >= MaxFailedRecords {
+		s.TruncatedRecords = true
+		return
+	}
+	s.SkippedDuplicates = append(s.SkippedDuplicates, record)
+}
+
+func (s *Summary) addDelayed(record DelayedRecord) {
+	if len(s.Delayed) >= MaxFailedRecords {
+		s.TruncatedRecords = true
+		return
+	}
+	s.Delayed = append(s.Delayed, record)
+}
+
+type parsedRow struct {
+	number int
+	values map[string]string
+	err    error
+}
+
+// readRows parses the header and every non-empty data row, translating rows
+// from foreign export layouts, or through mapping, into Anthology columns.
+func readRows(reader io.Reader, maxRows int, mapping *Mapping) (adapter, []parsedRow, error) {
+	if maxRows <= 0 {
+		maxRows = MaxImportRows
+	}
+
 	csvReader := csv.NewReader(reader)
 	csvReader.FieldsPerRecord = -1
 	csvReader.TrimLeadingSpace = true
@@ -98,24 +358,18 @@ func (i *CSVImporter) Import(ctx context.Context, reader io.Reader, ownerID uuid
 	header, err := csvReader.Read()
 	if err != nil {
 		if errors.Is(err, io.EOF) {
-			return Summary{}, fmt.Errorf("%w: file is empty", ErrInvalidCSV)
+			return adapter{}, nil, fmt.Errorf("%w: file is empty", ErrInvalidCSV)
 		}
-		return Summary{}, fmt.Errorf("%w: failed to read header", ErrInvalidCSV)
+		return adapter{}, nil, fmt.Errorf("%w: failed to read header", ErrInvalidCSV)
 	}
 
-	columns, err := normalizeHeader(header)
+	columns, format, err := normalizeHeader(header, mapping)
 	if err != nil {
-		return Summary{}, err
-	}
-
-	type parsedRow struct {
-		number int
-		values map[string]string
+		return adapter{}, nil, err
 	}
 
 	var rows []parsedRow
 	rowNumber := 1
-	totalRows := 0
 
 	for {
 		record, err := csvReader.Read()
@@ -123,7 +377,7 @@ func (i *CSVImporter) Import(ctx context.Context, reader io.Reader, ownerID uuid
 			if errors.Is(err, io.EOF) {
 				break
 			}
-			return Summary{}, fmt.Errorf("%w: failed to read row %d", ErrInvalidCSV, rowNumber+1)
+			return adapter{}, nil, fmt.Errorf("%w: failed to read row %d", ErrInvalidCSV, rowNumber+1)
 		}
 		rowNumber++
 		values := mapRecord(columns, record)
@@ -131,69 +385,21 @@ func (i *CSVImporter) Import(ctx context.Context, reader io.Reader, ownerID uuid
 			continue
 		}
 
-		totalRows++
-		if totalRows > MaxImportRows {
-			return Summary{}, fmt.Errorf("%w: CSV exceeds maximum of %d rows", ErrInvalidCSV, MaxImportRows)
+		if len(rows) >= maxRows {
+			return adapter{}, nil, fmt.Errorf("%w: CSV exceeds maximum of %d rows", ErrInvalidCSV, maxRows)
 		}
 
-		rows = append(rows, parsedRow{
-			number: rowNumber,
-			values: values,
-		})
-	}
-
-	summary := Summary{TotalRows: totalRows}
-
-	for _, row := range rows {
-		values := row.values
-		input, meta, rowErr := i.buildInput(ctx, values, ownerID)
-		if rowErr != nil {
-			if len(summary.Failed) < MaxFailedRecords {
-				summary.Failed = append(summary.Failed, FailedRecord{
-					Row:        row.number,
-					Title:      meta.title,
-					Identifier: meta.identifier,
-					Error:      rowErr.Error(),
-				})
+		parsed := parsedRow{number: rowNumber, values: values}
+		if format.translate != nil {
+			if translated, err := format.translate(values); err != nil {
+				parsed.err = err
 			} else {
-				summary.TruncatedRecords = true
+				parsed.values = translated
 			}
-			continue
 		}
-
-		if reason, ok := tracker.Check(input); ok {
-			if len(summary.SkippedDuplicates) < MaxFailedRecords {
-				summary.SkippedDuplicates = append(summary.SkippedDuplicates, SkippedRecord{
-					Row:        row.number,
-					Title:      input.Title,
-					Identifier: firstIdentifier(input),
-					Reason:     reason,
-				})
-			} else {
-				summary.TruncatedRecords = true
-			}
-			continue
-		}
-
-		if _, err := i.items.Create(ctx, input); err != nil {
-			if len(summary.Failed) < MaxFailedRecords {
-				summary.Failed = append(summary.Failed, FailedRecord{
-					Row:        row.number,
-					Title:      input.Title,
-					Identifier: firstIdentifier(input),
-					Error:      err.Error(),
-				})
-			} else {
-				summary.TruncatedRecords = true
-			}
-			continue
-		}
-
-		tracker.Add(input)
-		summary.Imported++
+		rows = append(rows, parsed)
 	}
-
-	return summary, nil
+	return format, rows, nil
 }
 
 type rowMeta struct {
@@ -271,13 +477,15 @@ func (i *CSVImporter) buildInput(ctx context.Context, values map[string]string,
 	platform := strings.TrimSpace(values["platform"])
 	ageGroup := strings.TrimSpace(values["agegroup"])
 	playerCount := strings.TrimSpace(values["playercount"])
+	tags := parseTags(values["tags"])
+	var metadataSource, metadataSourceID string
 
 	if itemType == items.ItemTypeBook && title == "" {
 		identifier := meta.identifier
 		if identifier == "" {
 			return items.CreateItemInput{}, meta, fmt.Errorf("provide a title or ISBN/UPC for books")
 		}
-		metadata, err := i.lookupBook(ctx, identifier)
+		metadata, err := i.lookupMetadata(ctx, identifier, catalog.CategoryBook)
 		if err != nil {
 			return items.CreateItemInput{}, meta, err
 		}
@@ -307,46 +515,86 @@ func (i *CSVImporter) buildInput(ctx context.Context, values map[string]string,
 		genre = items.Genre(metadata.Genre)
 		retailPriceUsd = metadata.RetailPriceUsd
 		googleVolumeId = metadata.GoogleVolumeId
+		metadataSource, metadataSourceID = metadata.Source, metadata.SourceID
+	}
+
+	input := items.CreateItemInput{
+		OwnerID:          ownerID,
+		Title:            title,
+		Creator:          creator,
+		ItemType:         itemType,
+		ReleaseYear:      releaseYear,
+		PageCount:        pageCount,
+		CurrentPage:      currentPage,
+		ISBN13:           isbn13,
+		ISBN10:           isbn10,
+		Description:      description,
+		CoverImage:       coverImage,
+		Format:           format,
+		Genre:            genre,
+		Rating:           rating,
+		RetailPriceUsd:   retailPriceUsd,
+		GoogleVolumeId:   googleVolumeId,
+		MetadataSource:   metadataSource,
+		MetadataSourceID: metadataSourceID,
+		Platform:         platform,
+		AgeGroup:         ageGroup,
+		PlayerCount:      playerCount,
+		ReadingStatus:    readingStatus,
+		ReadAt:           readAt,
+		Notes:            notes,
+		Tags:             tags,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+
+	if itemType == items.ItemTypeMovie {
+		if err := i.enrichMovie(ctx, &input, meta.identifier); err != nil {
+			return items.CreateItemInput{}, meta, err
+		}
 	}
 
-	if title == "" {
+	if input.Title == "" {
 		return items.CreateItemInput{}, meta, fmt.Errorf("title is required for %s rows", itemType)
 	}
 
-	return items.CreateItemInput{
-		OwnerID:        ownerID,
-		Title:          title,
-		Creator:        creator,
-		ItemType:       itemType,
-		ReleaseYear:    releaseYear,
-		PageCount:      pageCount,
-		CurrentPage:    currentPage,
-		ISBN13:         isbn13,
-		ISBN10:         isbn10,
-		Description:    description,
-		CoverImage:     coverImage,
-		Format:         format,
-		Genre:          genre,
-		Rating:         rating,
-		RetailPriceUsd: retailPriceUsd,
-		GoogleVolumeId: googleVolumeId,
-		Platform:       platform,
-		AgeGroup:       ageGroup,
-		PlayerCount:    playerCount,
-		ReadingStatus:  readingStatus,
-		ReadAt:         readAt,
-		Notes:          notes,
-		CreatedAt:      createdAt,
-		UpdatedAt:      updatedAt,
-	}, meta, nil
-}
-
-func (i *CSVImporter) lookupBook(ctx context.Context, query string) (catalog.Metadata, error) {
+	return input, meta, nil
+}
+
+// enrichMovie fills gaps in a movie row from the catalog. Rows without a title
+// must resolve through their UPC/EAN; titled rows are enriched on a best-effort basis.
+func (i *CSVImporter) enrichMovie(ctx context.Context, input *items.CreateItemInput, identifier string) error {
+	if input.Title == "" {
+		if identifier == "" {
+			return fmt.Errorf("provide a title or UPC for movies")
+		}
+		metadata, err := i.lookupMetadata(ctx, identifier, catalog.CategoryMovie)
+		if err != nil {
+			return err
+		}
+		input.Title = metadata.Title
+		mergeMovieMetadata(input, metadata)
+		return nil
+	}
+
+	if i.catalog == nil {
+		return nil
+	}
+	if input.Creator != "" && input.ReleaseYear != nil && input.Description != "" && input.CoverImage != "" {
+		return nil
+	}
+	if metadata, ok := i.matchMovie(ctx, input.Title, input.ReleaseYear); ok {
+		mergeMovieMetadata(input, metadata)
+	}
+	return nil
+}
+
+func (i *CSVImporter) lookupMetadata(ctx context.Context, query string, category catalog.Category) (catalog.Metadata, error) {
 	if i.catalog == nil {
 		return catalog.Metadata{}, fmt.Errorf("%w: metadata lookup is unavailable", ErrInvalidCSV)
 	}
 
-	metadata, err := i.catalog.Lookup(ctx, query, catalog.CategoryBook)
+	metadata, err := i.catalog.Lookup(ctx, query, category)
 	if err != nil {
 		if errors.Is(err, catalog.ErrNotFound) {
 			return catalog.Metadata{}, fmt.Errorf("no metadata found for %s", query)
@@ -362,7 +610,56 @@ func (i *CSVImporter) lookupBook(ctx context.Context, query string) (catalog.Met
 	return metadata[0], nil
 }
 
-func normalizeHeader(header []string) (map[int]string, error) {
+// matchMovie searches for a titled movie row and returns the first result whose
+// title matches and whose release year agrees with the row, when one is given.
+func (i *CSVImporter) matchMovie(ctx context.Context, title string, releaseYear *int) (catalog.Metadata, bool) {
+	query := title
+	if releaseYear != nil {
+		query = fmt.Sprintf("%s (%d)", title, *releaseYear)
+	}
+	results, err := i.catalog.Lookup(ctx, query, catalog.CategoryMovie)
+	if err != nil {
+		return catalog.Metadata{}, false
+	}
+	for _, metadata := range results {
+		if !strings.EqualFold(strings.TrimSpace(metadata.Title), title) {
+			continue
+		}
+		if releaseYear != nil && metadata.ReleaseYear != nil && *metadata.ReleaseYear != *releaseYear {
+			continue
+		}
+		return metadata, true
+	}
+	return catalog.Metadata{}, false
+}
+
+// mergeMovieMetadata fills empty movie fields without overwriting values supplied in the CSV.
+func mergeMovieMetadata(input *items.CreateItemInput, metadata catalog.Metadata) {
+	if input.Creator == "" {
+		input.Creator = metadata.Creator
+	}
+	if input.ReleaseYear == nil && metadata.ReleaseYear != nil {
+		input.ReleaseYear = metadata.ReleaseYear
+	}
+	if input.Description == "" {
+		input.Description = metadata.Description
+	}
+	if input.CoverImage == "" {
+		input.CoverImage = metadata.CoverImage
+	}
+	if input.Notes == "" {
+		input.Notes = metadata.Notes
+	}
+	if input.MetadataSource == "" && metadata.SourceID != "" {
+		input.MetadataSource = metadata.Source
+		input.MetadataSourceID = metadata.SourceID
+	}
+}
+
+// normalizeHeader lower-cases the header and picks the adapter for its layout:
+// the mapping when one is given, otherwise Anthology's own columns first and
+// then the Goodreads and StoryGraph exports.
+func normalizeHeader(header []string, mapping *Mapping) (map[int]string, adapter, error) {
 	columns := make(map[int]string, len(header))
 	seen := map[string]bool{}
 	for idx, raw := range header {
@@ -374,16 +671,26 @@ func normalizeHeader(header []string) (map[int]string, error) {
 		seen[cleaned] = true
 	}
 
+	if mapping != nil {
+		format, err := mappingAdapter(mapping, seen)
+		return columns, format, err
+	}
+	if anthologyAdapter.matches(seen) {
+		return columns, anthologyAdapter, nil
+	}
+	for _, candidate := range foreignAdapters {
+		if candidate.matches(seen) {
+			return columns, candidate, nil
+		}
+	}
+
 	missing := make([]string, 0)
 	for _, column := range requiredColumns {
 		if !seen[column] {
 			missing = append(missing, column)
 		}
 	}
-	if len(missing) > 0 {
-		return nil, fmt.Errorf("%w: missing required columns: %s", ErrInvalidCSV, strings.Join(missing, ", "))
-	}
-	return columns, nil
+	return nil, adapter{}, fmt.Errorf("%w: missing required columns: %s (Goodreads and StoryGraph exports are also accepted)", ErrInvalidCSV, strings.Join(missing, ", "))
 }
 
 func mapRecord(columns map[int]string, record []string) map[string]string {
@@ -476,6 +783,61 @@ func parseOptionalTime(value string, field string) (*time.Time, error) {
 	return &parsed, nil
 }
 
+// parseReviews decodes the JSON array written to the review column by the exporter.
+func parseReviews(value string) ([]reviews.ReviewInput, error) {
+	cleaned := strings.TrimSpace(value)
+	if cleaned == "" {
+		return nil, nil
+	}
+
+	var encoded []struct {
+		Title     string            `json:"title"`
+		Body      string            `json:"body"`
+		Sections  []reviews.Section `json:"sections"`
+		ReadOn    string            `json:"readOn"`
+		CreatedAt string            `json:"createdAt"`
+		UpdatedAt string            `json:"updatedAt"`
+	}
+	if err := json.Unmarshal([]byte(cleaned), &encoded); err != nil {
+		return nil, fmt.Errorf("review must be a JSON array of reviews")
+	}
+
+	inputs := make([]reviews.ReviewInput, 0, len(encoded))
+	for _, review := range encoded {
+		input := reviews.ReviewInput{
+			Title:    review.Title,
+			Body:     review.Body,
+			Sections: review.Sections,
+		}
+		if review.ReadOn != "" {
+			readOn, err := time.Parse(time.DateOnly, review.ReadOn)
+			if err != nil {
+				return nil, fmt.Errorf("review readOn must be a YYYY-MM-DD date")
+			}
+			input.ReadOn = &readOn
+		}
+		createdAt, err := parseOptionalTime(review.CreatedAt, "review createdAt")
+		if err != nil {
+			return nil, err
+		}
+		updatedAt, err := parseOptionalTime(review.UpdatedAt, "review updatedAt")
+		if err != nil {
+			return nil, err
+		}
+		input.CreatedAt, input.UpdatedAt = createdAt, updatedAt
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// parseTags splits a comma-separated tags cell. Normalization happens in the item service.
+func parseTags(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
 func firstNonEmpty(values ...string) string {
 	for _, value := range values {
 		if trimmed := strings.TrimSpace(value); trimmed != "" {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +14,7 @@ import (
 
 	"anthology/internal/catalog"
 	"anthology/internal/items"
+	"anthology/internal/reviews"
 )
 
 // testOwnerID is a fixed UUID for tests
@@ -42,11 +45,15 @@ func (s *stubStore) List(ctx context.Context, opts items.ListOptions) ([]items.I
 }
 
 type stubCatalog struct {
-	metadata []catalog.Metadata
-	err      error
+	metadata     []catalog.Metadata
+	err          error
+	lastQuery    string
+	lastCategory catalog.Category
 }
 
 func (s *stubCatalog) Lookup(ctx context.Context, query string, category catalog.Category) ([]catalog.Metadata, error) {
+	s.lastQuery = query
+	s.lastCategory = category
 	if s.err != nil {
 		return nil, s.err
 	}
@@ -91,6 +98,83 @@ func TestCSVImporter_PopulatesBookFromLookup(t *testing.T) {
 	}
 }
 
+func TestCSVImporter_PopulatesMovieFromBarcode(t *testing.T) {
+	store := &stubStore{}
+	year := 1999
+	runtime := 136
+	lookup := &stubCatalog{metadata: []catalog.Metadata{{
+		Title:          "The Matrix",
+		Creator:        "Lana Wachowski, Lilly Wachowski",
+		ItemType:       string(items.ItemTypeMovie),
+		ReleaseYear:    &year,
+		RuntimeMinutes: &runtime,
+	}}}
+	importer := NewCSVImporter(store, lookup)
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n" +
+		",,movie,,,085391163121,,,,\n"
+	summary, err := importer.Import(context.Background(), bytes.NewBufferString(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Imported != 1 {
+		t.Fatalf("expected 1 import, got %+v", summary)
+	}
+	if lookup.lastCategory != catalog.CategoryMovie || lookup.lastQuery != "085391163121" {
+		t.Fatalf("expected movie barcode lookup, got %q (%s)", lookup.lastQuery, lookup.lastCategory)
+	}
+	input := store.createdInputs[0]
+	if input.Title != "The Matrix" || input.ReleaseYear == nil || *input.ReleaseYear != 1999 {
+		t.Fatalf("expected movie metadata to be applied, got %+v", input)
+	}
+	if input.Notes != "" {
+		t.Fatalf("expected notes to be left to the user, got %q", input.Notes)
+	}
+}
+
+func TestCSVImporter_EnrichesIncompleteMovieRows(t *testing.T) {
+	store := &stubStore{}
+	year := 1979
+	remakeYear := 2003
+	lookup := &stubCatalog{metadata: []catalog.Metadata{
+		{Title: "Alien", Creator: "Someone Else", ReleaseYear: &remakeYear},
+		{Title: "Alien", Creator: "Ridley Scott", ReleaseYear: &year, Description: "Synopsis"},
+	}}
+	importer := NewCSVImporter(store, lookup)
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n" +
+		"Alien,,movie,1979,,,,,,My copy\n"
+	summary, err := importer.Import(context.Background(), bytes.NewBufferString(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Imported != 1 {
+		t.Fatalf("expected 1 import, got %+v", summary)
+	}
+	if lookup.lastQuery != "Alien (1979)" {
+		t.Fatalf("expected title/year query, got %q", lookup.lastQuery)
+	}
+	input := store.createdInputs[0]
+	if input.Creator != "Ridley Scott" || input.Description != "Synopsis" {
+		t.Fatalf("expected gaps to be filled from matching year, got %+v", input)
+	}
+	if input.Notes != "My copy" {
+		t.Fatalf("expected CSV notes to be preserved, got %q", input.Notes)
+	}
+}
+
+func TestCSVImporter_MovieEnrichmentFailureStillImports(t *testing.T) {
+	store := &stubStore{}
+	importer := NewCSVImporter(store, &stubCatalog{err: catalog.ErrNotFound})
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n" +
+		"Obscure Film,,movie,,,,,,,\n"
+	summary, err := importer.Import(context.Background(), bytes.NewBufferString(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Imported != 1 || len(summary.Failed) != 0 {
+		t.Fatalf("expected row to import without enrichment, got %+v", summary)
+	}
+}
+
 func TestCSVImporter_ReturnsRowErrors(t *testing.T) {
 	store := &stubStore{}
 	importer := NewCSVImporter(store, &stubCatalog{})
@@ -194,3 +278,139 @@ func TestCSVImporter_ImportsExtendedFields(t *testing.T) {
 		t.Fatalf("expected updatedAt to be %s", updatedAt.Format(time.RFC3339))
 	}
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestCSVImporter_ReportsRetriedLookups(t *testing.T) {
+	store := &stubStore{}
+	attempts := 0
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+		body := `{"items":[{"id":"vol","volumeInfo":{"title":"Throttled Title","authors":["Author"]}}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+	importer := NewCSVImporter(store, catalog.NewService(client))
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n" +
+		",,book,,,9780000000002,,,,\n"
+
+	summary, err := importer.Import(context.Background(), bytes.NewBufferString(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Imported != 1 {
+		t.Fatalf("expected 1 import, got %d (failed: %+v)", summary.Imported, summary.Failed)
+	}
+	if len(summary.Delayed) != 1 {
+		t.Fatalf("expected 1 delayed record, got %+v", summary.Delayed)
+	}
+	if entry := summary.Delayed[0]; entry.Row != 2 || entry.Retries != 1 || entry.Title != "Throttled Title" {
+		t.Fatalf("unexpected delayed record %+v", entry)
+	}
+}
+
+func TestCSVImporter_ParsesTagsColumn(t *testing.T) {
+	store := &stubStore{}
+	importer := NewCSVImporter(store, &stubCatalog{})
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes,tags\n" +
+		"Tagged Book,Author,book,,,,,,,,\"signed, gift\"\n"
+
+	summary, err := importer.Import(context.Background(), bytes.NewBufferString(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Imported != 1 {
+		t.Fatalf("expected 1 import, got %d (failed: %+v)", summary.Imported, summary.Failed)
+	}
+	tags := store.createdInputs[0].Tags
+	if len(tags) != 2 || strings.TrimSpace(tags[0]) != "signed" || strings.TrimSpace(tags[1]) != "gift" {
+		t.Fatalf("unexpected tags %q", tags)
+	}
+}
+
+type stubReviewStore struct {
+	itemIDs []uuid.UUID
+	inputs  []reviews.ReviewInput
+}
+
+func (s *stubReviewStore) Create(ctx context.Context, itemID uuid.UUID, input reviews.ReviewInput, ownerID uuid.UUID) (reviews.Review, error) {
+	s.itemIDs = append(s.itemIDs, itemID)
+	s.inputs = append(s.inputs, input)
+	return reviews.Review{ID: uuid.New(), ItemID: itemID, OwnerID: ownerID}, nil
+}
+
+func TestCSVImporter_RestoresReviewColumn(t *testing.T) {
+	store := &stubStore{}
+	reviewStore := &stubReviewStore{}
+	importer := NewCSVImporter(store, &stubCatalog{}, WithReviewStore(reviewStore))
+	review := `[{"title":"Spice","body":"Great","sections":[{"heading":"Ending","body":"Twist","spoiler":true}],"readOn":"2026-04-20","createdAt":"2026-04-21T09:00:00Z"}]`
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes,review\n" +
+		"Dune,Frank Herbert,book,,,,,,,,\"" + strings.ReplaceAll(review, `"`, `""`) + "\"\n" +
+		"Broken,Author,book,,,,,,,,not json\n"
+
+	summary, err := importer.Import(context.Background(), bytes.NewBufferString(csv), testOwnerID)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.Imported != 1 || len(summary.Failed) != 1 || summary.Failed[0].Row != 3 {
+		t.Fatalf("expected one import and the malformed review row to fail, got %+v", summary)
+	}
+	if len(reviewStore.inputs) != 1 || reviewStore.itemIDs[0] != store.items[0].ID {
+		t.Fatalf("expected review attached to the imported item, got %+v", reviewStore.itemIDs)
+	}
+	input := reviewStore.inputs[0]
+	if input.Title != "Spice" || len(input.Sections) != 1 || !input.Sections[0].Spoiler {
+		t.Fatalf("unexpected review input %+v", input)
+	}
+	if input.ReadOn == nil || input.ReadOn.Format(time.DateOnly) != "2026-04-20" || input.CreatedAt == nil || input.CreatedAt.Hour() != 9 {
+		t.Fatalf("expected review dates restored, got %+v", input)
+	}
+}
+
+type stubBatchRecorder struct {
+	label   string
+	created []items.Item
+}
+
+func (s *stubBatchRecorder) RecordBatch(ctx context.Context, ownerID uuid.UUID, label string, created []items.Item) (uuid.UUID, error) {
+	s.label = label
+	s.created = created
+	return uuid.New(), nil
+}
+
+func TestCSVImporter_RecordsCreatedItemsAsBatch(t *testing.T) {
+	store := &stubStore{items: []items.Item{{Title: "Existing Title", OwnerID: testOwnerID}}}
+	recorder := &stubBatchRecorder{}
+	importer := NewCSVImporter(store, &stubCatalog{}, WithBatchRecorder(recorder))
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n" +
+		"New Book,Author,book,,,,,,,\n" +
+		"Existing Title,Someone,book,,,,,,,\n" +
+		"Another Book,Author,book,,,,,,,\n"
+
+	summary, err := importer.ImportWithOptions(context.Background(), bytes.NewBufferString(csv), testOwnerID, Options{Label: "library.csv"})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.BatchID == nil || recorder.label != "library.csv" || len(recorder.created) != 2 || recorder.created[1].Title != "Another Book" {
+		t.Fatalf("expected the two created items recorded, got %+v for summary %+v", recorder, summary)
+	}
+
+	recorder.created = nil
+	summary, err = importer.ImportWithOptions(context.Background(), bytes.NewBufferString(csv), testOwnerID, Options{})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if summary.BatchID != nil || recorder.created != nil {
+		t.Fatalf("expected no batch when nothing was created, got %+v", summary)
+	}
+}
@@ -0,0 +1,276 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrMappingNotFound is returned by a MappingStore when a saved mapping does not exist.
+var ErrMappingNotFound = errors.New("saved mapping not found")
+
+// MaxMappingColumns bounds how many columns one mapping may describe.
+const MaxMappingColumns = 100
+
+// MappingStore looks up an owner's saved column mappings.
+type MappingStore interface {
+	Spec(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Mapping, error)
+}
+
+// WithMappingStore lets imports reference saved mappings by ID.
+func WithMappingStore(store MappingStore) Option {
+	return func(i *CSVImporter) {
+		i.mappings = store
+	}
+}
+
+// TransformKind selects how a mapped cell is converted.
+type TransformKind string
+
+const (
+	// TransformDate parses the cell with Format (e.g. "DD/MM/YYYY").
+	TransformDate TransformKind = "date"
+	// TransformBoolean turns yes/no style cells into the True or False value.
+	TransformBoolean TransformKind = "boolean"
+	// TransformEnum replaces cells listed in Values; other cells pass through.
+	TransformEnum TransformKind = "enum"
+)
+
+// Transform converts a mapped cell before it is read as the target field.
+type Transform struct {
+	Kind   TransformKind     `json:"kind"`
+	Format string            `json:"format,omitempty"`
+	True   string            `json:"true,omitempty"`
+	False  string            `json:"false,omitempty"`
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// ColumnMapping reads the upload's Column (matched case-insensitively) into
+// the item Field, such as "creator" or "releaseYear".
+type ColumnMapping struct {
+	Column    string     `json:"column"`
+	Field     string     `json:"field"`
+	Transform *Transform `json:"transform,omitempty"`
+}
+
+// Mapping describes a spreadsheet layout. Columns already named after item
+// fields are read as usual; Defaults fill fields left empty, such as an
+// itemType for a sheet that only lists books.
+type Mapping struct {
+	Columns  []ColumnMapping   `json:"columns"`
+	Defaults map[string]string `json:"defaults,omitempty"`
+}
+
+// mappableFields are the Anthology columns a mapping may target.
+var mappableFields = map[string]struct{}{
+	"title": {}, "creator": {}, "itemtype": {}, "releaseyear": {}, "pagecount": {}, "currentpage": {},
+	"isbn13": {}, "isbn10": {}, "description": {}, "coverimage": {}, "notes": {}, "format": {},
+	"genre": {}, "rating": {}, "retailpriceusd": {}, "googlevolumeid": {}, "platform": {},
+	"agegroup": {}, "playercount": {}, "readingstatus": {}, "readat": {}, "tags": {},
+	"createdat": {}, "updatedat": {},
+}
+
+// dateFields hold timestamps; releaseYear may also use a date transform and keeps the year.
+var dateFields = map[string]struct{}{"readat": {}, "createdat": {}, "updatedat": {}, "releaseyear": {}}
+
+// Validate checks that every column targets a known field once and that
+// transforms are complete.
+func (m Mapping) Validate() error {
+	if len(m.Columns) == 0 && len(m.Defaults) == 0 {
+		return errors.New("mapping needs at least one column or default")
+	}
+	if len(m.Columns) > MaxMappingColumns {
+		return fmt.Errorf("mapping can describe at most %d columns", MaxMappingColumns)
+	}
+
+	targeted := make(map[string]string, len(m.Columns))
+	for _, column := range m.Columns {
+		source := strings.TrimSpace(column.Column)
+		if source == "" {
+			return errors.New("every mapped column needs a column name")
+		}
+		field := fieldKey(column.Field)
+		if _, ok := mappableFields[field]; !ok {
+			return fmt.Errorf("column %q targets unknown field %q", source, column.Field)
+		}
+		if other, dup := targeted[field]; dup {
+			return fmt.Errorf("columns %q and %q both target %s", other, source, column.Field)
+		}
+		targeted[field] = source
+		if err := column.Transform.validate(field); err != nil {
+			return fmt.Errorf("column %q: %w", source, err)
+		}
+	}
+	for field := range m.Defaults {
+		if _, ok := mappableFields[fieldKey(field)]; !ok {
+			return fmt.Errorf("default targets unknown field %q", field)
+		}
+	}
+	return nil
+}
+
+func (t *Transform) validate(field string) error {
+	if t == nil {
+		return nil
+	}
+	switch t.Kind {
+	case TransformDate:
+		if _, ok := dateFields[field]; !ok {
+			return errors.New("date transforms only apply to readAt, createdAt, updatedAt, and releaseYear")
+		}
+	case TransformBoolean:
+		if t.True == "" && t.False == "" {
+			return errors.New("boolean transforms need a true or false value")
+		}
+	case TransformEnum:
+		if len(t.Values) == 0 {
+			return errors.New("enum transforms need values")
+		}
+	default:
+		return fmt.Errorf("unknown transform %q (expected date, boolean, or enum)", t.Kind)
+	}
+	return nil
+}
+
+// resolveMapping returns the mapping an import should use: an inline one, a
+// saved one, or nil for the built-in layouts.
+func (i *CSVImporter) resolveMapping(ctx context.Context, opts Options, ownerID uuid.UUID) (*Mapping, error) {
+	if opts.Mapping != nil && opts.MappingID != uuid.Nil {
+		return nil, fmt.Errorf("%w: provide either a mapping or a saved mapping id, not both", ErrInvalidCSV)
+	}
+	mapping := opts.Mapping
+	if opts.MappingID != uuid.Nil {
+		if i.mappings == nil {
+			return nil, fmt.Errorf("%w: saved mappings are not available", ErrInvalidCSV)
+		}
+		saved, err := i.mappings.Spec(ctx, opts.MappingID, ownerID)
+		if err != nil {
+			if errors.Is(err, ErrMappingNotFound) {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidCSV, err)
+			}
+			return nil, err
+		}
+		mapping = &saved
+	}
+	if mapping == nil {
+		return nil, nil
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	return mapping, nil
+}
+
+// mappingAdapter builds an adapter for a header read through mapping. Every
+// mapped column must be present in the header.
+func mappingAdapter(mapping *Mapping, seen map[string]bool) (adapter, error) {
+	var missing []string
+	for _, column := range mapping.Columns {
+		if !seen[strings.ToLower(strings.TrimSpace(column.Column))] {
+			missing = append(missing, column.Column)
+		}
+	}
+	if len(missing) > 0 {
+		return adapter{}, fmt.Errorf("%w: mapped columns not found: %s", ErrInvalidCSV, strings.Join(missing, ", "))
+	}
+
+	return adapter{
+		name: AdapterMapping,
+		translate: func(values map[string]string) (map[string]string, error) {
+			return mapping.apply(values)
+		},
+	}, nil
+}
+
+// apply converts one row's cells into Anthology columns.
+func (m *Mapping) apply(values map[string]string) (map[string]string, error) {
+	translated := make(map[string]string, len(values)+len(m.Defaults))
+	for column, value := range values {
+		if _, ok := mappableFields[column]; ok {
+			translated[column] = value
+		}
+	}
+	for _, column := range m.Columns {
+		field := fieldKey(column.Field)
+		value, err := column.Transform.apply(field, values[strings.ToLower(strings.TrimSpace(column.Column))])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", column.Column, err)
+		}
+		translated[field] = value
+	}
+	for field, value := range m.Defaults {
+		if key := fieldKey(field); strings.TrimSpace(translated[key]) == "" {
+			translated[key] = value
+		}
+	}
+	return translated, nil
+}
+
+func (t *Transform) apply(field string, value string) (string, error) {
+	cleaned := strings.TrimSpace(value)
+	if t == nil {
+		return cleaned, nil
+	}
+
+	switch t.Kind {
+	case TransformDate:
+		if cleaned == "" {
+			return "", nil
+		}
+		parsed, err := parseMappedDate(cleaned, t.Format)
+		if err != nil {
+			return "", err
+		}
+		if field == "releaseyear" {
+			return strconv.Itoa(parsed.Year()), nil
+		}
+		return parsed.Format(time.RFC3339), nil
+	case TransformBoolean:
+		switch strings.ToLower(cleaned) {
+		case "yes", "y", "true", "t", "1", "x", "✓":
+			return t.True, nil
+		case "no", "n", "false", "f", "0", "":
+			return t.False, nil
+		default:
+			return "", fmt.Errorf("%q is not a yes/no value", cleaned)
+		}
+	case TransformEnum:
+		for from, to := range t.Values {
+			if strings.EqualFold(strings.TrimSpace(from), cleaned) {
+				return to, nil
+			}
+		}
+		return cleaned, nil
+	default:
+		return cleaned, nil
+	}
+}
+
+// parseMappedDate reads a date written with YYYY/YY, MM/M, and DD/D tokens,
+// or common layouts when no format is given.
+func parseMappedDate(value string, format string) (time.Time, error) {
+	layouts := []string{time.RFC3339, time.DateOnly, "2006/01/02", "01/02/2006"}
+	if format != "" {
+		layout := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "M", "1", "DD", "02", "D", "2").Replace(format)
+		layouts = []string{layout}
+	}
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+	if format != "" {
+		return time.Time{}, fmt.Errorf("%q does not match date format %s", value, format)
+	}
+	return time.Time{}, fmt.Errorf("%q is not a recognised date", value)
+}
+
+// fieldKey normalizes a field name such as "releaseYear" to its column key.
+func fieldKey(field string) string {
+	return strings.ToLower(strings.TrimSpace(field))
+}
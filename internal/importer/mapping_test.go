@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+type stubMappingStore struct {
+	mappings map[uuid.UUID]Mapping
+}
+
+func (s stubMappingStore) Spec(_ context.Context, id uuid.UUID, _ uuid.UUID) (Mapping, error) {
+	mapping, ok := s.mappings[id]
+	if !ok {
+		return Mapping{}, ErrMappingNotFound
+	}
+	return mapping, nil
+}
+
+var spreadsheetMapping = Mapping{
+	Columns: []ColumnMapping{
+		{Column: "Book Name", Field: "title"},
+		{Column: "Writer", Field: "creator"},
+		{Column: "Finished On", Field: "readAt", Transform: &Transform{Kind: TransformDate, Format: "DD/MM/YYYY"}},
+		{Column: "Done?", Field: "readingStatus", Transform: &Transform{Kind: TransformBoolean, True: "read", False: "want_to_read"}},
+		{Column: "Kind", Field: "format", Transform: &Transform{Kind: TransformEnum, Values: map[string]string{"HB": "HARDCOVER", "PB": "PAPERBACK"}}},
+	},
+	Defaults: map[string]string{"itemType": "book"},
+}
+
+func TestCSVImporter_ImportsThroughColumnMapping(t *testing.T) {
+	ctx := context.Background()
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	importer := NewCSVImporter(itemSvc, &stubCatalog{})
+	csv := "Book Name,Writer,Finished On,Done?,Kind,notes\n" +
+		"Dune,Frank Herbert,14/03/2024,yes,HB,Reread soon\n" +
+		"Piranesi,Susanna Clarke,,no,PB,\n" +
+		"Bad Date,Someone,2024-03-14,yes,,\n"
+
+	summary, err := importer.ImportWithOptions(ctx, strings.NewReader(csv), testOwnerID, Options{Mapping: &spreadsheetMapping})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if summary.Adapter != AdapterMapping || summary.Imported != 2 || len(summary.Failed) != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if !strings.Contains(summary.Failed[0].Error, "Finished On") {
+		t.Fatalf("expected the failing column to be named, got %q", summary.Failed[0].Error)
+	}
+
+	list, _ := itemSvc.List(ctx, items.ListOptions{OwnerID: testOwnerID})
+	byTitle := make(map[string]items.Item, len(list))
+	for _, item := range list {
+		byTitle[item.Title] = item
+	}
+	dune := byTitle["Dune"]
+	if dune.ItemType != items.ItemTypeBook || dune.Creator != "Frank Herbert" || dune.Format != items.FormatHardcover || dune.Notes != "Reread soon" {
+		t.Fatalf("unexpected mapped item %+v", dune)
+	}
+	if dune.ReadingStatus != items.BookStatusRead || dune.ReadAt == nil || !dune.ReadAt.Equal(time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected read on 2024-03-14, got %v %v", dune.ReadingStatus, dune.ReadAt)
+	}
+	if piranesi := byTitle["Piranesi"]; piranesi.ReadingStatus != items.BookStatusWantToRead || piranesi.Format != items.FormatPaperback {
+		t.Fatalf("unexpected mapped item %+v", piranesi)
+	}
+}
+
+func TestCSVImporter_MappingRequiresMappedColumns(t *testing.T) {
+	importer := NewCSVImporter(items.NewService(items.NewInMemoryRepository(nil)), &stubCatalog{})
+
+	_, err := importer.ImportWithOptions(context.Background(), strings.NewReader("Book Name,Writer\nDune,Frank Herbert\n"), testOwnerID, Options{Mapping: &spreadsheetMapping})
+	if !errors.Is(err, ErrInvalidCSV) || !strings.Contains(err.Error(), "Finished On") {
+		t.Fatalf("expected missing column error, got %v", err)
+	}
+}
+
+func TestCSVImporter_ResolvesSavedMapping(t *testing.T) {
+	ctx := context.Background()
+	savedID := uuid.New()
+	store := stubMappingStore{mappings: map[uuid.UUID]Mapping{
+		savedID: {Columns: []ColumnMapping{{Column: "Name", Field: "title"}}, Defaults: map[string]string{"itemType": "game"}},
+	}}
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	importer := NewCSVImporter(itemSvc, &stubCatalog{}, WithMappingStore(store))
+
+	summary, err := importer.ImportWithOptions(ctx, strings.NewReader("Name\nHades\n"), testOwnerID, Options{MappingID: savedID})
+	if err != nil || summary.Imported != 1 {
+		t.Fatalf("expected one imported row, got %+v (%v)", summary, err)
+	}
+
+	_, err = importer.ImportWithOptions(ctx, strings.NewReader("Name\nHades\n"), testOwnerID, Options{MappingID: uuid.New()})
+	if !errors.Is(err, ErrInvalidCSV) || !errors.Is(err, ErrMappingNotFound) {
+		t.Fatalf("expected unknown mapping error, got %v", err)
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping Mapping
+		wantErr string
+	}{
+		{name: "empty", mapping: Mapping{}, wantErr: "at least one"},
+		{name: "unknown field", mapping: Mapping{Columns: []ColumnMapping{{Column: "A", Field: "colour"}}}, wantErr: "unknown field"},
+		{name: "duplicate target", mapping: Mapping{Columns: []ColumnMapping{{Column: "A", Field: "title"}, {Column: "B", Field: "Title"}}}, wantErr: "both target"},
+		{name: "date on text field", mapping: Mapping{Columns: []ColumnMapping{{Column: "A", Field: "title", Transform: &Transform{Kind: TransformDate}}}}, wantErr: "date transforms"},
+		{name: "unknown transform", mapping: Mapping{Columns: []ColumnMapping{{Column: "A", Field: "title", Transform: &Transform{Kind: "upper"}}}}, wantErr: "unknown transform"},
+		{name: "valid", mapping: spreadsheetMapping},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected valid mapping, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
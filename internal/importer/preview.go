@@ -0,0 +1,290 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// PreviewTTL is how long a preview can be committed before it must be redone.
+const PreviewTTL = 30 * time.Minute
+
+// Previews hold up to MaxImportRows planned rows each, so the store is capped.
+// When full, the oldest preview is evicted, starting with the requesting
+// owner's once they hold maxOwnerPreviews.
+const (
+	maxPreviews      = 200
+	maxOwnerPreviews = 5
+)
+
+// ErrPreviewNotFound is returned when a preview token is unknown, expired,
+// already committed, or belongs to another owner.
+var ErrPreviewNotFound = errors.New("import preview not found or expired")
+
+// PreviewItem is an item a previewed row would create. Item is the normalized
+// record as it would be saved, without an ID.
+type PreviewItem struct {
+	Row     int        `json:"row"`
+	Item    items.Item `json:"item"`
+	Reviews int        `json:"reviews,omitempty"`
+}
+
+// Preview is the outcome of a dry run: what would be created, skipped, and
+// rejected. Commit the Token to create the items.
+type Preview struct {
+	Token             string          `json:"token"`
+	ExpiresAt         time.Time       `json:"expiresAt"`
+	Adapter           string          `json:"adapter"`
+	TotalRows         int             `json:"totalRows"`
+	Items             []PreviewItem   `json:"items"`
+	SkippedDuplicates []SkippedRecord `json:"skippedDuplicates"`
+	Failed            []FailedRecord  `json:"failed"`
+	Delayed           []DelayedRecord `json:"delayed,omitempty"`
+	TruncatedRecords  bool            `json:"truncatedRecords,omitempty"`
+}
+
+// itemValidator is implemented by item stores that can show the normalized
+// item Create would save; items.Service does.
+type itemValidator interface {
+	ValidateCreate(input items.CreateItemInput) (items.Item, error)
+}
+
+// Preview parses, enriches, and deduplicates a CSV without creating anything.
+// The planned rows are held under the returned token until Commit or PreviewTTL.
+// Only the mapping and label settings in opts apply.
+func (i *CSVImporter) Preview(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts Options) (Preview, error) {
+	if i.items == nil {
+		return Preview{}, fmt.Errorf("%w: item store is not configured", ErrInvalidCSV)
+	}
+
+	existing, err := i.items.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		return Preview{}, err
+	}
+	tracker := newDuplicateTracker(existing)
+
+	mapping, err := i.resolveMapping(ctx, opts, ownerID)
+	if err != nil {
+		return Preview{}, err
+	}
+	format, rows, err := readRows(reader, MaxImportRows, mapping)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	summary := Summary{Adapter: format.name, TotalRows: len(rows)}
+	plans := make([]plannedRow, 0, len(rows))
+	previewItems := make([]PreviewItem, 0, len(rows))
+	validator, _ := i.items.(itemValidator)
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return Preview{}, err
+		}
+		plan, ok := i.planRow(ctx, row, tracker, &summary, ownerID)
+		if !ok {
+			continue
+		}
+
+		draft := items.Item{Title: plan.input.Title, Creator: plan.input.Creator, ItemType: plan.input.ItemType, ISBN13: plan.input.ISBN13, ISBN10: plan.input.ISBN10}
+		if validator != nil {
+			if draft, err = validator.ValidateCreate(plan.input); err != nil {
+				summary.addFailed(FailedRecord{
+					Row:        plan.number,
+					Title:      plan.input.Title,
+					Identifier: firstIdentifier(plan.input),
+					Error:      err.Error(),
+				})
+				continue
+			}
+		}
+
+		tracker.Add(plan.input)
+		plans = append(plans, plan)
+		previewItems = append(previewItems, PreviewItem{Row: plan.number, Item: draft, Reviews: len(plan.reviews)})
+	}
+
+	stored := i.previews.put(ownerID, opts.Label, summary, plans)
+	return Preview{
+		Token:             stored.token,
+		ExpiresAt:         stored.expiresAt,
+		Adapter:           summary.Adapter,
+		TotalRows:         summary.TotalRows,
+		Items:             previewItems,
+		SkippedDuplicates: summary.SkippedDuplicates,
+		Failed:            summary.Failed,
+		Delayed:           summary.Delayed,
+		TruncatedRecords:  summary.TruncatedRecords,
+	}, nil
+}
+
+// Commit creates the items from a preview, leaving out excludeRows (the row
+// numbers reported in the preview). Rows that became duplicates since the
+// preview are skipped. A token can be committed once; it stays usable if the
+// commit fails before creating anything.
+func (i *CSVImporter) Commit(ctx context.Context, token string, excludeRows []int, ownerID uuid.UUID) (Summary, error) {
+	stored, ok := i.previews.claim(token, ownerID)
+	if !ok {
+		return Summary{}, ErrPreviewNotFound
+	}
+
+	existing, err := i.items.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		i.previews.release(token)
+		return Summary{}, err
+	}
+	// From here rows are written, so the preview cannot be committed again.
+	defer i.previews.remove(token)
+	tracker := newDuplicateTracker(existing)
+
+	summary := stored.summary
+	var created []items.Item
+	for _, plan := range stored.plans {
+		if slices.Contains(excludeRows, plan.number) {
+			summary.ExcludedRows++
+			continue
+		}
+		if reason, dup := tracker.Check(plan.input); dup {
+			summary.addSkipped(SkippedRecord{
+				Row:        plan.number,
+				Title:      plan.input.Title,
+				Identifier: firstIdentifier(plan.input),
+				Reason:     reason,
+			})
+			continue
+		}
+		if item, ok := i.createRow(ctx, plan, tracker, &summary, ownerID); ok {
+			created = append(created, item)
+		}
+	}
+	if err := i.recordBatch(ctx, ownerID, stored.label, created, &summary); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+type storedPreview struct {
+	token      string
+	ownerID    uuid.UUID
+	label      string
+	expiresAt  time.Time
+	summary    Summary
+	plans      []plannedRow
+	committing bool
+}
+
+// previewStore holds planned rows in memory between Preview and Commit.
+type previewStore struct {
+	mu       sync.Mutex
+	entries  map[string]storedPreview
+	maxTotal int
+	maxOwner int
+	now      func() time.Time
+}
+
+func newPreviewStore() *previewStore {
+	return &previewStore{
+		entries:  make(map[string]storedPreview),
+		maxTotal: maxPreviews,
+		maxOwner: maxOwnerPreviews,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (p *previewStore) put(ownerID uuid.UUID, label string, summary Summary, plans []plannedRow) storedPreview {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Commit appends to the summary, so keep it apart from the preview response.
+	summary.SkippedDuplicates = slices.Clone(summary.SkippedDuplicates)
+	summary.Failed = slices.Clone(summary.Failed)
+	summary.Delayed = slices.Clone(summary.Delayed)
+
+	now := p.now()
+	owned := 0
+	for token, entry := range p.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(p.entries, token)
+		} else if entry.ownerID == ownerID {
+			owned++
+		}
+	}
+	if owned >= p.maxOwner {
+		p.evictOldest(func(entry storedPreview) bool { return entry.ownerID == ownerID })
+	}
+	if len(p.entries) >= p.maxTotal {
+		p.evictOldest(func(storedPreview) bool { return true })
+	}
+
+	entry := storedPreview{
+		token:     uuid.NewString(),
+		ownerID:   ownerID,
+		label:     label,
+		expiresAt: now.Add(PreviewTTL),
+		summary:   summary,
+		plans:     plans,
+	}
+	p.entries[entry.token] = entry
+	return entry
+}
+
+// evictOldest drops the matching preview closest to expiry. Previews being
+// committed are left alone.
+func (p *previewStore) evictOldest(match func(storedPreview) bool) {
+	oldest := ""
+	for token, entry := range p.entries {
+		if entry.committing || !match(entry) {
+			continue
+		}
+		if oldest == "" || entry.expiresAt.Before(p.entries[oldest].expiresAt) {
+			oldest = token
+		}
+	}
+	if oldest != "" {
+		delete(p.entries, oldest)
+	}
+}
+
+// claim reserves a preview for a commit so concurrent commits of the same
+// token cannot both run. Follow with release or remove.
+func (p *previewStore) claim(token string, ownerID uuid.UUID) (storedPreview, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[token]
+	if !ok || entry.ownerID != ownerID || entry.committing {
+		return storedPreview{}, false
+	}
+	if !p.now().Before(entry.expiresAt) {
+		delete(p.entries, token)
+		return storedPreview{}, false
+	}
+	entry.committing = true
+	p.entries[token] = entry
+	return entry, true
+}
+
+// release makes a claimed preview available to commit again.
+func (p *previewStore) release(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if entry, ok := p.entries[token]; ok {
+		entry.committing = false
+		p.entries[token] = entry
+	}
+}
+
+// remove consumes a preview once its commit has written rows.
+func (p *previewStore) remove(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, token)
+}
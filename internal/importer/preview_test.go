@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+func TestCSVImporter_PreviewWritesNothingUntilCommit(t *testing.T) {
+	ctx := context.Background()
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	if _, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: testOwnerID, Title: "Existing", ItemType: items.ItemTypeBook}); err != nil {
+		t.Fatalf("seed item: %v", err)
+	}
+	recorder := &stubBatchRecorder{}
+	importer := NewCSVImporter(itemSvc, &stubCatalog{}, WithBatchRecorder(recorder))
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes,rating\n" +
+		"Dune,Frank Herbert,book,1965,,,,,,,8\n" +
+		"Existing,Someone,book,,,,,,,,\n" +
+		"Bad Year,Someone,book,year,,,,,,,\n" +
+		"Hades,,game,,,,,,,,\n" +
+		"Piranesi,Susanna Clarke,book,2020,,,,,,,\n"
+
+	preview, err := importer.Preview(ctx, strings.NewReader(csv), testOwnerID, Options{Label: "library.csv"})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if len(preview.Items) != 3 || len(preview.SkippedDuplicates) != 1 || len(preview.Failed) != 1 {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	if dune := preview.Items[0]; dune.Row != 2 || dune.Item.ID != uuid.Nil || dune.Item.Rating == nil || *dune.Item.Rating != 8 {
+		t.Fatalf("expected a normalized draft for row 2, got %+v", dune)
+	}
+	list, _ := itemSvc.List(ctx, items.ListOptions{OwnerID: testOwnerID})
+	if len(list) != 1 {
+		t.Fatalf("preview must not create items, found %d", len(list))
+	}
+
+	if _, err := importer.Commit(ctx, preview.Token, nil, uuid.New()); !errors.Is(err, ErrPreviewNotFound) {
+		t.Fatalf("expected other owners to be rejected, got %v", err)
+	}
+
+	// Piranesi is added by hand between preview and commit.
+	if _, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: testOwnerID, Title: "Piranesi", ItemType: items.ItemTypeBook}); err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	summary, err := importer.Commit(ctx, preview.Token, []int{5}, testOwnerID)
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if summary.Imported != 1 || summary.ExcludedRows != 1 || len(summary.SkippedDuplicates) != 2 || len(summary.Failed) != 1 {
+		t.Fatalf("unexpected commit summary %+v", summary)
+	}
+	if summary.BatchID == nil || recorder.label != "library.csv" || len(recorder.created) != 1 || recorder.created[0].Title != "Dune" {
+		t.Fatalf("expected the committed item recorded as a batch, got %+v", recorder)
+	}
+
+	if _, err := importer.Commit(ctx, preview.Token, nil, testOwnerID); !errors.Is(err, ErrPreviewNotFound) {
+		t.Fatalf("expected token to be single use, got %v", err)
+	}
+}
+
+// flakyListStore fails List once armed, as a dropped database connection would.
+type flakyListStore struct {
+	*items.Service
+	fail bool
+}
+
+func (s *flakyListStore) List(ctx context.Context, opts items.ListOptions) ([]items.Item, error) {
+	if s.fail {
+		s.fail = false
+		return nil, errors.New("connection reset")
+	}
+	return s.Service.List(ctx, opts)
+}
+
+func TestCSVImporter_CommitKeepsTokenWhenItFailsBeforeWriting(t *testing.T) {
+	ctx := context.Background()
+	store := &flakyListStore{Service: items.NewService(items.NewInMemoryRepository(nil))}
+	importer := NewCSVImporter(store, &stubCatalog{})
+	csv := "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\nDune,Frank Herbert,book,1965,,,,,,\n"
+
+	preview, err := importer.Preview(ctx, strings.NewReader(csv), testOwnerID, Options{})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+
+	store.fail = true
+	if _, err := importer.Commit(ctx, preview.Token, nil, testOwnerID); err == nil || errors.Is(err, ErrPreviewNotFound) {
+		t.Fatalf("expected the list error, got %v", err)
+	}
+	summary, err := importer.Commit(ctx, preview.Token, nil, testOwnerID)
+	if err != nil {
+		t.Fatalf("retry commit: %v", err)
+	}
+	if summary.Imported != 1 {
+		t.Fatalf("expected the retried commit to import the row, got %+v", summary)
+	}
+}
+
+func TestPreviewStoreEvictsOldestWhenFull(t *testing.T) {
+	store := newPreviewStore()
+	store.maxTotal, store.maxOwner = 3, 2
+	now := time.Now().UTC()
+	store.now = func() time.Time { return now }
+	put := func(ownerID uuid.UUID) string {
+		now = now.Add(time.Second)
+		return store.put(ownerID, "", Summary{}, nil).token
+	}
+
+	other := uuid.New()
+	first := put(testOwnerID)
+	second := put(testOwnerID)
+	third := put(testOwnerID)
+	if _, ok := store.claim(first, testOwnerID); ok {
+		t.Fatal("expected the owner's oldest preview to be evicted at the per-owner cap")
+	}
+	if _, ok := store.claim(second, testOwnerID); !ok {
+		t.Fatal("expected the newer preview to be kept")
+	}
+
+	// The store is now full; the claimed preview is being committed, so the
+	// oldest unclaimed one makes room.
+	put(other)
+	put(other)
+	if _, ok := store.claim(third, testOwnerID); ok {
+		t.Fatal("expected the oldest unclaimed preview to be evicted at the total cap")
+	}
+	store.remove(second)
+	if len(store.entries) != 2 {
+		t.Fatalf("expected only the other owner's previews left, got %d", len(store.entries))
+	}
+}
@@ -0,0 +1,82 @@
+package imports
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu   sync.RWMutex
+	jobs map[uuid.UUID]Job
+}
+
+// NewInMemoryRepository constructs an empty import job repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{jobs: make(map[uuid.UUID]Job)}
+}
+
+func (r *inMemoryRepository) Create(_ context.Context, job Job) (Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = job
+	return job, nil
+}
+
+func (r *inMemoryRepository) Get(_ context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[id]
+	if !ok || job.OwnerID != ownerID {
+		return Job{}, ErrNotFound
+	}
+	return job, nil
+}
+
+func (r *inMemoryRepository) List(_ context.Context, ownerID uuid.UUID) ([]Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	jobs := make([]Job, 0)
+	for _, job := range r.jobs {
+		if job.OwnerID == ownerID {
+			jobs = append(jobs, job)
+		}
+	}
+	slices.SortFunc(jobs, func(a, b Job) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return jobs, nil
+}
+
+func (r *inMemoryRepository) Update(_ context.Context, job Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.jobs[job.ID]
+	if !ok || existing.OwnerID != job.OwnerID {
+		return ErrNotFound
+	}
+	if existing.Status.Finished() {
+		return ErrFinished
+	}
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *inMemoryRepository) FailUnfinished(_ context.Context, message string, finishedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for id, job := range r.jobs {
+		if job.Status.Finished() {
+			continue
+		}
+		job.Status = StatusFailed
+		job.Error = message
+		job.FinishedAt = &finishedAt
+		job.UpdatedAt = finishedAt
+		r.jobs[id] = job
+		count++
+	}
+	return count, nil
+}
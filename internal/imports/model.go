@@ -0,0 +1,69 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/importer"
+)
+
+// ErrNotFound is returned when an import job does not exist for the owner.
+var ErrNotFound = errors.New("import job not found")
+
+// ErrFinished is returned when cancelling or updating a job that has already stopped.
+var ErrFinished = errors.New("import job has already finished")
+
+// Status tracks an import job through its lifecycle.
+type Status string
+
+const (
+	// StatusQueued jobs are waiting for a free worker.
+	StatusQueued Status = "queued"
+	// StatusRunning jobs are importing rows.
+	StatusRunning Status = "running"
+	// StatusCompleted jobs processed every row; failures are listed in the summary.
+	StatusCompleted Status = "completed"
+	// StatusCancelled jobs were stopped by the owner; rows imported before then are kept.
+	StatusCancelled Status = "cancelled"
+	// StatusFailed jobs stopped on an unexpected error or a server restart.
+	StatusFailed Status = "failed"
+)
+
+// Finished reports whether the job has stopped for good.
+func (s Status) Finished() bool {
+	return s == StatusCompleted || s == StatusCancelled || s == StatusFailed
+}
+
+// Job is a background CSV import. Summary holds the rows processed so far and
+// is final once the job has finished.
+type Job struct {
+	ID            uuid.UUID        `json:"id"`
+	OwnerID       uuid.UUID        `json:"-"`
+	Filename      string           `json:"filename"`
+	Status        Status           `json:"status"`
+	TotalRows     int              `json:"totalRows"`
+	ProcessedRows int              `json:"processedRows"`
+	Summary       importer.Summary `json:"summary"`
+	Error         string           `json:"error,omitempty"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	StartedAt     *time.Time       `json:"startedAt,omitempty"`
+	FinishedAt    *time.Time       `json:"finishedAt,omitempty"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+}
+
+// Repository persists import jobs.
+type Repository interface {
+	Create(ctx context.Context, job Job) (Job, error)
+	Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]Job, error)
+	// Update saves a job's progress or final state. A job that has already
+	// finished is left as it is and ErrFinished is returned, so the first
+	// final state recorded wins.
+	Update(ctx context.Context, job Job) error
+	// FailUnfinished marks every queued or running job as failed with message.
+	// It is used at startup to close out jobs interrupted by a restart.
+	FailUnfinished(ctx context.Context, message string, finishedAt time.Time) (int, error)
+}
@@ -0,0 +1,167 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"anthology/internal/importer"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates an import job repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const jobColumns = `id, owner_id, filename, status, total_rows, processed_rows, summary, error, created_at, started_at, finished_at, updated_at`
+
+// jobRow mirrors import_jobs; the summary is stored as jsonb.
+type jobRow struct {
+	ID            uuid.UUID  `db:"id"`
+	OwnerID       uuid.UUID  `db:"owner_id"`
+	Filename      string     `db:"filename"`
+	Status        Status     `db:"status"`
+	TotalRows     int        `db:"total_rows"`
+	ProcessedRows int        `db:"processed_rows"`
+	Summary       []byte     `db:"summary"`
+	Error         string     `db:"error"`
+	CreatedAt     time.Time  `db:"created_at"`
+	StartedAt     *time.Time `db:"started_at"`
+	FinishedAt    *time.Time `db:"finished_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+func newJobRow(job Job) (jobRow, error) {
+	summary, err := json.Marshal(job.Summary)
+	if err != nil {
+		return jobRow{}, fmt.Errorf("encode import summary: %w", err)
+	}
+	return jobRow{
+		ID:            job.ID,
+		OwnerID:       job.OwnerID,
+		Filename:      job.Filename,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		Summary:       summary,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}, nil
+}
+
+func (row jobRow) job() (Job, error) {
+	var summary importer.Summary
+	if len(row.Summary) > 0 {
+		if err := json.Unmarshal(row.Summary, &summary); err != nil {
+			return Job{}, fmt.Errorf("decode import summary: %w", err)
+		}
+	}
+	return Job{
+		ID:            row.ID,
+		OwnerID:       row.OwnerID,
+		Filename:      row.Filename,
+		Status:        row.Status,
+		TotalRows:     row.TotalRows,
+		ProcessedRows: row.ProcessedRows,
+		Summary:       summary,
+		Error:         row.Error,
+		CreatedAt:     row.CreatedAt,
+		StartedAt:     row.StartedAt,
+		FinishedAt:    row.FinishedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, job Job) (Job, error) {
+	row, err := newJobRow(job)
+	if err != nil {
+		return Job{}, err
+	}
+	query := `INSERT INTO import_jobs (` + jobColumns + `)
+VALUES (:id, :owner_id, :filename, :status, :total_rows, :processed_rows, :summary, :error, :created_at, :started_at, :finished_at, :updated_at)`
+	if _, err := r.db.NamedExecContext(ctx, query, row); err != nil {
+		return Job{}, fmt.Errorf("insert import job: %w", err)
+	}
+	return job, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error) {
+	var row jobRow
+	query := `SELECT ` + jobColumns + ` FROM import_jobs WHERE id = $1 AND owner_id = $2`
+	if err := r.db.GetContext(ctx, &row, query, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, ErrNotFound
+		}
+		return Job{}, fmt.Errorf("get import job: %w", err)
+	}
+	return row.job()
+}
+
+func (r *postgresRepository) List(ctx context.Context, ownerID uuid.UUID) ([]Job, error) {
+	var rows []jobRow
+	query := `SELECT ` + jobColumns + ` FROM import_jobs WHERE owner_id = $1 ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &rows, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list import jobs: %w", err)
+	}
+	jobs := make([]Job, 0, len(rows))
+	for _, row := range rows {
+		job, err := row.job()
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, job Job) error {
+	row, err := newJobRow(job)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE import_jobs SET status = :status, total_rows = :total_rows, processed_rows = :processed_rows,
+summary = :summary, error = :error, started_at = :started_at, finished_at = :finished_at, updated_at = :updated_at
+WHERE id = :id AND owner_id = :owner_id AND status IN ('queued', 'running')`
+	res, err := r.db.NamedExecContext(ctx, query, row)
+	if err != nil {
+		return fmt.Errorf("update import job: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update import job rows: %w", err)
+	}
+	if affected == 0 {
+		// Either the job is missing or it already finished.
+		if _, err := r.Get(ctx, job.ID, job.OwnerID); err != nil {
+			return err
+		}
+		return ErrFinished
+	}
+	return nil
+}
+
+func (r *postgresRepository) FailUnfinished(ctx context.Context, message string, finishedAt time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `UPDATE import_jobs SET status = $1, error = $2, finished_at = $3, updated_at = $3
+WHERE status IN ($4, $5)`, StatusFailed, message, finishedAt, StatusQueued, StatusRunning)
+	if err != nil {
+		return 0, fmt.Errorf("fail unfinished import jobs: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("fail unfinished import jobs rows: %w", err)
+	}
+	return int(affected), nil
+}
@@ -0,0 +1,295 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/importer"
+)
+
+// MaxJobRows caps background imports. It is far above importer.MaxImportRows
+// because jobs do not run inside a request timeout.
+const MaxJobRows = 50000
+
+// DefaultWorkers is how many jobs import at once; later jobs wait as queued.
+const DefaultWorkers = 2
+
+// progressInterval throttles how often a running job's progress is saved.
+const progressInterval = time.Second
+
+var (
+	errCancelledByOwner = errors.New("import cancelled")
+	errShuttingDown     = errors.New("interrupted by server shutdown")
+)
+
+// Importer runs the CSV import for a job.
+type Importer interface {
+	CountRows(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts importer.Options) (int, error)
+	ImportWithOptions(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts importer.Options) (importer.Summary, error)
+}
+
+// Service starts import jobs and runs them in the background.
+type Service struct {
+	repo     Repository
+	importer Importer
+	logger   *slog.Logger
+	now      func() time.Time
+
+	slots chan struct{}
+	ctx   context.Context
+	stop  context.CancelCauseFunc
+	wg    sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[uuid.UUID]*runningJob
+}
+
+type runningJob struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// Option configures optional service behaviour.
+type Option func(*Service)
+
+// WithWorkers sets how many jobs may import concurrently.
+func WithWorkers(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// NewService constructs an import job service. Call Shutdown to stop workers.
+func NewService(repo Repository, importer Importer, logger *slog.Logger, opts ...Option) *Service {
+	ctx, stop := context.WithCancelCause(context.Background())
+	s := &Service{
+		repo:     repo,
+		importer: importer,
+		logger:   logger,
+		now:      func() time.Time { return time.Now().UTC() },
+		slots:    make(chan struct{}, DefaultWorkers),
+		ctx:      ctx,
+		stop:     stop,
+		running:  make(map[uuid.UUID]*runningJob),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Recover fails jobs left queued or running by a previous process. Their
+// uploads were held in memory, so they cannot be resumed.
+func (s *Service) Recover(ctx context.Context) error {
+	count, err := s.repo.FailUnfinished(ctx, "interrupted by a server restart; upload the file again to finish", s.now())
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		s.logger.Info("marked interrupted import jobs as failed", "count", count)
+	}
+	return nil
+}
+
+// Start validates the upload's header and row count, records a queued job,
+// and imports it in the background. Only the mapping settings in opts apply;
+// the recorded batch is labelled with the filename.
+func (s *Service) Start(ctx context.Context, ownerID uuid.UUID, filename string, data []byte, opts importer.Options) (Job, error) {
+	settings := importer.Options{MaxRows: MaxJobRows, Mapping: opts.Mapping, MappingID: opts.MappingID, Label: filename}
+	total, err := s.importer.CountRows(ctx, bytes.NewReader(data), ownerID, settings)
+	if err != nil {
+		return Job{}, err
+	}
+
+	now := s.now()
+	job := Job{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		Filename:  filename,
+		Status:    StatusQueued,
+		TotalRows: total,
+		Summary:   importer.Summary{TotalRows: total},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := s.repo.Create(ctx, job); err != nil {
+		return Job{}, err
+	}
+
+	jobCtx, cancel := context.WithCancelCause(s.ctx)
+	handle := &runningJob{cancel: cancel, done: make(chan struct{})}
+	s.mu.Lock()
+	s.running[job.ID] = handle
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.run(jobCtx, job, data, settings, handle)
+	return job, nil
+}
+
+// Get returns a job with its progress and summary so far.
+func (s *Service) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error) {
+	return s.repo.Get(ctx, id, ownerID)
+}
+
+// List returns the owner's jobs, newest first.
+func (s *Service) List(ctx context.Context, ownerID uuid.UUID) ([]Job, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Cancel stops a queued or running job and waits for it to record its final
+// state. Items imported before the cancellation are kept. A job that finishes
+// first keeps its own final state, which is returned with ErrFinished.
+func (s *Service) Cancel(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error) {
+	job, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Status.Finished() {
+		return job, finishedError(job)
+	}
+
+	s.mu.Lock()
+	handle, ok := s.running[id]
+	s.mu.Unlock()
+	if !ok {
+		// Not owned by this process, or it finished since the read above; the
+		// repository only closes it out if it is still unfinished.
+		now := s.now()
+		job.Status = StatusCancelled
+		job.FinishedAt = &now
+		job.UpdatedAt = now
+		if err := s.repo.Update(ctx, job); err != nil {
+			if errors.Is(err, ErrFinished) {
+				return s.finalState(ctx, id, ownerID)
+			}
+			return Job{}, err
+		}
+		return job, nil
+	}
+
+	handle.cancel(errCancelledByOwner)
+	select {
+	case <-handle.done:
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+	return s.finalState(ctx, id, ownerID)
+}
+
+// finalState reads a job after a cancellation, reporting ErrFinished when the
+// job ended some other way first.
+func (s *Service) finalState(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error) {
+	job, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Status != StatusCancelled {
+		return job, finishedError(job)
+	}
+	return job, nil
+}
+
+func finishedError(job Job) error {
+	return fmt.Errorf("%w as %s", ErrFinished, job.Status)
+}
+
+// Shutdown stops every job and waits for them to record their state, or for
+// ctx to expire. Interrupted jobs are marked failed.
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.stop(errShuttingDown)
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Service) run(ctx context.Context, job Job, data []byte, settings importer.Options, handle *runningJob) {
+	defer s.wg.Done()
+	defer close(handle.done)
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.ID)
+		s.mu.Unlock()
+	}()
+
+	// Progress must still be saved after the job's context is cancelled.
+	store := context.WithoutCancel(ctx)
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		s.finish(store, job, context.Cause(ctx))
+		return
+	}
+
+	started := s.now()
+	job.Status = StatusRunning
+	job.StartedAt = &started
+	job.UpdatedAt = started
+	s.save(store, job)
+
+	lastSave := started
+	settings.Progress = func(summary importer.Summary, processed int) {
+		job.Summary = summary
+		job.ProcessedRows = processed
+		if now := s.now(); now.Sub(lastSave) >= progressInterval {
+			job.UpdatedAt = now
+			s.save(store, job)
+			lastSave = now
+		}
+	}
+	summary, err := s.importer.ImportWithOptions(ctx, bytes.NewReader(data), job.OwnerID, settings)
+	if err == nil || ctx.Err() != nil {
+		job.Summary = summary
+	}
+	if ctx.Err() != nil {
+		err = context.Cause(ctx)
+	}
+	s.finish(store, job, err)
+}
+
+// finish records a job's final state from the error its import ended with.
+func (s *Service) finish(ctx context.Context, job Job, err error) {
+	now := s.now()
+	switch {
+	case err == nil:
+		job.Status = StatusCompleted
+		job.ProcessedRows = job.TotalRows
+	case errors.Is(err, errCancelledByOwner):
+		job.Status = StatusCancelled
+	default:
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		if !errors.Is(err, errShuttingDown) {
+			s.logger.Error("import job failed", "job_id", job.ID, "error", err)
+		}
+	}
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+	s.save(ctx, job)
+}
+
+func (s *Service) save(ctx context.Context, job Job) {
+	if err := s.repo.Update(ctx, job); err != nil {
+		s.logger.Error("save import job", "job_id", job.ID, "error", err)
+	}
+}
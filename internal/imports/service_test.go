@@ -0,0 +1,198 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/importer"
+	"anthology/internal/items"
+)
+
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+const header = "title,creator,itemType,releaseYear,pageCount,isbn13,isbn10,description,coverImage,notes\n"
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// blockingImporter reports one processed row and then waits to be cancelled.
+type blockingImporter struct {
+	started chan struct{}
+}
+
+func (b *blockingImporter) CountRows(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts importer.Options) (int, error) {
+	return 3, nil
+}
+
+func (b *blockingImporter) ImportWithOptions(ctx context.Context, reader io.Reader, ownerID uuid.UUID, opts importer.Options) (importer.Summary, error) {
+	summary := importer.Summary{TotalRows: 3, Imported: 1}
+	opts.Progress(summary, 1)
+	close(b.started)
+	<-ctx.Done()
+	return summary, ctx.Err()
+}
+
+func waitForJob(t *testing.T, svc *Service, id uuid.UUID) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := svc.Get(context.Background(), id, testOwnerID)
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+		if job.Status.Finished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return Job{}
+}
+
+func TestServiceRunsJobToCompletion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	svc := NewService(NewInMemoryRepository(), importer.NewCSVImporter(itemSvc, nil), newTestLogger())
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	csv := header + "Dune,Frank Herbert,book,1965,,,,,,\nDune,Frank Herbert,book,1965,,,,,,\nHades,,game,,,,,,,\n"
+	job, err := svc.Start(ctx, testOwnerID, "library.csv", []byte(csv), importer.Options{})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if job.Status != StatusQueued || job.TotalRows != 3 {
+		t.Fatalf("unexpected new job %+v", job)
+	}
+
+	job = waitForJob(t, svc, job.ID)
+	if job.Status != StatusCompleted || job.ProcessedRows != 3 || job.FinishedAt == nil {
+		t.Fatalf("unexpected finished job %+v", job)
+	}
+	if job.Summary.Imported != 2 || len(job.Summary.SkippedDuplicates) != 1 {
+		t.Fatalf("unexpected summary %+v", job.Summary)
+	}
+
+	jobs, err := svc.List(ctx, testOwnerID)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("expected one kept job, got %d (%v)", len(jobs), err)
+	}
+	if _, err := svc.Cancel(ctx, job.ID, testOwnerID); !errors.Is(err, ErrFinished) {
+		t.Fatalf("expected finished error, got %v", err)
+	}
+}
+
+func TestServiceCancelKeepsPartialSummary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	blocking := &blockingImporter{started: make(chan struct{})}
+	svc := NewService(NewInMemoryRepository(), blocking, newTestLogger())
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	job, err := svc.Start(ctx, testOwnerID, "big.csv", []byte("ignored"), importer.Options{})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	<-blocking.started
+
+	cancelled, err := svc.Cancel(ctx, job.ID, testOwnerID)
+	if err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if cancelled.Status != StatusCancelled || cancelled.ProcessedRows != 1 || cancelled.Summary.Imported != 1 {
+		t.Fatalf("unexpected cancelled job %+v", cancelled)
+	}
+	if _, err := svc.Get(ctx, job.ID, uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected other owners not to see the job, got %v", err)
+	}
+}
+
+func TestServiceRejectsInvalidUploadWithoutJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	itemSvc := items.NewService(items.NewInMemoryRepository(nil))
+	svc := NewService(NewInMemoryRepository(), importer.NewCSVImporter(itemSvc, nil), newTestLogger())
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	if _, err := svc.Start(ctx, testOwnerID, "bad.csv", []byte("title\nDune\n"), importer.Options{}); !errors.Is(err, importer.ErrInvalidCSV) {
+		t.Fatalf("expected invalid csv error, got %v", err)
+	}
+	jobs, err := svc.List(ctx, testOwnerID)
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("expected no jobs, got %d (%v)", len(jobs), err)
+	}
+}
+
+func TestServiceRecoverFailsInterruptedJobs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := NewInMemoryRepository()
+	now := time.Now().UTC()
+	if _, err := repo.Create(ctx, Job{ID: uuid.New(), OwnerID: testOwnerID, Status: StatusRunning, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("seed job: %v", err)
+	}
+	svc := NewService(repo, &blockingImporter{}, newTestLogger())
+
+	if err := svc.Recover(ctx); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	jobs, _ := svc.List(ctx, testOwnerID)
+	if len(jobs) != 1 || jobs[0].Status != StatusFailed || !strings.Contains(jobs[0].Error, "restart") {
+		t.Fatalf("unexpected recovered jobs %+v", jobs)
+	}
+}
+
+// staleReadRepository serves its first Get from a copy taken before the job
+// finished, as a read racing another process's final save would.
+type staleReadRepository struct {
+	Repository
+	stale *Job
+}
+
+func (r *staleReadRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Job, error) {
+	if r.stale != nil {
+		job := *r.stale
+		r.stale = nil
+		return job, nil
+	}
+	return r.Repository.Get(ctx, id, ownerID)
+}
+
+func TestServiceCancelKeepsJobThatFinishedFirst(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	running := Job{ID: uuid.New(), OwnerID: testOwnerID, Status: StatusRunning, TotalRows: 3, CreatedAt: now, UpdatedAt: now}
+	repo := NewInMemoryRepository()
+	if _, err := repo.Create(ctx, running); err != nil {
+		t.Fatalf("seed job: %v", err)
+	}
+	completed := running
+	completed.Status = StatusCompleted
+	completed.ProcessedRows = 3
+	completed.FinishedAt = &now
+	if err := repo.Update(ctx, completed); err != nil {
+		t.Fatalf("complete job: %v", err)
+	}
+	svc := NewService(&staleReadRepository{Repository: repo, stale: &running}, &blockingImporter{}, newTestLogger())
+
+	job, err := svc.Cancel(ctx, running.ID, testOwnerID)
+	if !errors.Is(err, ErrFinished) {
+		t.Fatalf("expected finished error, got %v", err)
+	}
+	if job.Status != StatusCompleted {
+		t.Fatalf("expected the completed state to be returned, got %s", job.Status)
+	}
+	stored, _ := repo.Get(ctx, running.ID, testOwnerID)
+	if stored.Status != StatusCompleted || stored.ProcessedRows != 3 {
+		t.Fatalf("expected the completed job to be kept, got %+v", stored)
+	}
+}
@@ -0,0 +1,160 @@
+package items
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrForbidden is returned when a shared item may be seen but not changed.
+var ErrForbidden = errors.New("not allowed to change this item")
+
+// Role describes what a user may do with items in a library.
+type Role string
+
+const (
+	// RoleOwner is the library's owner.
+	RoleOwner Role = "owner"
+	// RoleEditor may change shared items but not delete them.
+	RoleEditor Role = "editor"
+	// RoleViewer may only read shared items.
+	RoleViewer Role = "viewer"
+)
+
+// Valid reports whether the role can be granted to another user.
+func (r Role) Valid() bool {
+	return r == RoleEditor || r == RoleViewer
+}
+
+// AccessGrant is an accepted share of OwnerID's library. A nil ShelfID shares
+// the whole catalog; otherwise only the items placed on that shelf.
+type AccessGrant struct {
+	OwnerID   uuid.UUID
+	OwnerName string
+	ShelfID   *uuid.UUID
+	Role      Role
+}
+
+// covers reports whether the grant reaches an item of ownerID placed on shelfID.
+func (g AccessGrant) covers(ownerID uuid.UUID, shelfID *uuid.UUID) bool {
+	if g.OwnerID != ownerID {
+		return false
+	}
+	if g.ShelfID == nil {
+		return true
+	}
+	return shelfID != nil && *shelfID == *g.ShelfID
+}
+
+// GrantSource lists the grants a user has accepted.
+type GrantSource interface {
+	AccessGrants(ctx context.Context, userID uuid.UUID) ([]AccessGrant, error)
+}
+
+// Access tells the caller whose library an item belongs to and what they may do with it.
+type Access struct {
+	OwnerID   uuid.UUID `json:"ownerId"`
+	OwnerName string    `json:"ownerName,omitempty"`
+	Role      Role      `json:"role"`
+	CanEdit   bool      `json:"canEdit"`
+	CanDelete bool      `json:"canDelete"`
+}
+
+// OwnerAccess is the access an owner has to their own library.
+func OwnerAccess(ownerID uuid.UUID) Access {
+	return Access{OwnerID: ownerID, Role: RoleOwner, CanEdit: true, CanDelete: true}
+}
+
+// ResolveAccess returns userID's access to an item or shelf of ownerID. Pass
+// the shelf the item is placed on, or the shelf itself; nil matches only
+// catalog grants. When several grants apply, editor wins over viewer.
+func ResolveAccess(userID, ownerID uuid.UUID, shelfID *uuid.UUID, grants []AccessGrant) (Access, bool) {
+	if userID == ownerID {
+		return OwnerAccess(ownerID), true
+	}
+	var (
+		access Access
+		found  bool
+	)
+	for _, grant := range grants {
+		if !grant.covers(ownerID, shelfID) {
+			continue
+		}
+		if !found || grant.Role == RoleEditor {
+			access = Access{OwnerID: ownerID, OwnerName: grant.OwnerName, Role: grant.Role, CanEdit: grant.Role == RoleEditor}
+			found = true
+		}
+	}
+	return access, found
+}
+
+// ItemAccess returns userID's access to item through grants. It is the one
+// rule for which shared items a user can see: catalog grants reach all of the
+// owner's items, and shelf grants reach items whose current slot placement
+// (Item.ShelfPlacement) is on that shelf. Listing, lookups, and shelf views
+// all use it; the Postgres List filter is its SQL form.
+func ItemAccess(userID uuid.UUID, item Item, grants []AccessGrant) (Access, bool) {
+	return ResolveAccess(userID, item.OwnerID, placementShelf(item), grants)
+}
+
+// placementShelf returns the shelf an item is placed on, if any.
+func placementShelf(item Item) *uuid.UUID {
+	if item.ShelfPlacement == nil {
+		return nil
+	}
+	return &item.ShelfPlacement.ShelfID
+}
+
+// visibleTo reports whether List should return item for opts.
+func (opts ListOptions) visibleTo(item Item) bool {
+	_, ok := ItemAccess(opts.OwnerID, item, opts.Shared)
+	return ok
+}
+
+func (s *Service) grantsFor(ctx context.Context, userID uuid.UUID) ([]AccessGrant, error) {
+	if s.grants == nil {
+		return nil, nil
+	}
+	return s.grants.AccessGrants(ctx, userID)
+}
+
+// resolve loads an item the user owns or has been granted, with its access.
+func (s *Service) resolve(ctx context.Context, id uuid.UUID, userID uuid.UUID) (Item, error) {
+	item, err := s.repo.Get(ctx, id, userID)
+	if err == nil {
+		access := OwnerAccess(userID)
+		item.Access = &access
+		return item, nil
+	}
+	if !errors.Is(err, ErrNotFound) || s.grants == nil {
+		return Item{}, err
+	}
+
+	grants, err := s.grantsFor(ctx, userID)
+	if err != nil {
+		return Item{}, err
+	}
+	tried := make(map[uuid.UUID]bool, len(grants))
+	for _, grant := range grants {
+		if tried[grant.OwnerID] {
+			continue
+		}
+		tried[grant.OwnerID] = true
+
+		item, err := s.repo.Get(ctx, id, grant.OwnerID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return Item{}, err
+		}
+		access, ok := ItemAccess(userID, item, grants)
+		if !ok {
+			return Item{}, ErrNotFound
+		}
+		item.Access = &access
+		return item, nil
+	}
+	return Item{}, ErrNotFound
+}
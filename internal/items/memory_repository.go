@@ -5,6 +5,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +15,8 @@ type InMemoryRepository struct {
 	mu    sync.RWMutex
 	data  map[uuid.UUID]Item
 	order []uuid.UUID
+	// tags maps owner ID to defined tag names and their creation time.
+	tags map[uuid.UUID]map[string]time.Time
 }
 
 // NewInMemoryRepository constructs a repository seeded with optional initial items.
@@ -24,7 +27,32 @@ func NewInMemoryRepository(initial []Item) *InMemoryRepository {
 		data[item.ID] = item
 		order = append(order, item.ID)
 	}
-	return &InMemoryRepository{data: data, order: order}
+	repo := &InMemoryRepository{data: data, order: order, tags: make(map[uuid.UUID]map[string]time.Time)}
+	for _, item := range initial {
+		repo.registerTags(item.OwnerID, item.Tags)
+	}
+	return repo
+}
+
+// registerTags records tag definitions for names the owner has not used before.
+// Callers must hold the write lock.
+func (r *InMemoryRepository) registerTags(ownerID uuid.UUID, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+	if r.tags == nil {
+		r.tags = make(map[uuid.UUID]map[string]time.Time)
+	}
+	owned, ok := r.tags[ownerID]
+	if !ok {
+		owned = make(map[string]time.Time)
+		r.tags[ownerID] = owned
+	}
+	for _, name := range tags {
+		if _, exists := owned[name]; !exists {
+			owned[name] = time.Now().UTC()
+		}
+	}
 }
 
 // Create stores a new item.
@@ -34,6 +62,7 @@ func (r *InMemoryRepository) Create(_ context.Context, item Item) (Item, error)
 
 	r.data[item.ID] = item
 	r.order = append(r.order, item.ID)
+	r.registerTags(item.OwnerID, item.Tags)
 	return item, nil
 }
 
@@ -66,8 +95,8 @@ func (r *InMemoryRepository) List(_ context.Context, opts ListOptions) ([]Item,
 
 	for _, id := range r.order {
 		if item, ok := r.data[id]; ok {
-			// Always filter by owner_id
-			if item.OwnerID != opts.OwnerID {
+			// Always filter by owner_id, widened by any shared grants
+			if !opts.visibleTo(item) {
 				continue
 			}
 			if opts.ItemType != nil && item.ItemType != *opts.ItemType {
@@ -139,6 +168,23 @@ func (r *InMemoryRepository) List(_ context.Context, opts ListOptions) ([]Item,
 				}
 			}
 
+			if opts.LoanStatus != nil {
+				switch *opts.LoanStatus {
+				case LoanStatusOnLoan:
+					if item.ActiveLoan == nil {
+						continue
+					}
+				case LoanStatusAvailable:
+					if item.ActiveLoan != nil {
+						continue
+					}
+				}
+			}
+
+			if len(opts.Tags) > 0 && !matchesTags(item.Tags, opts.Tags, opts.TagMatch) {
+				continue
+			}
+
 			items = append(items, item)
 		}
 	}
@@ -165,6 +211,7 @@ func (r *InMemoryRepository) Update(_ context.Context, item Item) (Item, error)
 		return Item{}, ErrNotFound
 	}
 	r.data[item.ID] = item
+	r.registerTags(item.OwnerID, item.Tags)
 	return item, nil
 }
 
@@ -212,6 +259,27 @@ func (r *InMemoryRepository) UpdateShelfPlacement(_ context.Context, itemID uuid
 	return nil
 }
 
+// UpdateActiveLoan updates the cached active loan for an item.
+func (r *InMemoryRepository) UpdateActiveLoan(_ context.Context, itemID uuid.UUID, loan *ActiveLoan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.data[itemID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	if loan == nil {
+		item.ActiveLoan = nil
+	} else {
+		copy := *loan
+		item.ActiveLoan = &copy
+	}
+
+	r.data[itemID] = item
+	return nil
+}
+
 // Histogram returns a count of items grouped by first letter of title.
 func (r *InMemoryRepository) Histogram(_ context.Context, opts HistogramOptions) (LetterHistogram, error) {
 	r.mu.RLock()
@@ -590,3 +658,125 @@ func (r *InMemoryRepository) ClearSeriesName(_ context.Context, seriesName strin
 	}
 	return count, nil
 }
+
+// matchesTags reports whether an item's tags satisfy the filter under the given match mode.
+func matchesTags(itemTags []string, filter []string, match TagMatch) bool {
+	for _, tag := range filter {
+		has := slices.Contains(itemTags, tag)
+		if match == TagMatchAll && !has {
+			return false
+		}
+		if match != TagMatchAll && has {
+			return true
+		}
+	}
+	return match == TagMatchAll
+}
+
+// ListTags returns the owner's tags with the number of items carrying each one.
+func (r *InMemoryRepository) ListTags(_ context.Context, ownerID uuid.UUID) ([]Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := make([]Tag, 0, len(r.tags[ownerID]))
+	for name, createdAt := range r.tags[ownerID] {
+		tag := Tag{Name: name, CreatedAt: createdAt}
+		for _, item := range r.data {
+			if item.OwnerID == ownerID && slices.Contains(item.Tags, name) {
+				tag.ItemCount++
+			}
+		}
+		tags = append(tags, tag)
+	}
+	slices.SortFunc(tags, func(a, b Tag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return tags, nil
+}
+
+// CreateTag defines a tag for the owner. Creating an existing tag is a no-op.
+func (r *InMemoryRepository) CreateTag(_ context.Context, name string, ownerID uuid.UUID) (Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.registerTags(ownerID, []string{name})
+	return Tag{Name: name, CreatedAt: r.tags[ownerID][name]}, nil
+}
+
+// RenameTag replaces oldName with newName on all of the owner's items and in the tag definitions.
+func (r *InMemoryRepository) RenameTag(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (int64, error) {
+	return r.MergeTags(ctx, []string{oldName}, newName, ownerID)
+}
+
+// MergeTags replaces every source tag with target on all of the owner's items, removing
+// duplicates, and drops the source definitions. Returns the count of affected items.
+func (r *InMemoryRepository) MergeTags(_ context.Context, sources []string, target string, ownerID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owned := r.tags[ownerID]
+	removed := 0
+	for _, source := range sources {
+		if _, ok := owned[source]; ok && source != target {
+			delete(owned, source)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0, ErrNotFound
+	}
+	r.registerTags(ownerID, []string{target})
+
+	// Match the Postgres repository, which bumps updated_at on every retagged item.
+	now := time.Now().UTC()
+	var count int64
+	for _, id := range r.order {
+		item, ok := r.data[id]
+		if !ok || item.OwnerID != ownerID {
+			continue
+		}
+		if !slices.ContainsFunc(item.Tags, func(tag string) bool { return slices.Contains(sources, tag) }) {
+			continue
+		}
+		merged := TagList{}
+		for _, tag := range item.Tags {
+			if slices.Contains(sources, tag) {
+				tag = target
+			}
+			if !slices.Contains(merged, tag) {
+				merged = append(merged, tag)
+			}
+		}
+		slices.Sort(merged)
+		item.Tags = merged
+		item.UpdatedAt = now
+		r.data[id] = item
+		count++
+	}
+	return count, nil
+}
+
+// DeleteTag removes a tag from all of the owner's items and deletes its definition.
+func (r *InMemoryRepository) DeleteTag(_ context.Context, name string, ownerID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tags[ownerID][name]; !ok {
+		return 0, ErrNotFound
+	}
+	delete(r.tags[ownerID], name)
+
+	now := time.Now().UTC()
+	var count int64
+	for _, id := range r.order {
+		item, ok := r.data[id]
+		if !ok || item.OwnerID != ownerID || !slices.Contains(item.Tags, name) {
+			continue
+		}
+		item.Tags = slices.DeleteFunc(slices.Clone(item.Tags), func(tag string) bool { return tag == name })
+		item.UpdatedAt = now
+		r.data[id] = item
+		count++
+	}
+	return count, nil
+}
@@ -78,35 +78,40 @@ const (
 
 // Item represents a catalog entry in Anthology.
 type Item struct {
-	ID             uuid.UUID       `db:"id" json:"id"`
-	OwnerID        uuid.UUID       `db:"owner_id" json:"-"`
-	Title          string          `db:"title" json:"title"`
-	Creator        string          `db:"creator" json:"creator"`
-	ItemType       ItemType        `db:"item_type" json:"itemType"`
-	ReleaseYear    *int            `db:"release_year" json:"releaseYear,omitempty"`
-	PageCount      *int            `db:"page_count" json:"pageCount,omitempty"`
-	CurrentPage    *int            `db:"current_page" json:"currentPage,omitempty"`
-	ISBN13         string          `db:"isbn_13" json:"isbn13"`
-	ISBN10         string          `db:"isbn_10" json:"isbn10"`
-	Description    string          `db:"description" json:"description"`
-	CoverImage     string          `db:"cover_image" json:"coverImage"`
-	Format         Format          `db:"format" json:"format"`
-	Genre          Genre           `db:"genre" json:"genre"`
-	Rating         *int            `db:"rating" json:"rating,omitempty"`
-	RetailPriceUsd *float64        `db:"retail_price_usd" json:"retailPriceUsd,omitempty"`
-	GoogleVolumeId string          `db:"google_volume_id" json:"googleVolumeId"`
-	Platform       string          `db:"platform" json:"platform"`
-	AgeGroup       string          `db:"age_group" json:"ageGroup"`
-	PlayerCount    string          `db:"player_count" json:"playerCount"`
-	ReadingStatus  BookStatus      `db:"reading_status" json:"readingStatus"`
-	ReadAt         *time.Time      `db:"read_at" json:"readAt,omitempty"`
-	Notes          string          `db:"notes" json:"notes"`
-	SeriesName     string          `db:"series_name" json:"seriesName"`
-	VolumeNumber   *int            `db:"volume_number" json:"volumeNumber,omitempty"`
-	TotalVolumes   *int            `db:"total_volumes" json:"totalVolumes,omitempty"`
-	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
-	UpdatedAt      time.Time       `db:"updated_at" json:"updatedAt"`
-	ShelfPlacement *ShelfPlacement `db:"-" json:"shelfPlacement,omitempty"`
+	ID               uuid.UUID       `db:"id" json:"id"`
+	OwnerID          uuid.UUID       `db:"owner_id" json:"-"`
+	Title            string          `db:"title" json:"title"`
+	Creator          string          `db:"creator" json:"creator"`
+	ItemType         ItemType        `db:"item_type" json:"itemType"`
+	ReleaseYear      *int            `db:"release_year" json:"releaseYear,omitempty"`
+	PageCount        *int            `db:"page_count" json:"pageCount,omitempty"`
+	CurrentPage      *int            `db:"current_page" json:"currentPage,omitempty"`
+	ISBN13           string          `db:"isbn_13" json:"isbn13"`
+	ISBN10           string          `db:"isbn_10" json:"isbn10"`
+	Description      string          `db:"description" json:"description"`
+	CoverImage       string          `db:"cover_image" json:"coverImage"`
+	Format           Format          `db:"format" json:"format"`
+	Genre            Genre           `db:"genre" json:"genre"`
+	Rating           *int            `db:"rating" json:"rating,omitempty"`
+	RetailPriceUsd   *float64        `db:"retail_price_usd" json:"retailPriceUsd,omitempty"`
+	GoogleVolumeId   string          `db:"google_volume_id" json:"googleVolumeId"`
+	MetadataSource   string          `db:"metadata_source" json:"metadataSource"`
+	MetadataSourceID string          `db:"metadata_source_id" json:"metadataSourceId"`
+	Platform         string          `db:"platform" json:"platform"`
+	AgeGroup         string          `db:"age_group" json:"ageGroup"`
+	PlayerCount      string          `db:"player_count" json:"playerCount"`
+	ReadingStatus    BookStatus      `db:"reading_status" json:"readingStatus"`
+	ReadAt           *time.Time      `db:"read_at" json:"readAt,omitempty"`
+	Notes            string          `db:"notes" json:"notes"`
+	SeriesName       string          `db:"series_name" json:"seriesName"`
+	VolumeNumber     *int            `db:"volume_number" json:"volumeNumber,omitempty"`
+	TotalVolumes     *int            `db:"total_volumes" json:"totalVolumes,omitempty"`
+	Tags             TagList         `db:"tags" json:"tags"`
+	CreatedAt        time.Time       `db:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time       `db:"updated_at" json:"updatedAt"`
+	ShelfPlacement   *ShelfPlacement `db:"-" json:"shelfPlacement,omitempty"`
+	ActiveLoan       *ActiveLoan     `db:"-" json:"activeLoan,omitempty"`
+	Access           *Access         `db:"-" json:"access,omitempty"`
 }
 
 // ShelfPlacement summarizes where an item lives on a shelf layout.
@@ -118,6 +123,15 @@ type ShelfPlacement struct {
 	ColIndex  int       `json:"colIndex"`
 }
 
+// ActiveLoan summarizes the outstanding loan for an item that is currently lent out.
+type ActiveLoan struct {
+	LoanID       uuid.UUID  `json:"loanId"`
+	BorrowerID   *uuid.UUID `json:"borrowerId,omitempty"`
+	BorrowerName string     `json:"borrowerName"`
+	LentAt       time.Time  `json:"lentAt"`
+	DueAt        *time.Time `json:"dueAt,omitempty"`
+}
+
 // CreateItemInput captures the data needed to create a new Item.
 type CreateItemInput struct {
 	OwnerID        uuid.UUID
@@ -136,45 +150,53 @@ type CreateItemInput struct {
 	Rating         *int
 	RetailPriceUsd *float64
 	GoogleVolumeId string
-	Platform       string
-	AgeGroup       string
-	PlayerCount    string
-	ReadingStatus  BookStatus
-	ReadAt         *time.Time
-	Notes          string
-	SeriesName     string
-	VolumeNumber   *int
-	TotalVolumes   *int
-	CreatedAt      *time.Time
-	UpdatedAt      *time.Time
+	// MetadataSource and MetadataSourceID record the catalog provider and record
+	// the item was populated from so re-syncs can return to the same source.
+	MetadataSource   string
+	MetadataSourceID string
+	Platform         string
+	AgeGroup         string
+	PlayerCount      string
+	ReadingStatus    BookStatus
+	ReadAt           *time.Time
+	Notes            string
+	SeriesName       string
+	VolumeNumber     *int
+	TotalVolumes     *int
+	Tags             []string
+	CreatedAt        *time.Time
+	UpdatedAt        *time.Time
 }
 
 // UpdateItemInput captures the editable fields for an existing item.
 type UpdateItemInput struct {
-	Title          *string
-	Creator        *string
-	ItemType       *ItemType
-	ReleaseYear    **int
-	PageCount      **int
-	CurrentPage    **int
-	ISBN13         *string
-	ISBN10         *string
-	Description    *string
-	CoverImage     *string
-	Format         *Format
-	Genre          *Genre
-	Rating         **int
-	RetailPriceUsd **float64
-	GoogleVolumeId *string
-	Platform       *string
-	AgeGroup       *string
-	PlayerCount    *string
-	ReadingStatus  *BookStatus
-	ReadAt         **time.Time
-	Notes          *string
-	SeriesName     *string
-	VolumeNumber   **int
-	TotalVolumes   **int
+	Title            *string
+	Creator          *string
+	ItemType         *ItemType
+	ReleaseYear      **int
+	PageCount        **int
+	CurrentPage      **int
+	ISBN13           *string
+	ISBN10           *string
+	Description      *string
+	CoverImage       *string
+	Format           *Format
+	Genre            *Genre
+	Rating           **int
+	RetailPriceUsd   **float64
+	GoogleVolumeId   *string
+	MetadataSource   *string
+	MetadataSourceID *string
+	Platform         *string
+	AgeGroup         *string
+	PlayerCount      *string
+	ReadingStatus    *BookStatus
+	ReadAt           **time.Time
+	Notes            *string
+	SeriesName       *string
+	VolumeNumber     **int
+	TotalVolumes     **int
+	Tags             *[]string
 }
 
 // ShelfStatus describes whether an item has been assigned to a shelf.
@@ -189,15 +211,34 @@ const (
 	ShelfStatusOff ShelfStatus = "off"
 )
 
+// LoanStatus describes whether an item is currently lent out.
+type LoanStatus string
+
+const (
+	// LoanStatusAll shows all items regardless of loans.
+	LoanStatusAll LoanStatus = "all"
+	// LoanStatusOnLoan shows only items with an active loan.
+	LoanStatusOnLoan LoanStatus = "on_loan"
+	// LoanStatusAvailable shows only items that are not lent out.
+	LoanStatusAvailable LoanStatus = "available"
+)
+
 // ListOptions describes filters for listing items.
 type ListOptions struct {
 	OwnerID       uuid.UUID
 	ItemType      *ItemType
 	ReadingStatus *BookStatus
 	ShelfStatus   *ShelfStatus
+	LoanStatus    *LoanStatus
 	Initial       *string
 	Query         *string
+	Tags          []string
+	TagMatch      TagMatch
 	Limit         *int
+	// IncludeShared asks the service to list items shared with OwnerID too;
+	// it fills Shared with the caller's grants for the repository to honour.
+	IncludeShared bool
+	Shared        []AccessGrant
 }
 
 // HistogramOptions describes filters for histogram aggregation.
@@ -282,4 +323,9 @@ type Repository interface {
 	ListSeriesNamesByNameCI(ctx context.Context, name string, ownerID uuid.UUID) ([]string, error)
 	UpdateSeriesName(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (int64, error)
 	ClearSeriesName(ctx context.Context, seriesName string, ownerID uuid.UUID) (int64, error)
+	ListTags(ctx context.Context, ownerID uuid.UUID) ([]Tag, error)
+	CreateTag(ctx context.Context, name string, ownerID uuid.UUID) (Tag, error)
+	RenameTag(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (int64, error)
+	MergeTags(ctx context.Context, sources []string, target string, ownerID uuid.UUID) (int64, error)
+	DeleteTag(ctx context.Context, name string, ownerID uuid.UUID) (int64, error)
 }
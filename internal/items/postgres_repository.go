@@ -5,9 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"anthology/internal/platform/database"
 )
 
 // PostgresRepository persists items to a Postgres database.
@@ -39,6 +43,8 @@ SELECT
     i.rating,
     i.retail_price_usd,
     i.google_volume_id,
+    i.metadata_source,
+    i.metadata_source_id,
     i.platform,
     i.age_group,
     i.player_count,
@@ -48,13 +54,19 @@ SELECT
     i.series_name,
     i.volume_number,
     i.total_volumes,
+    i.tags,
     i.created_at,
     i.updated_at,
     placement.shelf_id AS placement_shelf_id,
     placement.shelf_slot_id AS placement_shelf_slot_id,
     placement.shelf_name AS placement_shelf_name,
     placement.row_index AS placement_row_index,
-    placement.col_index AS placement_col_index
+    placement.col_index AS placement_col_index,
+    loan.id AS loan_id,
+    loan.borrower_id AS loan_borrower_id,
+    loan.borrower_name AS loan_borrower_name,
+    loan.lent_at AS loan_lent_at,
+    loan.due_at AS loan_due_at
 FROM items i
 LEFT JOIN LATERAL (
     SELECT
@@ -70,6 +82,12 @@ LEFT JOIN LATERAL (
     ORDER BY isl.created_at DESC
     LIMIT 1
 ) AS placement ON true
+LEFT JOIN LATERAL (
+    SELECT il.id, il.borrower_id, il.borrower_name, il.lent_at, il.due_at
+    FROM item_loans il
+    WHERE il.item_id = i.id AND il.returned_at IS NULL
+    LIMIT 1
+) AS loan ON true
 `
 
 type itemRow struct {
@@ -79,6 +97,11 @@ type itemRow struct {
 	PlacementShelfName   *string    `db:"placement_shelf_name"`
 	PlacementRowIndex    *int       `db:"placement_row_index"`
 	PlacementColIndex    *int       `db:"placement_col_index"`
+	LoanID               *uuid.UUID `db:"loan_id"`
+	LoanBorrowerID       *uuid.UUID `db:"loan_borrower_id"`
+	LoanBorrowerName     *string    `db:"loan_borrower_name"`
+	LoanLentAt           *time.Time `db:"loan_lent_at"`
+	LoanDueAt            *time.Time `db:"loan_due_at"`
 }
 
 func (row itemRow) toItem() Item {
@@ -96,17 +119,40 @@ func (row itemRow) toItem() Item {
 			ColIndex:  *row.PlacementColIndex,
 		}
 	}
+	if row.LoanID != nil && row.LoanLentAt != nil {
+		item.ActiveLoan = &ActiveLoan{
+			LoanID:     *row.LoanID,
+			BorrowerID: row.LoanBorrowerID,
+			LentAt:     *row.LoanLentAt,
+			DueAt:      row.LoanDueAt,
+		}
+		if row.LoanBorrowerName != nil {
+			item.ActiveLoan.BorrowerName = *row.LoanBorrowerName
+		}
+	}
 	return item
 }
 
 // Create inserts a new row and returns the stored representation.
 func (r *PostgresRepository) Create(ctx context.Context, item Item) (Item, error) {
-	insert := `INSERT INTO items (id, owner_id, title, creator, item_type, release_year, page_count, current_page, isbn_13, isbn_10, description, cover_image, format, genre, rating, retail_price_usd, google_volume_id, platform, age_group, player_count, reading_status, read_at, notes, series_name, volume_number, total_volumes, created_at, updated_at)
-VALUES (:id, :owner_id, :title, :creator, :item_type, :release_year, :page_count, :current_page, :isbn_13, :isbn_10, :description, :cover_image, :format, :genre, :rating, :retail_price_usd, :google_volume_id, :platform, :age_group, :player_count, :reading_status, :read_at, :notes, :series_name, :volume_number, :total_volumes, :created_at, :updated_at)`
+	insert := `INSERT INTO items (id, owner_id, title, creator, item_type, release_year, page_count, current_page, isbn_13, isbn_10, description, cover_image, format, genre, rating, retail_price_usd, google_volume_id, metadata_source, metadata_source_id, platform, age_group, player_count, reading_status, read_at, notes, series_name, volume_number, total_volumes, tags, created_at, updated_at)
+VALUES (:id, :owner_id, :title, :creator, :item_type, :release_year, :page_count, :current_page, :isbn_13, :isbn_10, :description, :cover_image, :format, :genre, :rating, :retail_price_usd, :google_volume_id, :metadata_source, :metadata_source_id, :platform, :age_group, :player_count, :reading_status, :read_at, :notes, :series_name, :volume_number, :total_volumes, :tags, :created_at, :updated_at)`
 
-	if _, err := r.db.NamedExecContext(ctx, insert, item); err != nil {
+	tx, err := database.Begin(ctx, r.db)
+	if err != nil {
+		return Item{}, fmt.Errorf("begin insert item: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.NamedExecContext(ctx, insert, item); err != nil {
 		return Item{}, fmt.Errorf("insert item: %w", err)
 	}
+	if err := registerTags(ctx, tx, item.OwnerID, item.Tags); err != nil {
+		return Item{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Item{}, fmt.Errorf("commit insert item: %w", err)
+	}
 
 	return r.Get(ctx, item.ID, item.OwnerID)
 }
@@ -114,7 +160,7 @@ VALUES (:id, :owner_id, :title, :creator, :item_type, :release_year, :page_count
 // Get retrieves a row by primary key and owner.
 func (r *PostgresRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Item, error) {
 	var row itemRow
-	if err := r.db.GetContext(ctx, &row, baseSelect+" WHERE i.id = $1 AND i.owner_id = $2", id, ownerID); err != nil {
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &row, baseSelect+" WHERE i.id = $1 AND i.owner_id = $2", id, ownerID); err != nil {
 		if err == sql.ErrNoRows {
 			return Item{}, ErrNotFound
 		}
@@ -129,9 +175,21 @@ func (r *PostgresRepository) List(ctx context.Context, opts ListOptions) ([]Item
 	clauses := []string{}
 	args := []any{}
 
-	// Always filter by owner_id first
-	clauses = append(clauses, fmt.Sprintf("i.owner_id = $%d", len(args)+1))
+	// Always filter by owner_id first, widened by any shared grants
+	owners := []string{fmt.Sprintf("i.owner_id = $%d", len(args)+1)}
 	args = append(args, opts.OwnerID)
+	for _, grant := range opts.Shared {
+		if grant.ShelfID == nil {
+			owners = append(owners, fmt.Sprintf("i.owner_id = $%d", len(args)+1))
+			args = append(args, grant.OwnerID)
+			continue
+		}
+		// Shelf grants reach items whose current placement is on the shelf,
+		// matching ItemAccess.
+		owners = append(owners, fmt.Sprintf("(i.owner_id = $%d AND placement.shelf_id = $%d)", len(args)+1, len(args)+2))
+		args = append(args, grant.OwnerID, *grant.ShelfID)
+	}
+	clauses = append(clauses, "("+strings.Join(owners, " OR ")+")")
 
 	if opts.ItemType != nil {
 		clauses = append(clauses, fmt.Sprintf("i.item_type = $%d", len(args)+1))
@@ -185,6 +243,24 @@ func (r *PostgresRepository) List(ctx context.Context, opts ListOptions) ([]Item
 		}
 	}
 
+	if opts.LoanStatus != nil {
+		switch *opts.LoanStatus {
+		case LoanStatusOnLoan:
+			clauses = append(clauses, "loan.id IS NOT NULL")
+		case LoanStatusAvailable:
+			clauses = append(clauses, "loan.id IS NULL")
+		}
+	}
+
+	if len(opts.Tags) > 0 {
+		if opts.TagMatch == TagMatchAll {
+			clauses = append(clauses, fmt.Sprintf("i.tags @> $%d::text[]", len(args)+1))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("i.tags && $%d::text[]", len(args)+1))
+		}
+		args = append(args, pq.Array(opts.Tags))
+	}
+
 	if len(clauses) > 0 {
 		query = query + " WHERE " + strings.Join(clauses, " AND ")
 	}
@@ -196,7 +272,7 @@ func (r *PostgresRepository) List(ctx context.Context, opts ListOptions) ([]Item
 	}
 
 	rows := []itemRow{}
-	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, args...); err != nil {
 		return nil, fmt.Errorf("list items: %w", err)
 	}
 
@@ -225,6 +301,8 @@ SET title = :title,
     rating = :rating,
     retail_price_usd = :retail_price_usd,
     google_volume_id = :google_volume_id,
+    metadata_source = :metadata_source,
+    metadata_source_id = :metadata_source_id,
     platform = :platform,
     age_group = :age_group,
     player_count = :player_count,
@@ -234,10 +312,17 @@ SET title = :title,
     series_name = :series_name,
     volume_number = :volume_number,
     total_volumes = :total_volumes,
+    tags = :tags,
     updated_at = :updated_at
 WHERE id = :id AND owner_id = :owner_id`
 
-	res, err := r.db.NamedExecContext(ctx, query, item)
+	tx, err := database.Begin(ctx, r.db)
+	if err != nil {
+		return Item{}, fmt.Errorf("begin update item: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.NamedExecContext(ctx, query, item)
 	if err != nil {
 		return Item{}, fmt.Errorf("update item: %w", err)
 	}
@@ -245,13 +330,19 @@ WHERE id = :id AND owner_id = :owner_id`
 	if err == nil && rows == 0 {
 		return Item{}, ErrNotFound
 	}
+	if err := registerTags(ctx, tx, item.OwnerID, item.Tags); err != nil {
+		return Item{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Item{}, fmt.Errorf("commit update item: %w", err)
+	}
 
 	return r.Get(ctx, item.ID, item.OwnerID)
 }
 
 // Delete removes an item.
 func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
-	res, err := r.db.ExecContext(ctx, "DELETE FROM items WHERE id = $1 AND owner_id = $2", id, ownerID)
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, "DELETE FROM items WHERE id = $1 AND owner_id = $2", id, ownerID)
 	if err != nil {
 		return fmt.Errorf("delete item: %w", err)
 	}
@@ -318,7 +409,7 @@ FROM items`
 	}
 
 	rows := []letterCount{}
-	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, args...); err != nil {
 		return nil, fmt.Errorf("histogram: %w", err)
 	}
 
@@ -368,7 +459,7 @@ func (r *PostgresRepository) FindDuplicates(ctx context.Context, input Duplicate
 	query := baseSelect + " WHERE " + ownerClause + " AND (" + strings.Join(clauses, " OR ") + ") ORDER BY i.updated_at DESC LIMIT 5"
 
 	rows := []itemRow{}
-	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, args...); err != nil {
 		return nil, fmt.Errorf("find duplicates: %w", err)
 	}
 
@@ -386,7 +477,7 @@ func (r *PostgresRepository) ListSeries(ctx context.Context, opts SeriesRepoList
 	query := baseSelect + ` WHERE i.owner_id = $1 AND i.series_name != '' AND i.item_type = 'book' ORDER BY i.series_name, i.volume_number NULLS LAST, i.title`
 
 	rows := []itemRow{}
-	if err := r.db.SelectContext(ctx, &rows, query, ownerID); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, ownerID); err != nil {
 		return nil, fmt.Errorf("list series: %w", err)
 	}
 
@@ -435,7 +526,7 @@ func (r *PostgresRepository) GetSeriesByName(ctx context.Context, name string, o
 	query := baseSelect + ` WHERE i.owner_id = $1 AND i.series_name = $2 AND i.item_type = 'book' ORDER BY i.volume_number NULLS LAST, i.title`
 
 	rows := []itemRow{}
-	if err := r.db.SelectContext(ctx, &rows, query, ownerID, name); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, ownerID, name); err != nil {
 		return SeriesSummary{}, fmt.Errorf("get series: %w", err)
 	}
 
@@ -481,7 +572,7 @@ func (r *PostgresRepository) ListSeriesNamesByNameCI(ctx context.Context, name s
 		ORDER BY series_name`
 
 	names := []string{}
-	if err := r.db.SelectContext(ctx, &names, query, ownerID, name); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &names, query, ownerID, name); err != nil {
 		return nil, fmt.Errorf("list series names: %w", err)
 	}
 	return names, nil
@@ -490,7 +581,7 @@ func (r *PostgresRepository) ListSeriesNamesByNameCI(ctx context.Context, name s
 // UpdateSeriesName updates series_name on all items matching oldName for the given owner.
 func (r *PostgresRepository) UpdateSeriesName(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (int64, error) {
 	query := `UPDATE items SET series_name = $1, updated_at = NOW() WHERE series_name = $2 AND owner_id = $3 AND item_type = 'book'`
-	res, err := r.db.ExecContext(ctx, query, newName, oldName, ownerID)
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, query, newName, oldName, ownerID)
 	if err != nil {
 		return 0, fmt.Errorf("update series name: %w", err)
 	}
@@ -500,9 +591,129 @@ func (r *PostgresRepository) UpdateSeriesName(ctx context.Context, oldName, newN
 // ClearSeriesName clears series_name, volume_number, and total_volumes on all items matching seriesName for the given owner.
 func (r *PostgresRepository) ClearSeriesName(ctx context.Context, seriesName string, ownerID uuid.UUID) (int64, error) {
 	query := `UPDATE items SET series_name = '', volume_number = NULL, total_volumes = NULL, updated_at = NOW() WHERE series_name = $1 AND owner_id = $2 AND item_type = 'book'`
-	res, err := r.db.ExecContext(ctx, query, seriesName, ownerID)
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, query, seriesName, ownerID)
 	if err != nil {
 		return 0, fmt.Errorf("clear series name: %w", err)
 	}
 	return res.RowsAffected()
 }
+
+// registerTags records any tag names the owner has not used before.
+func registerTags(ctx context.Context, tx database.Queryer, ownerID uuid.UUID, tags TagList) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	query := `INSERT INTO tags (owner_id, name) SELECT $1, unnest($2::text[]) ON CONFLICT (owner_id, name) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, ownerID, pq.Array([]string(tags))); err != nil {
+		return fmt.Errorf("register tags: %w", err)
+	}
+	return nil
+}
+
+// ListTags returns the owner's tags with the number of items carrying each one.
+func (r *PostgresRepository) ListTags(ctx context.Context, ownerID uuid.UUID) ([]Tag, error) {
+	query := `SELECT t.name, t.created_at, COUNT(i.id) AS item_count
+		FROM tags t
+		LEFT JOIN items i ON i.owner_id = t.owner_id AND i.tags @> ARRAY[t.name]
+		WHERE t.owner_id = $1
+		GROUP BY t.name, t.created_at
+		ORDER BY t.name`
+
+	tags := []Tag{}
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &tags, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return tags, nil
+}
+
+// CreateTag defines a tag for the owner. Creating an existing tag is a no-op.
+func (r *PostgresRepository) CreateTag(ctx context.Context, name string, ownerID uuid.UUID) (Tag, error) {
+	query := `INSERT INTO tags (owner_id, name) VALUES ($1, $2)
+		ON CONFLICT (owner_id, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING name, created_at`
+
+	var tag Tag
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &tag, query, ownerID, name); err != nil {
+		return Tag{}, fmt.Errorf("create tag: %w", err)
+	}
+	return tag, nil
+}
+
+// RenameTag replaces oldName with newName on all of the owner's items and in the tag definitions.
+func (r *PostgresRepository) RenameTag(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (int64, error) {
+	return r.MergeTags(ctx, []string{oldName}, newName, ownerID)
+}
+
+// MergeTags replaces every source tag with target on all of the owner's items, removing
+// duplicates, and drops the source definitions. Returns the count of affected items.
+func (r *PostgresRepository) MergeTags(ctx context.Context, sources []string, target string, ownerID uuid.UUID) (int64, error) {
+	tx, err := database.Begin(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("begin merge tags: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE owner_id = $1 AND name = ANY($2::text[]) AND name <> $3`, ownerID, pq.Array(sources), target)
+	if err != nil {
+		return 0, fmt.Errorf("delete merged tags: %w", err)
+	}
+	if removed, err := res.RowsAffected(); err == nil && removed == 0 {
+		return 0, ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO tags (owner_id, name) VALUES ($1, $2) ON CONFLICT (owner_id, name) DO NOTHING`, ownerID, target); err != nil {
+		return 0, fmt.Errorf("create merge target: %w", err)
+	}
+
+	query := `UPDATE items
+		SET tags = ARRAY(
+			SELECT DISTINCT CASE WHEN tag = ANY($1::text[]) THEN $2::text ELSE tag END
+			FROM unnest(tags) AS tag
+			ORDER BY 1
+		), updated_at = NOW()
+		WHERE owner_id = $3 AND tags && $1::text[]`
+	res, err = tx.ExecContext(ctx, query, pq.Array(sources), target, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("merge tags: %w", err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("merge tags rows: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit merge tags: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteTag removes a tag from all of the owner's items and deletes its definition.
+func (r *PostgresRepository) DeleteTag(ctx context.Context, name string, ownerID uuid.UUID) (int64, error) {
+	tx, err := database.Begin(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("begin delete tag: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE owner_id = $1 AND name = $2`, ownerID, name)
+	if err != nil {
+		return 0, fmt.Errorf("delete tag: %w", err)
+	}
+	if removed, err := res.RowsAffected(); err == nil && removed == 0 {
+		return 0, ErrNotFound
+	}
+
+	res, err = tx.ExecContext(ctx, `UPDATE items SET tags = array_remove(tags, $1), updated_at = NOW() WHERE owner_id = $2 AND tags @> ARRAY[$1::text]`, name, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("remove tag from items: %w", err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("remove tag rows: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit delete tag: %w", err)
+	}
+	return count, nil
+}
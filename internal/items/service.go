@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"slices"
 	"strings"
@@ -31,16 +32,76 @@ var allowedImageMIMETypes = map[string]bool{
 
 // Service orchestrates validation and persistence for items.
 type Service struct {
-	repo Repository
+	repo        Repository
+	deleteHooks []DeleteHook
+	grants      GrantSource
+	logger      *slog.Logger
+}
+
+// DeleteHook tidies up data that referenced an item after it is deleted.
+// Postgres foreign keys already cascade the rows away with the item, so hooks
+// only handle what a cascade cannot (such as renumbering the queue) and must
+// tolerate finding nothing left to remove.
+type DeleteHook interface {
+	ItemDeleted(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error
+}
+
+// Option configures the Service during construction.
+type Option func(*Service)
+
+// WithDeleteHook registers a hook that runs whenever Delete removes an item.
+func WithDeleteHook(hook DeleteHook) Option {
+	return func(s *Service) {
+		s.deleteHooks = append(s.deleteHooks, hook)
+	}
+}
+
+// WithLogger sets the logger used to report delete hooks that fail.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithGrantSource lets other users see and edit items shared with them.
+func WithGrantSource(source GrantSource) Option {
+	return func(s *Service) {
+		s.grants = source
+	}
 }
 
 // NewService wires a Service with the provided repository.
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo Repository, opts ...Option) *Service {
+	svc := &Service{repo: repo, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
 }
 
 // Create validates and persists a new item.
 func (s *Service) Create(ctx context.Context, input CreateItemInput) (Item, error) {
+	item, err := newItem(input)
+	if err != nil {
+		return Item{}, err
+	}
+	return s.repo.Create(ctx, item)
+}
+
+// ValidateCreate reports whether Create would accept the input, without
+// saving anything. It returns the normalized item Create would save, which
+// has no ID yet.
+func (s *Service) ValidateCreate(input CreateItemInput) (Item, error) {
+	item, err := newItem(input)
+	if err != nil {
+		return Item{}, err
+	}
+	item.ID = uuid.Nil
+	return item, nil
+}
+
+// newItem validates and normalizes an input into an Item with a fresh ID.
+func newItem(input CreateItemInput) (Item, error) {
 	if input.OwnerID == (uuid.UUID{}) {
 		return Item{}, validationErr("ownerID is required")
 	}
@@ -74,6 +135,8 @@ func (s *Service) Create(ctx context.Context, input CreateItemInput) (Item, erro
 		input.GoogleVolumeId,
 	)
 
+	metadataSource, metadataSourceID := normalizeMetadataSource(input.MetadataSource, input.MetadataSourceID, googleVolumeId)
+
 	// Clear game-specific fields for non-game items
 	platform, ageGroup, playerCount := normalizeGameFields(
 		input.ItemType,
@@ -93,6 +156,11 @@ func (s *Service) Create(ctx context.Context, input CreateItemInput) (Item, erro
 		return Item{}, err
 	}
 
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return Item{}, err
+	}
+
 	now := time.Now().UTC()
 	createdAt := now
 	if input.CreatedAt != nil && !input.CreatedAt.IsZero() {
@@ -103,45 +171,71 @@ func (s *Service) Create(ctx context.Context, input CreateItemInput) (Item, erro
 		updatedAt = input.UpdatedAt.UTC()
 	}
 	item := Item{
-		ID:             uuid.New(),
-		OwnerID:        input.OwnerID,
-		Title:          strings.TrimSpace(input.Title),
-		Creator:        strings.TrimSpace(input.Creator),
-		ItemType:       input.ItemType,
-		ReleaseYear:    normalizeYear(input.ReleaseYear),
-		PageCount:      pageCount,
-		CurrentPage:    normalizedCurrentPage,
-		ISBN13:         strings.TrimSpace(input.ISBN13),
-		ISBN10:         strings.TrimSpace(input.ISBN10),
-		Description:    strings.TrimSpace(input.Description),
-		CoverImage:     coverImage,
-		Format:         format,
-		Genre:          genre,
-		Rating:         rating,
-		RetailPriceUsd: retailPriceUsd,
-		GoogleVolumeId: googleVolumeId,
-		Platform:       platform,
-		AgeGroup:       ageGroup,
-		PlayerCount:    playerCount,
-		ReadingStatus:  readingStatus,
-		ReadAt:         readAt,
-		Notes:          strings.TrimSpace(input.Notes),
-		SeriesName:     seriesName,
-		VolumeNumber:   volumeNumber,
-		TotalVolumes:   totalVolumes,
-		CreatedAt:      createdAt,
-		UpdatedAt:      updatedAt,
-	}
-
-	return s.repo.Create(ctx, item)
+		ID:               uuid.New(),
+		OwnerID:          input.OwnerID,
+		Title:            strings.TrimSpace(input.Title),
+		Creator:          strings.TrimSpace(input.Creator),
+		ItemType:         input.ItemType,
+		ReleaseYear:      normalizeYear(input.ReleaseYear),
+		PageCount:        pageCount,
+		CurrentPage:      normalizedCurrentPage,
+		ISBN13:           strings.TrimSpace(input.ISBN13),
+		ISBN10:           strings.TrimSpace(input.ISBN10),
+		Description:      strings.TrimSpace(input.Description),
+		CoverImage:       coverImage,
+		Format:           format,
+		Genre:            genre,
+		Rating:           rating,
+		RetailPriceUsd:   retailPriceUsd,
+		GoogleVolumeId:   googleVolumeId,
+		MetadataSource:   metadataSource,
+		MetadataSourceID: metadataSourceID,
+		Platform:         platform,
+		AgeGroup:         ageGroup,
+		PlayerCount:      playerCount,
+		ReadingStatus:    readingStatus,
+		ReadAt:           readAt,
+		Notes:            strings.TrimSpace(input.Notes),
+		SeriesName:       seriesName,
+		VolumeNumber:     volumeNumber,
+		TotalVolumes:     totalVolumes,
+		Tags:             tags,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+
+	return item, nil
 }
 
-// List returns catalogued items ordered by creation date descending.
+// List returns catalogued items ordered by creation date descending. Each
+// item carries the caller's access; with IncludeShared, items shared with the
+// owner are listed alongside their own.
 func (s *Service) List(ctx context.Context, opts ListOptions) ([]Item, error) {
+	if len(opts.Tags) > 0 {
+		opts.Tags = normalizeTagFilter(opts.Tags)
+		if len(opts.Tags) == 0 {
+			return []Item{}, nil
+		}
+	}
+
+	opts.Shared = nil
+	if opts.IncludeShared {
+		grants, err := s.grantsFor(ctx, opts.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		opts.Shared = grants
+	}
+
 	items, err := s.repo.List(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
+	for i := range items {
+		if access, ok := ItemAccess(opts.OwnerID, items[i], opts.Shared); ok {
+			items[i].Access = &access
+		}
+	}
 
 	slices.SortFunc(items, compareItemsByCreatedDesc)
 
@@ -152,14 +246,38 @@ func (s *Service) List(ctx context.Context, opts ListOptions) ([]Item, error) {
 	return items, nil
 }
 
-// Get retrieves an item by ID and owner.
-func (s *Service) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Item, error) {
-	return s.repo.Get(ctx, id, ownerID)
+// Get retrieves an item the user owns or that has been shared with them.
+func (s *Service) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (Item, error) {
+	return s.resolve(ctx, id, userID)
+}
+
+// editable loads an item the user may change. The item is returned without
+// its access, which is returned separately for save.
+func (s *Service) editable(ctx context.Context, id uuid.UUID, userID uuid.UUID) (Item, *Access, error) {
+	item, err := s.resolve(ctx, id, userID)
+	if err != nil {
+		return Item{}, nil, err
+	}
+	access := item.Access
+	if !access.CanEdit {
+		return Item{}, nil, ErrForbidden
+	}
+	item.Access = nil
+	return item, access, nil
 }
 
-// Update applies modifications to an item.
-func (s *Service) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, input UpdateItemInput) (Item, error) {
-	existing, err := s.repo.Get(ctx, id, ownerID)
+func (s *Service) save(ctx context.Context, item Item, access *Access) (Item, error) {
+	updated, err := s.repo.Update(ctx, item)
+	if err != nil {
+		return Item{}, err
+	}
+	updated.Access = access
+	return updated, nil
+}
+
+// Update applies modifications to an item owned by or shared for editing with userID.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateItemInput) (Item, error) {
+	existing, access, err := s.editable(ctx, id, userID)
 	if err != nil {
 		return Item{}, err
 	}
@@ -255,6 +373,22 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, i
 		existing.GoogleVolumeId = strings.TrimSpace(*input.GoogleVolumeId)
 	}
 
+	if input.MetadataSource != nil {
+		existing.MetadataSource = strings.TrimSpace(*input.MetadataSource)
+	}
+
+	if input.MetadataSourceID != nil {
+		existing.MetadataSourceID = strings.TrimSpace(*input.MetadataSourceID)
+	}
+
+	if input.Tags != nil {
+		tags, err := normalizeTags(*input.Tags)
+		if err != nil {
+			return Item{}, err
+		}
+		existing.Tags = tags
+	}
+
 	// Handle series fields
 	if input.SeriesName != nil {
 		existing.SeriesName = strings.TrimSpace(*input.SeriesName)
@@ -300,12 +434,32 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, i
 	existing.ReadAt = normalizedReadAt
 	existing.CurrentPage = normalizedCurrentPage
 	existing.UpdatedAt = time.Now().UTC()
-	return s.repo.Update(ctx, existing)
+	return s.save(ctx, existing, access)
 }
 
-// Delete removes an item by ID and owner.
+// Delete removes an item by ID and owner, then runs any registered delete
+// hooks. In Postgres the delete cascades to the item's loans, reading history,
+// queue entry, reviews, and shelf placements; the queue hook is still needed
+// to renumber the positions left behind. The item is gone once the repository
+// delete succeeds, so hook failures are logged rather than returned. Users the
+// item is shared with get ErrForbidden.
 func (s *Service) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
-	return s.repo.Delete(ctx, id, ownerID)
+	item, err := s.resolve(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if !item.Access.CanDelete {
+		return ErrForbidden
+	}
+	if err := s.repo.Delete(ctx, id, ownerID); err != nil {
+		return err
+	}
+	for _, hook := range s.deleteHooks {
+		if err := hook.ItemDeleted(ctx, id, ownerID); err != nil {
+			s.logger.Error("failed to clean up after deleting item", "item_id", id, "error", err)
+		}
+	}
+	return nil
 }
 
 // Histogram returns a count of items grouped by first letter of title.
@@ -511,47 +665,47 @@ func (s *Service) determineSeriesStatus(summary SeriesSummary) SeriesStatus {
 	return SeriesStatusUnknown
 }
 
-// ResyncMetadata refreshes an item's metadata from Google Books.
-// Uses googleVolumeId if available, otherwise falls back to ISBN lookup.
-// Only updates fields that Google provides (genre, retailPriceUsd, googleVolumeId).
-// Does NOT overwrite user-entered fields like format and rating.
-func (s *Service) ResyncMetadata(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, catalogSvc *catalog.Service) (Item, error) {
-	existing, err := s.repo.Get(ctx, id, ownerID)
+// ResyncMetadata refreshes an item's metadata from the provider that originally supplied it.
+// Uses the recorded metadata source when available, otherwise falls back to an identifier lookup
+// across the providers registered for the item's category.
+// Only fills provider-owned fields and gaps; does NOT overwrite user-entered fields like format and rating.
+func (s *Service) ResyncMetadata(ctx context.Context, id uuid.UUID, userID uuid.UUID, catalogSvc *catalog.Service) (Item, error) {
+	existing, access, err := s.editable(ctx, id, userID)
 	if err != nil {
 		return Item{}, err
 	}
 
-	if existing.ItemType != ItemTypeBook {
-		return Item{}, validationErr("re-sync is only available for books")
+	source, sourceID := existing.MetadataSource, existing.MetadataSourceID
+	if (source == "" || sourceID == "") && existing.GoogleVolumeId != "" {
+		source, sourceID = catalog.ProviderGoogleBooks, existing.GoogleVolumeId
 	}
 
 	var metadata catalog.Metadata
-	var lookupErr error
 
-	// Prefer volume ID for precise lookup
-	if existing.GoogleVolumeId != "" {
-		metadata, lookupErr = catalogSvc.LookupByVolumeID(ctx, existing.GoogleVolumeId)
-		if lookupErr != nil && !errors.Is(lookupErr, catalog.ErrNotFound) {
-			return Item{}, fmt.Errorf("lookup by volume ID: %w", lookupErr)
+	// Prefer the recorded provider record for a precise lookup
+	if source != "" && sourceID != "" {
+		metadata, err = catalogSvc.LookupFromProvider(ctx, source, sourceID)
+		if err != nil && !errors.Is(err, catalog.ErrNotFound) && !errors.Is(err, catalog.ErrUnknownProvider) {
+			return Item{}, fmt.Errorf("lookup from %s: %w", source, err)
 		}
 	}
 
-	// Fall back to ISBN if volume ID lookup failed or wasn't available
+	// Fall back to the stored identifier if the source lookup failed or wasn't available
 	if metadata.Title == "" {
 		query := existing.ISBN13
 		if query == "" {
 			query = existing.ISBN10
 		}
 		if query == "" {
-			return Item{}, validationErr("no googleVolumeId or ISBN available for re-sync")
+			return Item{}, validationErr("no metadata source or identifier available for re-sync")
 		}
 
-		results, err := catalogSvc.Lookup(ctx, query, catalog.CategoryBook)
+		results, err := catalogSvc.Lookup(ctx, query, catalog.Category(existing.ItemType))
 		if err != nil {
-			if errors.Is(err, catalog.ErrNotFound) {
+			if errors.Is(err, catalog.ErrNotFound) || errors.Is(err, catalog.ErrUnsupportedCategory) {
 				return Item{}, validationErr("no metadata found for this item")
 			}
-			return Item{}, fmt.Errorf("lookup by ISBN: %w", err)
+			return Item{}, fmt.Errorf("lookup by identifier: %w", err)
 		}
 		if len(results) == 0 {
 			return Item{}, validationErr("no metadata found for this item")
@@ -559,7 +713,11 @@ func (s *Service) ResyncMetadata(ctx context.Context, id uuid.UUID, ownerID uuid
 		metadata = results[0]
 	}
 
-	// Apply refreshed metadata - only update Google-provided fields
+	// Apply refreshed metadata - provider-owned fields are replaced
+	if metadata.Source != "" && metadata.SourceID != "" {
+		existing.MetadataSource = metadata.Source
+		existing.MetadataSourceID = metadata.SourceID
+	}
 	if metadata.GoogleVolumeId != "" {
 		existing.GoogleVolumeId = metadata.GoogleVolumeId
 	}
@@ -570,16 +728,25 @@ func (s *Service) ResyncMetadata(ctx context.Context, id uuid.UUID, ownerID uuid
 		existing.RetailPriceUsd = metadata.RetailPriceUsd
 	}
 
-	// Also refresh standard fields if they were empty and Google provides them
+	// Also refresh standard fields if they were empty and the provider supplies them
 	if existing.CoverImage == "" && metadata.CoverImage != "" {
 		existing.CoverImage = metadata.CoverImage
 	}
 	if existing.Description == "" && metadata.Description != "" {
 		existing.Description = metadata.Description
 	}
+	if existing.Platform == "" && metadata.Platform != "" {
+		existing.Platform = metadata.Platform
+	}
+	if existing.AgeGroup == "" && metadata.AgeGroup != "" {
+		existing.AgeGroup = metadata.AgeGroup
+	}
+	if existing.PlayerCount == "" && metadata.PlayerCount != "" {
+		existing.PlayerCount = metadata.PlayerCount
+	}
 
 	existing.UpdatedAt = time.Now().UTC()
-	return s.repo.Update(ctx, existing)
+	return s.save(ctx, existing, access)
 }
 
 // NormalizeTitle prepares a title for duplicate comparison by lowercasing and trimming whitespace.
@@ -782,6 +949,20 @@ func normalizeGameFields(itemType ItemType, platform, ageGroup, playerCount stri
 	return strings.TrimSpace(platform), strings.TrimSpace(ageGroup), strings.TrimSpace(playerCount)
 }
 
+// normalizeMetadataSource trims the recorded catalog source. Items created from
+// Google Books before sources were tracked fall back to their volume ID.
+func normalizeMetadataSource(source, sourceID, googleVolumeId string) (string, string) {
+	source = strings.TrimSpace(source)
+	sourceID = strings.TrimSpace(sourceID)
+	if source == "" && googleVolumeId != "" {
+		return catalog.ProviderGoogleBooks, googleVolumeId
+	}
+	if source == "" || sourceID == "" {
+		return "", ""
+	}
+	return source, sourceID
+}
+
 // normalizeSeriesFields validates and normalizes series-specific fields.
 // For non-book items, all series fields are cleared.
 // Validates that volumeNumber does not exceed totalVolumes if both are set.
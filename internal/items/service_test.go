@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"io"
+	"log/slog"
 	"slices"
 	"strings"
 	"testing"
@@ -429,6 +431,42 @@ func TestServiceListSupportsSearchAndLimit(t *testing.T) {
 	}
 }
 
+type deleteHookFunc func(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error
+
+func (f deleteHookFunc) ItemDeleted(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+	return f(ctx, itemID, ownerID)
+}
+
+func TestServiceDeleteRunsHooksAfterRemovingItem(t *testing.T) {
+	repo := NewInMemoryRepository(nil)
+	var visible []bool
+	observe := deleteHookFunc(func(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+		_, err := repo.Get(ctx, itemID, ownerID)
+		visible = append(visible, err == nil)
+		return nil
+	})
+	failing := deleteHookFunc(func(context.Context, uuid.UUID, uuid.UUID) error {
+		return errors.New("queue unavailable")
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(repo, WithDeleteHook(failing), WithDeleteHook(observe), WithLogger(logger))
+
+	item, err := svc.Create(context.Background(), CreateItemInput{OwnerID: testOwnerID, Title: "Dune", ItemType: ItemTypeBook})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := svc.Delete(context.Background(), item.ID, testOwnerID); err != nil {
+		t.Fatalf("expected the delete to succeed despite the hook failure, got %v", err)
+	}
+
+	if _, err := svc.Get(context.Background(), item.ID, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected item to be deleted despite the hook failure, got %v", err)
+	}
+	if len(visible) != 1 || visible[0] {
+		t.Fatalf("expected later hooks to run once the item was gone, got %v", visible)
+	}
+}
+
 func TestServiceCreateTrimsInputAndNormalizesYear(t *testing.T) {
 	repo := NewInMemoryRepository(nil)
 	svc := NewService(repo)
@@ -728,6 +766,36 @@ func (r *seriesUpdateRepo) ClearSeriesName(context.Context, string, uuid.UUID) (
 	return 0, nil
 }
 
+func (r *seriesUpdateRepo) ListTags(context.Context, uuid.UUID) ([]Tag, error) {
+	r.t.Helper()
+	r.t.Fatalf("unexpected ListTags call")
+	return nil, nil
+}
+
+func (r *seriesUpdateRepo) CreateTag(context.Context, string, uuid.UUID) (Tag, error) {
+	r.t.Helper()
+	r.t.Fatalf("unexpected CreateTag call")
+	return Tag{}, nil
+}
+
+func (r *seriesUpdateRepo) RenameTag(context.Context, string, string, uuid.UUID) (int64, error) {
+	r.t.Helper()
+	r.t.Fatalf("unexpected RenameTag call")
+	return 0, nil
+}
+
+func (r *seriesUpdateRepo) MergeTags(context.Context, []string, string, uuid.UUID) (int64, error) {
+	r.t.Helper()
+	r.t.Fatalf("unexpected MergeTags call")
+	return 0, nil
+}
+
+func (r *seriesUpdateRepo) DeleteTag(context.Context, string, uuid.UUID) (int64, error) {
+	r.t.Helper()
+	r.t.Fatalf("unexpected DeleteTag call")
+	return 0, nil
+}
+
 func TestServiceAllowsDataURIsLongerThanURLLimitWhenUnderByteCap(t *testing.T) {
 	repo := NewInMemoryRepository(nil)
 	svc := NewService(repo)
@@ -0,0 +1,243 @@
+package items
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	maxTagLength   = 50
+	maxTagsPerItem = 25
+)
+
+// TagMatch controls how multiple tag filters are combined when listing items.
+type TagMatch string
+
+const (
+	// TagMatchAny returns items carrying at least one of the requested tags.
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll returns only items carrying every requested tag.
+	TagMatchAll TagMatch = "all"
+)
+
+// Tag is a user-defined label such as "signed" or "first-edition".
+type Tag struct {
+	Name      string    `db:"name" json:"name"`
+	ItemCount int       `db:"item_count" json:"itemCount"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// TagList is the normalized set of tag names attached to an item.
+// It is stored as a Postgres text[] and always serializes as a JSON array.
+type TagList []string
+
+// Value implements driver.Valuer.
+func (t TagList) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	return pq.StringArray(t).Value()
+}
+
+// Scan implements sql.Scanner.
+func (t *TagList) Scan(src any) error {
+	var values pq.StringArray
+	if err := values.Scan(src); err != nil {
+		return err
+	}
+	*t = TagList(values)
+	return nil
+}
+
+// MarshalJSON renders a nil list as an empty array.
+func (t TagList) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(t))
+}
+
+// NormalizeTagName lowercases a tag and collapses internal whitespace so
+// "First  Edition" and "first edition" refer to the same tag.
+func NormalizeTagName(raw string) (string, error) {
+	name := strings.ToLower(strings.Join(strings.Fields(raw), " "))
+	if name == "" {
+		return "", validationErr("tag name is required")
+	}
+	if strings.Contains(name, ",") {
+		return "", validationErr("tag names cannot contain commas")
+	}
+	if len(name) > maxTagLength {
+		return "", validationErr(fmt.Sprintf("tag names must be %d characters or less", maxTagLength))
+	}
+	return name, nil
+}
+
+// normalizeTags normalizes, de-duplicates, and sorts a set of tag names.
+// Blank entries are ignored.
+func normalizeTags(raw []string) (TagList, error) {
+	tags := TagList{}
+	for _, value := range raw {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		name, err := NormalizeTagName(value)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	if len(tags) > maxTagsPerItem {
+		return nil, validationErr(fmt.Sprintf("items can have at most %d tags", maxTagsPerItem))
+	}
+	slices.Sort(tags)
+	return tags, nil
+}
+
+// normalizeTagFilter prepares list filters, silently dropping values that could never match.
+func normalizeTagFilter(raw []string) []string {
+	filter := []string{}
+	for _, value := range raw {
+		name, err := NormalizeTagName(value)
+		if err != nil {
+			continue
+		}
+		if !slices.Contains(filter, name) {
+			filter = append(filter, name)
+		}
+	}
+	return filter
+}
+
+func findTag(tags []Tag, name string) (Tag, bool) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
+
+// ListTags returns every tag defined by the owner with usage counts.
+func (s *Service) ListTags(ctx context.Context, ownerID uuid.UUID) ([]Tag, error) {
+	return s.repo.ListTags(ctx, ownerID)
+}
+
+// CreateTag defines a new tag so it can be offered before any item uses it.
+func (s *Service) CreateTag(ctx context.Context, name string, ownerID uuid.UUID) (Tag, error) {
+	name, err := NormalizeTagName(name)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	existing, err := s.repo.ListTags(ctx, ownerID)
+	if err != nil {
+		return Tag{}, err
+	}
+	if _, ok := findTag(existing, name); ok {
+		return Tag{}, validationErr("a tag with this name already exists")
+	}
+
+	return s.repo.CreateTag(ctx, name, ownerID)
+}
+
+// RenameTag renames a tag across all of the owner's items.
+// Renaming onto an existing tag is rejected; use MergeTags instead.
+func (s *Service) RenameTag(ctx context.Context, oldName, newName string, ownerID uuid.UUID) (Tag, error) {
+	oldName, err := NormalizeTagName(oldName)
+	if err != nil {
+		return Tag{}, err
+	}
+	newName, err = NormalizeTagName(newName)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	existing, err := s.repo.ListTags(ctx, ownerID)
+	if err != nil {
+		return Tag{}, err
+	}
+	current, ok := findTag(existing, oldName)
+	if !ok {
+		return Tag{}, ErrNotFound
+	}
+	if oldName == newName {
+		return current, nil
+	}
+	if _, ok := findTag(existing, newName); ok {
+		return Tag{}, validationErr("a tag with this name already exists; merge the tags instead")
+	}
+
+	count, err := s.repo.RenameTag(ctx, oldName, newName, ownerID)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	return Tag{Name: newName, ItemCount: int(count), CreatedAt: current.CreatedAt}, nil
+}
+
+// MergeTags folds the source tags into target across all of the owner's items.
+// The target is created if it does not exist yet.
+func (s *Service) MergeTags(ctx context.Context, sources []string, target string, ownerID uuid.UUID) (Tag, error) {
+	target, err := NormalizeTagName(target)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	existing, err := s.repo.ListTags(ctx, ownerID)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	normalizedSources := []string{}
+	for _, source := range sources {
+		name, err := NormalizeTagName(source)
+		if err != nil {
+			return Tag{}, err
+		}
+		if name == target || slices.Contains(normalizedSources, name) {
+			continue
+		}
+		if _, ok := findTag(existing, name); !ok {
+			return Tag{}, validationErr(fmt.Sprintf("tag %q does not exist", name))
+		}
+		normalizedSources = append(normalizedSources, name)
+	}
+	if len(normalizedSources) == 0 {
+		return Tag{}, validationErr("at least one source tag different from the target is required")
+	}
+
+	if _, err := s.repo.MergeTags(ctx, normalizedSources, target, ownerID); err != nil {
+		return Tag{}, err
+	}
+
+	merged, err := s.repo.ListTags(ctx, ownerID)
+	if err != nil {
+		return Tag{}, err
+	}
+	tag, ok := findTag(merged, target)
+	if !ok {
+		return Tag{}, ErrNotFound
+	}
+	return tag, nil
+}
+
+// DeleteTag removes a tag from all of the owner's items and deletes its definition.
+// Returns the count of affected items.
+func (s *Service) DeleteTag(ctx context.Context, name string, ownerID uuid.UUID) (int64, error) {
+	name, err := NormalizeTagName(name)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.DeleteTag(ctx, name, ownerID)
+}
@@ -0,0 +1,187 @@
+package items
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func createTaggedItem(t *testing.T, svc *Service, ownerID uuid.UUID, title string, tags ...string) Item {
+	t.Helper()
+	item, err := svc.Create(context.Background(), CreateItemInput{OwnerID: ownerID, Title: title, ItemType: ItemTypeBook, Tags: tags})
+	if err != nil {
+		t.Fatalf("create %q: %v", title, err)
+	}
+	return item
+}
+
+func TestServiceCreateNormalizesTags(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+	ownerID := uuid.New()
+
+	item := createTaggedItem(t, svc, ownerID, "Dune", "  Signed ", "First   Edition", "signed", "")
+
+	if !slices.Equal(item.Tags, TagList{"first edition", "signed"}) {
+		t.Fatalf("unexpected tags %q", item.Tags)
+	}
+
+	tags, err := svc.ListTags(context.Background(), ownerID)
+	if err != nil {
+		t.Fatalf("list tags: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Name != "first edition" || tags[0].ItemCount != 1 {
+		t.Fatalf("unexpected tag definitions %+v", tags)
+	}
+}
+
+func TestServiceCreateRejectsInvalidTags(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+
+	_, err := svc.Create(context.Background(), CreateItemInput{OwnerID: uuid.New(), Title: "Dune", ItemType: ItemTypeBook, Tags: []string{"signed, gift"}})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestServiceListFiltersByTags(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+	ownerID := uuid.New()
+	createTaggedItem(t, svc, ownerID, "Signed Gift", "signed", "gift")
+	createTaggedItem(t, svc, ownerID, "Signed Only", "signed")
+	createTaggedItem(t, svc, ownerID, "Untagged")
+
+	anyMatch, err := svc.List(context.Background(), ListOptions{OwnerID: ownerID, Tags: []string{"Gift", "signed"}})
+	if err != nil {
+		t.Fatalf("list any: %v", err)
+	}
+	if len(anyMatch) != 2 {
+		t.Fatalf("expected 2 items matching any tag, got %d", len(anyMatch))
+	}
+
+	allMatch, err := svc.List(context.Background(), ListOptions{OwnerID: ownerID, Tags: []string{"gift", "signed"}, TagMatch: TagMatchAll})
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if len(allMatch) != 1 || allMatch[0].Title != "Signed Gift" {
+		t.Fatalf("expected only the item with every tag, got %+v", allMatch)
+	}
+}
+
+func TestServiceUpdateReplacesTags(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+	ownerID := uuid.New()
+	item := createTaggedItem(t, svc, ownerID, "Dune", "signed")
+
+	tags := []string{"gift"}
+	updated, err := svc.Update(context.Background(), item.ID, ownerID, UpdateItemInput{Tags: &tags})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !slices.Equal(updated.Tags, TagList{"gift"}) {
+		t.Fatalf("unexpected tags %q", updated.Tags)
+	}
+
+	title := "Dune Messiah"
+	updated, err = svc.Update(context.Background(), item.ID, ownerID, UpdateItemInput{Title: &title})
+	if err != nil {
+		t.Fatalf("update title: %v", err)
+	}
+	if !slices.Equal(updated.Tags, TagList{"gift"}) {
+		t.Fatalf("expected tags to be untouched, got %q", updated.Tags)
+	}
+}
+
+func TestServiceRenameTag(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+	ownerID := uuid.New()
+	item := createTaggedItem(t, svc, ownerID, "Dune", "autographed")
+	createTaggedItem(t, svc, ownerID, "Emma", "gift")
+
+	tag, err := svc.RenameTag(context.Background(), "Autographed", "signed", ownerID)
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if tag.Name != "signed" || tag.ItemCount != 1 {
+		t.Fatalf("unexpected tag %+v", tag)
+	}
+
+	stored, _ := svc.Get(context.Background(), item.ID, ownerID)
+	if !slices.Equal(stored.Tags, TagList{"signed"}) {
+		t.Fatalf("expected item to be retagged, got %q", stored.Tags)
+	}
+
+	if _, err := svc.RenameTag(context.Background(), "signed", "GIFT", ownerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected collision to be rejected, got %v", err)
+	}
+	if _, err := svc.RenameTag(context.Background(), "missing", "other", ownerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestServiceMergeTags(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+	ownerID := uuid.New()
+	both := createTaggedItem(t, svc, ownerID, "Dune", "autographed", "signed")
+	createTaggedItem(t, svc, ownerID, "Emma", "inscribed")
+
+	tag, err := svc.MergeTags(context.Background(), []string{"autographed", "inscribed"}, "signed", ownerID)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if tag.Name != "signed" || tag.ItemCount != 2 {
+		t.Fatalf("unexpected merged tag %+v", tag)
+	}
+
+	stored, _ := svc.Get(context.Background(), both.ID, ownerID)
+	if !slices.Equal(stored.Tags, TagList{"signed"}) {
+		t.Fatalf("expected duplicate tags to collapse, got %q", stored.Tags)
+	}
+	if !stored.UpdatedAt.After(both.UpdatedAt) {
+		t.Fatalf("expected merge to bump updated_at, got %s", stored.UpdatedAt)
+	}
+
+	tags, _ := svc.ListTags(context.Background(), ownerID)
+	if len(tags) != 1 {
+		t.Fatalf("expected source tags to be removed, got %+v", tags)
+	}
+
+	if _, err := svc.MergeTags(context.Background(), []string{"unknown"}, "signed", ownerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected unknown source to be rejected, got %v", err)
+	}
+}
+
+func TestServiceCreateAndDeleteTag(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(nil))
+	ownerID := uuid.New()
+	item := createTaggedItem(t, svc, ownerID, "Dune", "gift", "signed")
+
+	if _, err := svc.CreateTag(context.Background(), "Wishlist", ownerID); err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	if _, err := svc.CreateTag(context.Background(), "wishlist", ownerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected duplicate tag to be rejected, got %v", err)
+	}
+
+	count, err := svc.DeleteTag(context.Background(), "gift", ownerID)
+	if err != nil {
+		t.Fatalf("delete tag: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 item updated, got %d", count)
+	}
+
+	stored, _ := svc.Get(context.Background(), item.ID, ownerID)
+	if !slices.Equal(stored.Tags, TagList{"signed"}) {
+		t.Fatalf("expected tag to be removed, got %q", stored.Tags)
+	}
+	if !stored.UpdatedAt.After(item.UpdatedAt) {
+		t.Fatalf("expected delete to bump updated_at, got %s", stored.UpdatedAt)
+	}
+
+	if _, err := svc.DeleteTag(context.Background(), "gift", ownerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
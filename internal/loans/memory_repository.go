@@ -0,0 +1,175 @@
+package loans
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu        sync.RWMutex
+	borrowers map[uuid.UUID]Borrower
+	loans     map[uuid.UUID]Loan
+}
+
+// NewInMemoryRepository seeds an empty loan repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{
+		borrowers: make(map[uuid.UUID]Borrower),
+		loans:     make(map[uuid.UUID]Loan),
+	}
+}
+
+func (m *inMemoryRepository) ListBorrowers(_ context.Context, ownerID uuid.UUID) ([]Borrower, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	borrowers := []Borrower{}
+	for _, borrower := range m.borrowers {
+		if borrower.OwnerID == ownerID {
+			borrowers = append(borrowers, borrower)
+		}
+	}
+	slices.SortFunc(borrowers, func(a, b Borrower) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return borrowers, nil
+}
+
+func (m *inMemoryRepository) GetBorrower(_ context.Context, id uuid.UUID, ownerID uuid.UUID) (Borrower, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	borrower, ok := m.borrowers[id]
+	if !ok || borrower.OwnerID != ownerID {
+		return Borrower{}, ErrBorrowerNotFound
+	}
+	return borrower, nil
+}
+
+func (m *inMemoryRepository) FindBorrowerByName(_ context.Context, name string, ownerID uuid.UUID) (Borrower, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, borrower := range m.borrowers {
+		if borrower.OwnerID == ownerID && strings.EqualFold(borrower.Name, name) {
+			return borrower, nil
+		}
+	}
+	return Borrower{}, ErrBorrowerNotFound
+}
+
+func (m *inMemoryRepository) CreateBorrower(_ context.Context, borrower Borrower) (Borrower, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.borrowers[borrower.ID] = borrower
+	return borrower, nil
+}
+
+func (m *inMemoryRepository) UpdateBorrower(_ context.Context, borrower Borrower) (Borrower, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.borrowers[borrower.ID]
+	if !ok || existing.OwnerID != borrower.OwnerID {
+		return Borrower{}, ErrBorrowerNotFound
+	}
+	m.borrowers[borrower.ID] = borrower
+	return borrower, nil
+}
+
+func (m *inMemoryRepository) DeleteBorrower(_ context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	borrower, ok := m.borrowers[id]
+	if !ok || borrower.OwnerID != ownerID {
+		return ErrBorrowerNotFound
+	}
+	delete(m.borrowers, id)
+
+	// Mirror ON DELETE SET NULL: past loans keep the borrower name only.
+	for loanID, loan := range m.loans {
+		if loan.BorrowerID != nil && *loan.BorrowerID == id {
+			loan.BorrowerID = nil
+			m.loans[loanID] = loan
+		}
+	}
+	return nil
+}
+
+func (m *inMemoryRepository) CreateLoan(_ context.Context, loan Loan) (Loan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if loan.ReturnedAt == nil {
+		for _, existing := range m.loans {
+			if existing.ItemID == loan.ItemID && existing.ReturnedAt == nil {
+				return Loan{}, ErrAlreadyOnLoan
+			}
+		}
+	}
+	m.loans[loan.ID] = loan
+	return loan, nil
+}
+
+func (m *inMemoryRepository) GetActiveLoan(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) (Loan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, loan := range m.loans {
+		if loan.ItemID == itemID && loan.OwnerID == ownerID && loan.ReturnedAt == nil {
+			return loan, nil
+		}
+	}
+	return Loan{}, ErrNotFound
+}
+
+func (m *inMemoryRepository) ReturnLoan(_ context.Context, loanID uuid.UUID, ownerID uuid.UUID, returnedAt time.Time) (Loan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	loan, ok := m.loans[loanID]
+	if !ok || loan.OwnerID != ownerID || loan.ReturnedAt != nil {
+		return Loan{}, ErrNotFound
+	}
+	loan.ReturnedAt = &returnedAt
+	loan.UpdatedAt = time.Now().UTC()
+	m.loans[loanID] = loan
+	return loan, nil
+}
+
+func (m *inMemoryRepository) ListLoansForItem(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Loan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	loans := []Loan{}
+	for _, loan := range m.loans {
+		if loan.ItemID == itemID && loan.OwnerID == ownerID {
+			loans = append(loans, loan)
+		}
+	}
+	slices.SortFunc(loans, func(a, b Loan) int {
+		return b.LentAt.Compare(a.LentAt)
+	})
+	return loans, nil
+}
+
+func (m *inMemoryRepository) ListActiveLoans(_ context.Context, ownerID uuid.UUID) ([]Loan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	loans := []Loan{}
+	for _, loan := range m.loans {
+		if loan.OwnerID == ownerID && loan.ReturnedAt == nil {
+			loans = append(loans, loan)
+		}
+	}
+	slices.SortFunc(loans, compareLoansByDue)
+	return loans, nil
+}
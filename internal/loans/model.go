@@ -0,0 +1,88 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when a loan or borrower cannot be found.
+var ErrNotFound = errors.New("loan not found")
+
+// ErrBorrowerNotFound is returned when a borrower cannot be found.
+var ErrBorrowerNotFound = errors.New("borrower not found")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// ErrAlreadyOnLoan is returned when lending an item that has an active loan.
+var ErrAlreadyOnLoan = fmt.Errorf("%w: item is already on loan", ErrValidation)
+
+// Borrower is a person items are lent to.
+type Borrower struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	OwnerID   uuid.UUID `db:"owner_id" json:"-"`
+	Name      string    `db:"name" json:"name"`
+	Contact   string    `db:"contact" json:"contact"`
+	Notes     string    `db:"notes" json:"notes"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// Loan records an item being lent to a borrower.
+// BorrowerName is captured when the loan is made so history survives borrower deletion.
+type Loan struct {
+	ID           uuid.UUID  `db:"id" json:"id"`
+	OwnerID      uuid.UUID  `db:"owner_id" json:"-"`
+	ItemID       uuid.UUID  `db:"item_id" json:"itemId"`
+	ItemTitle    string     `db:"-" json:"itemTitle,omitempty"`
+	BorrowerID   *uuid.UUID `db:"borrower_id" json:"borrowerId,omitempty"`
+	BorrowerName string     `db:"borrower_name" json:"borrowerName"`
+	LentAt       time.Time  `db:"lent_at" json:"lentAt"`
+	DueAt        *time.Time `db:"due_at" json:"dueAt,omitempty"`
+	ReturnedAt   *time.Time `db:"returned_at" json:"returnedAt,omitempty"`
+	Notes        string     `db:"notes" json:"notes"`
+	Overdue      bool       `db:"-" json:"overdue"`
+	CreatedAt    time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+// BorrowerInput captures editable borrower fields.
+type BorrowerInput struct {
+	Name    string `json:"name"`
+	Contact string `json:"contact"`
+	Notes   string `json:"notes"`
+}
+
+// LendInput captures the data needed to lend an item. Either BorrowerID or
+// BorrowerName is required; an unknown name creates a new borrower.
+type LendInput struct {
+	BorrowerID   *uuid.UUID `json:"borrowerId"`
+	BorrowerName string     `json:"borrowerName"`
+	LentAt       *time.Time `json:"lentAt"`
+	DueAt        *time.Time `json:"dueAt"`
+	Notes        string     `json:"notes"`
+}
+
+// ReturnInput captures optional details when an item comes back.
+type ReturnInput struct {
+	ReturnedAt *time.Time `json:"returnedAt"`
+}
+
+// Repository defines persistence for borrowers and loans.
+type Repository interface {
+	ListBorrowers(ctx context.Context, ownerID uuid.UUID) ([]Borrower, error)
+	GetBorrower(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Borrower, error)
+	FindBorrowerByName(ctx context.Context, name string, ownerID uuid.UUID) (Borrower, error)
+	CreateBorrower(ctx context.Context, borrower Borrower) (Borrower, error)
+	UpdateBorrower(ctx context.Context, borrower Borrower) (Borrower, error)
+	DeleteBorrower(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+	CreateLoan(ctx context.Context, loan Loan) (Loan, error)
+	GetActiveLoan(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (Loan, error)
+	ReturnLoan(ctx context.Context, loanID uuid.UUID, ownerID uuid.UUID, returnedAt time.Time) (Loan, error)
+	ListLoansForItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Loan, error)
+	ListActiveLoans(ctx context.Context, ownerID uuid.UUID) ([]Loan, error)
+}
@@ -0,0 +1,165 @@
+package loans
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"anthology/internal/platform/database"
+)
+
+const (
+	uniqueViolation      = "23505"
+	activeLoanConstraint = "uq_item_loans_active_item"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a loans repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const borrowerColumns = `id, owner_id, name, contact, notes, created_at, updated_at`
+
+const loanColumns = `id, owner_id, item_id, borrower_id, borrower_name, lent_at, due_at, returned_at, notes, created_at, updated_at`
+
+func (r *postgresRepository) ListBorrowers(ctx context.Context, ownerID uuid.UUID) ([]Borrower, error) {
+	borrowers := []Borrower{}
+	query := `SELECT ` + borrowerColumns + ` FROM borrowers WHERE owner_id = $1 ORDER BY lower(name)`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &borrowers, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list borrowers: %w", err)
+	}
+	return borrowers, nil
+}
+
+func (r *postgresRepository) GetBorrower(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Borrower, error) {
+	var borrower Borrower
+	query := `SELECT ` + borrowerColumns + ` FROM borrowers WHERE id = $1 AND owner_id = $2`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &borrower, query, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Borrower{}, ErrBorrowerNotFound
+		}
+		return Borrower{}, fmt.Errorf("get borrower: %w", err)
+	}
+	return borrower, nil
+}
+
+func (r *postgresRepository) FindBorrowerByName(ctx context.Context, name string, ownerID uuid.UUID) (Borrower, error) {
+	var borrower Borrower
+	query := `SELECT ` + borrowerColumns + ` FROM borrowers WHERE owner_id = $1 AND lower(name) = lower($2)`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &borrower, query, ownerID, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Borrower{}, ErrBorrowerNotFound
+		}
+		return Borrower{}, fmt.Errorf("find borrower: %w", err)
+	}
+	return borrower, nil
+}
+
+func (r *postgresRepository) CreateBorrower(ctx context.Context, borrower Borrower) (Borrower, error) {
+	query := `INSERT INTO borrowers (` + borrowerColumns + `)
+VALUES (:id, :owner_id, :name, :contact, :notes, :created_at, :updated_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, borrower); err != nil {
+		return Borrower{}, fmt.Errorf("insert borrower: %w", err)
+	}
+	return r.GetBorrower(ctx, borrower.ID, borrower.OwnerID)
+}
+
+func (r *postgresRepository) UpdateBorrower(ctx context.Context, borrower Borrower) (Borrower, error) {
+	query := `UPDATE borrowers
+SET name = :name,
+    contact = :contact,
+    notes = :notes,
+    updated_at = :updated_at
+WHERE id = :id AND owner_id = :owner_id`
+	res, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, borrower)
+	if err != nil {
+		return Borrower{}, fmt.Errorf("update borrower: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return Borrower{}, ErrBorrowerNotFound
+	}
+	return r.GetBorrower(ctx, borrower.ID, borrower.OwnerID)
+}
+
+func (r *postgresRepository) DeleteBorrower(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM borrowers WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("delete borrower: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete borrower rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrBorrowerNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) CreateLoan(ctx context.Context, loan Loan) (Loan, error) {
+	query := `INSERT INTO item_loans (` + loanColumns + `)
+VALUES (:id, :owner_id, :item_id, :borrower_id, :borrower_name, :lent_at, :due_at, :returned_at, :notes, :created_at, :updated_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, loan); err != nil {
+		// A concurrent Lend can pass the active-loan check before this insert.
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == activeLoanConstraint {
+			return Loan{}, ErrAlreadyOnLoan
+		}
+		return Loan{}, fmt.Errorf("insert loan: %w", err)
+	}
+	return loan, nil
+}
+
+func (r *postgresRepository) GetActiveLoan(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (Loan, error) {
+	var loan Loan
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE item_id = $1 AND owner_id = $2 AND returned_at IS NULL`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &loan, query, itemID, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Loan{}, ErrNotFound
+		}
+		return Loan{}, fmt.Errorf("get active loan: %w", err)
+	}
+	return loan, nil
+}
+
+func (r *postgresRepository) ReturnLoan(ctx context.Context, loanID uuid.UUID, ownerID uuid.UUID, returnedAt time.Time) (Loan, error) {
+	var loan Loan
+	query := `UPDATE item_loans SET returned_at = $1, updated_at = NOW()
+WHERE id = $2 AND owner_id = $3 AND returned_at IS NULL
+RETURNING ` + loanColumns
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &loan, query, returnedAt, loanID, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Loan{}, ErrNotFound
+		}
+		return Loan{}, fmt.Errorf("return loan: %w", err)
+	}
+	return loan, nil
+}
+
+func (r *postgresRepository) ListLoansForItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Loan, error) {
+	loans := []Loan{}
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE item_id = $1 AND owner_id = $2 ORDER BY lent_at DESC`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &loans, query, itemID, ownerID); err != nil {
+		return nil, fmt.Errorf("list item loans: %w", err)
+	}
+	return loans, nil
+}
+
+func (r *postgresRepository) ListActiveLoans(ctx context.Context, ownerID uuid.UUID) ([]Loan, error) {
+	loans := []Loan{}
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE owner_id = $1 AND returned_at IS NULL ORDER BY due_at NULLS LAST, lent_at`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &loans, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list active loans: %w", err)
+	}
+	return loans, nil
+}
@@ -0,0 +1,346 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+const (
+	maxBorrowerNameLength = 200
+	maxLoanNotesLength    = 2000
+)
+
+// Service coordinates borrower and loan bookkeeping.
+type Service struct {
+	repo      Repository
+	itemsRepo items.Repository
+	now       func() time.Time
+}
+
+type loanCacheUpdater interface {
+	UpdateActiveLoan(ctx context.Context, itemID uuid.UUID, loan *items.ActiveLoan) error
+}
+
+// NewService wires a loan service.
+func NewService(repo Repository, itemsRepo items.Repository) *Service {
+	return &Service{
+		repo:      repo,
+		itemsRepo: itemsRepo,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ListBorrowers returns the owner's borrowers ordered by name.
+func (s *Service) ListBorrowers(ctx context.Context, ownerID uuid.UUID) ([]Borrower, error) {
+	return s.repo.ListBorrowers(ctx, ownerID)
+}
+
+// CreateBorrower validates and stores a borrower.
+func (s *Service) CreateBorrower(ctx context.Context, input BorrowerInput, ownerID uuid.UUID) (Borrower, error) {
+	name, err := normalizeBorrowerName(input.Name)
+	if err != nil {
+		return Borrower{}, err
+	}
+	if err := s.ensureBorrowerNameAvailable(ctx, name, uuid.Nil, ownerID); err != nil {
+		return Borrower{}, err
+	}
+
+	now := s.now()
+	return s.repo.CreateBorrower(ctx, Borrower{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		Name:      name,
+		Contact:   strings.TrimSpace(input.Contact),
+		Notes:     strings.TrimSpace(input.Notes),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// UpdateBorrower replaces a borrower's details.
+func (s *Service) UpdateBorrower(ctx context.Context, id uuid.UUID, input BorrowerInput, ownerID uuid.UUID) (Borrower, error) {
+	existing, err := s.repo.GetBorrower(ctx, id, ownerID)
+	if err != nil {
+		return Borrower{}, err
+	}
+
+	name, err := normalizeBorrowerName(input.Name)
+	if err != nil {
+		return Borrower{}, err
+	}
+	if err := s.ensureBorrowerNameAvailable(ctx, name, id, ownerID); err != nil {
+		return Borrower{}, err
+	}
+
+	existing.Name = name
+	existing.Contact = strings.TrimSpace(input.Contact)
+	existing.Notes = strings.TrimSpace(input.Notes)
+	existing.UpdatedAt = s.now()
+	return s.repo.UpdateBorrower(ctx, existing)
+}
+
+// DeleteBorrower removes a borrower who has nothing outstanding.
+// Past loans keep the borrower's name.
+func (s *Service) DeleteBorrower(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	if _, err := s.repo.GetBorrower(ctx, id, ownerID); err != nil {
+		return err
+	}
+
+	active, err := s.repo.ListActiveLoans(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	for _, loan := range active {
+		if loan.BorrowerID != nil && *loan.BorrowerID == id {
+			return fmt.Errorf("%w: borrower still has items on loan", ErrValidation)
+		}
+	}
+
+	return s.repo.DeleteBorrower(ctx, id, ownerID)
+}
+
+// Lend records a new loan for an item that is not already lent out.
+func (s *Service) Lend(ctx context.Context, itemID uuid.UUID, input LendInput, ownerID uuid.UUID) (Loan, error) {
+	if _, err := s.itemsRepo.Get(ctx, itemID, ownerID); err != nil {
+		return Loan{}, err
+	}
+
+	if _, err := s.repo.GetActiveLoan(ctx, itemID, ownerID); err == nil {
+		return Loan{}, ErrAlreadyOnLoan
+	} else if !errors.Is(err, ErrNotFound) {
+		return Loan{}, err
+	}
+
+	borrower, err := s.resolveBorrower(ctx, input, ownerID)
+	if err != nil {
+		return Loan{}, err
+	}
+
+	now := s.now()
+	lentAt := now
+	if input.LentAt != nil && !input.LentAt.IsZero() {
+		lentAt = input.LentAt.UTC()
+	}
+
+	var dueAt *time.Time
+	if input.DueAt != nil && !input.DueAt.IsZero() {
+		due := input.DueAt.UTC()
+		if due.Before(lentAt) {
+			return Loan{}, fmt.Errorf("%w: dueAt cannot be before lentAt", ErrValidation)
+		}
+		dueAt = &due
+	}
+
+	notes := strings.TrimSpace(input.Notes)
+	if len(notes) > maxLoanNotesLength {
+		return Loan{}, fmt.Errorf("%w: notes must be %d characters or less", ErrValidation, maxLoanNotesLength)
+	}
+
+	borrowerID := borrower.ID
+	loan, err := s.repo.CreateLoan(ctx, Loan{
+		ID:           uuid.New(),
+		OwnerID:      ownerID,
+		ItemID:       itemID,
+		BorrowerID:   &borrowerID,
+		BorrowerName: borrower.Name,
+		LentAt:       lentAt,
+		DueAt:        dueAt,
+		Notes:        notes,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		return Loan{}, err
+	}
+
+	if err := s.syncActiveLoan(ctx, itemID, &loan); err != nil {
+		return Loan{}, err
+	}
+
+	return s.decorate(loan, now), nil
+}
+
+// Return closes the active loan for an item.
+func (s *Service) Return(ctx context.Context, itemID uuid.UUID, input ReturnInput, ownerID uuid.UUID) (Loan, error) {
+	if _, err := s.itemsRepo.Get(ctx, itemID, ownerID); err != nil {
+		return Loan{}, err
+	}
+
+	active, err := s.repo.GetActiveLoan(ctx, itemID, ownerID)
+	if err != nil {
+		return Loan{}, err
+	}
+
+	now := s.now()
+	returnedAt := now
+	if input.ReturnedAt != nil && !input.ReturnedAt.IsZero() {
+		returnedAt = input.ReturnedAt.UTC()
+	}
+	if returnedAt.Before(active.LentAt) {
+		return Loan{}, fmt.Errorf("%w: returnedAt cannot be before lentAt", ErrValidation)
+	}
+
+	loan, err := s.repo.ReturnLoan(ctx, active.ID, ownerID, returnedAt)
+	if err != nil {
+		return Loan{}, err
+	}
+
+	if err := s.syncActiveLoan(ctx, itemID, nil); err != nil {
+		return Loan{}, err
+	}
+
+	return s.decorate(loan, now), nil
+}
+
+// ListItemLoans returns the loan history for an item, newest first.
+func (s *Service) ListItemLoans(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Loan, error) {
+	item, err := s.itemsRepo.Get(ctx, itemID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := s.repo.ListLoansForItem(ctx, itemID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	for i := range loans {
+		loans[i] = s.decorate(loans[i], now)
+		loans[i].ItemTitle = item.Title
+	}
+	return loans, nil
+}
+
+// ListActiveLoans returns every outstanding loan ordered by due date.
+func (s *Service) ListActiveLoans(ctx context.Context, ownerID uuid.UUID) ([]Loan, error) {
+	loans, err := s.repo.ListActiveLoans(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withItemTitles(ctx, loans, ownerID)
+}
+
+// ListOverdue returns outstanding loans whose due date has passed.
+func (s *Service) ListOverdue(ctx context.Context, ownerID uuid.UUID) ([]Loan, error) {
+	loans, err := s.ListActiveLoans(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(loans, func(loan Loan) bool { return !loan.Overdue }), nil
+}
+
+func (s *Service) withItemTitles(ctx context.Context, loans []Loan, ownerID uuid.UUID) ([]Loan, error) {
+	if len(loans) == 0 {
+		return loans, nil
+	}
+
+	onLoan := items.LoanStatusOnLoan
+	lent, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID, LoanStatus: &onLoan})
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[uuid.UUID]string, len(lent))
+	for _, item := range lent {
+		titles[item.ID] = item.Title
+	}
+
+	now := s.now()
+	for i := range loans {
+		loans[i] = s.decorate(loans[i], now)
+		loans[i].ItemTitle = titles[loans[i].ItemID]
+	}
+
+	slices.SortStableFunc(loans, compareLoansByDue)
+	return loans, nil
+}
+
+func (s *Service) resolveBorrower(ctx context.Context, input LendInput, ownerID uuid.UUID) (Borrower, error) {
+	if input.BorrowerID != nil {
+		return s.repo.GetBorrower(ctx, *input.BorrowerID, ownerID)
+	}
+
+	name, err := normalizeBorrowerName(input.BorrowerName)
+	if err != nil {
+		return Borrower{}, fmt.Errorf("%w: borrowerId or borrowerName is required", ErrValidation)
+	}
+
+	borrower, err := s.repo.FindBorrowerByName(ctx, name, ownerID)
+	if err == nil {
+		return borrower, nil
+	}
+	if !errors.Is(err, ErrBorrowerNotFound) {
+		return Borrower{}, err
+	}
+	return s.CreateBorrower(ctx, BorrowerInput{Name: name}, ownerID)
+}
+
+func (s *Service) ensureBorrowerNameAvailable(ctx context.Context, name string, selfID uuid.UUID, ownerID uuid.UUID) error {
+	existing, err := s.repo.FindBorrowerByName(ctx, name, ownerID)
+	if err != nil {
+		if errors.Is(err, ErrBorrowerNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != selfID {
+		return fmt.Errorf("%w: a borrower with this name already exists", ErrValidation)
+	}
+	return nil
+}
+
+// syncActiveLoan refreshes repositories that cache the active loan on items.
+func (s *Service) syncActiveLoan(ctx context.Context, itemID uuid.UUID, loan *Loan) error {
+	updater, ok := s.itemsRepo.(loanCacheUpdater)
+	if !ok {
+		return nil
+	}
+	if loan == nil {
+		return updater.UpdateActiveLoan(ctx, itemID, nil)
+	}
+	return updater.UpdateActiveLoan(ctx, itemID, &items.ActiveLoan{
+		LoanID:       loan.ID,
+		BorrowerID:   loan.BorrowerID,
+		BorrowerName: loan.BorrowerName,
+		LentAt:       loan.LentAt,
+		DueAt:        loan.DueAt,
+	})
+}
+
+func (s *Service) decorate(loan Loan, now time.Time) Loan {
+	loan.Overdue = loan.ReturnedAt == nil && loan.DueAt != nil && loan.DueAt.Before(now)
+	return loan
+}
+
+func normalizeBorrowerName(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	if name == "" {
+		return "", fmt.Errorf("%w: borrower name is required", ErrValidation)
+	}
+	if len(name) > maxBorrowerNameLength {
+		return "", fmt.Errorf("%w: borrower name must be %d characters or less", ErrValidation, maxBorrowerNameLength)
+	}
+	return name, nil
+}
+
+// compareLoansByDue orders loans with the earliest due date first; loans without one sort last.
+func compareLoansByDue(a, b Loan) int {
+	switch {
+	case a.DueAt == nil && b.DueAt == nil:
+		return a.LentAt.Compare(b.LentAt)
+	case a.DueAt == nil:
+		return 1
+	case b.DueAt == nil:
+		return -1
+	default:
+		return a.DueAt.Compare(*b.DueAt)
+	}
+}
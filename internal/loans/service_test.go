@@ -0,0 +1,173 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func newTestService(t *testing.T, titles ...string) (*Service, *items.InMemoryRepository, []items.Item) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	itemSvc := items.NewService(itemsRepo)
+
+	var created []items.Item
+	for _, title := range titles {
+		item, err := itemSvc.Create(context.Background(), items.CreateItemInput{OwnerID: testOwnerID, Title: title, ItemType: items.ItemTypeGame})
+		if err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+		created = append(created, item)
+	}
+	return NewService(NewInMemoryRepository(), itemsRepo), itemsRepo, created
+}
+
+func TestLendAndReturnTracksActiveLoanOnItem(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, itemsRepo, created := newTestService(t, "Catan")
+	item := created[0]
+
+	due := time.Now().Add(14 * 24 * time.Hour)
+	loan, err := svc.Lend(ctx, item.ID, LendInput{BorrowerName: "  Sam ", DueAt: &due}, testOwnerID)
+	if err != nil {
+		t.Fatalf("lend: %v", err)
+	}
+	if loan.BorrowerName != "Sam" || loan.BorrowerID == nil || loan.Overdue {
+		t.Fatalf("unexpected loan %+v", loan)
+	}
+
+	stored, _ := itemsRepo.Get(ctx, item.ID, testOwnerID)
+	if stored.ActiveLoan == nil || stored.ActiveLoan.BorrowerName != "Sam" {
+		t.Fatalf("expected active loan on item, got %+v", stored.ActiveLoan)
+	}
+
+	onLoan := items.LoanStatusOnLoan
+	lent, _ := itemsRepo.List(ctx, items.ListOptions{OwnerID: testOwnerID, LoanStatus: &onLoan})
+	if len(lent) != 1 {
+		t.Fatalf("expected on-loan filter to match, got %d items", len(lent))
+	}
+
+	if _, err := svc.Lend(ctx, item.ID, LendInput{BorrowerName: "Alex"}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected double loan to be rejected, got %v", err)
+	}
+
+	returned, err := svc.Return(ctx, item.ID, ReturnInput{}, testOwnerID)
+	if err != nil {
+		t.Fatalf("return: %v", err)
+	}
+	if returned.ReturnedAt == nil {
+		t.Fatalf("expected returnedAt to be set")
+	}
+
+	stored, _ = itemsRepo.Get(ctx, item.ID, testOwnerID)
+	if stored.ActiveLoan != nil {
+		t.Fatalf("expected active loan to be cleared, got %+v", stored.ActiveLoan)
+	}
+
+	if _, err := svc.Return(ctx, item.ID, ReturnInput{}, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for second return, got %v", err)
+	}
+
+	history, err := svc.ListItemLoans(ctx, item.ID, testOwnerID)
+	if err != nil || len(history) != 1 || history[0].ItemTitle != "Catan" {
+		t.Fatalf("unexpected history %+v, %v", history, err)
+	}
+}
+
+func TestLendReusesBorrowerByName(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _, created := newTestService(t, "Catan", "Azul")
+
+	first, err := svc.Lend(ctx, created[0].ID, LendInput{BorrowerName: "Sam"}, testOwnerID)
+	if err != nil {
+		t.Fatalf("lend first: %v", err)
+	}
+	second, err := svc.Lend(ctx, created[1].ID, LendInput{BorrowerName: "sam"}, testOwnerID)
+	if err != nil {
+		t.Fatalf("lend second: %v", err)
+	}
+	if *first.BorrowerID != *second.BorrowerID {
+		t.Fatalf("expected the same borrower to be reused")
+	}
+
+	borrowers, _ := svc.ListBorrowers(ctx, testOwnerID)
+	if len(borrowers) != 1 {
+		t.Fatalf("expected one borrower, got %d", len(borrowers))
+	}
+
+	if err := svc.DeleteBorrower(ctx, *first.BorrowerID, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected delete with active loans to be rejected, got %v", err)
+	}
+}
+
+func TestListOverdueReturnsOnlyPastDueLoans(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _, created := newTestService(t, "Overdue Game", "Current Game", "Open-ended Game")
+
+	lentAt := time.Now().Add(-30 * 24 * time.Hour)
+	pastDue := time.Now().Add(-24 * time.Hour)
+	futureDue := time.Now().Add(24 * time.Hour)
+	for i, due := range []*time.Time{&pastDue, &futureDue, nil} {
+		if _, err := svc.Lend(ctx, created[i].ID, LendInput{BorrowerName: "Sam", LentAt: &lentAt, DueAt: due}, testOwnerID); err != nil {
+			t.Fatalf("lend %d: %v", i, err)
+		}
+	}
+
+	overdue, err := svc.ListOverdue(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("list overdue: %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].ItemTitle != "Overdue Game" || !overdue[0].Overdue {
+		t.Fatalf("unexpected overdue loans %+v", overdue)
+	}
+
+	active, _ := svc.ListActiveLoans(ctx, testOwnerID)
+	if len(active) != 3 || active[2].DueAt != nil {
+		t.Fatalf("expected loans without a due date last, got %+v", active)
+	}
+}
+
+func TestLendRejectsDueDateBeforeLentDate(t *testing.T) {
+	t.Parallel()
+	svc, _, created := newTestService(t, "Catan")
+
+	due := time.Now().Add(-time.Hour)
+	if _, err := svc.Lend(context.Background(), created[0].ID, LendInput{BorrowerName: "Sam", DueAt: &due}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+// staleActiveLoanRepository misses active loans, like a Lend racing another
+// one that has not committed yet.
+type staleActiveLoanRepository struct {
+	Repository
+}
+
+func (r staleActiveLoanRepository) GetActiveLoan(context.Context, uuid.UUID, uuid.UUID) (Loan, error) {
+	return Loan{}, ErrNotFound
+}
+
+func TestLendRejectsConcurrentLoanOfSameItem(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, itemsRepo, created := newTestService(t, "Catan")
+	svc := NewService(staleActiveLoanRepository{NewInMemoryRepository()}, itemsRepo)
+
+	if _, err := svc.Lend(ctx, created[0].ID, LendInput{BorrowerName: "Sam"}, testOwnerID); err != nil {
+		t.Fatalf("lend: %v", err)
+	}
+	if _, err := svc.Lend(ctx, created[0].ID, LendInput{BorrowerName: "Alex"}, testOwnerID); !errors.Is(err, ErrAlreadyOnLoan) {
+		t.Fatalf("expected the losing lend to report the item on loan, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package maintenance
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type inMemoryRepository struct {
+	mu   sync.RWMutex
+	runs []Run
+}
+
+// NewInMemoryRepository constructs an empty run history.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{}
+}
+
+func (r *inMemoryRepository) Record(_ context.Context, run Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, run)
+	return nil
+}
+
+func (r *inMemoryRepository) List(_ context.Context, job string, limit int) ([]Run, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	runs := make([]Run, 0)
+	for _, run := range r.runs {
+		if job == "" || run.Job == job {
+			runs = append(runs, run)
+		}
+	}
+	slices.SortStableFunc(runs, func(a, b Run) int { return b.StartedAt.Compare(a.StartedAt) })
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+func (r *inMemoryRepository) DeleteBefore(_ context.Context, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.runs[:0]
+	for _, run := range r.runs {
+		if !run.StartedAt.Before(cutoff) {
+			kept = append(kept, run)
+		}
+	}
+	removed := int64(len(r.runs) - len(kept))
+	r.runs = kept
+	return removed, nil
+}
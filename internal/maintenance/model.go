@@ -0,0 +1,60 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrValidation is returned when a job definition is incomplete.
+var ErrValidation = errors.New("validation failed")
+
+// Status records how a maintenance run ended.
+type Status string
+
+const (
+	// StatusSucceeded runs finished without error.
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed runs returned an error.
+	StatusFailed Status = "failed"
+	// StatusCancelled runs were interrupted by shutdown.
+	StatusCancelled Status = "cancelled"
+)
+
+// Job is a task the scheduler runs every Interval. Run reports how many rows
+// or entries it removed.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) (int64, error)
+}
+
+// Run is one recorded execution of a job.
+type Run struct {
+	ID         uuid.UUID `json:"id"`
+	Job        string    `json:"job"`
+	Status     Status    `json:"status"`
+	Affected   int64     `json:"affected"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// JobStatus describes a registered job and when it last and next runs.
+type JobStatus struct {
+	Name      string     `json:"name"`
+	Interval  string     `json:"interval"`
+	LastRun   *Run       `json:"lastRun,omitempty"`
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+}
+
+// Repository persists run history.
+type Repository interface {
+	Record(ctx context.Context, run Run) error
+	// List returns runs newest first, limited to job when it is non-empty.
+	List(ctx context.Context, job string, limit int) ([]Run, error)
+	// DeleteBefore removes runs that started before cutoff.
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,75 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a run history backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const runColumns = `id, job, status, affected, error, started_at, finished_at`
+
+type runRow struct {
+	ID         uuid.UUID `db:"id"`
+	Job        string    `db:"job"`
+	Status     Status    `db:"status"`
+	Affected   int64     `db:"affected"`
+	Error      string    `db:"error"`
+	StartedAt  time.Time `db:"started_at"`
+	FinishedAt time.Time `db:"finished_at"`
+}
+
+func (row runRow) run() Run {
+	return Run{
+		ID:         row.ID,
+		Job:        row.Job,
+		Status:     row.Status,
+		Affected:   row.Affected,
+		Error:      row.Error,
+		StartedAt:  row.StartedAt,
+		FinishedAt: row.FinishedAt,
+	}
+}
+
+func (r *postgresRepository) Record(ctx context.Context, run Run) error {
+	query := `INSERT INTO maintenance_runs (` + runColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	if _, err := r.db.ExecContext(ctx, query, run.ID, run.Job, run.Status, run.Affected, run.Error, run.StartedAt, run.FinishedAt); err != nil {
+		return fmt.Errorf("insert maintenance run: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) List(ctx context.Context, job string, limit int) ([]Run, error) {
+	var rows []runRow
+	query := `SELECT ` + runColumns + ` FROM maintenance_runs
+WHERE ($1 = '' OR job = $1)
+ORDER BY started_at DESC
+LIMIT NULLIF($2, 0)`
+	if err := r.db.SelectContext(ctx, &rows, query, job, limit); err != nil {
+		return nil, fmt.Errorf("list maintenance runs: %w", err)
+	}
+	runs := make([]Run, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, row.run())
+	}
+	return runs, nil
+}
+
+func (r *postgresRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM maintenance_runs WHERE started_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("delete maintenance runs: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,241 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultJitter spreads each wait by up to ±10% of the job's interval so
+	// several API instances do not sweep the database in lockstep.
+	DefaultJitter = 0.1
+	// DefaultHistoryRetention is how long recorded runs are kept.
+	DefaultHistoryRetention = 30 * 24 * time.Hour
+	// recordTimeout bounds saving a run after the scheduler has been stopped.
+	recordTimeout = 5 * time.Second
+)
+
+// Scheduler runs registered jobs on their intervals until its context ends.
+type Scheduler struct {
+	repo      Repository
+	logger    *slog.Logger
+	jitter    float64
+	retention time.Duration
+	now       func() time.Time
+	random    func() float64
+
+	mu      sync.Mutex
+	jobs    []Job
+	nextRun map[string]time.Time
+	started bool
+	wg      sync.WaitGroup
+}
+
+// Option configures optional scheduler behaviour.
+type Option func(*Scheduler)
+
+// WithJitter sets the fraction of each interval used to randomise waits.
+// Values outside [0, 1) are ignored.
+func WithJitter(fraction float64) Option {
+	return func(s *Scheduler) {
+		if fraction >= 0 && fraction < 1 {
+			s.jitter = fraction
+		}
+	}
+}
+
+// WithHistoryRetention sets how long recorded runs are kept.
+func WithHistoryRetention(retention time.Duration) Option {
+	return func(s *Scheduler) {
+		if retention > 0 {
+			s.retention = retention
+		}
+	}
+}
+
+// NewScheduler constructs a scheduler that records runs in repo.
+func NewScheduler(repo Repository, logger *slog.Logger, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		repo:      repo,
+		logger:    logger,
+		jitter:    DefaultJitter,
+		retention: DefaultHistoryRetention,
+		now:       func() time.Time { return time.Now().UTC() },
+		random:    rand.Float64,
+		nextRun:   make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register adds a job. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) error {
+	job.Name = strings.TrimSpace(job.Name)
+	if job.Name == "" {
+		return fmt.Errorf("%w: job name is required", ErrValidation)
+	}
+	if job.Interval <= 0 {
+		return fmt.Errorf("%w: job %s needs a positive interval", ErrValidation, job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("%w: job %s has no run function", ErrValidation, job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("%w: scheduler already started", ErrValidation)
+	}
+	for _, existing := range s.jobs {
+		if existing.Name == job.Name {
+			return fmt.Errorf("%w: job %s is already registered", ErrValidation, job.Name)
+		}
+	}
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Start runs every registered job in the background until ctx is cancelled.
+// Each job first runs after a random share of the jitter window, so a restart
+// does not postpone cleanup by a whole interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Wait blocks until every job loop has stopped, or until ctx expires. Loops
+// stop once the context passed to Start is cancelled and any run in progress
+// has been recorded.
+func (s *Scheduler) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Jobs lists registered jobs with their latest recorded run.
+func (s *Scheduler) Jobs(ctx context.Context) ([]JobStatus, error) {
+	s.mu.Lock()
+	jobs := make([]JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		status := JobStatus{Name: job.Name, Interval: job.Interval.String()}
+		if next, ok := s.nextRun[job.Name]; ok {
+			status.NextRunAt = &next
+		}
+		jobs = append(jobs, status)
+	}
+	s.mu.Unlock()
+
+	for i := range jobs {
+		runs, err := s.repo.List(ctx, jobs[i].Name, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 {
+			jobs[i].LastRun = &runs[0]
+		}
+	}
+	return jobs, nil
+}
+
+// Runs returns recorded runs newest first, optionally for a single job.
+func (s *Scheduler) Runs(ctx context.Context, job string, limit int) ([]Run, error) {
+	return s.repo.List(ctx, strings.TrimSpace(job), limit)
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	wait := time.Duration(s.random() * s.jitter * float64(job.Interval))
+	for {
+		s.mu.Lock()
+		s.nextRun[job.Name] = s.now().Add(wait)
+		s.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.runOnce(ctx, job)
+		wait = s.delay(job.Interval)
+	}
+}
+
+// delay returns interval shifted by up to ±jitter of itself.
+func (s *Scheduler) delay(interval time.Duration) time.Duration {
+	offset := (s.random()*2 - 1) * s.jitter * float64(interval)
+	return interval + time.Duration(offset)
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	run := Run{ID: uuid.New(), Job: job.Name, StartedAt: s.now()}
+	affected, err := s.call(ctx, job)
+	run.FinishedAt = s.now()
+	run.Affected = affected
+
+	switch {
+	case err == nil:
+		run.Status = StatusSucceeded
+		s.logger.Info("maintenance job finished", "job", job.Name, "affected", affected, "duration", run.FinishedAt.Sub(run.StartedAt))
+	case ctx.Err() != nil && errors.Is(err, ctx.Err()):
+		run.Status = StatusCancelled
+		run.Error = err.Error()
+		s.logger.Info("maintenance job interrupted", "job", job.Name)
+	default:
+		run.Status = StatusFailed
+		run.Error = err.Error()
+		s.logger.Error("maintenance job failed", "job", job.Name, "error", err)
+	}
+
+	// The run is saved even when shutdown interrupted it.
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	if err := s.repo.Record(recordCtx, run); err != nil {
+		s.logger.Error("failed to record maintenance run", "job", job.Name, "error", err)
+	}
+	if _, err := s.repo.DeleteBefore(recordCtx, run.StartedAt.Add(-s.retention)); err != nil {
+		s.logger.Error("failed to prune maintenance history", "error", err)
+	}
+}
+
+// call runs the job, turning a panic into an error so the run is recorded as
+// failed and the job's loop keeps ticking.
+func (s *Scheduler) call(ctx context.Context, job Job) (affected int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("maintenance job panicked", "job", job.Name, "panic", r, "stack", string(debug.Stack()))
+			affected, err = 0, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
@@ -0,0 +1,174 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func waitForRuns(t *testing.T, s *Scheduler, job string, count int) []Run {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		runs, err := s.Runs(context.Background(), job, 0)
+		if err != nil {
+			t.Fatalf("list runs: %v", err)
+		}
+		if len(runs) >= count {
+			return runs
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not run %d times", job, count)
+	return nil
+}
+
+func TestSchedulerRunsJobsAndRecordsHistory(t *testing.T) {
+	s := NewScheduler(NewInMemoryRepository(), newTestLogger(), WithJitter(0))
+	var calls atomic.Int64
+	if err := s.Register(Job{Name: "sweep", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+		return calls.Add(1), nil
+	}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := s.Register(Job{Name: "broken", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+		return 0, errors.New("boom")
+	}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	sweeps := waitForRuns(t, s, "sweep", 2)
+	failures := waitForRuns(t, s, "broken", 1)
+	cancel()
+	if err := s.Wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	if sweeps[0].Status != StatusSucceeded || sweeps[0].Affected < sweeps[1].Affected {
+		t.Fatalf("expected newest successful run first, got %+v", sweeps[:2])
+	}
+	if failures[0].Status != StatusFailed || failures[0].Error != "boom" {
+		t.Fatalf("expected failed run with error, got %+v", failures[0])
+	}
+
+	jobs, err := s.Jobs(context.Background())
+	if err != nil {
+		t.Fatalf("jobs: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Name != "sweep" || jobs[0].LastRun == nil || jobs[0].Interval != "10ms" {
+		t.Fatalf("unexpected job statuses: %+v", jobs)
+	}
+}
+
+func TestSchedulerRecoversPanickingJob(t *testing.T) {
+	s := NewScheduler(NewInMemoryRepository(), newTestLogger(), WithJitter(0))
+	if err := s.Register(Job{Name: "panicky", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+		panic("nil map")
+	}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	// A second run shows the job's loop survived the first panic.
+	runs := waitForRuns(t, s, "panicky", 2)
+	cancel()
+	if err := s.Wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	if runs[0].Status != StatusFailed || runs[0].Error != "panic: nil map" {
+		t.Fatalf("expected the panic recorded as a failed run, got %+v", runs[0])
+	}
+}
+
+func TestSchedulerRecordsRunInterruptedByShutdown(t *testing.T) {
+	s := NewScheduler(NewInMemoryRepository(), newTestLogger(), WithJitter(0))
+	started := make(chan struct{})
+	if err := s.Register(Job{Name: "slow", Interval: time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+		close(started)
+		<-ctx.Done()
+		return 3, ctx.Err()
+	}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	<-started
+	cancel()
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer waitCancel()
+	if err := s.Wait(waitCtx); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	runs, err := s.Runs(context.Background(), "slow", 10)
+	if err != nil {
+		t.Fatalf("list runs: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Status != StatusCancelled || runs[0].Affected != 3 {
+		t.Fatalf("expected one cancelled run, got %+v", runs)
+	}
+}
+
+func TestSchedulerRegisterValidatesJobs(t *testing.T) {
+	s := NewScheduler(NewInMemoryRepository(), newTestLogger())
+	noop := func(ctx context.Context) (int64, error) { return 0, nil }
+
+	cases := []Job{
+		{Name: " ", Interval: time.Minute, Run: noop},
+		{Name: "zero", Run: noop},
+		{Name: "no-run", Interval: time.Minute},
+	}
+	for _, job := range cases {
+		if err := s.Register(job); !errors.Is(err, ErrValidation) {
+			t.Fatalf("expected validation error for %+v, got %v", job, err)
+		}
+	}
+
+	if err := s.Register(Job{Name: "dup", Interval: time.Minute, Run: noop}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := s.Register(Job{Name: "dup", Interval: time.Minute, Run: noop}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected duplicate to be rejected, got %v", err)
+	}
+}
+
+func TestSchedulerDelayStaysWithinJitter(t *testing.T) {
+	s := NewScheduler(NewInMemoryRepository(), newTestLogger(), WithJitter(0.2))
+	for _, r := range []float64{0, 0.5, 0.999} {
+		s.random = func() float64 { return r }
+		got := s.delay(time.Hour)
+		if got < 48*time.Minute || got > 72*time.Minute {
+			t.Fatalf("delay %v outside ±20%% of an hour for random %v", got, r)
+		}
+	}
+}
+
+func TestInMemoryRepositoryDeleteBefore(t *testing.T) {
+	repo := NewInMemoryRepository()
+	ctx := context.Background()
+	now := time.Now()
+	_ = repo.Record(ctx, Run{Job: "a", StartedAt: now.Add(-48 * time.Hour)})
+	_ = repo.Record(ctx, Run{Job: "a", StartedAt: now})
+
+	removed, err := repo.DeleteBefore(ctx, now.Add(-24*time.Hour))
+	if err != nil || removed != 1 {
+		t.Fatalf("expected one run removed, got %d (%v)", removed, err)
+	}
+	runs, _ := repo.List(ctx, "", 0)
+	if len(runs) != 1 || !runs[0].StartedAt.Equal(now) {
+		t.Fatalf("unexpected remaining runs: %+v", runs)
+	}
+}
@@ -0,0 +1,79 @@
+package mappings
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu       sync.RWMutex
+	mappings map[uuid.UUID]SavedMapping
+}
+
+// NewInMemoryRepository seeds an empty mapping repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{mappings: make(map[uuid.UUID]SavedMapping)}
+}
+
+func (m *inMemoryRepository) List(_ context.Context, ownerID uuid.UUID) ([]SavedMapping, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	saved := []SavedMapping{}
+	for _, mapping := range m.mappings {
+		if mapping.OwnerID == ownerID {
+			saved = append(saved, mapping)
+		}
+	}
+	slices.SortFunc(saved, func(a, b SavedMapping) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return saved, nil
+}
+
+func (m *inMemoryRepository) Get(_ context.Context, id uuid.UUID, ownerID uuid.UUID) (SavedMapping, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	mapping, ok := m.mappings[id]
+	if !ok || mapping.OwnerID != ownerID {
+		return SavedMapping{}, ErrNotFound
+	}
+	return mapping, nil
+}
+
+func (m *inMemoryRepository) Create(_ context.Context, mapping SavedMapping) (SavedMapping, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mappings[mapping.ID] = mapping
+	return mapping, nil
+}
+
+func (m *inMemoryRepository) Update(_ context.Context, mapping SavedMapping) (SavedMapping, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.mappings[mapping.ID]
+	if !ok || existing.OwnerID != mapping.OwnerID {
+		return SavedMapping{}, ErrNotFound
+	}
+	m.mappings[mapping.ID] = mapping
+	return mapping, nil
+}
+
+func (m *inMemoryRepository) Delete(_ context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.mappings[id]
+	if !ok || existing.OwnerID != ownerID {
+		return ErrNotFound
+	}
+	delete(m.mappings, id)
+	return nil
+}
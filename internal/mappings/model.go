@@ -0,0 +1,42 @@
+package mappings
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/importer"
+)
+
+// ErrNotFound is returned when a saved mapping cannot be found.
+var ErrNotFound = errors.New("mapping not found")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// SavedMapping is a named CSV column mapping an owner can reuse across imports.
+type SavedMapping struct {
+	ID        uuid.UUID        `json:"id"`
+	OwnerID   uuid.UUID        `json:"-"`
+	Name      string           `json:"name"`
+	Mapping   importer.Mapping `json:"mapping"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// MappingInput names a mapping and describes its columns.
+type MappingInput struct {
+	Name    string           `json:"name"`
+	Mapping importer.Mapping `json:"mapping"`
+}
+
+// Repository persists saved mappings.
+type Repository interface {
+	List(ctx context.Context, ownerID uuid.UUID) ([]SavedMapping, error)
+	Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (SavedMapping, error)
+	Create(ctx context.Context, mapping SavedMapping) (SavedMapping, error)
+	Update(ctx context.Context, mapping SavedMapping) (SavedMapping, error)
+	Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+}
@@ -0,0 +1,141 @@
+package mappings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a mapping repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const mappingColumns = `id, owner_id, name, spec, created_at, updated_at`
+
+// mappingRow mirrors import_mappings; the mapping itself is stored as jsonb.
+type mappingRow struct {
+	ID        uuid.UUID `db:"id"`
+	OwnerID   uuid.UUID `db:"owner_id"`
+	Name      string    `db:"name"`
+	Spec      []byte    `db:"spec"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func newMappingRow(mapping SavedMapping) (mappingRow, error) {
+	spec, err := json.Marshal(mapping.Mapping)
+	if err != nil {
+		return mappingRow{}, fmt.Errorf("encode mapping: %w", err)
+	}
+	return mappingRow{
+		ID:        mapping.ID,
+		OwnerID:   mapping.OwnerID,
+		Name:      mapping.Name,
+		Spec:      spec,
+		CreatedAt: mapping.CreatedAt,
+		UpdatedAt: mapping.UpdatedAt,
+	}, nil
+}
+
+func (row mappingRow) mapping() (SavedMapping, error) {
+	saved := SavedMapping{
+		ID:        row.ID,
+		OwnerID:   row.OwnerID,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if err := json.Unmarshal(row.Spec, &saved.Mapping); err != nil {
+		return SavedMapping{}, fmt.Errorf("decode mapping: %w", err)
+	}
+	return saved, nil
+}
+
+func (r *postgresRepository) List(ctx context.Context, ownerID uuid.UUID) ([]SavedMapping, error) {
+	var rows []mappingRow
+	query := `SELECT ` + mappingColumns + ` FROM import_mappings WHERE owner_id = $1 ORDER BY lower(name)`
+	if err := r.db.SelectContext(ctx, &rows, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list mappings: %w", err)
+	}
+	saved := make([]SavedMapping, 0, len(rows))
+	for _, row := range rows {
+		mapping, err := row.mapping()
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, mapping)
+	}
+	return saved, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (SavedMapping, error) {
+	var row mappingRow
+	query := `SELECT ` + mappingColumns + ` FROM import_mappings WHERE id = $1 AND owner_id = $2`
+	if err := r.db.GetContext(ctx, &row, query, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return SavedMapping{}, ErrNotFound
+		}
+		return SavedMapping{}, fmt.Errorf("get mapping: %w", err)
+	}
+	return row.mapping()
+}
+
+func (r *postgresRepository) Create(ctx context.Context, mapping SavedMapping) (SavedMapping, error) {
+	row, err := newMappingRow(mapping)
+	if err != nil {
+		return SavedMapping{}, err
+	}
+	query := `INSERT INTO import_mappings (` + mappingColumns + `)
+VALUES (:id, :owner_id, :name, :spec, :created_at, :updated_at)`
+	if _, err := r.db.NamedExecContext(ctx, query, row); err != nil {
+		return SavedMapping{}, fmt.Errorf("insert mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, mapping SavedMapping) (SavedMapping, error) {
+	row, err := newMappingRow(mapping)
+	if err != nil {
+		return SavedMapping{}, err
+	}
+	query := `UPDATE import_mappings SET name = :name, spec = :spec, updated_at = :updated_at
+WHERE id = :id AND owner_id = :owner_id`
+	res, err := r.db.NamedExecContext(ctx, query, row)
+	if err != nil {
+		return SavedMapping{}, fmt.Errorf("update mapping: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return SavedMapping{}, fmt.Errorf("update mapping rows: %w", err)
+	}
+	if affected == 0 {
+		return SavedMapping{}, ErrNotFound
+	}
+	return mapping, nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM import_mappings WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("delete mapping: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete mapping rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package mappings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/importer"
+)
+
+const maxNameLength = 100
+
+// Service manages saved import mappings.
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewService constructs a mapping service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: func() time.Time { return time.Now().UTC() }}
+}
+
+// List returns the owner's mappings sorted by name.
+func (s *Service) List(ctx context.Context, ownerID uuid.UUID) ([]SavedMapping, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Get returns a single mapping.
+func (s *Service) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (SavedMapping, error) {
+	return s.repo.Get(ctx, id, ownerID)
+}
+
+// Spec returns a saved mapping's column specification for an import.
+func (s *Service) Spec(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (importer.Mapping, error) {
+	saved, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return importer.Mapping{}, fmt.Errorf("%w: %s", importer.ErrMappingNotFound, id)
+		}
+		return importer.Mapping{}, err
+	}
+	return saved.Mapping, nil
+}
+
+// Create saves a new mapping under a name unique to the owner.
+func (s *Service) Create(ctx context.Context, input MappingInput, ownerID uuid.UUID) (SavedMapping, error) {
+	name, err := s.validate(ctx, input, uuid.Nil, ownerID)
+	if err != nil {
+		return SavedMapping{}, err
+	}
+
+	now := s.now()
+	return s.repo.Create(ctx, SavedMapping{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		Name:      name,
+		Mapping:   input.Mapping,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// Update replaces a mapping's name and columns.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input MappingInput, ownerID uuid.UUID) (SavedMapping, error) {
+	existing, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return SavedMapping{}, err
+	}
+	name, err := s.validate(ctx, input, id, ownerID)
+	if err != nil {
+		return SavedMapping{}, err
+	}
+
+	existing.Name = name
+	existing.Mapping = input.Mapping
+	existing.UpdatedAt = s.now()
+	return s.repo.Update(ctx, existing)
+}
+
+// Delete removes a mapping.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// validate checks the mapping and that no other mapping of the owner's uses the name.
+func (s *Service) validate(ctx context.Context, input MappingInput, selfID uuid.UUID, ownerID uuid.UUID) (string, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if len(name) > maxNameLength {
+		return "", fmt.Errorf("%w: name must be %d characters or fewer", ErrValidation, maxNameLength)
+	}
+	if err := input.Mapping.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	existing, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return "", err
+	}
+	for _, mapping := range existing {
+		if mapping.ID != selfID && strings.EqualFold(mapping.Name, name) {
+			return "", fmt.Errorf("%w: a mapping named %q already exists", ErrValidation, name)
+		}
+	}
+	return name, nil
+}
@@ -0,0 +1,68 @@
+package mappings
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/importer"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+var titleOnly = importer.Mapping{Columns: []importer.ColumnMapping{{Column: "Name", Field: "title"}}}
+
+func TestServiceCreateValidatesAndKeepsNamesUnique(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewInMemoryRepository())
+
+	created, err := svc.Create(ctx, MappingInput{Name: "  Spreadsheet ", Mapping: titleOnly}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Name != "Spreadsheet" {
+		t.Fatalf("expected trimmed name, got %q", created.Name)
+	}
+
+	if _, err := svc.Create(ctx, MappingInput{Name: "spreadsheet", Mapping: titleOnly}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+	if _, err := svc.Create(ctx, MappingInput{Name: "spreadsheet", Mapping: titleOnly}, uuid.New()); err != nil {
+		t.Fatalf("other owners may reuse the name: %v", err)
+	}
+	bad := importer.Mapping{Columns: []importer.ColumnMapping{{Column: "Name", Field: "colour"}}}
+	if _, err := svc.Create(ctx, MappingInput{Name: "Bad", Mapping: bad}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected invalid mapping error, got %v", err)
+	}
+
+	if _, err := svc.Update(ctx, created.ID, MappingInput{Name: "SPREADSHEET", Mapping: titleOnly}, testOwnerID); err != nil {
+		t.Fatalf("renaming a mapping to its own name should succeed: %v", err)
+	}
+}
+
+func TestServiceSpecReportsMissingMappings(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewInMemoryRepository())
+	created, err := svc.Create(ctx, MappingInput{Name: "Games", Mapping: titleOnly}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	spec, err := svc.Spec(ctx, created.ID, testOwnerID)
+	if err != nil || len(spec.Columns) != 1 {
+		t.Fatalf("expected saved spec, got %+v (%v)", spec, err)
+	}
+	if _, err := svc.Spec(ctx, created.ID, uuid.New()); !errors.Is(err, importer.ErrMappingNotFound) {
+		t.Fatalf("expected other owners not to resolve the mapping, got %v", err)
+	}
+
+	if err := svc.Delete(ctx, created.ID, testOwnerID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := svc.Get(ctx, created.ID, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type txKey struct{}
+
+// Queryer is the part of *sqlx.DB and *sqlx.Tx that repositories use.
+type Queryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+}
+
+// Conn returns the transaction a Transactor carries on ctx, or db outside one.
+func Conn(ctx context.Context, db *sqlx.DB) Queryer {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// Tx is a transaction a repository method started itself, or the enclosing
+// one it joined.
+type Tx struct {
+	*sqlx.Tx
+	joined bool
+}
+
+// Begin starts a transaction, or joins the one a Transactor carries on ctx.
+// Commit and Rollback of a joined transaction are left to the Transactor.
+func Begin(ctx context.Context, db *sqlx.DB) (*Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return &Tx{Tx: tx, joined: true}, nil
+	}
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+// Commit commits a transaction the caller started.
+func (t *Tx) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+// Rollback rolls back a transaction the caller started.
+func (t *Tx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// Transactor runs work that spans several repositories in one transaction.
+// The item-related repositories (items, shelves, loans, reading, queue,
+// reviews, sharing, and batches) join it; the others always use the pool.
+type Transactor struct {
+	db *sqlx.DB
+}
+
+// NewTransactor constructs a Transactor for db.
+func NewTransactor(db *sqlx.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTx calls fn with a context carrying a new transaction. Repositories
+// that reach the database through Conn or Begin join it, and it commits only
+// if fn succeeds. Calls nested inside fn reuse the same transaction.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package queue
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID]Entry
+}
+
+// NewInMemoryRepository seeds an empty queue repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{entries: make(map[uuid.UUID]Entry)}
+}
+
+func (m *inMemoryRepository) List(_ context.Context, ownerID uuid.UUID) ([]Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := []Entry{}
+	for _, entry := range m.entries {
+		if entry.OwnerID == ownerID {
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if a.Status != b.Status {
+			if a.Status == StatusInProgress {
+				return -1
+			}
+			return 1
+		}
+		if a.StartedAt != nil && b.StartedAt != nil {
+			if c := a.StartedAt.Compare(*b.StartedAt); c != 0 {
+				return c
+			}
+		}
+		if c := cmp.Compare(a.Position, b.Position); c != 0 {
+			return c
+		}
+		return a.AddedAt.Compare(b.AddedAt)
+	})
+	return entries, nil
+}
+
+func (m *inMemoryRepository) GetByItem(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) (Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, entry := range m.entries {
+		if entry.ItemID == itemID && entry.OwnerID == ownerID {
+			return entry, nil
+		}
+	}
+	return Entry{}, ErrNotFound
+}
+
+func (m *inMemoryRepository) Create(_ context.Context, entry Entry) (Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[entry.ID] = entry
+	return entry, nil
+}
+
+func (m *inMemoryRepository) Start(_ context.Context, id uuid.UUID, ownerID uuid.UUID, startedAt time.Time) (Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[id]
+	if !ok || entry.OwnerID != ownerID || entry.Status != StatusQueued {
+		return Entry{}, ErrNotFound
+	}
+	entry.Status = StatusInProgress
+	entry.Position = 0
+	entry.StartedAt = &startedAt
+	m.entries[id] = entry
+	return entry, nil
+}
+
+func (m *inMemoryRepository) Delete(_ context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[id]
+	if !ok || entry.OwnerID != ownerID {
+		return ErrNotFound
+	}
+	delete(m.entries, id)
+	return nil
+}
+
+func (m *inMemoryRepository) Reorder(_ context.Context, ownerID uuid.UUID, entryIDs []uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, id := range entryIDs {
+		entry, ok := m.entries[id]
+		if !ok || entry.OwnerID != ownerID || entry.Status != StatusQueued {
+			continue
+		}
+		entry.Position = i + 1
+		m.entries[id] = entry
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// ErrNotFound is returned when an item is not in the queue.
+var ErrNotFound = errors.New("item is not queued")
+
+// ErrEmpty is returned when popping from a queue with nothing waiting.
+var ErrEmpty = errors.New("queue is empty")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// Status tracks whether a queue entry is waiting or has been started.
+type Status string
+
+const (
+	// StatusQueued entries are waiting in Position order.
+	StatusQueued Status = "queued"
+	// StatusInProgress entries were popped off the queue and are being read, played, or watched.
+	StatusInProgress Status = "in_progress"
+)
+
+// Entry places an item in the owner's "Up Next" queue. Position is 1-based
+// among queued entries and zero once an entry is in progress.
+type Entry struct {
+	ID        uuid.UUID   `db:"id" json:"id"`
+	OwnerID   uuid.UUID   `db:"owner_id" json:"-"`
+	ItemID    uuid.UUID   `db:"item_id" json:"itemId"`
+	Position  int         `db:"position" json:"position"`
+	Status    Status      `db:"status" json:"status"`
+	AddedAt   time.Time   `db:"added_at" json:"addedAt"`
+	StartedAt *time.Time  `db:"started_at" json:"startedAt,omitempty"`
+	Item      *items.Item `db:"-" json:"item,omitempty"`
+}
+
+// Queue is the owner's in-progress entries followed by what is up next.
+type Queue struct {
+	InProgress []Entry `json:"inProgress"`
+	UpNext     []Entry `json:"upNext"`
+}
+
+// EnqueueInput adds an item at Position (1-based), or at the end when omitted.
+type EnqueueInput struct {
+	ItemID   uuid.UUID `json:"itemId"`
+	Position *int      `json:"position"`
+}
+
+// MoveInput moves a queued item to a new 1-based position.
+type MoveInput struct {
+	Position int `json:"position"`
+}
+
+// Repository defines persistence for queue entries.
+type Repository interface {
+	// List returns in-progress entries by start time, then queued entries by position.
+	List(ctx context.Context, ownerID uuid.UUID) ([]Entry, error)
+	GetByItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (Entry, error)
+	Create(ctx context.Context, entry Entry) (Entry, error)
+	Start(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, startedAt time.Time) (Entry, error)
+	Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+	// Reorder assigns positions 1..n to the given queued entries in order.
+	Reorder(ctx context.Context, ownerID uuid.UUID, entryIDs []uuid.UUID) error
+}
@@ -0,0 +1,96 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"anthology/internal/platform/database"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a queue repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const entryColumns = `id, owner_id, item_id, position, status, added_at, started_at`
+
+func (r *postgresRepository) List(ctx context.Context, ownerID uuid.UUID) ([]Entry, error) {
+	entries := []Entry{}
+	query := `SELECT ` + entryColumns + ` FROM queue_entries WHERE owner_id = $1
+ORDER BY CASE WHEN status = 'in_progress' THEN 0 ELSE 1 END, started_at, position, added_at`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &entries, query, ownerID); err != nil {
+		return nil, fmt.Errorf("list queue: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *postgresRepository) GetByItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (Entry, error) {
+	var entry Entry
+	query := `SELECT ` + entryColumns + ` FROM queue_entries WHERE item_id = $1 AND owner_id = $2`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &entry, query, itemID, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Entry{}, ErrNotFound
+		}
+		return Entry{}, fmt.Errorf("get queue entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, entry Entry) (Entry, error) {
+	query := `INSERT INTO queue_entries (` + entryColumns + `)
+VALUES (:id, :owner_id, :item_id, :position, :status, :added_at, :started_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, entry); err != nil {
+		return Entry{}, fmt.Errorf("insert queue entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *postgresRepository) Start(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, startedAt time.Time) (Entry, error) {
+	var entry Entry
+	query := `UPDATE queue_entries SET status = 'in_progress', position = 0, started_at = $1
+WHERE id = $2 AND owner_id = $3 AND status = 'queued'
+RETURNING ` + entryColumns
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &entry, query, startedAt, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Entry{}, ErrNotFound
+		}
+		return Entry{}, fmt.Errorf("start queue entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM queue_entries WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("delete queue entry: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete queue entry rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) Reorder(ctx context.Context, ownerID uuid.UUID, entryIDs []uuid.UUID) error {
+	query := `UPDATE queue_entries q SET position = o.ord
+FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+WHERE q.id = o.id AND q.owner_id = $1 AND q.status = 'queued'`
+	if _, err := database.Conn(ctx, r.db).ExecContext(ctx, query, ownerID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf("reorder queue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// Service maintains the ordered "Up Next" queue.
+type Service struct {
+	repo      Repository
+	itemsRepo items.Repository
+	now       func() time.Time
+}
+
+// NewService wires a queue service. Register it with items.WithDeleteHook so
+// deleted items leave the queue.
+func NewService(repo Repository, itemsRepo items.Repository) *Service {
+	return &Service{
+		repo:      repo,
+		itemsRepo: itemsRepo,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// List returns the owner's queue with items attached.
+func (s *Service) List(ctx context.Context, ownerID uuid.UUID) (Queue, error) {
+	entries, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return Queue{}, err
+	}
+
+	catalog, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		return Queue{}, err
+	}
+	byID := make(map[uuid.UUID]items.Item, len(catalog))
+	for _, item := range catalog {
+		byID[item.ID] = item
+	}
+
+	queue := Queue{InProgress: []Entry{}, UpNext: []Entry{}}
+	for _, entry := range entries {
+		if item, ok := byID[entry.ItemID]; ok {
+			entry.Item = &item
+		}
+		if entry.Status == StatusInProgress {
+			queue.InProgress = append(queue.InProgress, entry)
+		} else {
+			queue.UpNext = append(queue.UpNext, entry)
+		}
+	}
+	return queue, nil
+}
+
+// Enqueue adds an item of any type to the queue, at the end unless a position is given.
+func (s *Service) Enqueue(ctx context.Context, input EnqueueInput, ownerID uuid.UUID) (Queue, error) {
+	if _, err := s.itemsRepo.Get(ctx, input.ItemID, ownerID); err != nil {
+		return Queue{}, err
+	}
+	if _, err := s.repo.GetByItem(ctx, input.ItemID, ownerID); err == nil {
+		return Queue{}, fmt.Errorf("%w: item is already queued", ErrValidation)
+	} else if !errors.Is(err, ErrNotFound) {
+		return Queue{}, err
+	}
+
+	queued, err := s.queuedIDs(ctx, ownerID)
+	if err != nil {
+		return Queue{}, err
+	}
+	index := len(queued)
+	if input.Position != nil {
+		if *input.Position < 1 || *input.Position > len(queued)+1 {
+			return Queue{}, fmt.Errorf("%w: position must be between 1 and %d", ErrValidation, len(queued)+1)
+		}
+		index = *input.Position - 1
+	}
+
+	entry, err := s.repo.Create(ctx, Entry{
+		ID:       uuid.New(),
+		OwnerID:  ownerID,
+		ItemID:   input.ItemID,
+		Position: index + 1,
+		Status:   StatusQueued,
+		AddedAt:  s.now(),
+	})
+	if err != nil {
+		return Queue{}, err
+	}
+
+	if err := s.repo.Reorder(ctx, ownerID, slices.Insert(queued, index, entry.ID)); err != nil {
+		return Queue{}, err
+	}
+	return s.List(ctx, ownerID)
+}
+
+// Dequeue removes an item from the queue whether it is waiting or in progress.
+func (s *Service) Dequeue(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+	entry, err := s.repo.GetByItem(ctx, itemID, ownerID)
+	if err != nil {
+		return err
+	}
+	return s.remove(ctx, entry)
+}
+
+// Move places a queued item at a new 1-based position, shifting the others.
+func (s *Service) Move(ctx context.Context, itemID uuid.UUID, input MoveInput, ownerID uuid.UUID) (Queue, error) {
+	entry, err := s.repo.GetByItem(ctx, itemID, ownerID)
+	if err != nil {
+		return Queue{}, err
+	}
+	if entry.Status != StatusQueued {
+		return Queue{}, fmt.Errorf("%w: only queued items can be reordered", ErrValidation)
+	}
+
+	queued, err := s.queuedIDs(ctx, ownerID)
+	if err != nil {
+		return Queue{}, err
+	}
+	if input.Position < 1 || input.Position > len(queued) {
+		return Queue{}, fmt.Errorf("%w: position must be between 1 and %d", ErrValidation, len(queued))
+	}
+
+	queued = slices.DeleteFunc(queued, func(id uuid.UUID) bool { return id == entry.ID })
+	queued = slices.Insert(queued, input.Position-1, entry.ID)
+	if err := s.repo.Reorder(ctx, ownerID, queued); err != nil {
+		return Queue{}, err
+	}
+	return s.List(ctx, ownerID)
+}
+
+// PopNext starts the first queued item. Books are also moved to the reading status.
+func (s *Service) PopNext(ctx context.Context, ownerID uuid.UUID) (Entry, error) {
+	queued, err := s.queuedIDs(ctx, ownerID)
+	if err != nil {
+		return Entry{}, err
+	}
+	if len(queued) == 0 {
+		return Entry{}, ErrEmpty
+	}
+
+	entry, err := s.repo.Start(ctx, queued[0], ownerID, s.now())
+	if err != nil {
+		return Entry{}, err
+	}
+	if err := s.repo.Reorder(ctx, ownerID, queued[1:]); err != nil {
+		return Entry{}, err
+	}
+
+	item, err := s.itemsRepo.Get(ctx, entry.ItemID, ownerID)
+	if err != nil {
+		return Entry{}, err
+	}
+	if item.ItemType == items.ItemTypeBook && item.ReadingStatus != items.BookStatusReading {
+		item.ReadingStatus = items.BookStatusReading
+		item.ReadAt = nil
+		item.UpdatedAt = s.now()
+		if item, err = s.itemsRepo.Update(ctx, item); err != nil {
+			return Entry{}, err
+		}
+	}
+	entry.Item = &item
+	return entry, nil
+}
+
+// ItemDeleted drops a deleted item from the queue and closes the gap it left.
+// In Postgres the entry has already cascaded away with the item, so only the
+// remaining entries are renumbered. It implements items.DeleteHook.
+func (s *Service) ItemDeleted(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+	entry, err := s.repo.GetByItem(ctx, itemID, ownerID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		if err := s.repo.Delete(ctx, entry.ID, entry.OwnerID); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	queued, err := s.queuedIDs(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Reorder(ctx, ownerID, queued)
+}
+
+// ItemUsedSince reports whether the item was added to the owner's queue
+// after since.
+func (s *Service) ItemUsedSince(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error) {
+	entry, err := s.repo.GetByItem(ctx, itemID, ownerID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return entry.AddedAt.After(since), nil
+}
+
+func (s *Service) remove(ctx context.Context, entry Entry) error {
+	if err := s.repo.Delete(ctx, entry.ID, entry.OwnerID); err != nil {
+		return err
+	}
+	if entry.Status != StatusQueued {
+		return nil
+	}
+
+	queued, err := s.queuedIDs(ctx, entry.OwnerID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Reorder(ctx, entry.OwnerID, queued)
+}
+
+// queuedIDs returns the IDs of waiting entries in queue order.
+func (s *Service) queuedIDs(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	entries, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Status == StatusQueued {
+			ids = append(ids, entry.ID)
+		}
+	}
+	return ids, nil
+}
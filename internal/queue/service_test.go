@@ -0,0 +1,196 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func newTestService(t *testing.T) (*Service, *items.Service, []items.Item) {
+	t.Helper()
+	ctx := context.Background()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	svc := NewService(NewInMemoryRepository(), itemsRepo)
+	itemSvc := items.NewService(itemsRepo, items.WithDeleteHook(svc))
+
+	created := []items.Item{}
+	for _, input := range []items.CreateItemInput{
+		{Title: "Dune", ItemType: items.ItemTypeBook, ReadingStatus: items.BookStatusWantToRead},
+		{Title: "Hades", ItemType: items.ItemTypeGame},
+		{Title: "Alien", ItemType: items.ItemTypeMovie},
+	} {
+		input.OwnerID = testOwnerID
+		item, err := itemSvc.Create(ctx, input)
+		if err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+		created = append(created, item)
+	}
+	return svc, itemSvc, created
+}
+
+func upNextTitles(queue Queue) []string {
+	titles := make([]string, 0, len(queue.UpNext))
+	for _, entry := range queue.UpNext {
+		titles = append(titles, entry.Item.Title)
+	}
+	return titles
+}
+
+func assertOrder(t *testing.T, queue Queue, want ...string) {
+	t.Helper()
+	got := upNextTitles(queue)
+	if len(got) != len(want) {
+		t.Fatalf("expected queue %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] || queue.UpNext[i].Position != i+1 {
+			t.Fatalf("expected queue %v, got %v (%+v)", want, got, queue.UpNext)
+		}
+	}
+}
+
+func TestEnqueueAndMoveAcrossItemTypes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _, created := newTestService(t)
+	dune, hades, alien := created[0], created[1], created[2]
+
+	if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: dune.ID}, testOwnerID); err != nil {
+		t.Fatalf("enqueue dune: %v", err)
+	}
+	if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: hades.ID}, testOwnerID); err != nil {
+		t.Fatalf("enqueue hades: %v", err)
+	}
+	first := 1
+	queue, err := svc.Enqueue(ctx, EnqueueInput{ItemID: alien.ID, Position: &first}, testOwnerID)
+	if err != nil {
+		t.Fatalf("enqueue alien: %v", err)
+	}
+	assertOrder(t, queue, "Alien", "Dune", "Hades")
+
+	queue, err = svc.Move(ctx, alien.ID, MoveInput{Position: 3}, testOwnerID)
+	if err != nil {
+		t.Fatalf("move alien: %v", err)
+	}
+	assertOrder(t, queue, "Dune", "Hades", "Alien")
+
+	if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: dune.ID}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected duplicate enqueue to be rejected, got %v", err)
+	}
+	if _, err := svc.Move(ctx, dune.ID, MoveInput{Position: 4}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected out-of-range move to be rejected, got %v", err)
+	}
+	if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: uuid.New()}, testOwnerID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected unknown item to be rejected, got %v", err)
+	}
+}
+
+func TestPopNextStartsFirstItem(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _, created := newTestService(t)
+
+	if _, err := svc.PopNext(ctx, testOwnerID); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+	for _, item := range created {
+		if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: item.ID}, testOwnerID); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	entry, err := svc.PopNext(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("pop next: %v", err)
+	}
+	if entry.Status != StatusInProgress || entry.StartedAt == nil || entry.Item.ReadingStatus != items.BookStatusReading {
+		t.Fatalf("expected book to be in progress, got %+v", entry)
+	}
+
+	queue, err := svc.List(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(queue.InProgress) != 1 || queue.InProgress[0].ItemID != created[0].ID {
+		t.Fatalf("unexpected in-progress entries %+v", queue.InProgress)
+	}
+	assertOrder(t, queue, "Hades", "Alien")
+}
+
+func TestDeletingItemRemovesItFromQueue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, itemSvc, created := newTestService(t)
+	for _, item := range created {
+		if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: item.ID}, testOwnerID); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	if err := itemSvc.Delete(ctx, created[0].ID, testOwnerID); err != nil {
+		t.Fatalf("delete item: %v", err)
+	}
+
+	queue, err := svc.List(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	assertOrder(t, queue, "Hades", "Alien")
+
+	if err := svc.Dequeue(ctx, created[2].ID, testOwnerID); err != nil {
+		t.Fatalf("dequeue: %v", err)
+	}
+	if err := svc.Dequeue(ctx, created[2].ID, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for dequeued item, got %v", err)
+	}
+}
+
+func TestItemDeletedRenumbersAfterCascade(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := NewInMemoryRepository()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	svc := NewService(repo, itemsRepo)
+	itemSvc := items.NewService(itemsRepo)
+
+	var created []items.Item
+	for _, title := range []string{"Dune", "Hades", "Alien"} {
+		item, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: testOwnerID, Title: title, ItemType: items.ItemTypeGame})
+		if err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+		if _, err := svc.Enqueue(ctx, EnqueueInput{ItemID: item.ID}, testOwnerID); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		created = append(created, item)
+	}
+
+	// Postgres drops the entry together with the item before the hook runs.
+	entry, err := repo.GetByItem(ctx, created[0].ID, testOwnerID)
+	if err != nil {
+		t.Fatalf("get entry: %v", err)
+	}
+	if err := repo.Delete(ctx, entry.ID, testOwnerID); err != nil {
+		t.Fatalf("delete entry: %v", err)
+	}
+	if err := itemsRepo.Delete(ctx, created[0].ID, testOwnerID); err != nil {
+		t.Fatalf("delete item: %v", err)
+	}
+
+	if err := svc.ItemDeleted(ctx, created[0].ID, testOwnerID); err != nil {
+		t.Fatalf("item deleted: %v", err)
+	}
+	queue, err := svc.List(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	assertOrder(t, queue, "Hades", "Alien")
+}
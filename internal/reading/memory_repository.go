@@ -0,0 +1,118 @@
+package reading
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu           sync.RWMutex
+	readThroughs map[uuid.UUID]ReadThrough
+	sessions     map[uuid.UUID]Session
+}
+
+// NewInMemoryRepository seeds an empty reading log repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{
+		readThroughs: make(map[uuid.UUID]ReadThrough),
+		sessions:     make(map[uuid.UUID]Session),
+	}
+}
+
+func (m *inMemoryRepository) ListReadThroughs(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]ReadThrough, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	readThroughs := []ReadThrough{}
+	for _, readThrough := range m.readThroughs {
+		if readThrough.ItemID == itemID && readThrough.OwnerID == ownerID {
+			readThroughs = append(readThroughs, readThrough)
+		}
+	}
+	slices.SortFunc(readThroughs, func(a, b ReadThrough) int {
+		if c := b.StartedAt.Compare(a.StartedAt); c != 0 {
+			return c
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return readThroughs, nil
+}
+
+func (m *inMemoryRepository) ListSessions(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sessions := []Session{}
+	for _, session := range m.sessions {
+		if session.ItemID == itemID && session.OwnerID == ownerID {
+			sessions = append(sessions, session)
+		}
+	}
+	slices.SortFunc(sessions, func(a, b Session) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return sessions, nil
+}
+
+func (m *inMemoryRepository) GetOpenReadThrough(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) (ReadThrough, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, readThrough := range m.readThroughs {
+		if readThrough.ItemID == itemID && readThrough.OwnerID == ownerID && readThrough.ReadAt == nil {
+			return readThrough, nil
+		}
+	}
+	return ReadThrough{}, ErrNotFound
+}
+
+func (m *inMemoryRepository) CreateReadThrough(_ context.Context, readThrough ReadThrough) (ReadThrough, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.readThroughs[readThrough.ID] = readThrough
+	return readThrough, nil
+}
+
+func (m *inMemoryRepository) FinishReadThrough(_ context.Context, id uuid.UUID, ownerID uuid.UUID, readAt time.Time) (ReadThrough, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	readThrough, ok := m.readThroughs[id]
+	if !ok || readThrough.OwnerID != ownerID || readThrough.ReadAt != nil {
+		return ReadThrough{}, ErrNotFound
+	}
+	readThrough.ReadAt = &readAt
+	readThrough.UpdatedAt = time.Now().UTC()
+	m.readThroughs[id] = readThrough
+	return readThrough, nil
+}
+
+func (m *inMemoryRepository) CreateSession(_ context.Context, session Session) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[session.ID] = session
+	return session, nil
+}
+
+func (m *inMemoryRepository) ListSessionDates(_ context.Context, ownerID uuid.UUID) ([]time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	dates := []time.Time{}
+	for _, session := range m.sessions {
+		if session.OwnerID == ownerID {
+			dates = append(dates, session.Date)
+		}
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	return dates, nil
+}
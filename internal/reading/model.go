@@ -0,0 +1,95 @@
+package reading
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// ErrNotFound is returned when a read-through cannot be found.
+var ErrNotFound = errors.New("read-through not found")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// ReadThrough is one pass through a book, from first session to finish.
+// ReadAt is set once the read-through is finished.
+type ReadThrough struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	OwnerID   uuid.UUID  `db:"owner_id" json:"-"`
+	ItemID    uuid.UUID  `db:"item_id" json:"itemId"`
+	StartedAt time.Time  `db:"started_at" json:"startedAt"`
+	ReadAt    *time.Time `db:"read_at" json:"readAt,omitempty"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time  `db:"updated_at" json:"updatedAt"`
+	Sessions  []Session  `db:"-" json:"sessions"`
+	PagesRead int        `db:"-" json:"pagesRead"`
+	Minutes   int        `db:"-" json:"minutes"`
+}
+
+// Session records a single sitting of reading.
+type Session struct {
+	ID            uuid.UUID `db:"id" json:"id"`
+	OwnerID       uuid.UUID `db:"owner_id" json:"-"`
+	ItemID        uuid.UUID `db:"item_id" json:"itemId"`
+	ReadThroughID uuid.UUID `db:"read_through_id" json:"readThroughId"`
+	Date          time.Time `db:"session_date" json:"date"`
+	StartPage     int       `db:"start_page" json:"startPage"`
+	EndPage       int       `db:"end_page" json:"endPage"`
+	Minutes       *int      `db:"minutes" json:"minutes,omitempty"`
+	Notes         string    `db:"notes" json:"notes"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+}
+
+// History is the full reading log for an item, newest read-through first.
+type History struct {
+	ItemID       uuid.UUID     `json:"itemId"`
+	ReadCount    int           `json:"readCount"`
+	ReadThroughs []ReadThrough `json:"readThroughs"`
+}
+
+// LogSessionInput captures a reading session. StartPage defaults to where the
+// previous session ended; Finished closes the read-through even if the last page
+// was not reached.
+type LogSessionInput struct {
+	Date      *time.Time `json:"date"`
+	StartPage *int       `json:"startPage"`
+	EndPage   int        `json:"endPage"`
+	Minutes   *int       `json:"minutes"`
+	Notes     string     `json:"notes"`
+	Finished  bool       `json:"finished"`
+}
+
+// SessionResult returns the logged session with the state it changed.
+type SessionResult struct {
+	Session     Session     `json:"session"`
+	ReadThrough ReadThrough `json:"readThrough"`
+	Item        items.Item  `json:"item"`
+}
+
+// ReadThroughInput captures an optional timestamp for starting or finishing a read-through.
+type ReadThroughInput struct {
+	At *time.Time `json:"at"`
+}
+
+// ReadThroughResult returns a started or finished read-through with the updated item.
+type ReadThroughResult struct {
+	ReadThrough ReadThrough `json:"readThrough"`
+	Item        items.Item  `json:"item"`
+}
+
+// Repository defines persistence for read-throughs and sessions.
+type Repository interface {
+	ListReadThroughs(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]ReadThrough, error)
+	ListSessions(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Session, error)
+	GetOpenReadThrough(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (ReadThrough, error)
+	CreateReadThrough(ctx context.Context, readThrough ReadThrough) (ReadThrough, error)
+	FinishReadThrough(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, readAt time.Time) (ReadThrough, error)
+	CreateSession(ctx context.Context, session Session) (Session, error)
+	// ListSessionDates returns the date of every session the owner has logged, across all items.
+	ListSessionDates(ctx context.Context, ownerID uuid.UUID) ([]time.Time, error)
+}
@@ -0,0 +1,97 @@
+package reading
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"anthology/internal/platform/database"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a reading log repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const readThroughColumns = `id, owner_id, item_id, started_at, read_at, created_at, updated_at`
+
+const sessionColumns = `id, owner_id, item_id, read_through_id, session_date, start_page, end_page, minutes, notes, created_at`
+
+func (r *postgresRepository) ListReadThroughs(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]ReadThrough, error) {
+	readThroughs := []ReadThrough{}
+	query := `SELECT ` + readThroughColumns + ` FROM read_throughs WHERE item_id = $1 AND owner_id = $2 ORDER BY started_at DESC, created_at DESC`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &readThroughs, query, itemID, ownerID); err != nil {
+		return nil, fmt.Errorf("list read-throughs: %w", err)
+	}
+	return readThroughs, nil
+}
+
+func (r *postgresRepository) ListSessions(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Session, error) {
+	sessions := []Session{}
+	query := `SELECT ` + sessionColumns + ` FROM reading_sessions WHERE item_id = $1 AND owner_id = $2 ORDER BY session_date, created_at`
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &sessions, query, itemID, ownerID); err != nil {
+		return nil, fmt.Errorf("list reading sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *postgresRepository) GetOpenReadThrough(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (ReadThrough, error) {
+	var readThrough ReadThrough
+	query := `SELECT ` + readThroughColumns + ` FROM read_throughs WHERE item_id = $1 AND owner_id = $2 AND read_at IS NULL`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &readThrough, query, itemID, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ReadThrough{}, ErrNotFound
+		}
+		return ReadThrough{}, fmt.Errorf("get open read-through: %w", err)
+	}
+	return readThrough, nil
+}
+
+func (r *postgresRepository) CreateReadThrough(ctx context.Context, readThrough ReadThrough) (ReadThrough, error) {
+	query := `INSERT INTO read_throughs (` + readThroughColumns + `)
+VALUES (:id, :owner_id, :item_id, :started_at, :read_at, :created_at, :updated_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, readThrough); err != nil {
+		return ReadThrough{}, fmt.Errorf("insert read-through: %w", err)
+	}
+	return readThrough, nil
+}
+
+func (r *postgresRepository) FinishReadThrough(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, readAt time.Time) (ReadThrough, error) {
+	var readThrough ReadThrough
+	query := `UPDATE read_throughs SET read_at = $1, updated_at = NOW()
+WHERE id = $2 AND owner_id = $3 AND read_at IS NULL
+RETURNING ` + readThroughColumns
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &readThrough, query, readAt, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ReadThrough{}, ErrNotFound
+		}
+		return ReadThrough{}, fmt.Errorf("finish read-through: %w", err)
+	}
+	return readThrough, nil
+}
+
+func (r *postgresRepository) CreateSession(ctx context.Context, session Session) (Session, error) {
+	query := `INSERT INTO reading_sessions (` + sessionColumns + `)
+VALUES (:id, :owner_id, :item_id, :read_through_id, :session_date, :start_page, :end_page, :minutes, :notes, :created_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, session); err != nil {
+		return Session{}, fmt.Errorf("insert reading session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *postgresRepository) ListSessionDates(ctx context.Context, ownerID uuid.UUID) ([]time.Time, error) {
+	dates := []time.Time{}
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &dates, `SELECT session_date FROM reading_sessions WHERE owner_id = $1 ORDER BY session_date`, ownerID); err != nil {
+		return nil, fmt.Errorf("list reading session dates: %w", err)
+	}
+	return dates, nil
+}
@@ -0,0 +1,318 @@
+package reading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+const maxSessionNotesLength = 2000
+
+// Service records reading sessions and keeps an item's progress fields in sync.
+type Service struct {
+	repo      Repository
+	itemsRepo items.Repository
+	now       func() time.Time
+}
+
+// NewService wires a reading log service.
+func NewService(repo Repository, itemsRepo items.Repository) *Service {
+	return &Service{
+		repo:      repo,
+		itemsRepo: itemsRepo,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ItemUsedSince reports whether a reading session has been logged for the
+// item after since.
+func (s *Service) ItemUsedSince(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error) {
+	sessions, err := s.repo.ListSessions(ctx, itemID, ownerID)
+	if err != nil {
+		return false, err
+	}
+	for _, session := range sessions {
+		if session.CreatedAt.After(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// History returns every read-through for an item with its sessions attached.
+func (s *Service) History(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (History, error) {
+	if _, err := s.itemsRepo.Get(ctx, itemID, ownerID); err != nil {
+		return History{}, err
+	}
+
+	readThroughs, err := s.repo.ListReadThroughs(ctx, itemID, ownerID)
+	if err != nil {
+		return History{}, err
+	}
+	sessions, err := s.repo.ListSessions(ctx, itemID, ownerID)
+	if err != nil {
+		return History{}, err
+	}
+
+	byReadThrough := make(map[uuid.UUID][]Session, len(readThroughs))
+	for _, session := range sessions {
+		byReadThrough[session.ReadThroughID] = append(byReadThrough[session.ReadThroughID], session)
+	}
+
+	history := History{ItemID: itemID, ReadThroughs: make([]ReadThrough, 0, len(readThroughs))}
+	for _, readThrough := range readThroughs {
+		if readThrough.ReadAt != nil {
+			history.ReadCount++
+		}
+		history.ReadThroughs = append(history.ReadThroughs, withSessions(readThrough, byReadThrough[readThrough.ID]))
+	}
+	return history, nil
+}
+
+// LogSession records a reading session against the item's open read-through,
+// starting one if needed. The item moves to reading with CurrentPage set to the
+// session's end page, or to read once the last page is reached or Finished is set.
+func (s *Service) LogSession(ctx context.Context, itemID uuid.UUID, input LogSessionInput, ownerID uuid.UUID) (SessionResult, error) {
+	item, err := s.getBook(ctx, itemID, ownerID)
+	if err != nil {
+		return SessionResult{}, err
+	}
+
+	date := s.now()
+	if input.Date != nil && !input.Date.IsZero() {
+		date = input.Date.UTC()
+	}
+
+	if input.EndPage < 0 {
+		return SessionResult{}, fmt.Errorf("%w: endPage must be zero or greater", ErrValidation)
+	}
+	if item.PageCount != nil && input.EndPage > *item.PageCount {
+		return SessionResult{}, fmt.Errorf("%w: endPage cannot exceed pageCount", ErrValidation)
+	}
+	if input.Minutes != nil && *input.Minutes <= 0 {
+		return SessionResult{}, fmt.Errorf("%w: minutes must be greater than zero", ErrValidation)
+	}
+	notes := strings.TrimSpace(input.Notes)
+	if len(notes) > maxSessionNotesLength {
+		return SessionResult{}, fmt.Errorf("%w: notes must be %d characters or less", ErrValidation, maxSessionNotesLength)
+	}
+
+	readThrough, err := s.repo.GetOpenReadThrough(ctx, itemID, ownerID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		readThrough, err = s.createReadThrough(ctx, item, date)
+		if err != nil {
+			return SessionResult{}, err
+		}
+	case err != nil:
+		return SessionResult{}, err
+	}
+
+	sessions, err := s.sessionsFor(ctx, readThrough)
+	if err != nil {
+		return SessionResult{}, err
+	}
+
+	startPage := 0
+	switch {
+	case input.StartPage != nil:
+		startPage = *input.StartPage
+	case len(sessions) > 0:
+		startPage = sessions[len(sessions)-1].EndPage
+	case item.CurrentPage != nil:
+		startPage = *item.CurrentPage
+	}
+	if startPage < 0 {
+		return SessionResult{}, fmt.Errorf("%w: startPage must be zero or greater", ErrValidation)
+	}
+	if startPage > input.EndPage {
+		return SessionResult{}, fmt.Errorf("%w: endPage cannot be before startPage", ErrValidation)
+	}
+
+	session, err := s.repo.CreateSession(ctx, Session{
+		ID:            uuid.New(),
+		OwnerID:       ownerID,
+		ItemID:        itemID,
+		ReadThroughID: readThrough.ID,
+		Date:          date,
+		StartPage:     startPage,
+		EndPage:       input.EndPage,
+		Minutes:       input.Minutes,
+		Notes:         notes,
+		CreatedAt:     s.now(),
+	})
+	if err != nil {
+		return SessionResult{}, err
+	}
+	sessions = append(sessions, session)
+
+	finished := input.Finished || (item.PageCount != nil && input.EndPage >= *item.PageCount)
+	if finished {
+		readThrough, err = s.repo.FinishReadThrough(ctx, readThrough.ID, ownerID, date)
+		if err != nil {
+			return SessionResult{}, err
+		}
+		markRead(&item, date)
+	} else {
+		endPage := input.EndPage
+		item.ReadingStatus = items.BookStatusReading
+		item.ReadAt = nil
+		item.CurrentPage = &endPage
+	}
+
+	item, err = s.saveItem(ctx, item)
+	if err != nil {
+		return SessionResult{}, err
+	}
+
+	return SessionResult{
+		Session:     session,
+		ReadThrough: withSessions(readThrough, sessions),
+		Item:        item,
+	}, nil
+}
+
+// StartReadThrough opens a new read-through, e.g. for a re-read of a finished book.
+func (s *Service) StartReadThrough(ctx context.Context, itemID uuid.UUID, input ReadThroughInput, ownerID uuid.UUID) (ReadThroughResult, error) {
+	item, err := s.getBook(ctx, itemID, ownerID)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	if _, err := s.repo.GetOpenReadThrough(ctx, itemID, ownerID); err == nil {
+		return ReadThroughResult{}, fmt.Errorf("%w: a read-through is already in progress", ErrValidation)
+	} else if !errors.Is(err, ErrNotFound) {
+		return ReadThroughResult{}, err
+	}
+
+	startedAt := s.now()
+	if input.At != nil && !input.At.IsZero() {
+		startedAt = input.At.UTC()
+	}
+
+	readThrough, err := s.createReadThrough(ctx, item, startedAt)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	start := 0
+	item.ReadingStatus = items.BookStatusReading
+	item.ReadAt = nil
+	item.CurrentPage = &start
+	item, err = s.saveItem(ctx, item)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	return ReadThroughResult{ReadThrough: withSessions(readThrough, nil), Item: item}, nil
+}
+
+// FinishReadThrough closes the open read-through and marks the item as read.
+func (s *Service) FinishReadThrough(ctx context.Context, itemID uuid.UUID, input ReadThroughInput, ownerID uuid.UUID) (ReadThroughResult, error) {
+	item, err := s.getBook(ctx, itemID, ownerID)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	readThrough, err := s.repo.GetOpenReadThrough(ctx, itemID, ownerID)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	readAt := s.now()
+	if input.At != nil && !input.At.IsZero() {
+		readAt = input.At.UTC()
+	}
+	if readAt.Before(readThrough.StartedAt) {
+		return ReadThroughResult{}, fmt.Errorf("%w: readAt cannot be before startedAt", ErrValidation)
+	}
+
+	sessions, err := s.sessionsFor(ctx, readThrough)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	readThrough, err = s.repo.FinishReadThrough(ctx, readThrough.ID, ownerID, readAt)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	markRead(&item, readAt)
+	item, err = s.saveItem(ctx, item)
+	if err != nil {
+		return ReadThroughResult{}, err
+	}
+
+	return ReadThroughResult{ReadThrough: withSessions(readThrough, sessions), Item: item}, nil
+}
+
+func (s *Service) getBook(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) (items.Item, error) {
+	item, err := s.itemsRepo.Get(ctx, itemID, ownerID)
+	if err != nil {
+		return items.Item{}, err
+	}
+	if item.ItemType != items.ItemTypeBook {
+		return items.Item{}, fmt.Errorf("%w: reading progress can only be tracked for books", ErrValidation)
+	}
+	return item, nil
+}
+
+func (s *Service) createReadThrough(ctx context.Context, item items.Item, startedAt time.Time) (ReadThrough, error) {
+	now := s.now()
+	return s.repo.CreateReadThrough(ctx, ReadThrough{
+		ID:        uuid.New(),
+		OwnerID:   item.OwnerID,
+		ItemID:    item.ID,
+		StartedAt: startedAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (s *Service) sessionsFor(ctx context.Context, readThrough ReadThrough) ([]Session, error) {
+	all, err := s.repo.ListSessions(ctx, readThrough.ItemID, readThrough.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := []Session{}
+	for _, session := range all {
+		if session.ReadThroughID == readThrough.ID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *Service) saveItem(ctx context.Context, item items.Item) (items.Item, error) {
+	item.UpdatedAt = s.now()
+	return s.itemsRepo.Update(ctx, item)
+}
+
+func markRead(item *items.Item, readAt time.Time) {
+	item.ReadingStatus = items.BookStatusRead
+	item.ReadAt = &readAt
+	item.CurrentPage = nil
+}
+
+// withSessions attaches sessions to a read-through and totals its pages and minutes.
+func withSessions(readThrough ReadThrough, sessions []Session) ReadThrough {
+	if sessions == nil {
+		sessions = []Session{}
+	}
+	readThrough.Sessions = sessions
+	readThrough.PagesRead = 0
+	readThrough.Minutes = 0
+	for _, session := range sessions {
+		readThrough.PagesRead += session.EndPage - session.StartPage
+		if session.Minutes != nil {
+			readThrough.Minutes += *session.Minutes
+		}
+	}
+	return readThrough
+}
@@ -0,0 +1,136 @@
+package reading
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func newTestService(t *testing.T, input items.CreateItemInput) (*Service, items.Repository, items.Item) {
+	t.Helper()
+	itemsRepo := items.NewInMemoryRepository(nil)
+	input.OwnerID = testOwnerID
+	item, err := items.NewService(itemsRepo).Create(context.Background(), input)
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	return NewService(NewInMemoryRepository(), itemsRepo), itemsRepo, item
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestLogSessionTracksProgressAndFinishes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, itemsRepo, item := newTestService(t, items.CreateItemInput{Title: "Dune", ItemType: items.ItemTypeBook, PageCount: intPtr(300)})
+
+	day1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	first, err := svc.LogSession(ctx, item.ID, LogSessionInput{Date: &day1, EndPage: 120, Minutes: intPtr(45)}, testOwnerID)
+	if err != nil {
+		t.Fatalf("log first session: %v", err)
+	}
+	if first.Session.StartPage != 0 || first.Item.ReadingStatus != items.BookStatusReading || *first.Item.CurrentPage != 120 {
+		t.Fatalf("unexpected first result %+v", first)
+	}
+
+	day2 := day1.Add(24 * time.Hour)
+	second, err := svc.LogSession(ctx, item.ID, LogSessionInput{Date: &day2, EndPage: 300, Minutes: intPtr(90)}, testOwnerID)
+	if err != nil {
+		t.Fatalf("log second session: %v", err)
+	}
+	if second.Session.StartPage != 120 {
+		t.Fatalf("expected start page to continue from 120, got %d", second.Session.StartPage)
+	}
+	if second.ReadThrough.ReadAt == nil || !second.ReadThrough.ReadAt.Equal(day2) {
+		t.Fatalf("expected read-through to finish on the last page, got %+v", second.ReadThrough)
+	}
+	if second.ReadThrough.PagesRead != 300 || second.ReadThrough.Minutes != 135 {
+		t.Fatalf("unexpected totals %+v", second.ReadThrough)
+	}
+
+	stored, _ := itemsRepo.Get(ctx, item.ID, testOwnerID)
+	if stored.ReadingStatus != items.BookStatusRead || stored.ReadAt == nil || !stored.ReadAt.Equal(day2) || stored.CurrentPage != nil {
+		t.Fatalf("expected item to be marked read, got %+v", stored)
+	}
+}
+
+func TestReReadKeepsSeparateReadThroughs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _, item := newTestService(t, items.CreateItemInput{Title: "Dune", ItemType: items.ItemTypeBook})
+
+	firstRead := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	if _, err := svc.LogSession(ctx, item.ID, LogSessionInput{Date: &firstRead, EndPage: 50, Finished: true}, testOwnerID); err != nil {
+		t.Fatalf("log first read: %v", err)
+	}
+
+	reReadStart := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	started, err := svc.StartReadThrough(ctx, item.ID, ReadThroughInput{At: &reReadStart}, testOwnerID)
+	if err != nil {
+		t.Fatalf("start re-read: %v", err)
+	}
+	if started.Item.ReadingStatus != items.BookStatusReading || *started.Item.CurrentPage != 0 {
+		t.Fatalf("expected item back in reading, got %+v", started.Item)
+	}
+	if _, err := svc.StartReadThrough(ctx, item.ID, ReadThroughInput{}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected second open read-through to be rejected, got %v", err)
+	}
+
+	finishedAt := reReadStart.Add(72 * time.Hour)
+	if _, err := svc.FinishReadThrough(ctx, item.ID, ReadThroughInput{At: &finishedAt}, testOwnerID); err != nil {
+		t.Fatalf("finish re-read: %v", err)
+	}
+
+	history, err := svc.History(ctx, item.ID, testOwnerID)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if history.ReadCount != 2 || len(history.ReadThroughs) != 2 {
+		t.Fatalf("expected two finished read-throughs, got %+v", history)
+	}
+	if !history.ReadThroughs[0].ReadAt.Equal(finishedAt) || !history.ReadThroughs[1].ReadAt.Equal(firstRead) {
+		t.Fatalf("expected newest read-through first, got %+v", history.ReadThroughs)
+	}
+	if len(history.ReadThroughs[1].Sessions) != 1 || len(history.ReadThroughs[0].Sessions) != 0 {
+		t.Fatalf("expected sessions grouped by read-through, got %+v", history.ReadThroughs)
+	}
+
+	if _, err := svc.FinishReadThrough(ctx, item.ID, ReadThroughInput{}, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without an open read-through, got %v", err)
+	}
+}
+
+func TestLogSessionValidatesPages(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, _, item := newTestService(t, items.CreateItemInput{Title: "Dune", ItemType: items.ItemTypeBook, PageCount: intPtr(100)})
+
+	cases := map[string]LogSessionInput{
+		"past page count":   {EndPage: 101},
+		"end before start":  {StartPage: intPtr(40), EndPage: 20},
+		"negative end page": {EndPage: -1},
+		"zero minutes":      {EndPage: 10, Minutes: intPtr(0)},
+	}
+	for name, input := range cases {
+		if _, err := svc.LogSession(ctx, item.ID, input, testOwnerID); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestLogSessionRejectsNonBooks(t *testing.T) {
+	t.Parallel()
+	svc, _, item := newTestService(t, items.CreateItemInput{Title: "Catan", ItemType: items.ItemTypeGame})
+
+	if _, err := svc.LogSession(context.Background(), item.ID, LogSessionInput{EndPage: 1}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
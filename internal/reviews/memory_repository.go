@@ -0,0 +1,100 @@
+package reviews
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu      sync.RWMutex
+	reviews map[uuid.UUID]Review
+}
+
+// NewInMemoryRepository seeds an empty review repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{reviews: make(map[uuid.UUID]Review)}
+}
+
+func (m *inMemoryRepository) List(_ context.Context, ownerID uuid.UUID, itemID *uuid.UUID) ([]Review, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reviews := []Review{}
+	for _, review := range m.reviews {
+		if review.OwnerID != ownerID || (itemID != nil && review.ItemID != *itemID) {
+			continue
+		}
+		reviews = append(reviews, cloneReview(review))
+	}
+	slices.SortFunc(reviews, func(a, b Review) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return slices.Compare(b.ID[:], a.ID[:])
+	})
+	return reviews, nil
+}
+
+func (m *inMemoryRepository) Get(_ context.Context, id uuid.UUID, ownerID uuid.UUID) (Review, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	review, ok := m.reviews[id]
+	if !ok || review.OwnerID != ownerID {
+		return Review{}, ErrNotFound
+	}
+	return cloneReview(review), nil
+}
+
+func (m *inMemoryRepository) Create(_ context.Context, review Review) (Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reviews[review.ID] = cloneReview(review)
+	return review, nil
+}
+
+func (m *inMemoryRepository) Update(_ context.Context, review Review) (Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.reviews[review.ID]
+	if !ok || existing.OwnerID != review.OwnerID {
+		return Review{}, ErrNotFound
+	}
+	m.reviews[review.ID] = cloneReview(review)
+	return review, nil
+}
+
+func (m *inMemoryRepository) Delete(_ context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	review, ok := m.reviews[id]
+	if !ok || review.OwnerID != ownerID {
+		return ErrNotFound
+	}
+	delete(m.reviews, id)
+	return nil
+}
+
+func (m *inMemoryRepository) DeleteByItem(_ context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, review := range m.reviews {
+		if review.ItemID == itemID && review.OwnerID == ownerID {
+			delete(m.reviews, id)
+		}
+	}
+	return nil
+}
+
+func cloneReview(review Review) Review {
+	review.Sections = slices.Clone(review.Sections)
+	review.Item = nil
+	return review
+}
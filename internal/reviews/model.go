@@ -0,0 +1,111 @@
+package reviews
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// ErrNotFound is returned when a review cannot be found.
+var ErrNotFound = errors.New("review not found")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// Section is a titled block of Markdown appended to a review body. Spoiler
+// sections are meant to stay collapsed until the reader opts in.
+type Section struct {
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+	Spoiler bool   `json:"spoiler"`
+}
+
+// SectionList is stored as a Postgres jsonb array and always serializes as a JSON array.
+type SectionList []Section
+
+// Value implements driver.Valuer.
+func (s SectionList) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Section(s))
+}
+
+// Scan implements sql.Scanner.
+func (s *SectionList) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*s = SectionList{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("scan review sections: unsupported type %T", src)
+	}
+	var sections []Section
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		return fmt.Errorf("scan review sections: %w", err)
+	}
+	*s = SectionList(sections)
+	return nil
+}
+
+// MarshalJSON renders a nil list as an empty array.
+func (s SectionList) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Section(s))
+}
+
+// Review is a long-form, Markdown write-up of an item. ReadOn is the date of
+// the reading (or viewing, or play-through) the review refers to.
+type Review struct {
+	ID        uuid.UUID   `db:"id" json:"id"`
+	OwnerID   uuid.UUID   `db:"owner_id" json:"-"`
+	ItemID    uuid.UUID   `db:"item_id" json:"itemId"`
+	Title     string      `db:"title" json:"title"`
+	Body      string      `db:"body" json:"body"`
+	Sections  SectionList `db:"sections" json:"sections"`
+	ReadOn    *time.Time  `db:"read_on" json:"readOn,omitempty"`
+	CreatedAt time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time   `db:"updated_at" json:"updatedAt"`
+	Item      *items.Item `db:"-" json:"item,omitempty"`
+}
+
+// ReviewInput captures the editable fields of a review. CreatedAt and
+// UpdatedAt are only honoured on create so imports can keep original timestamps.
+type ReviewInput struct {
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Sections  []Section  `json:"sections"`
+	ReadOn    *time.Time `json:"readOn"`
+	CreatedAt *time.Time `json:"-"`
+	UpdatedAt *time.Time `json:"-"`
+}
+
+// ListOptions narrows a review listing.
+type ListOptions struct {
+	ItemID   *uuid.UUID
+	ItemType *items.ItemType
+}
+
+// Repository defines persistence for reviews.
+type Repository interface {
+	List(ctx context.Context, ownerID uuid.UUID, itemID *uuid.UUID) ([]Review, error)
+	Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Review, error)
+	Create(ctx context.Context, review Review) (Review, error)
+	Update(ctx context.Context, review Review) (Review, error)
+	Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+	DeleteByItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error
+}
@@ -0,0 +1,101 @@
+package reviews
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"anthology/internal/platform/database"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a review repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+const reviewColumns = `id, owner_id, item_id, title, body, sections, read_on, created_at, updated_at`
+
+func (r *postgresRepository) List(ctx context.Context, ownerID uuid.UUID, itemID *uuid.UUID) ([]Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE owner_id = $1`
+	args := []any{ownerID}
+	if itemID != nil {
+		query += ` AND item_id = $2`
+		args = append(args, *itemID)
+	}
+	query += ` ORDER BY created_at DESC, id DESC`
+
+	reviews := []Review{}
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &reviews, query, args...); err != nil {
+		return nil, fmt.Errorf("list reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Review, error) {
+	var review Review
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE id = $1 AND owner_id = $2`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &review, query, id, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Review{}, ErrNotFound
+		}
+		return Review{}, fmt.Errorf("get review: %w", err)
+	}
+	return review, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, review Review) (Review, error) {
+	query := `INSERT INTO reviews (` + reviewColumns + `)
+VALUES (:id, :owner_id, :item_id, :title, :body, :sections, :read_on, :created_at, :updated_at)`
+	if _, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, review); err != nil {
+		return Review{}, fmt.Errorf("insert review: %w", err)
+	}
+	return review, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, review Review) (Review, error) {
+	query := `UPDATE reviews
+SET title = :title,
+    body = :body,
+    sections = :sections,
+    read_on = :read_on,
+    updated_at = :updated_at
+WHERE id = :id AND owner_id = :owner_id`
+	res, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, review)
+	if err != nil {
+		return Review{}, fmt.Errorf("update review: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return Review{}, ErrNotFound
+	}
+	return r.Get(ctx, review.ID, review.OwnerID)
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM reviews WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("delete review: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete review rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+	if _, err := database.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM reviews WHERE item_id = $1 AND owner_id = $2`, itemID, ownerID); err != nil {
+		return fmt.Errorf("delete item reviews: %w", err)
+	}
+	return nil
+}
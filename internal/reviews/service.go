@@ -0,0 +1,228 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+const (
+	maxTitleLength   = 200
+	maxHeadingLength = 200
+	maxBodyLength    = 100_000
+	maxSections      = 50
+)
+
+// Service manages item reviews.
+type Service struct {
+	repo      Repository
+	itemsRepo items.Repository
+	now       func() time.Time
+}
+
+// NewService wires a review service. Register it with items.WithDeleteHook so
+// deleted items take their reviews with them.
+func NewService(repo Repository, itemsRepo items.Repository) *Service {
+	return &Service{
+		repo:      repo,
+		itemsRepo: itemsRepo,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// List returns the owner's reviews across the catalog, newest first, with items attached.
+func (s *Service) List(ctx context.Context, ownerID uuid.UUID, opts ListOptions) ([]Review, error) {
+	reviews, err := s.repo.List(ctx, ownerID, opts.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := s.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID, ItemType: opts.ItemType})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]items.Item, len(catalog))
+	for _, item := range catalog {
+		byID[item.ID] = item
+	}
+
+	filtered := make([]Review, 0, len(reviews))
+	for _, review := range reviews {
+		item, ok := byID[review.ItemID]
+		if !ok {
+			continue
+		}
+		review.Item = &item
+		filtered = append(filtered, review)
+	}
+	return filtered, nil
+}
+
+// ListForItem returns an item's reviews, newest first.
+func (s *Service) ListForItem(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) ([]Review, error) {
+	if _, err := s.itemsRepo.Get(ctx, itemID, ownerID); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, ownerID, &itemID)
+}
+
+// ItemUsedSince reports whether the item has a review written or changed
+// after since. Imports restore reviews before their batch is recorded.
+func (s *Service) ItemUsedSince(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error) {
+	reviews, err := s.repo.List(ctx, ownerID, &itemID)
+	if err != nil {
+		return false, err
+	}
+	for _, review := range reviews {
+		if review.UpdatedAt.After(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ByItem groups all of the owner's reviews by item ID, newest first.
+func (s *Service) ByItem(ctx context.Context, ownerID uuid.UUID) (map[uuid.UUID][]Review, error) {
+	reviews, err := s.repo.List(ctx, ownerID, nil)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[uuid.UUID][]Review)
+	for _, review := range reviews {
+		grouped[review.ItemID] = append(grouped[review.ItemID], review)
+	}
+	return grouped, nil
+}
+
+// Get returns a single review with its item attached.
+func (s *Service) Get(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (Review, error) {
+	review, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return Review{}, err
+	}
+	return s.withItem(ctx, review)
+}
+
+// Create validates and stores a review for an item.
+func (s *Service) Create(ctx context.Context, itemID uuid.UUID, input ReviewInput, ownerID uuid.UUID) (Review, error) {
+	if _, err := s.itemsRepo.Get(ctx, itemID, ownerID); err != nil {
+		return Review{}, err
+	}
+	review, err := normalizeInput(input)
+	if err != nil {
+		return Review{}, err
+	}
+
+	now := s.now()
+	review.ID = uuid.New()
+	review.OwnerID = ownerID
+	review.ItemID = itemID
+	review.CreatedAt = now
+	review.UpdatedAt = now
+	if input.CreatedAt != nil && !input.CreatedAt.IsZero() {
+		review.CreatedAt = input.CreatedAt.UTC()
+		review.UpdatedAt = review.CreatedAt
+	}
+	if input.UpdatedAt != nil && !input.UpdatedAt.IsZero() {
+		review.UpdatedAt = input.UpdatedAt.UTC()
+	}
+
+	created, err := s.repo.Create(ctx, review)
+	if err != nil {
+		return Review{}, err
+	}
+	return s.withItem(ctx, created)
+}
+
+// Update replaces a review's title, body, sections, and reading date.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input ReviewInput, ownerID uuid.UUID) (Review, error) {
+	existing, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return Review{}, err
+	}
+	review, err := normalizeInput(input)
+	if err != nil {
+		return Review{}, err
+	}
+
+	existing.Title = review.Title
+	existing.Body = review.Body
+	existing.Sections = review.Sections
+	existing.ReadOn = review.ReadOn
+	existing.UpdatedAt = s.now()
+	updated, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		return Review{}, err
+	}
+	return s.withItem(ctx, updated)
+}
+
+// Delete removes a review.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// ItemDeleted drops a deleted item's reviews. It implements items.DeleteHook.
+func (s *Service) ItemDeleted(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID) error {
+	return s.repo.DeleteByItem(ctx, itemID, ownerID)
+}
+
+func (s *Service) withItem(ctx context.Context, review Review) (Review, error) {
+	item, err := s.itemsRepo.Get(ctx, review.ItemID, review.OwnerID)
+	if errors.Is(err, items.ErrNotFound) {
+		return review, nil
+	}
+	if err != nil {
+		return Review{}, err
+	}
+	review.Item = &item
+	return review, nil
+}
+
+// normalizeInput trims and validates an input, returning the editable fields as a Review.
+func normalizeInput(input ReviewInput) (Review, error) {
+	review := Review{
+		Title:    strings.TrimSpace(input.Title),
+		Body:     strings.TrimSpace(input.Body),
+		Sections: SectionList{},
+	}
+	if len(review.Title) > maxTitleLength {
+		return Review{}, fmt.Errorf("%w: title must be %d characters or fewer", ErrValidation, maxTitleLength)
+	}
+	if len(input.Sections) > maxSections {
+		return Review{}, fmt.Errorf("%w: a review can have at most %d sections", ErrValidation, maxSections)
+	}
+
+	length := len(review.Body)
+	for i, section := range input.Sections {
+		section.Heading = strings.TrimSpace(section.Heading)
+		section.Body = strings.TrimSpace(section.Body)
+		if section.Body == "" {
+			return Review{}, fmt.Errorf("%w: section %d needs a body", ErrValidation, i+1)
+		}
+		if len(section.Heading) > maxHeadingLength {
+			return Review{}, fmt.Errorf("%w: section %d heading must be %d characters or fewer", ErrValidation, i+1, maxHeadingLength)
+		}
+		length += len(section.Body)
+		review.Sections = append(review.Sections, section)
+	}
+	if length == 0 {
+		return Review{}, fmt.Errorf("%w: review body is required", ErrValidation)
+	}
+	if length > maxBodyLength {
+		return Review{}, fmt.Errorf("%w: review text must be %d characters or fewer", ErrValidation, maxBodyLength)
+	}
+
+	if input.ReadOn != nil && !input.ReadOn.IsZero() {
+		readOn := input.ReadOn.UTC()
+		readOn = time.Date(readOn.Year(), readOn.Month(), readOn.Day(), 0, 0, 0, 0, time.UTC)
+		review.ReadOn = &readOn
+	}
+	return review, nil
+}
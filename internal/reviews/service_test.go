@@ -0,0 +1,121 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func newTestService(t *testing.T, catalog []items.Item) *Service {
+	t.Helper()
+	svc := NewService(NewInMemoryRepository(), items.NewInMemoryRepository(catalog))
+	svc.now = func() time.Time { return time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+func TestCreateNormalizesReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	book := items.Item{ID: uuid.New(), OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook}
+	svc := newTestService(t, []items.Item{book})
+
+	readOn := time.Date(2026, time.April, 20, 22, 30, 0, 0, time.UTC)
+	review, err := svc.Create(ctx, book.ID, ReviewInput{
+		Title: "  Spice and sand ",
+		Body:  "A slow start that pays off.",
+		Sections: []Section{
+			{Heading: "The ending", Body: " Paul drinks the Water of Life. ", Spoiler: true},
+		},
+		ReadOn: &readOn,
+	}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create review: %v", err)
+	}
+	if review.Title != "Spice and sand" || review.Sections[0].Body != "Paul drinks the Water of Life." || !review.Sections[0].Spoiler {
+		t.Fatalf("unexpected review %+v", review)
+	}
+	if review.ReadOn == nil || !review.ReadOn.Equal(time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected readOn truncated to the day, got %v", review.ReadOn)
+	}
+	if review.Item == nil || review.Item.Title != "Dune" {
+		t.Fatalf("expected item attached, got %+v", review.Item)
+	}
+}
+
+func TestCreateValidation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	book := items.Item{ID: uuid.New(), OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook}
+	svc := newTestService(t, []items.Item{book})
+
+	if _, err := svc.Create(ctx, book.ID, ReviewInput{Title: "Empty"}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error for empty review, got %v", err)
+	}
+	if _, err := svc.Create(ctx, book.ID, ReviewInput{Body: "ok", Sections: []Section{{Heading: "Blank"}}}, testOwnerID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error for blank section, got %v", err)
+	}
+	if _, err := svc.Create(ctx, uuid.New(), ReviewInput{Body: "ok"}, testOwnerID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected item not found, got %v", err)
+	}
+	// Spoiler-only reviews are allowed.
+	if _, err := svc.Create(ctx, book.ID, ReviewInput{Sections: []Section{{Body: "Twist", Spoiler: true}}}, testOwnerID); err != nil {
+		t.Fatalf("create spoiler-only review: %v", err)
+	}
+}
+
+func TestListAcrossCatalogAndUpdate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	book := items.Item{ID: uuid.New(), OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook}
+	movie := items.Item{ID: uuid.New(), OwnerID: testOwnerID, Title: "Arrival", ItemType: items.ItemTypeMovie}
+	svc := newTestService(t, []items.Item{book, movie})
+
+	first, err := svc.Create(ctx, book.ID, ReviewInput{Body: "First read"}, testOwnerID)
+	if err != nil {
+		t.Fatalf("create book review: %v", err)
+	}
+	svc.now = func() time.Time { return time.Date(2026, time.May, 2, 12, 0, 0, 0, time.UTC) }
+	if _, err := svc.Create(ctx, movie.ID, ReviewInput{Body: "Heptapods"}, testOwnerID); err != nil {
+		t.Fatalf("create movie review: %v", err)
+	}
+
+	all, err := svc.List(ctx, testOwnerID, ListOptions{})
+	if err != nil {
+		t.Fatalf("list reviews: %v", err)
+	}
+	if len(all) != 2 || all[0].Item.Title != "Arrival" || all[1].Item.Title != "Dune" {
+		t.Fatalf("expected newest review first, got %+v", all)
+	}
+
+	bookType := items.ItemTypeBook
+	books, err := svc.List(ctx, testOwnerID, ListOptions{ItemType: &bookType})
+	if err != nil {
+		t.Fatalf("list book reviews: %v", err)
+	}
+	if len(books) != 1 || books[0].ID != first.ID {
+		t.Fatalf("expected only the book review, got %+v", books)
+	}
+
+	updated, err := svc.Update(ctx, first.ID, ReviewInput{Title: "Reread", Body: "Better the second time"}, testOwnerID)
+	if err != nil {
+		t.Fatalf("update review: %v", err)
+	}
+	if updated.Body != "Better the second time" || !updated.CreatedAt.Equal(first.CreatedAt) || !updated.UpdatedAt.After(first.UpdatedAt) {
+		t.Fatalf("unexpected updated review %+v", updated)
+	}
+
+	if err := svc.ItemDeleted(ctx, book.ID, testOwnerID); err != nil {
+		t.Fatalf("item deleted: %v", err)
+	}
+	if _, err := svc.Get(ctx, first.ID, testOwnerID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected review removed with item, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package sharing
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryRepository struct {
+	mu     sync.RWMutex
+	shares map[uuid.UUID]Share
+}
+
+// NewInMemoryRepository constructs an empty share repository.
+func NewInMemoryRepository() Repository {
+	return &inMemoryRepository{shares: make(map[uuid.UUID]Share)}
+}
+
+func (r *inMemoryRepository) Create(_ context.Context, share Share) (Share, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shares[share.ID] = share
+	return share.withDerived(), nil
+}
+
+func (r *inMemoryRepository) Get(_ context.Context, id uuid.UUID) (Share, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	share, ok := r.shares[id]
+	if !ok {
+		return Share{}, ErrNotFound
+	}
+	return share.withDerived(), nil
+}
+
+func (r *inMemoryRepository) ListByOwner(_ context.Context, ownerID uuid.UUID) ([]Share, error) {
+	return r.list(func(share Share) bool { return share.OwnerID == ownerID }), nil
+}
+
+func (r *inMemoryRepository) ListForGrantee(_ context.Context, userID uuid.UUID, email string) ([]Share, error) {
+	return r.list(func(share Share) bool {
+		if share.GranteeID != nil {
+			return *share.GranteeID == userID
+		}
+		return share.Email == email
+	}), nil
+}
+
+func (r *inMemoryRepository) list(match func(Share) bool) []Share {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	shares := make([]Share, 0)
+	for _, share := range r.shares {
+		if match(share) {
+			shares = append(shares, share.withDerived())
+		}
+	}
+	slices.SortFunc(shares, func(a, b Share) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return shares
+}
+
+func (r *inMemoryRepository) Update(_ context.Context, share Share) (Share, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.shares[share.ID]; !ok {
+		return Share{}, ErrNotFound
+	}
+	r.shares[share.ID] = share
+	return share.withDerived(), nil
+}
+
+func (r *inMemoryRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.shares[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.shares, id)
+	return nil
+}
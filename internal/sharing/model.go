@@ -0,0 +1,78 @@
+package sharing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+)
+
+// ErrNotFound is returned when a share does not exist for the caller.
+var ErrNotFound = errors.New("share not found")
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// Scope says how much of a library a share covers.
+type Scope string
+
+const (
+	// ScopeCatalog shares every item and shelf the owner has.
+	ScopeCatalog Scope = "catalog"
+	// ScopeShelf shares one shelf and the items placed on it.
+	ScopeShelf Scope = "shelf"
+)
+
+// Status tracks an invitation until the invitee accepts it.
+type Status string
+
+const (
+	// StatusPending shares wait for the invitee to accept.
+	StatusPending Status = "pending"
+	// StatusAccepted shares give the invitee access.
+	StatusAccepted Status = "accepted"
+)
+
+// Share invites Email to view or edit OwnerID's catalog or one of their
+// shelves. It grants access once a user signed in with that email accepts it.
+type Share struct {
+	ID         uuid.UUID  `json:"id"`
+	OwnerID    uuid.UUID  `json:"ownerId"`
+	OwnerName  string     `json:"ownerName"`
+	OwnerEmail string     `json:"ownerEmail"`
+	Scope      Scope      `json:"scope"`
+	ShelfID    *uuid.UUID `json:"shelfId,omitempty"`
+	Email      string     `json:"email"`
+	GranteeID  *uuid.UUID `json:"-"`
+	Role       items.Role `json:"role"`
+	Status     Status     `json:"status"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+}
+
+// withDerived fills Scope and Status from the stored fields.
+func (s Share) withDerived() Share {
+	s.Scope = ScopeCatalog
+	if s.ShelfID != nil {
+		s.Scope = ScopeShelf
+	}
+	s.Status = StatusPending
+	if s.AcceptedAt != nil {
+		s.Status = StatusAccepted
+	}
+	return s
+}
+
+// Repository persists shares.
+type Repository interface {
+	Create(ctx context.Context, share Share) (Share, error)
+	Get(ctx context.Context, id uuid.UUID) (Share, error)
+	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]Share, error)
+	// ListForGrantee returns shares accepted by userID and pending invitations to email.
+	ListForGrantee(ctx context.Context, userID uuid.UUID, email string) ([]Share, error)
+	Update(ctx context.Context, share Share) (Share, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
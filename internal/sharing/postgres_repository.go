@@ -0,0 +1,135 @@
+package sharing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"anthology/internal/items"
+	"anthology/internal/platform/database"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a share repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// shareSelect joins the owner so invitees can see whose library is shared.
+const shareSelect = `
+SELECT ls.id, ls.owner_id, u.name AS owner_name, u.email AS owner_email, ls.shelf_id, ls.email,
+       ls.grantee_id, ls.role, ls.created_at, ls.accepted_at
+FROM library_shares ls
+JOIN users u ON u.id = ls.owner_id
+`
+
+type shareRow struct {
+	ID         uuid.UUID  `db:"id"`
+	OwnerID    uuid.UUID  `db:"owner_id"`
+	OwnerName  string     `db:"owner_name"`
+	OwnerEmail string     `db:"owner_email"`
+	ShelfID    *uuid.UUID `db:"shelf_id"`
+	Email      string     `db:"email"`
+	GranteeID  *uuid.UUID `db:"grantee_id"`
+	Role       items.Role `db:"role"`
+	CreatedAt  time.Time  `db:"created_at"`
+	AcceptedAt *time.Time `db:"accepted_at"`
+}
+
+func (row shareRow) share() Share {
+	return Share{
+		ID:         row.ID,
+		OwnerID:    row.OwnerID,
+		OwnerName:  row.OwnerName,
+		OwnerEmail: row.OwnerEmail,
+		ShelfID:    row.ShelfID,
+		Email:      row.Email,
+		GranteeID:  row.GranteeID,
+		Role:       row.Role,
+		CreatedAt:  row.CreatedAt,
+		AcceptedAt: row.AcceptedAt,
+	}.withDerived()
+}
+
+func (r *postgresRepository) Create(ctx context.Context, share Share) (Share, error) {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+INSERT INTO library_shares (id, owner_id, shelf_id, email, grantee_id, role, created_at, accepted_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		share.ID, share.OwnerID, share.ShelfID, share.Email, share.GranteeID, share.Role, share.CreatedAt, share.AcceptedAt)
+	if err != nil {
+		return Share{}, fmt.Errorf("insert share: %w", err)
+	}
+	return r.Get(ctx, share.ID)
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID) (Share, error) {
+	var row shareRow
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &row, shareSelect+` WHERE ls.id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Share{}, ErrNotFound
+		}
+		return Share{}, fmt.Errorf("get share: %w", err)
+	}
+	return row.share(), nil
+}
+
+func (r *postgresRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]Share, error) {
+	return r.list(ctx, shareSelect+` WHERE ls.owner_id = $1 ORDER BY ls.created_at DESC`, ownerID)
+}
+
+func (r *postgresRepository) ListForGrantee(ctx context.Context, userID uuid.UUID, email string) ([]Share, error) {
+	return r.list(ctx, shareSelect+`
+WHERE ls.grantee_id = $1 OR (ls.grantee_id IS NULL AND ls.email = $2)
+ORDER BY ls.created_at DESC`, userID, email)
+}
+
+func (r *postgresRepository) list(ctx context.Context, query string, args ...any) ([]Share, error) {
+	var rows []shareRow
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("list shares: %w", err)
+	}
+	shares := make([]Share, 0, len(rows))
+	for _, row := range rows {
+		shares = append(shares, row.share())
+	}
+	return shares, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, share Share) (Share, error) {
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, `UPDATE library_shares SET role = $2, grantee_id = $3, accepted_at = $4 WHERE id = $1`,
+		share.ID, share.Role, share.GranteeID, share.AcceptedAt)
+	if err != nil {
+		return Share{}, fmt.Errorf("update share: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Share{}, fmt.Errorf("update share rows: %w", err)
+	}
+	if affected == 0 {
+		return Share{}, ErrNotFound
+	}
+	return r.Get(ctx, share.ID)
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := database.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM library_shares WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete share: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete share rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package sharing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/auth"
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+const maxEmailLength = 320
+
+// ShelfOwners looks up who owns a shelf so only its owner can share it.
+type ShelfOwners interface {
+	ShelfOwner(ctx context.Context, shelfID uuid.UUID) (uuid.UUID, error)
+}
+
+// Service manages share invitations and answers which libraries a user may see.
+type Service struct {
+	repo    Repository
+	shelves ShelfOwners
+	now     func() time.Time
+}
+
+// NewService constructs a sharing service.
+func NewService(repo Repository, shelves ShelfOwners) *Service {
+	return &Service{
+		repo:    repo,
+		shelves: shelves,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// InviteInput describes whom to invite and to what. A nil ShelfID shares the
+// whole catalog.
+type InviteInput struct {
+	Email   string     `json:"email"`
+	Role    items.Role `json:"role"`
+	ShelfID *uuid.UUID `json:"shelfId"`
+}
+
+// Invite shares the owner's catalog or one of their shelves with an email
+// address. Inviting the same address to the same scope again changes its role.
+func (s *Service) Invite(ctx context.Context, owner *auth.User, input InviteInput) (Share, error) {
+	email, err := normalizeEmail(input.Email)
+	if err != nil {
+		return Share{}, err
+	}
+	if email == strings.ToLower(strings.TrimSpace(owner.Email)) {
+		return Share{}, fmt.Errorf("%w: you cannot share your library with yourself", ErrValidation)
+	}
+	if !input.Role.Valid() {
+		return Share{}, fmt.Errorf("%w: role must be viewer or editor", ErrValidation)
+	}
+	if input.ShelfID != nil {
+		shelfOwner, err := s.shelves.ShelfOwner(ctx, *input.ShelfID)
+		if err != nil && !errors.Is(err, shelves.ErrNotFound) {
+			return Share{}, err
+		}
+		if err != nil || shelfOwner != owner.ID {
+			return Share{}, fmt.Errorf("%w: shelf not found", ErrValidation)
+		}
+	}
+
+	existing, err := s.repo.ListByOwner(ctx, owner.ID)
+	if err != nil {
+		return Share{}, err
+	}
+	for _, share := range existing {
+		if share.Email == email && sameShelf(share.ShelfID, input.ShelfID) {
+			share.Role = input.Role
+			return s.repo.Update(ctx, share)
+		}
+	}
+
+	return s.repo.Create(ctx, Share{
+		ID:         uuid.New(),
+		OwnerID:    owner.ID,
+		OwnerName:  owner.Name,
+		OwnerEmail: owner.Email,
+		ShelfID:    input.ShelfID,
+		Email:      email,
+		Role:       input.Role,
+		CreatedAt:  s.now(),
+	})
+}
+
+// ListOutgoing returns the shares the owner has made, newest first.
+func (s *Service) ListOutgoing(ctx context.Context, ownerID uuid.UUID) ([]Share, error) {
+	return s.repo.ListByOwner(ctx, ownerID)
+}
+
+// ListIncoming returns shares the user has accepted and invitations waiting
+// for their email address.
+func (s *Service) ListIncoming(ctx context.Context, user *auth.User) ([]Share, error) {
+	return s.repo.ListForGrantee(ctx, user.ID, strings.ToLower(strings.TrimSpace(user.Email)))
+}
+
+// UpdateRole changes what the invitee of one of the owner's shares may do.
+func (s *Service) UpdateRole(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, role items.Role) (Share, error) {
+	if !role.Valid() {
+		return Share{}, fmt.Errorf("%w: role must be viewer or editor", ErrValidation)
+	}
+	share, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return Share{}, err
+	}
+	if share.OwnerID != ownerID {
+		return Share{}, ErrNotFound
+	}
+	share.Role = role
+	return s.repo.Update(ctx, share)
+}
+
+// Revoke deletes one of the owner's shares, ending the invitee's access.
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	share, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if share.OwnerID != ownerID {
+		return ErrNotFound
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Accept gives the user access through an invitation sent to their email.
+// Accepting a share the user already holds returns it unchanged.
+func (s *Service) Accept(ctx context.Context, id uuid.UUID, user *auth.User) (Share, error) {
+	share, err := s.incoming(ctx, id, user)
+	if err != nil {
+		return Share{}, err
+	}
+	if share.AcceptedAt != nil {
+		return share, nil
+	}
+	now := s.now()
+	share.GranteeID = &user.ID
+	share.AcceptedAt = &now
+	return s.repo.Update(ctx, share)
+}
+
+// Decline turns down an invitation, or gives up access to a share the user
+// accepted earlier.
+func (s *Service) Decline(ctx context.Context, id uuid.UUID, user *auth.User) error {
+	if _, err := s.incoming(ctx, id, user); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// AccessGrants lists the shares the user has accepted as item access grants.
+func (s *Service) AccessGrants(ctx context.Context, userID uuid.UUID) ([]items.AccessGrant, error) {
+	shares, err := s.repo.ListForGrantee(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]items.AccessGrant, 0, len(shares))
+	for _, share := range shares {
+		if share.GranteeID == nil || *share.GranteeID != userID || share.AcceptedAt == nil {
+			continue
+		}
+		grants = append(grants, items.AccessGrant{
+			OwnerID:   share.OwnerID,
+			OwnerName: share.OwnerName,
+			ShelfID:   share.ShelfID,
+			Role:      share.Role,
+		})
+	}
+	return grants, nil
+}
+
+// incoming loads a share addressed to the user: accepted by them, or pending
+// for their email address.
+func (s *Service) incoming(ctx context.Context, id uuid.UUID, user *auth.User) (Share, error) {
+	share, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return Share{}, err
+	}
+	if share.GranteeID != nil {
+		if *share.GranteeID != user.ID {
+			return Share{}, ErrNotFound
+		}
+		return share, nil
+	}
+	if share.Email != strings.ToLower(strings.TrimSpace(user.Email)) {
+		return Share{}, ErrNotFound
+	}
+	return share, nil
+}
+
+func normalizeEmail(value string) (string, error) {
+	email := strings.ToLower(strings.TrimSpace(value))
+	if email == "" {
+		return "", fmt.Errorf("%w: email is required", ErrValidation)
+	}
+	if len(email) > maxEmailLength {
+		return "", fmt.Errorf("%w: email must be %d characters or fewer", ErrValidation, maxEmailLength)
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", fmt.Errorf("%w: email is not a valid address", ErrValidation)
+	}
+	return email, nil
+}
+
+func sameShelf(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,318 @@
+package sharing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/auth"
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+var (
+	owner   = &auth.User{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Email: "owner@example.com", Name: "Owner"}
+	partner = &auth.User{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Email: "Partner@Example.com", Name: "Partner"}
+)
+
+type library struct {
+	sharing  *Service
+	items    *items.Service
+	shelves  *shelves.Service
+	shelfID  uuid.UUID
+	slotID   uuid.UUID
+	onShelf  items.Item
+	offShelf items.Item
+}
+
+// newLibrary gives the owner two books and a shelf holding one of them.
+func newLibrary(t *testing.T) library {
+	t.Helper()
+	ctx := context.Background()
+
+	shelfRepo := shelves.NewInMemoryRepository()
+	sharingSvc := NewService(NewInMemoryRepository(), shelfRepo)
+	itemsRepo := items.NewInMemoryRepository(nil)
+	itemSvc := items.NewService(itemsRepo, items.WithGrantSource(sharingSvc))
+	shelfSvc := shelves.NewService(shelfRepo, itemsRepo, nil, itemSvc, shelves.WithGrantSource(sharingSvc))
+
+	onShelf, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: owner.ID, Title: "Dune", ItemType: items.ItemTypeBook})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	offShelf, err := itemSvc.Create(ctx, items.CreateItemInput{OwnerID: owner.ID, Title: "Emma", ItemType: items.ItemTypeBook})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	shelf, err := shelfSvc.CreateShelf(ctx, shelves.CreateShelfInput{Name: "Hall", PhotoURL: "https://example.com/hall.jpg"}, owner.ID)
+	if err != nil {
+		t.Fatalf("create shelf: %v", err)
+	}
+	slotID := shelf.Slots[0].ID
+	if _, err := shelfSvc.AssignItem(ctx, shelf.Shelf.ID, slotID, onShelf.ID, owner.ID); err != nil {
+		t.Fatalf("assign item: %v", err)
+	}
+
+	return library{
+		sharing:  sharingSvc,
+		items:    itemSvc,
+		shelves:  shelfSvc,
+		shelfID:  shelf.Shelf.ID,
+		slotID:   slotID,
+		onShelf:  onShelf,
+		offShelf: offShelf,
+	}
+}
+
+func (l library) share(t *testing.T, role items.Role, shelfID *uuid.UUID) Share {
+	t.Helper()
+	ctx := context.Background()
+	share, err := l.sharing.Invite(ctx, owner, InviteInput{Email: " partner@example.COM ", Role: role, ShelfID: shelfID})
+	if err != nil {
+		t.Fatalf("invite: %v", err)
+	}
+	accepted, err := l.sharing.Accept(ctx, share.ID, partner)
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	return accepted
+}
+
+func TestInvitationGrantsAccessOnlyOnceAccepted(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+
+	share, err := lib.sharing.Invite(ctx, owner, InviteInput{Email: "partner@example.com", Role: items.RoleViewer})
+	if err != nil {
+		t.Fatalf("invite: %v", err)
+	}
+	if share.Status != StatusPending || share.Scope != ScopeCatalog {
+		t.Fatalf("expected pending catalog share, got %+v", share)
+	}
+
+	incoming, err := lib.sharing.ListIncoming(ctx, partner)
+	if err != nil || len(incoming) != 1 || incoming[0].OwnerName != "Owner" {
+		t.Fatalf("expected the invitation to reach the partner, got %+v (%v)", incoming, err)
+	}
+	if _, err := lib.items.Get(ctx, lib.offShelf.ID, partner.ID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected no access before accepting, got %v", err)
+	}
+
+	stranger := &auth.User{ID: uuid.New(), Email: "stranger@example.com"}
+	if _, err := lib.sharing.Accept(ctx, share.ID, stranger); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected invitations to be bound to their email, got %v", err)
+	}
+
+	if _, err := lib.sharing.Accept(ctx, share.ID, partner); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	item, err := lib.items.Get(ctx, lib.offShelf.ID, partner.ID)
+	if err != nil {
+		t.Fatalf("get shared item: %v", err)
+	}
+	if item.Access == nil || item.Access.Role != items.RoleViewer || item.Access.OwnerID != owner.ID || item.Access.CanEdit {
+		t.Fatalf("unexpected access %+v", item.Access)
+	}
+
+	if err := lib.sharing.Revoke(ctx, share.ID, owner.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := lib.items.Get(ctx, lib.offShelf.ID, partner.ID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected access to end when revoked, got %v", err)
+	}
+}
+
+func TestListIncludesSharedItemsOnlyWhenAsked(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+	lib.share(t, items.RoleViewer, nil)
+
+	own, err := lib.items.List(ctx, items.ListOptions{OwnerID: partner.ID})
+	if err != nil || len(own) != 0 {
+		t.Fatalf("expected the partner's own list to stay empty, got %d (%v)", len(own), err)
+	}
+
+	all, err := lib.items.List(ctx, items.ListOptions{OwnerID: partner.ID, IncludeShared: true})
+	if err != nil {
+		t.Fatalf("list shared: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected both shared items, got %d", len(all))
+	}
+	for _, item := range all {
+		if item.Access == nil || item.Access.OwnerName != "Owner" || item.Access.Role != items.RoleViewer {
+			t.Fatalf("expected shared items to name their owner, got %+v", item.Access)
+		}
+	}
+}
+
+func TestShelfShareCoversOnlyItemsOnThatShelf(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+	share := lib.share(t, items.RoleViewer, &lib.shelfID)
+	if share.Scope != ScopeShelf {
+		t.Fatalf("expected a shelf share, got %s", share.Scope)
+	}
+
+	list, err := lib.items.List(ctx, items.ListOptions{OwnerID: partner.ID, IncludeShared: true})
+	if err != nil {
+		t.Fatalf("list shared: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != lib.onShelf.ID {
+		t.Fatalf("expected only the shelved item, got %+v", list)
+	}
+	if _, err := lib.items.Get(ctx, lib.offShelf.ID, partner.ID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected items off the shelf to stay private, got %v", err)
+	}
+
+	shelf, err := lib.shelves.GetShelf(ctx, lib.shelfID, partner.ID)
+	if err != nil {
+		t.Fatalf("get shared shelf: %v", err)
+	}
+	if shelf.Access == nil || shelf.Access.Role != items.RoleViewer || len(shelf.Placements) != 1 {
+		t.Fatalf("unexpected shared shelf %+v", shelf)
+	}
+	summaries, err := lib.shelves.ListShelves(ctx, partner.ID)
+	if err != nil || len(summaries) != 1 || summaries[0].Access.OwnerID != owner.ID {
+		t.Fatalf("expected the shared shelf in the partner's list, got %+v (%v)", summaries, err)
+	}
+
+	if _, err := lib.shelves.RemoveItem(ctx, lib.shelfID, lib.slotID, lib.onShelf.ID, partner.ID); !errors.Is(err, shelves.ErrForbidden) {
+		t.Fatalf("expected viewers to be unable to rearrange the shelf, got %v", err)
+	}
+}
+
+func TestEditorsCanChangeButNotDeleteSharedItems(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+	share := lib.share(t, items.RoleViewer, nil)
+
+	title := "Dune Messiah"
+	if _, err := lib.items.Update(ctx, lib.onShelf.ID, partner.ID, items.UpdateItemInput{Title: &title}); !errors.Is(err, items.ErrForbidden) {
+		t.Fatalf("expected viewers to be unable to edit, got %v", err)
+	}
+
+	if _, err := lib.sharing.UpdateRole(ctx, share.ID, owner.ID, items.RoleEditor); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	updated, err := lib.items.Update(ctx, lib.onShelf.ID, partner.ID, items.UpdateItemInput{Title: &title})
+	if err != nil {
+		t.Fatalf("editor update: %v", err)
+	}
+	if updated.Title != title || updated.Access.Role != items.RoleEditor {
+		t.Fatalf("unexpected update result %+v", updated)
+	}
+	if err := lib.items.Delete(ctx, lib.onShelf.ID, partner.ID); !errors.Is(err, items.ErrForbidden) {
+		t.Fatalf("expected editors to be unable to delete, got %v", err)
+	}
+
+	if _, err := lib.shelves.AssignItem(ctx, lib.shelfID, lib.slotID, lib.offShelf.ID, partner.ID); err != nil {
+		t.Fatalf("editor assign: %v", err)
+	}
+}
+
+func TestShelfEditorsCanOnlyPlaceItemsAlreadyOnTheShelf(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+	lib.share(t, items.RoleEditor, &lib.shelfID)
+
+	if _, err := lib.shelves.AssignItem(ctx, lib.shelfID, lib.slotID, lib.offShelf.ID, partner.ID); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected items off the shelf to stay out of reach, got %v", err)
+	}
+	if _, err := lib.shelves.AssignItem(ctx, lib.shelfID, lib.slotID, lib.onShelf.ID, partner.ID); err != nil {
+		t.Fatalf("expected the editor to rearrange items on the shelf: %v", err)
+	}
+
+	shelf, err := lib.shelves.GetShelf(ctx, lib.shelfID, owner.ID)
+	if err != nil {
+		t.Fatalf("get shelf: %v", err)
+	}
+	for _, placement := range shelf.Placements {
+		if placement.Item.ID == lib.offShelf.ID {
+			t.Fatalf("expected the private item to stay off the shelf, got %+v", shelf.Placements)
+		}
+	}
+}
+
+func TestSharedLabelsMatchWhatCanBeUpdated(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+	lib.share(t, items.RoleEditor, &lib.shelfID)
+
+	// Moving the shared item to a private shelf takes it out of the share,
+	// even though it keeps its old spot on the shared shelf.
+	study, err := lib.shelves.CreateShelf(ctx, shelves.CreateShelfInput{Name: "Study", PhotoURL: "https://example.com/study.jpg"}, owner.ID)
+	if err != nil {
+		t.Fatalf("create shelf: %v", err)
+	}
+	if _, err := lib.shelves.AssignItem(ctx, study.Shelf.ID, study.Slots[0].ID, lib.onShelf.ID, owner.ID); err != nil {
+		t.Fatalf("assign item: %v", err)
+	}
+
+	listed, err := lib.items.List(ctx, items.ListOptions{OwnerID: partner.ID, IncludeShared: true})
+	if err != nil {
+		t.Fatalf("list shared: %v", err)
+	}
+	shelf, err := lib.shelves.GetShelf(ctx, lib.shelfID, partner.ID)
+	if err != nil {
+		t.Fatalf("get shared shelf: %v", err)
+	}
+	for _, placement := range append(shelf.Placements, shelf.Unplaced...) {
+		listed = append(listed, placement.Item)
+	}
+	if len(listed) != 0 {
+		t.Fatalf("expected nothing reachable through the shelf share, got %+v", listed)
+	}
+	title := "Dune Messiah"
+	if _, err := lib.items.Update(ctx, lib.onShelf.ID, partner.ID, items.UpdateItemInput{Title: &title}); !errors.Is(err, items.ErrNotFound) {
+		t.Fatalf("expected the moved item to be out of reach, got %v", err)
+	}
+
+	// Back on the shared shelf, the label and the update path agree again.
+	if _, err := lib.shelves.AssignItem(ctx, lib.shelfID, lib.slotID, lib.onShelf.ID, owner.ID); err != nil {
+		t.Fatalf("assign item: %v", err)
+	}
+	listed, err = lib.items.List(ctx, items.ListOptions{OwnerID: partner.ID, IncludeShared: true})
+	if err != nil {
+		t.Fatalf("list shared: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Access == nil || !listed[0].Access.CanEdit {
+		t.Fatalf("expected the item listed as editable, got %+v", listed)
+	}
+	if _, err := lib.items.Update(ctx, listed[0].ID, partner.ID, items.UpdateItemInput{Title: &title}); err != nil {
+		t.Fatalf("expected an item labelled editable to update: %v", err)
+	}
+}
+
+func TestInviteValidatesInput(t *testing.T) {
+	lib := newLibrary(t)
+	ctx := context.Background()
+	otherShelf := uuid.New()
+
+	cases := []InviteInput{
+		{Email: "", Role: items.RoleViewer},
+		{Email: "not-an-email", Role: items.RoleViewer},
+		{Email: "OWNER@example.com", Role: items.RoleViewer},
+		{Email: "partner@example.com", Role: items.RoleOwner},
+		{Email: "partner@example.com", Role: items.RoleViewer, ShelfID: &otherShelf},
+	}
+	for _, input := range cases {
+		if _, err := lib.sharing.Invite(ctx, owner, input); !errors.Is(err, ErrValidation) {
+			t.Fatalf("expected validation error for %+v, got %v", input, err)
+		}
+	}
+
+	first, err := lib.sharing.Invite(ctx, owner, InviteInput{Email: "partner@example.com", Role: items.RoleViewer})
+	if err != nil {
+		t.Fatalf("invite: %v", err)
+	}
+	again, err := lib.sharing.Invite(ctx, owner, InviteInput{Email: "partner@example.com", Role: items.RoleEditor})
+	if err != nil {
+		t.Fatalf("re-invite: %v", err)
+	}
+	if again.ID != first.ID || again.Role != items.RoleEditor {
+		t.Fatalf("expected re-inviting to change the role, got %+v", again)
+	}
+}
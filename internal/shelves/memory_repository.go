@@ -96,6 +96,17 @@ func (m *inMemoryRepository) GetShelf(ctx context.Context, shelfID uuid.UUID, ow
 	return m.buildLayout(ctx, shelfID, ownerID)
 }
 
+func (m *inMemoryRepository) ShelfOwner(ctx context.Context, shelfID uuid.UUID) (uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	shelf, ok := m.shelves[shelfID]
+	if !ok {
+		return uuid.UUID{}, ErrNotFound
+	}
+	return shelf.OwnerID, nil
+}
+
 func (m *inMemoryRepository) SaveLayout(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, rows []ShelfRow, columns []ShelfColumn, slots []ShelfSlot, removedSlotIDs []uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -225,6 +236,21 @@ func (m *inMemoryRepository) ListPlacements(ctx context.Context, shelfID uuid.UU
 	return placements, nil
 }
 
+func (m *inMemoryRepository) ItemShelved(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for shelfID, placements := range m.placements {
+		if m.shelves[shelfID].OwnerID != ownerID {
+			continue
+		}
+		if placement, ok := placements[itemID]; ok && placement.CreatedAt.After(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (m *inMemoryRepository) UpsertUnplaced(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, itemID uuid.UUID) (ItemPlacement, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -255,6 +281,29 @@ func (m *inMemoryRepository) UpsertUnplaced(ctx context.Context, shelfID uuid.UU
 	return placement, nil
 }
 
+func (m *inMemoryRepository) DeleteShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	shelf, ok := m.shelves[shelfID]
+	if !ok || shelf.OwnerID != ownerID {
+		return ErrNotFound
+	}
+
+	delete(m.shelves, shelfID)
+	delete(m.rows, shelfID)
+	delete(m.columns, shelfID)
+	delete(m.slots, shelfID)
+	delete(m.placements, shelfID)
+	return nil
+}
+
+// DeleteOrphanedPlacements is a no-op: the in-memory repository cannot see
+// items, and its placements are removed together with their shelf.
+func (m *inMemoryRepository) DeleteOrphanedPlacements(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (m *inMemoryRepository) buildLayout(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, error) {
 	shelf := m.shelves[shelfID]
 	rows := slices.Clone(m.rows[shelfID])
@@ -16,6 +16,9 @@ var ErrNotFound = errors.New("shelf not found")
 // ErrSlotNotFound is returned when a slot cannot be located for a shelf.
 var ErrSlotNotFound = errors.New("shelf slot not found")
 
+// ErrForbidden is returned when a shared shelf may be seen but not changed.
+var ErrForbidden = errors.New("not allowed to change this shelf")
+
 // ErrValidation wraps user-correctable validation errors safe to expose to clients.
 var ErrValidation = errors.New("validation error")
 
@@ -105,6 +108,7 @@ type ShelfWithLayout struct {
 	Slots      []ShelfSlot         `json:"slots"`
 	Placements []PlacementWithItem `json:"placements"`
 	Unplaced   []PlacementWithItem `json:"unplaced"`
+	Access     *items.Access       `json:"access,omitempty"`
 }
 
 // RowWithColumns bundles a row and its columns for transport.
@@ -119,6 +123,8 @@ type ShelfSummary struct {
 	ItemCount   int   `json:"itemCount"`
 	PlacedCount int   `json:"placedCount"`
 	SlotCount   int   `json:"slotCount"`
+	// Access tells the caller whose shelf this is and what they may do with it.
+	Access *items.Access `json:"access,omitempty"`
 }
 
 // LayoutSlotInput captures layout updates for a slot's bounding box.
@@ -137,9 +143,18 @@ type Repository interface {
 	CreateShelf(ctx context.Context, shelf Shelf, rows []ShelfRow, columns []ShelfColumn, slots []ShelfSlot) (ShelfWithLayout, error)
 	ListShelves(ctx context.Context, ownerID uuid.UUID) ([]ShelfSummary, error)
 	GetShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, error)
+	// ShelfOwner returns who owns a shelf so grants to it can be checked.
+	ShelfOwner(ctx context.Context, shelfID uuid.UUID) (uuid.UUID, error)
 	SaveLayout(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, rows []ShelfRow, columns []ShelfColumn, slots []ShelfSlot, removedSlotIDs []uuid.UUID) error
 	AssignItemToSlot(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, slotID uuid.UUID, itemID uuid.UUID) (ItemPlacement, error)
 	RemoveItemFromSlot(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, slotID uuid.UUID, itemID uuid.UUID) error
 	ListPlacements(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) ([]ItemPlacement, error)
 	UpsertUnplaced(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, itemID uuid.UUID) (ItemPlacement, error)
+	DeleteShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) error
+	// ItemShelved reports whether the item was placed on any of the owner's
+	// shelves, in a slot or unplaced, after since.
+	ItemShelved(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error)
+	// DeleteOrphanedPlacements removes placements whose item no longer exists
+	// or no longer belongs to the shelf's owner, across all owners.
+	DeleteOrphanedPlacements(ctx context.Context) (int64, error)
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+
+	"anthology/internal/platform/database"
 )
 
 type postgresRepository struct {
@@ -21,7 +23,7 @@ func NewPostgresRepository(db *sqlx.DB) Repository {
 }
 
 func (r *postgresRepository) CreateShelf(ctx context.Context, shelf Shelf, rows []ShelfRow, columns []ShelfColumn, slots []ShelfSlot) (ShelfWithLayout, error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := database.Begin(ctx, r.db)
 	if err != nil {
 		return ShelfWithLayout{}, err
 	}
@@ -52,7 +54,7 @@ func (r *postgresRepository) CreateShelf(ctx context.Context, shelf Shelf, rows
 }
 
 func (r *postgresRepository) ListShelves(ctx context.Context, ownerID uuid.UUID) ([]ShelfSummary, error) {
-	rows, err := r.db.QueryxContext(ctx, `
+	rows, err := database.Conn(ctx, r.db).QueryxContext(ctx, `
         SELECT s.id, s.owner_id, s.name, s.description, s.photo_url, s.created_at, s.updated_at,
                COALESCE(COUNT(isl.id), 0) AS item_count,
                COALESCE(SUM(CASE WHEN isl.shelf_slot_id IS NOT NULL THEN 1 ELSE 0 END), 0) AS placed_count,
@@ -88,7 +90,7 @@ func (r *postgresRepository) ListShelves(ctx context.Context, ownerID uuid.UUID)
 
 func (r *postgresRepository) GetShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, error) {
 	var shelf Shelf
-	if err := r.db.GetContext(ctx, &shelf, `SELECT * FROM shelves WHERE id = $1 AND owner_id = $2`, shelfID, ownerID); err != nil {
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &shelf, `SELECT * FROM shelves WHERE id = $1 AND owner_id = $2`, shelfID, ownerID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ShelfWithLayout{}, ErrNotFound
 		}
@@ -135,8 +137,19 @@ func (r *postgresRepository) GetShelf(ctx context.Context, shelfID uuid.UUID, ow
 	}, nil
 }
 
+func (r *postgresRepository) ShelfOwner(ctx context.Context, shelfID uuid.UUID) (uuid.UUID, error) {
+	var ownerID uuid.UUID
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &ownerID, `SELECT owner_id FROM shelves WHERE id = $1`, shelfID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.UUID{}, ErrNotFound
+		}
+		return uuid.UUID{}, err
+	}
+	return ownerID, nil
+}
+
 func (r *postgresRepository) SaveLayout(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, rows []ShelfRow, columns []ShelfColumn, slots []ShelfSlot, removedSlotIDs []uuid.UUID) error {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := database.Begin(ctx, r.db)
 	if err != nil {
 		return err
 	}
@@ -196,7 +209,7 @@ func (r *postgresRepository) SaveLayout(ctx context.Context, shelfID uuid.UUID,
 }
 
 func (r *postgresRepository) AssignItemToSlot(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, slotID uuid.UUID, itemID uuid.UUID) (ItemPlacement, error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := database.Begin(ctx, r.db)
 	if err != nil {
 		return ItemPlacement{}, err
 	}
@@ -249,14 +262,14 @@ func (r *postgresRepository) AssignItemToSlot(ctx context.Context, shelfID uuid.
 func (r *postgresRepository) RemoveItemFromSlot(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, slotID uuid.UUID, itemID uuid.UUID) error {
 	// First verify shelf belongs to owner
 	var shelfExists bool
-	if err := r.db.GetContext(ctx, &shelfExists, `SELECT EXISTS(SELECT 1 FROM shelves WHERE id=$1 AND owner_id=$2)`, shelfID, ownerID); err != nil {
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &shelfExists, `SELECT EXISTS(SELECT 1 FROM shelves WHERE id=$1 AND owner_id=$2)`, shelfID, ownerID); err != nil {
 		return err
 	}
 	if !shelfExists {
 		return ErrNotFound
 	}
 
-	result, err := r.db.ExecContext(ctx, `
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, `
         UPDATE item_shelf_locations
         SET shelf_slot_id = NULL
         WHERE shelf_id=$1 AND item_id=$2 AND shelf_slot_id=$3
@@ -274,7 +287,7 @@ func (r *postgresRepository) RemoveItemFromSlot(ctx context.Context, shelfID uui
 func (r *postgresRepository) ListPlacements(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) ([]ItemPlacement, error) {
 	// Verify shelf belongs to owner
 	var shelfExists bool
-	if err := r.db.GetContext(ctx, &shelfExists, `SELECT EXISTS(SELECT 1 FROM shelves WHERE id=$1 AND owner_id=$2)`, shelfID, ownerID); err != nil {
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &shelfExists, `SELECT EXISTS(SELECT 1 FROM shelves WHERE id=$1 AND owner_id=$2)`, shelfID, ownerID); err != nil {
 		return nil, err
 	}
 	if !shelfExists {
@@ -282,14 +295,23 @@ func (r *postgresRepository) ListPlacements(ctx context.Context, shelfID uuid.UU
 	}
 
 	var placements []ItemPlacement
-	if err := r.db.SelectContext(ctx, &placements, `SELECT * FROM item_shelf_locations WHERE shelf_id=$1`, shelfID); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &placements, `SELECT * FROM item_shelf_locations WHERE shelf_id=$1`, shelfID); err != nil {
 		return nil, err
 	}
 	return placements, nil
 }
 
+func (r *postgresRepository) ItemShelved(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error) {
+	var shelved bool
+	query := `SELECT EXISTS(SELECT 1 FROM item_shelf_locations isl JOIN shelves s ON s.id = isl.shelf_id WHERE isl.item_id=$1 AND s.owner_id=$2 AND isl.created_at > $3)`
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &shelved, query, itemID, ownerID, since); err != nil {
+		return false, err
+	}
+	return shelved, nil
+}
+
 func (r *postgresRepository) UpsertUnplaced(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID, itemID uuid.UUID) (ItemPlacement, error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := database.Begin(ctx, r.db)
 	if err != nil {
 		return ItemPlacement{}, err
 	}
@@ -328,9 +350,35 @@ func (r *postgresRepository) UpsertUnplaced(ctx context.Context, shelfID uuid.UU
 	return placement, nil
 }
 
+func (r *postgresRepository) DeleteShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) error {
+	// Rows, columns, slots, and placements cascade with the shelf.
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM shelves WHERE id=$1 AND owner_id=$2`, shelfID, ownerID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteOrphanedPlacements(ctx context.Context) (int64, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+        DELETE FROM item_shelf_locations l
+        USING shelves s
+        WHERE l.shelf_id = s.id
+          AND NOT EXISTS (SELECT 1 FROM items i WHERE i.id = l.item_id AND i.owner_id = s.owner_id)
+    `)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func (r *postgresRepository) fetchRows(ctx context.Context, shelfID uuid.UUID) ([]ShelfRow, error) {
 	var rows []ShelfRow
-	if err := r.db.SelectContext(ctx, &rows, `SELECT * FROM shelf_rows WHERE shelf_id=$1 ORDER BY row_index`, shelfID); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &rows, `SELECT * FROM shelf_rows WHERE shelf_id=$1 ORDER BY row_index`, shelfID); err != nil {
 		return nil, err
 	}
 	return rows, nil
@@ -338,7 +386,7 @@ func (r *postgresRepository) fetchRows(ctx context.Context, shelfID uuid.UUID) (
 
 func (r *postgresRepository) fetchColumns(ctx context.Context, shelfID uuid.UUID) ([]ShelfColumn, error) {
 	var cols []ShelfColumn
-	if err := r.db.SelectContext(ctx, &cols, `
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &cols, `
         SELECT sc.* FROM shelf_columns sc
         JOIN shelf_rows sr ON sc.shelf_row_id = sr.id
         WHERE sr.shelf_id = $1
@@ -351,13 +399,13 @@ func (r *postgresRepository) fetchColumns(ctx context.Context, shelfID uuid.UUID
 
 func (r *postgresRepository) fetchSlots(ctx context.Context, shelfID uuid.UUID) ([]ShelfSlot, error) {
 	var slots []ShelfSlot
-	if err := r.db.SelectContext(ctx, &slots, `SELECT * FROM shelf_slots WHERE shelf_id=$1 ORDER BY row_index, col_index`, shelfID); err != nil {
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &slots, `SELECT * FROM shelf_slots WHERE shelf_id=$1 ORDER BY row_index, col_index`, shelfID); err != nil {
 		return nil, err
 	}
 	return slots, nil
 }
 
-func insertRows(ctx context.Context, tx *sqlx.Tx, rows []ShelfRow) error {
+func insertRows(ctx context.Context, tx database.Queryer, rows []ShelfRow) error {
 	for _, row := range rows {
 		if _, err := tx.NamedExecContext(ctx, `
             INSERT INTO shelf_rows (id, shelf_id, row_index, y_start_norm, y_end_norm)
@@ -370,7 +418,7 @@ func insertRows(ctx context.Context, tx *sqlx.Tx, rows []ShelfRow) error {
 	return nil
 }
 
-func insertColumns(ctx context.Context, tx *sqlx.Tx, columns []ShelfColumn) error {
+func insertColumns(ctx context.Context, tx database.Queryer, columns []ShelfColumn) error {
 	for _, col := range columns {
 		if _, err := tx.NamedExecContext(ctx, `
             INSERT INTO shelf_columns (id, shelf_row_id, col_index, x_start_norm, x_end_norm)
@@ -383,7 +431,7 @@ func insertColumns(ctx context.Context, tx *sqlx.Tx, columns []ShelfColumn) erro
 	return nil
 }
 
-func insertSlots(ctx context.Context, tx *sqlx.Tx, slots []ShelfSlot) error {
+func insertSlots(ctx context.Context, tx database.Queryer, slots []ShelfSlot) error {
 	for _, slot := range slots {
 		if _, err := tx.NamedExecContext(ctx, `
             INSERT INTO shelf_slots (id, shelf_id, shelf_row_id, shelf_column_id, row_index, col_index, x_start_norm, x_end_norm, y_start_norm, y_end_norm)
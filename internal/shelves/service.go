@@ -46,20 +46,35 @@ type Service struct {
 	itemsRepo   items.Repository
 	catalogSvc  CatalogService
 	itemService *items.Service
+	grants      items.GrantSource
 }
 
 type placementCacheUpdater interface {
 	UpdateShelfPlacement(ctx context.Context, itemID uuid.UUID, placement *items.ShelfPlacement) error
 }
 
+// Option configures optional service behaviour.
+type Option func(*Service)
+
+// WithGrantSource lets users see, and editors arrange, shelves shared with them.
+func WithGrantSource(source items.GrantSource) Option {
+	return func(s *Service) {
+		s.grants = source
+	}
+}
+
 // NewService wires a shelf service.
-func NewService(repo Repository, itemsRepo items.Repository, catalogSvc CatalogService, itemService *items.Service) *Service {
-	return &Service{
+func NewService(repo Repository, itemsRepo items.Repository, catalogSvc CatalogService, itemService *items.Service, opts ...Option) *Service {
+	s := &Service{
 		repo:        repo,
 		itemsRepo:   itemsRepo,
 		catalogSvc:  catalogSvc,
 		itemService: itemService,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateShelfInput captures the fields required to create a shelf.
@@ -138,22 +153,173 @@ func (s *Service) CreateShelf(ctx context.Context, input CreateShelfInput, owner
 		return ShelfWithLayout{}, err
 	}
 
-	return created, nil
+	return withAccess(created, items.OwnerAccess(ownerID)), nil
+}
+
+// ListShelves returns the user's shelf summaries followed by shelves shared with them.
+func (s *Service) ListShelves(ctx context.Context, userID uuid.UUID) ([]ShelfSummary, error) {
+	summaries, err := s.repo.ListShelves(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owner := items.OwnerAccess(userID)
+	for i := range summaries {
+		summaries[i].Access = &owner
+	}
+
+	grants, err := s.grantsFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	listed := map[uuid.UUID]bool{userID: true}
+	for _, grant := range grants {
+		if listed[grant.OwnerID] {
+			continue
+		}
+		listed[grant.OwnerID] = true
+
+		shared, err := s.repo.ListShelves(ctx, grant.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range shared {
+			access, ok := items.ResolveAccess(userID, grant.OwnerID, &summary.Shelf.ID, grants)
+			if !ok {
+				continue
+			}
+			summary.Access = &access
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// GetShelf returns a shelf the user owns or that has been shared with them,
+// with layout and placements hydrated with item details.
+func (s *Service) GetShelf(ctx context.Context, shelfID uuid.UUID, userID uuid.UUID) (ShelfWithLayout, error) {
+	access, err := s.access(ctx, shelfID, userID)
+	if err != nil {
+		return ShelfWithLayout{}, err
+	}
+
+	layout, err := s.repo.GetShelf(ctx, shelfID, access.OwnerID)
+	if err != nil {
+		return ShelfWithLayout{}, err
+	}
+
+	hydrated, err := s.attachItems(ctx, layout, access.OwnerID)
+	if err != nil {
+		return ShelfWithLayout{}, err
+	}
+	return s.labelFor(ctx, hydrated, access, userID)
+}
+
+func (s *Service) grantsFor(ctx context.Context, userID uuid.UUID) ([]items.AccessGrant, error) {
+	if s.grants == nil {
+		return nil, nil
+	}
+	return s.grants.AccessGrants(ctx, userID)
+}
+
+// access reports what userID may do with a shelf. Shelves neither owned by
+// nor shared with the user are reported as not found.
+func (s *Service) access(ctx context.Context, shelfID uuid.UUID, userID uuid.UUID) (items.Access, error) {
+	ownerID, err := s.repo.ShelfOwner(ctx, shelfID)
+	if err != nil {
+		return items.Access{}, err
+	}
+	grants, err := s.grantsFor(ctx, userID)
+	if err != nil {
+		return items.Access{}, err
+	}
+	access, ok := items.ResolveAccess(userID, ownerID, &shelfID, grants)
+	if !ok {
+		return items.Access{}, ErrNotFound
+	}
+	return access, nil
+}
+
+// editAccess is access for changes to a shelf's placements.
+func (s *Service) editAccess(ctx context.Context, shelfID uuid.UUID, userID uuid.UUID) (items.Access, error) {
+	access, err := s.access(ctx, shelfID, userID)
+	if err != nil {
+		return items.Access{}, err
+	}
+	if !access.CanEdit {
+		return items.Access{}, ErrForbidden
+	}
+	return access, nil
 }
 
-// ListShelves returns shelf summaries.
-func (s *Service) ListShelves(ctx context.Context, ownerID uuid.UUID) ([]ShelfSummary, error) {
-	return s.repo.ListShelves(ctx, ownerID)
+// checkPlaceable reports whether an editor of a shared shelf may place item on
+// it. Shelf grants only reach items already placed on that shelf; anything
+// else needs a catalog-wide editor grant. Items the caller cannot see at all
+// are reported as not found.
+func (s *Service) checkPlaceable(ctx context.Context, item items.Item, shelfID uuid.UUID, userID uuid.UUID) error {
+	grants, err := s.grantsFor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	catalog, visible := items.ResolveAccess(userID, item.OwnerID, nil, grants)
+	if visible && catalog.CanEdit {
+		return nil
+	}
+	if placement := item.ShelfPlacement; placement != nil {
+		if placement.ShelfID == shelfID {
+			return nil
+		}
+		if _, onShared := items.ResolveAccess(userID, item.OwnerID, &placement.ShelfID, grants); onShared {
+			visible = true
+		}
+	}
+	if visible {
+		return ErrForbidden
+	}
+	return items.ErrNotFound
 }
 
-// GetShelf returns a shelf with layout and placements hydrated with item details.
-func (s *Service) GetShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, error) {
-	layout, err := s.repo.GetShelf(ctx, shelfID, ownerID)
+// labelFor labels a shelf for userID. Owners see every item on it; other
+// users see only the items items.ItemAccess lets them reach, each labelled
+// with its own access, so an item shown as editable can be edited through the
+// item endpoints too.
+func (s *Service) labelFor(ctx context.Context, layout ShelfWithLayout, access items.Access, userID uuid.UUID) (ShelfWithLayout, error) {
+	if access.Role == items.RoleOwner {
+		return withAccess(layout, access), nil
+	}
+	grants, err := s.grantsFor(ctx, userID)
 	if err != nil {
 		return ShelfWithLayout{}, err
 	}
+	layout.Access = &access
+	layout.Placements = reachable(layout.Placements, userID, grants)
+	layout.Unplaced = reachable(layout.Unplaced, userID, grants)
+	return layout, nil
+}
+
+// reachable keeps the placements whose item userID's grants reach.
+func reachable(placements []PlacementWithItem, userID uuid.UUID, grants []items.AccessGrant) []PlacementWithItem {
+	var kept []PlacementWithItem
+	for _, placement := range placements {
+		itemAccess, ok := items.ItemAccess(userID, placement.Item, grants)
+		if !ok {
+			continue
+		}
+		placement.Item.Access = &itemAccess
+		kept = append(kept, placement)
+	}
+	return kept
+}
 
-	return s.attachItems(ctx, layout, ownerID)
+// withAccess labels a shelf and the items shown on it with the caller's access.
+func withAccess(layout ShelfWithLayout, access items.Access) ShelfWithLayout {
+	layout.Access = &access
+	for i := range layout.Placements {
+		layout.Placements[i].Item.Access = &access
+	}
+	for i := range layout.Unplaced {
+		layout.Unplaced[i].Item.Access = &access
+	}
+	return layout
 }
 
 // UpdateLayout replaces the layout while keeping stable slot IDs when possible.
@@ -234,14 +400,28 @@ func (s *Service) UpdateLayout(ctx context.Context, shelfID uuid.UUID, ownerID u
 		}
 	}
 
-	return hydrated, displaced, nil
+	return withAccess(hydrated, items.OwnerAccess(ownerID)), displaced, nil
 }
 
-// AssignItem assigns an item to a slot, clearing any previous placement on the shelf.
-func (s *Service) AssignItem(ctx context.Context, shelfID, slotID, itemID uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, error) {
-	if _, err := s.itemsRepo.Get(ctx, itemID, ownerID); err != nil {
+// AssignItem assigns an item to a slot, clearing any previous placement on the
+// shelf. Editors of a shared shelf may only rearrange items already on it,
+// unless they may also edit the owner's whole catalog.
+func (s *Service) AssignItem(ctx context.Context, shelfID, slotID, itemID uuid.UUID, userID uuid.UUID) (ShelfWithLayout, error) {
+	access, err := s.editAccess(ctx, shelfID, userID)
+	if err != nil {
 		return ShelfWithLayout{}, err
 	}
+	ownerID := access.OwnerID
+
+	item, err := s.itemsRepo.Get(ctx, itemID, ownerID)
+	if err != nil {
+		return ShelfWithLayout{}, err
+	}
+	if userID != ownerID {
+		if err := s.checkPlaceable(ctx, item, shelfID, userID); err != nil {
+			return ShelfWithLayout{}, err
+		}
+	}
 
 	if _, err := s.repo.AssignItemToSlot(ctx, shelfID, ownerID, slotID, itemID); err != nil {
 		return ShelfWithLayout{}, err
@@ -261,11 +441,29 @@ func (s *Service) AssignItem(ctx context.Context, shelfID, slotID, itemID uuid.U
 		return ShelfWithLayout{}, err
 	}
 
-	return hydrated, nil
+	return s.labelFor(ctx, hydrated, access, userID)
+}
+
+// ItemUsedSince reports whether the item has been put on one of the owner's
+// shelves after since.
+func (s *Service) ItemUsedSince(ctx context.Context, itemID uuid.UUID, ownerID uuid.UUID, since time.Time) (bool, error) {
+	return s.repo.ItemShelved(ctx, itemID, ownerID, since)
+}
+
+// CleanupOrphanedPlacements removes placements left behind for items that were
+// deleted or now belong to someone else. It returns the number removed.
+func (s *Service) CleanupOrphanedPlacements(ctx context.Context) (int64, error) {
+	return s.repo.DeleteOrphanedPlacements(ctx)
 }
 
 // RemoveItem removes an item from a slot, leaving it unplaced on the shelf.
-func (s *Service) RemoveItem(ctx context.Context, shelfID, slotID, itemID uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, error) {
+func (s *Service) RemoveItem(ctx context.Context, shelfID, slotID, itemID uuid.UUID, userID uuid.UUID) (ShelfWithLayout, error) {
+	access, err := s.editAccess(ctx, shelfID, userID)
+	if err != nil {
+		return ShelfWithLayout{}, err
+	}
+	ownerID := access.OwnerID
+
 	if err := s.repo.RemoveItemFromSlot(ctx, shelfID, ownerID, slotID, itemID); err != nil {
 		return ShelfWithLayout{}, err
 	}
@@ -284,7 +482,7 @@ func (s *Service) RemoveItem(ctx context.Context, shelfID, slotID, itemID uuid.U
 		return ShelfWithLayout{}, err
 	}
 
-	return hydrated, nil
+	return s.labelFor(ctx, hydrated, access, userID)
 }
 
 func removedSlots(previous, next []ShelfSlot) []uuid.UUID {
@@ -485,6 +683,14 @@ func (s *Service) updateItemPlacementCache(ctx context.Context, layout ShelfWith
 			return err
 		}
 	}
+	// Keep the items in the returned layout in step with the cache.
+	for _, list := range [][]PlacementWithItem{layout.Placements, layout.Unplaced} {
+		for i := range list {
+			if slices.Contains(itemIDs, list[i].Item.ID) {
+				list[i].Item.ShelfPlacement = placementByItem[list[i].Item.ID]
+			}
+		}
+	}
 
 	return nil
 }
@@ -637,20 +843,22 @@ func (s *Service) ScanAndAssign(ctx context.Context, shelfID, slotID uuid.UUID,
 
 		// Create the item
 		createInput := items.CreateItemInput{
-			OwnerID:        ownerID,
-			Title:          meta.Title,
-			Creator:        meta.Creator,
-			ItemType:       items.ItemType(meta.ItemType),
-			ReleaseYear:    meta.ReleaseYear,
-			PageCount:      meta.PageCount,
-			ISBN13:         meta.ISBN13,
-			ISBN10:         meta.ISBN10,
-			Description:    meta.Description,
-			CoverImage:     meta.CoverImage,
-			Notes:          meta.Notes,
-			Genre:          items.Genre(meta.Genre),
-			RetailPriceUsd: meta.RetailPriceUsd,
-			GoogleVolumeId: meta.GoogleVolumeId,
+			OwnerID:          ownerID,
+			Title:            meta.Title,
+			Creator:          meta.Creator,
+			ItemType:         items.ItemType(meta.ItemType),
+			ReleaseYear:      meta.ReleaseYear,
+			PageCount:        meta.PageCount,
+			ISBN13:           meta.ISBN13,
+			ISBN10:           meta.ISBN10,
+			Description:      meta.Description,
+			CoverImage:       meta.CoverImage,
+			Notes:            meta.Notes,
+			Genre:            items.Genre(meta.Genre),
+			RetailPriceUsd:   meta.RetailPriceUsd,
+			GoogleVolumeId:   meta.GoogleVolumeId,
+			MetadataSource:   meta.Source,
+			MetadataSourceID: meta.SourceID,
 		}
 
 		newItem, err := s.itemService.Create(ctx, createInput)
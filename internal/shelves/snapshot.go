@@ -0,0 +1,222 @@
+package shelves
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Snapshot is a self-contained copy of a shelf, its layout, and its placements,
+// used to move a shelf between accounts or restore it from an archive.
+type Snapshot struct {
+	Shelf      Shelf           `json:"shelf"`
+	Rows       []ShelfRow      `json:"rows"`
+	Columns    []ShelfColumn   `json:"columns"`
+	Slots      []ShelfSlot     `json:"slots"`
+	Placements []ItemPlacement `json:"placements"`
+}
+
+// Snapshots returns every shelf the owner has, oldest first.
+func (s *Service) Snapshots(ctx context.Context, ownerID uuid.UUID) ([]Snapshot, error) {
+	summaries, err := s.repo.ListShelves(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(summaries))
+	for i := len(summaries) - 1; i >= 0; i-- {
+		layout, err := s.repo.GetShelf(ctx, summaries[i].Shelf.ID, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := Snapshot{
+			Shelf:      layout.Shelf,
+			Rows:       []ShelfRow{},
+			Columns:    []ShelfColumn{},
+			Slots:      layout.Slots,
+			Placements: make([]ItemPlacement, 0, len(layout.Placements)),
+		}
+		if snapshot.Slots == nil {
+			snapshot.Slots = []ShelfSlot{}
+		}
+		for _, row := range layout.Rows {
+			snapshot.Rows = append(snapshot.Rows, row.ShelfRow)
+			snapshot.Columns = append(snapshot.Columns, row.Columns...)
+		}
+		for _, placement := range layout.Placements {
+			snapshot.Placements = append(snapshot.Placements, placement.Placement)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// ValidateSnapshot checks that a snapshot is internally consistent: rows,
+// columns, and slots reference each other, slot boundaries are valid, and
+// every placement points at a slot on the same shelf.
+func (s *Service) ValidateSnapshot(snapshot Snapshot) error {
+	_, _, _, err := snapshotLayout(snapshot)
+	return err
+}
+
+// RestoreSnapshot recreates a shelf from a snapshot under fresh IDs. itemIDs
+// maps the item IDs recorded in the snapshot to the owner's items; placements
+// for unmapped items are dropped. It returns the number of placements restored.
+func (s *Service) RestoreSnapshot(ctx context.Context, snapshot Snapshot, itemIDs map[uuid.UUID]uuid.UUID, ownerID uuid.UUID) (ShelfWithLayout, int, error) {
+	shelf, layoutInput, slotKeys, err := snapshotLayout(snapshot)
+	if err != nil {
+		return ShelfWithLayout{}, 0, err
+	}
+
+	shelf.ID = uuid.New()
+	shelf.OwnerID = ownerID
+	rows, columns, slots, err := normalizeSlots(layoutInput, shelf.ID, nil, nil, nil, nil)
+	if err != nil {
+		return ShelfWithLayout{}, 0, err
+	}
+	if _, err := s.repo.CreateShelf(ctx, shelf, rows, columns, slots); err != nil {
+		return ShelfWithLayout{}, 0, err
+	}
+
+	newSlotIDs := make(map[string]uuid.UUID, len(slots))
+	for _, slot := range slots {
+		newSlotIDs[slotKey(slot.RowIndex, slot.ColIndex)] = slot.ID
+	}
+
+	restored := 0
+	placedItems := make([]uuid.UUID, 0, len(snapshot.Placements))
+	for _, placement := range snapshot.Placements {
+		itemID, ok := itemIDs[placement.ItemID]
+		if !ok {
+			continue
+		}
+		if placement.ShelfSlotID == nil {
+			_, err = s.repo.UpsertUnplaced(ctx, shelf.ID, ownerID, itemID)
+		} else {
+			_, err = s.repo.AssignItemToSlot(ctx, shelf.ID, ownerID, newSlotIDs[slotKeys[*placement.ShelfSlotID]], itemID)
+		}
+		if err != nil {
+			return ShelfWithLayout{}, restored, err
+		}
+		placedItems = append(placedItems, itemID)
+		restored++
+	}
+
+	layout, err := s.repo.GetShelf(ctx, shelf.ID, ownerID)
+	if err != nil {
+		return ShelfWithLayout{}, restored, err
+	}
+	hydrated, err := s.attachItems(ctx, layout, ownerID)
+	if err != nil {
+		return ShelfWithLayout{}, restored, err
+	}
+	if err := s.updateItemPlacementCache(ctx, hydrated, placedItems); err != nil {
+		return ShelfWithLayout{}, restored, err
+	}
+	return hydrated, restored, nil
+}
+
+// DeleteShelf removes a shelf and its layout. Items on it become unshelved.
+func (s *Service) DeleteShelf(ctx context.Context, shelfID uuid.UUID, ownerID uuid.UUID) error {
+	layout, err := s.repo.GetShelf(ctx, shelfID, ownerID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteShelf(ctx, shelfID, ownerID); err != nil {
+		return err
+	}
+
+	updater, ok := s.itemsRepo.(placementCacheUpdater)
+	if !ok {
+		return nil
+	}
+	for _, placement := range layout.Placements {
+		if err := updater.UpdateShelfPlacement(ctx, placement.Placement.ItemID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotLayout validates a snapshot and converts its slots into layout input.
+// slotKeys maps each snapshot slot ID to its row/column key.
+func snapshotLayout(snapshot Snapshot) (Shelf, []LayoutSlotInput, map[uuid.UUID]string, error) {
+	shelf := snapshot.Shelf
+	shelf.Name = strings.TrimSpace(shelf.Name)
+	if shelf.Name == "" {
+		return Shelf{}, nil, nil, fmt.Errorf("%w: shelf name is required", ErrValidation)
+	}
+	photoURL, err := sanitizePhotoURL(shelf.PhotoURL)
+	if err != nil {
+		return Shelf{}, nil, nil, err
+	}
+	if photoURL == "" {
+		return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q needs a photoUrl", ErrValidation, shelf.Name)
+	}
+	shelf.PhotoURL = photoURL
+	shelf.Description = strings.TrimSpace(shelf.Description)
+
+	rowIDs := make(map[uuid.UUID]struct{}, len(snapshot.Rows))
+	for _, row := range snapshot.Rows {
+		if row.ShelfID != snapshot.Shelf.ID {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q has a row from another shelf", ErrValidation, shelf.Name)
+		}
+		rowIDs[row.ID] = struct{}{}
+	}
+	columnRows := make(map[uuid.UUID]uuid.UUID, len(snapshot.Columns))
+	for _, column := range snapshot.Columns {
+		if _, ok := rowIDs[column.ShelfRowID]; !ok {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q has a column without a row", ErrValidation, shelf.Name)
+		}
+		columnRows[column.ID] = column.ShelfRowID
+	}
+
+	layoutInput := make([]LayoutSlotInput, 0, len(snapshot.Slots))
+	slotKeys := make(map[uuid.UUID]string, len(snapshot.Slots))
+	for _, slot := range snapshot.Slots {
+		if slot.ShelfID != snapshot.Shelf.ID {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q has a slot from another shelf", ErrValidation, shelf.Name)
+		}
+		if rowID, ok := columnRows[slot.ShelfColumnID]; !ok || rowID != slot.ShelfRowID {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q slot %d/%d does not match its row and column", ErrValidation, shelf.Name, slot.RowIndex, slot.ColIndex)
+		}
+		if _, exists := slotKeys[slot.ID]; exists {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q lists slot %s twice", ErrValidation, shelf.Name, slot.ID)
+		}
+		slotKeys[slot.ID] = slotKey(slot.RowIndex, slot.ColIndex)
+		layoutInput = append(layoutInput, LayoutSlotInput{
+			RowIndex:   slot.RowIndex,
+			ColIndex:   slot.ColIndex,
+			XStartNorm: slot.XStartNorm,
+			XEndNorm:   slot.XEndNorm,
+			YStartNorm: slot.YStartNorm,
+			YEndNorm:   slot.YEndNorm,
+		})
+	}
+	if _, _, _, err := normalizeSlots(layoutInput, snapshot.Shelf.ID, nil, nil, nil, nil); err != nil {
+		return Shelf{}, nil, nil, fmt.Errorf("shelf %q: %w", shelf.Name, err)
+	}
+
+	placed := make(map[uuid.UUID]struct{}, len(snapshot.Placements))
+	for _, placement := range snapshot.Placements {
+		if placement.ShelfID != snapshot.Shelf.ID {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q has a placement from another shelf", ErrValidation, shelf.Name)
+		}
+		if placement.ShelfSlotID != nil {
+			if _, ok := slotKeys[*placement.ShelfSlotID]; !ok {
+				return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q places item %s in an unknown slot", ErrValidation, shelf.Name, placement.ItemID)
+			}
+		}
+		if _, dup := placed[placement.ItemID]; dup {
+			return Shelf{}, nil, nil, fmt.Errorf("%w: shelf %q places item %s twice", ErrValidation, shelf.Name, placement.ItemID)
+		}
+		placed[placement.ItemID] = struct{}{}
+	}
+	return shelf, layoutInput, slotKeys, nil
+}
+
+func slotKey(rowIdx, colIdx int) string {
+	return fmt.Sprintf("%d-%d", rowIdx, colIdx)
+}
@@ -0,0 +1,180 @@
+package stats
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+type inMemoryRepository struct {
+	itemsRepo   items.Repository
+	shelvesRepo shelves.Repository
+}
+
+// NewInMemoryRepository computes statistics by scanning the given item and
+// shelf repositories, which keeps aggregates testable without Postgres.
+func NewInMemoryRepository(itemsRepo items.Repository, shelvesRepo shelves.Repository) Repository {
+	return &inMemoryRepository{itemsRepo: itemsRepo, shelvesRepo: shelvesRepo}
+}
+
+func (m *inMemoryRepository) ReadByMonth(ctx context.Context, opts Options) ([]MonthBucket, error) {
+	catalog, err := m.itemsRepo.List(ctx, items.ListOptions{OwnerID: opts.OwnerID})
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[[2]int]*MonthBucket)
+	for _, item := range catalog {
+		if item.ItemType != items.ItemTypeBook || item.ReadingStatus != items.BookStatusRead || item.ReadAt == nil {
+			continue
+		}
+		bucket := monthBucket(buckets, item.ReadAt.UTC(), opts.Year)
+		if bucket == nil {
+			continue
+		}
+		bucket.Count++
+		if item.PageCount != nil {
+			bucket.Pages += *item.PageCount
+		}
+	}
+	return sortedMonths(buckets), nil
+}
+
+func (m *inMemoryRepository) AddedByMonth(ctx context.Context, opts Options) ([]MonthBucket, error) {
+	catalog, err := m.itemsRepo.List(ctx, items.ListOptions{OwnerID: opts.OwnerID})
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[[2]int]*MonthBucket)
+	for _, item := range catalog {
+		if bucket := monthBucket(buckets, item.CreatedAt.UTC(), opts.Year); bucket != nil {
+			bucket.Count++
+		}
+	}
+	return sortedMonths(buckets), nil
+}
+
+func (m *inMemoryRepository) Breakdown(ctx context.Context, ownerID uuid.UUID) (Breakdown, error) {
+	catalog, err := m.itemsRepo.List(ctx, items.ListOptions{OwnerID: ownerID})
+	if err != nil {
+		return Breakdown{}, err
+	}
+
+	byType := make(map[string]*Bucket)
+	byGenre := make(map[string]*Bucket)
+	byFormat := make(map[string]*Bucket)
+	var breakdown Breakdown
+	for _, item := range catalog {
+		value := 0.0
+		if item.RetailPriceUsd != nil {
+			breakdown.PricedItems++
+			value = *item.RetailPriceUsd
+		}
+		addToBucket(byType, string(item.ItemType), value)
+		addToBucket(byGenre, orUnknown(string(item.Genre)), value)
+		addToBucket(byFormat, orUnknown(string(item.Format)), value)
+	}
+
+	breakdown.ByType = sortedBuckets(byType)
+	breakdown.ByGenre = sortedBuckets(byGenre)
+	breakdown.ByFormat = sortedBuckets(byFormat)
+	return breakdown, nil
+}
+
+func (m *inMemoryRepository) ShelfFill(ctx context.Context, ownerID uuid.UUID) ([]ShelfFill, error) {
+	summaries, err := m.shelvesRepo.ListShelves(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	fills := make([]ShelfFill, 0, len(summaries))
+	for _, summary := range summaries {
+		placements, err := m.shelvesRepo.ListPlacements(ctx, summary.Shelf.ID, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		occupied := make(map[uuid.UUID]struct{})
+		for _, placement := range placements {
+			if placement.ShelfSlotID != nil {
+				occupied[*placement.ShelfSlotID] = struct{}{}
+			}
+		}
+		fills = append(fills, ShelfFill{
+			ShelfID:       summary.Shelf.ID,
+			ShelfName:     summary.Shelf.Name,
+			SlotCount:     summary.SlotCount,
+			OccupiedSlots: len(occupied),
+			ItemCount:     summary.ItemCount,
+			PlacedCount:   summary.PlacedCount,
+		})
+	}
+	return fills, nil
+}
+
+// monthBucket returns the bucket for ts, creating it on first use, or nil when
+// ts falls outside the requested year.
+func monthBucket(buckets map[[2]int]*MonthBucket, ts time.Time, year *int) *MonthBucket {
+	if year != nil && ts.Year() != *year {
+		return nil
+	}
+	key := [2]int{ts.Year(), int(ts.Month())}
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = &MonthBucket{Year: key[0], Month: key[1]}
+		buckets[key] = bucket
+	}
+	return bucket
+}
+
+func sortedMonths(buckets map[[2]int]*MonthBucket) []MonthBucket {
+	months := make([]MonthBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		months = append(months, *bucket)
+	}
+	slices.SortFunc(months, func(a, b MonthBucket) int {
+		if c := cmp.Compare(a.Year, b.Year); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Month, b.Month)
+	})
+	return months
+}
+
+func addToBucket(buckets map[string]*Bucket, key string, value float64) {
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = &Bucket{Key: key}
+		buckets[key] = bucket
+	}
+	bucket.Count++
+	bucket.ValueUsd += value
+}
+
+// sortedBuckets orders buckets by count descending, then key, matching Postgres.
+func sortedBuckets(buckets map[string]*Bucket) []Bucket {
+	sorted := make([]Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		sorted = append(sorted, *bucket)
+	}
+	slices.SortFunc(sorted, func(a, b Bucket) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return sorted
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return unknownKey
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+package stats
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrValidation wraps user-correctable validation errors safe to expose to clients.
+var ErrValidation = errors.New("validation error")
+
+// Options narrows aggregates to a single owner and, optionally, a calendar year.
+// Years and months are computed in UTC.
+type Options struct {
+	OwnerID uuid.UUID
+	Year    *int
+}
+
+// MonthBucket is a per-month aggregate. Pages is only populated for reading stats.
+type MonthBucket struct {
+	Year  int `db:"year" json:"year"`
+	Month int `db:"month" json:"month"`
+	Count int `db:"count" json:"count"`
+	Pages int `db:"pages" json:"pages"`
+}
+
+// YearSummary rolls up the months of a single year, oldest month first.
+type YearSummary struct {
+	Year   int           `json:"year"`
+	Count  int           `json:"count"`
+	Pages  int           `json:"pages"`
+	Months []MonthBucket `json:"months"`
+}
+
+// ReadingStats reports books and pages finished per year, newest year first.
+type ReadingStats struct {
+	TotalBooks int           `json:"totalBooks"`
+	TotalPages int           `json:"totalPages"`
+	Years      []YearSummary `json:"years"`
+}
+
+// AdditionStats reports items added to the collection per month, oldest first.
+type AdditionStats struct {
+	Total  int           `json:"total"`
+	Months []MonthBucket `json:"months"`
+}
+
+// Bucket counts items sharing a type, genre, or format along with their
+// combined retail value. Items without a genre or format use "UNKNOWN".
+type Bucket struct {
+	Key      string  `db:"key" json:"key"`
+	Count    int     `db:"count" json:"count"`
+	ValueUsd float64 `db:"value_usd" json:"valueUsd"`
+}
+
+// CollectionStats describes the shape and value of the collection.
+type CollectionStats struct {
+	TotalItems    int      `json:"totalItems"`
+	PricedItems   int      `json:"pricedItems"`
+	TotalValueUsd float64  `json:"totalValueUsd"`
+	ByType        []Bucket `json:"byType"`
+	ByGenre       []Bucket `json:"byGenre"`
+	ByFormat      []Bucket `json:"byFormat"`
+}
+
+// Breakdown is the raw distribution data returned by repositories.
+type Breakdown struct {
+	PricedItems int
+	ByType      []Bucket
+	ByGenre     []Bucket
+	ByFormat    []Bucket
+}
+
+// ShelfFill reports how much of a shelf's layout is in use.
+// FillRate is OccupiedSlots/SlotCount, or zero for shelves without slots.
+type ShelfFill struct {
+	ShelfID       uuid.UUID `db:"shelf_id" json:"shelfId"`
+	ShelfName     string    `db:"shelf_name" json:"shelfName"`
+	SlotCount     int       `db:"slot_count" json:"slotCount"`
+	OccupiedSlots int       `db:"occupied_slots" json:"occupiedSlots"`
+	ItemCount     int       `db:"item_count" json:"itemCount"`
+	PlacedCount   int       `db:"placed_count" json:"placedCount"`
+	FillRate      float64   `db:"-" json:"fillRate"`
+}
+
+// ShelfStats summarizes fill rates across every shelf.
+type ShelfStats struct {
+	SlotCount     int         `json:"slotCount"`
+	OccupiedSlots int         `json:"occupiedSlots"`
+	FillRate      float64     `json:"fillRate"`
+	Shelves       []ShelfFill `json:"shelves"`
+}
+
+// Dashboard bundles every statistic for a single request.
+type Dashboard struct {
+	Reading    ReadingStats    `json:"reading"`
+	Additions  AdditionStats   `json:"additions"`
+	Collection CollectionStats `json:"collection"`
+	Shelves    ShelfStats      `json:"shelves"`
+}
+
+// unknownKey labels items whose genre or format has not been set.
+const unknownKey = "UNKNOWN"
+
+// Repository computes aggregates over an owner's catalog.
+type Repository interface {
+	// ReadByMonth counts books marked read per month of ReadAt and sums their page counts.
+	ReadByMonth(ctx context.Context, opts Options) ([]MonthBucket, error)
+	// AddedByMonth counts items per month of CreatedAt.
+	AddedByMonth(ctx context.Context, opts Options) ([]MonthBucket, error)
+	// Breakdown groups items by type, genre, and format.
+	Breakdown(ctx context.Context, ownerID uuid.UUID) (Breakdown, error)
+	// ShelfFill reports slot usage per shelf, newest shelf first.
+	ShelfFill(ctx context.Context, ownerID uuid.UUID) ([]ShelfFill, error)
+}
@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a statistics repository backed by Postgres.
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) ReadByMonth(ctx context.Context, opts Options) ([]MonthBucket, error) {
+	query := `
+SELECT
+    EXTRACT(YEAR FROM read_at AT TIME ZONE 'UTC')::int AS year,
+    EXTRACT(MONTH FROM read_at AT TIME ZONE 'UTC')::int AS month,
+    COUNT(*) AS count,
+    COALESCE(SUM(page_count), 0) AS pages
+FROM items
+WHERE owner_id = $1 AND item_type = 'book' AND reading_status = 'read' AND read_at IS NOT NULL`
+	return r.selectMonths(ctx, query, "read_at", opts)
+}
+
+func (r *postgresRepository) AddedByMonth(ctx context.Context, opts Options) ([]MonthBucket, error) {
+	query := `
+SELECT
+    EXTRACT(YEAR FROM created_at AT TIME ZONE 'UTC')::int AS year,
+    EXTRACT(MONTH FROM created_at AT TIME ZONE 'UTC')::int AS month,
+    COUNT(*) AS count,
+    0 AS pages
+FROM items
+WHERE owner_id = $1`
+	return r.selectMonths(ctx, query, "created_at", opts)
+}
+
+func (r *postgresRepository) selectMonths(ctx context.Context, query, column string, opts Options) ([]MonthBucket, error) {
+	args := []any{opts.OwnerID}
+	if opts.Year != nil {
+		query += fmt.Sprintf(" AND EXTRACT(YEAR FROM %s AT TIME ZONE 'UTC') = $2", column)
+		args = append(args, *opts.Year)
+	}
+	query += " GROUP BY year, month ORDER BY year, month"
+
+	buckets := []MonthBucket{}
+	if err := r.db.SelectContext(ctx, &buckets, query, args...); err != nil {
+		return nil, fmt.Errorf("stats by month (%s): %w", column, err)
+	}
+	return buckets, nil
+}
+
+func (r *postgresRepository) Breakdown(ctx context.Context, ownerID uuid.UUID) (Breakdown, error) {
+	var breakdown Breakdown
+
+	if err := r.db.GetContext(ctx, &breakdown.PricedItems, `SELECT COUNT(*) FROM items WHERE owner_id = $1 AND retail_price_usd IS NOT NULL`, ownerID); err != nil {
+		return Breakdown{}, fmt.Errorf("count priced items: %w", err)
+	}
+
+	groups := []struct {
+		expr string
+		dest *[]Bucket
+	}{
+		{expr: "item_type", dest: &breakdown.ByType},
+		{expr: "COALESCE(NULLIF(genre, ''), '" + unknownKey + "')", dest: &breakdown.ByGenre},
+		{expr: "COALESCE(NULLIF(format, ''), '" + unknownKey + "')", dest: &breakdown.ByFormat},
+	}
+	for _, group := range groups {
+		query := `
+SELECT ` + group.expr + ` AS key, COUNT(*) AS count, COALESCE(SUM(retail_price_usd), 0)::float8 AS value_usd
+FROM items
+WHERE owner_id = $1
+GROUP BY key
+ORDER BY count DESC, key`
+		buckets := []Bucket{}
+		if err := r.db.SelectContext(ctx, &buckets, query, ownerID); err != nil {
+			return Breakdown{}, fmt.Errorf("stats breakdown: %w", err)
+		}
+		*group.dest = buckets
+	}
+
+	return breakdown, nil
+}
+
+func (r *postgresRepository) ShelfFill(ctx context.Context, ownerID uuid.UUID) ([]ShelfFill, error) {
+	query := `
+SELECT
+    s.id AS shelf_id,
+    s.name AS shelf_name,
+    (SELECT COUNT(*) FROM shelf_slots ss WHERE ss.shelf_id = s.id) AS slot_count,
+    (SELECT COUNT(DISTINCT l.shelf_slot_id) FROM item_shelf_locations l WHERE l.shelf_id = s.id) AS occupied_slots,
+    (SELECT COUNT(*) FROM item_shelf_locations l WHERE l.shelf_id = s.id) AS item_count,
+    (SELECT COUNT(*) FROM item_shelf_locations l WHERE l.shelf_id = s.id AND l.shelf_slot_id IS NOT NULL) AS placed_count
+FROM shelves s
+WHERE s.owner_id = $1
+ORDER BY s.created_at DESC`
+
+	fills := []ShelfFill{}
+	if err := r.db.SelectContext(ctx, &fills, query, ownerID); err != nil {
+		return nil, fmt.Errorf("shelf fill: %w", err)
+	}
+	return fills, nil
+}
@@ -0,0 +1,167 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+const (
+	minYear = 1000
+	maxYear = 9999
+)
+
+// Service turns raw repository aggregates into dashboard statistics.
+type Service struct {
+	repo Repository
+}
+
+// NewService wires a statistics service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Dashboard computes every statistic in one call. Options.Year only narrows the
+// reading and additions timelines; collection and shelf stats cover everything.
+func (s *Service) Dashboard(ctx context.Context, opts Options) (Dashboard, error) {
+	reading, err := s.Reading(ctx, opts)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	additions, err := s.Additions(ctx, opts)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	collection, err := s.Collection(ctx, opts.OwnerID)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	shelfStats, err := s.Shelves(ctx, opts.OwnerID)
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	return Dashboard{
+		Reading:    reading,
+		Additions:  additions,
+		Collection: collection,
+		Shelves:    shelfStats,
+	}, nil
+}
+
+// Reading reports books and pages read per year and month, newest year first.
+func (s *Service) Reading(ctx context.Context, opts Options) (ReadingStats, error) {
+	if err := validateOptions(opts); err != nil {
+		return ReadingStats{}, err
+	}
+
+	months, err := s.repo.ReadByMonth(ctx, opts)
+	if err != nil {
+		return ReadingStats{}, err
+	}
+
+	stats := ReadingStats{Years: []YearSummary{}}
+	// Months arrive oldest first, so walk them backwards to list newest years first
+	// while keeping each year's months in calendar order.
+	for i := len(months) - 1; i >= 0; i-- {
+		month := months[i]
+		if len(stats.Years) == 0 || stats.Years[len(stats.Years)-1].Year != month.Year {
+			stats.Years = append(stats.Years, YearSummary{Year: month.Year, Months: []MonthBucket{}})
+		}
+		year := &stats.Years[len(stats.Years)-1]
+		year.Count += month.Count
+		year.Pages += month.Pages
+		year.Months = append([]MonthBucket{month}, year.Months...)
+
+		stats.TotalBooks += month.Count
+		stats.TotalPages += month.Pages
+	}
+	return stats, nil
+}
+
+// Additions reports items added to the collection per month, oldest first.
+func (s *Service) Additions(ctx context.Context, opts Options) (AdditionStats, error) {
+	if err := validateOptions(opts); err != nil {
+		return AdditionStats{}, err
+	}
+
+	months, err := s.repo.AddedByMonth(ctx, opts)
+	if err != nil {
+		return AdditionStats{}, err
+	}
+
+	stats := AdditionStats{Months: months}
+	for _, month := range months {
+		stats.Total += month.Count
+	}
+	return stats, nil
+}
+
+// Collection reports type, genre, and format distribution alongside retail value.
+func (s *Service) Collection(ctx context.Context, ownerID uuid.UUID) (CollectionStats, error) {
+	breakdown, err := s.repo.Breakdown(ctx, ownerID)
+	if err != nil {
+		return CollectionStats{}, err
+	}
+
+	stats := CollectionStats{
+		PricedItems: breakdown.PricedItems,
+		ByType:      roundBuckets(breakdown.ByType),
+		ByGenre:     roundBuckets(breakdown.ByGenre),
+		ByFormat:    roundBuckets(breakdown.ByFormat),
+	}
+	for _, bucket := range stats.ByType {
+		stats.TotalItems += bucket.Count
+		stats.TotalValueUsd += bucket.ValueUsd
+	}
+	stats.TotalValueUsd = roundCents(stats.TotalValueUsd)
+	return stats, nil
+}
+
+// Shelves reports per-shelf and overall slot fill rates.
+func (s *Service) Shelves(ctx context.Context, ownerID uuid.UUID) (ShelfStats, error) {
+	fills, err := s.repo.ShelfFill(ctx, ownerID)
+	if err != nil {
+		return ShelfStats{}, err
+	}
+
+	stats := ShelfStats{Shelves: fills}
+	for i := range stats.Shelves {
+		fill := &stats.Shelves[i]
+		fill.FillRate = fillRate(fill.OccupiedSlots, fill.SlotCount)
+		stats.SlotCount += fill.SlotCount
+		stats.OccupiedSlots += fill.OccupiedSlots
+	}
+	stats.FillRate = fillRate(stats.OccupiedSlots, stats.SlotCount)
+	return stats, nil
+}
+
+func validateOptions(opts Options) error {
+	if opts.Year != nil && (*opts.Year < minYear || *opts.Year > maxYear) {
+		return fmt.Errorf("%w: year must be between %d and %d", ErrValidation, minYear, maxYear)
+	}
+	return nil
+}
+
+func roundBuckets(buckets []Bucket) []Bucket {
+	if buckets == nil {
+		return []Bucket{}
+	}
+	for i := range buckets {
+		buckets[i].ValueUsd = roundCents(buckets[i].ValueUsd)
+	}
+	return buckets
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func fillRate(occupied, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(occupied) / float64(total)
+}
@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"anthology/internal/items"
+	"anthology/internal/shelves"
+)
+
+// testOwnerID is a fixed UUID for tests
+var testOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+func timePtr(v time.Time) *time.Time { return &v }
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	otherOwner := uuid.New()
+	catalog := []items.Item{
+		{ID: uuid.New(), OwnerID: testOwnerID, Title: "Dune", ItemType: items.ItemTypeBook, Genre: items.GenreFiction, Format: items.FormatPaperback, PageCount: intPtr(600), ReadingStatus: items.BookStatusRead, ReadAt: timePtr(date(2023, time.March, 4)), RetailPriceUsd: floatPtr(9.99), CreatedAt: date(2023, time.January, 2)},
+		{ID: uuid.New(), OwnerID: testOwnerID, Title: "Emma", ItemType: items.ItemTypeBook, Genre: items.GenreFiction, Format: items.FormatHardcover, PageCount: intPtr(400), ReadingStatus: items.BookStatusRead, ReadAt: timePtr(date(2024, time.March, 10)), RetailPriceUsd: floatPtr(20.01), CreatedAt: date(2024, time.January, 5)},
+		{ID: uuid.New(), OwnerID: testOwnerID, Title: "SPQR", ItemType: items.ItemTypeBook, Genre: items.GenreHistory, ReadingStatus: items.BookStatusRead, ReadAt: timePtr(date(2024, time.March, 20)), CreatedAt: date(2024, time.January, 9)},
+		{ID: uuid.New(), OwnerID: testOwnerID, Title: "Hamlet", ItemType: items.ItemTypeBook, PageCount: intPtr(200), ReadingStatus: items.BookStatusReading, CreatedAt: date(2024, time.February, 1)},
+		{ID: uuid.New(), OwnerID: testOwnerID, Title: "Catan", ItemType: items.ItemTypeGame, RetailPriceUsd: floatPtr(45), CreatedAt: date(2024, time.February, 3)},
+		{ID: uuid.New(), OwnerID: otherOwner, Title: "Other", ItemType: items.ItemTypeBook, PageCount: intPtr(999), ReadingStatus: items.BookStatusRead, ReadAt: timePtr(date(2024, time.March, 1)), RetailPriceUsd: floatPtr(100), CreatedAt: date(2024, time.March, 1)},
+	}
+	return NewService(NewInMemoryRepository(items.NewInMemoryRepository(catalog), shelves.NewInMemoryRepository()))
+}
+
+func TestReadingGroupsByYearAndMonth(t *testing.T) {
+	t.Parallel()
+	svc := newTestService(t)
+
+	stats, err := svc.Reading(context.Background(), Options{OwnerID: testOwnerID})
+	if err != nil {
+		t.Fatalf("reading stats: %v", err)
+	}
+	if stats.TotalBooks != 3 || stats.TotalPages != 1000 {
+		t.Fatalf("unexpected totals %+v", stats)
+	}
+	if len(stats.Years) != 2 || stats.Years[0].Year != 2024 || stats.Years[1].Year != 2023 {
+		t.Fatalf("expected newest year first, got %+v", stats.Years)
+	}
+	latest := stats.Years[0]
+	if latest.Count != 2 || latest.Pages != 400 || len(latest.Months) != 1 || latest.Months[0].Month != 3 {
+		t.Fatalf("unexpected 2024 summary %+v", latest)
+	}
+
+	year := 2023
+	filtered, err := svc.Reading(context.Background(), Options{OwnerID: testOwnerID, Year: &year})
+	if err != nil {
+		t.Fatalf("filtered reading stats: %v", err)
+	}
+	if filtered.TotalBooks != 1 || filtered.TotalPages != 600 {
+		t.Fatalf("unexpected filtered totals %+v", filtered)
+	}
+}
+
+func TestAdditionsCountsItemsPerMonth(t *testing.T) {
+	t.Parallel()
+	svc := newTestService(t)
+
+	stats, err := svc.Additions(context.Background(), Options{OwnerID: testOwnerID})
+	if err != nil {
+		t.Fatalf("additions: %v", err)
+	}
+	if stats.Total != 5 || len(stats.Months) != 3 {
+		t.Fatalf("unexpected additions %+v", stats)
+	}
+	if stats.Months[0] != (MonthBucket{Year: 2023, Month: 1, Count: 1}) || stats.Months[2] != (MonthBucket{Year: 2024, Month: 2, Count: 2}) {
+		t.Fatalf("expected oldest month first, got %+v", stats.Months)
+	}
+
+	year := 12
+	if _, err := svc.Additions(context.Background(), Options{OwnerID: testOwnerID, Year: &year}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error for year, got %v", err)
+	}
+}
+
+func TestCollectionDistributionAndValue(t *testing.T) {
+	t.Parallel()
+	svc := newTestService(t)
+
+	stats, err := svc.Collection(context.Background(), testOwnerID)
+	if err != nil {
+		t.Fatalf("collection: %v", err)
+	}
+	if stats.TotalItems != 5 || stats.PricedItems != 3 || stats.TotalValueUsd != 75 {
+		t.Fatalf("unexpected totals %+v", stats)
+	}
+	if stats.ByType[0] != (Bucket{Key: "book", Count: 4, ValueUsd: 30}) {
+		t.Fatalf("unexpected type buckets %+v", stats.ByType)
+	}
+	if stats.ByGenre[0].Key != string(items.GenreFiction) || stats.ByGenre[1].Key != unknownKey || stats.ByGenre[1].Count != 2 {
+		t.Fatalf("unexpected genre buckets %+v", stats.ByGenre)
+	}
+	if stats.ByFormat[0] != (Bucket{Key: unknownKey, Count: 3, ValueUsd: 45}) {
+		t.Fatalf("unexpected format buckets %+v", stats.ByFormat)
+	}
+}
+
+func TestShelvesReportsFillRate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	shelfRepo := shelves.NewInMemoryRepository()
+	svc := NewService(NewInMemoryRepository(items.NewInMemoryRepository(nil), shelfRepo))
+
+	shelfID, rowID := uuid.New(), uuid.New()
+	slots := make([]shelves.ShelfSlot, 4)
+	columns := make([]shelves.ShelfColumn, 4)
+	for i := range slots {
+		columns[i] = shelves.ShelfColumn{ID: uuid.New(), ShelfRowID: rowID, ColIndex: i}
+		slots[i] = shelves.ShelfSlot{ID: uuid.New(), ShelfID: shelfID, ShelfRowID: rowID, ShelfColumnID: columns[i].ID, ColIndex: i}
+	}
+	shelf := shelves.Shelf{ID: shelfID, OwnerID: testOwnerID, Name: "Hall", CreatedAt: time.Now().UTC()}
+	if _, err := shelfRepo.CreateShelf(ctx, shelf, []shelves.ShelfRow{{ID: rowID, ShelfID: shelfID}}, columns, slots); err != nil {
+		t.Fatalf("create shelf: %v", err)
+	}
+	for _, slot := range []uuid.UUID{slots[0].ID, slots[0].ID, slots[1].ID} {
+		if _, err := shelfRepo.AssignItemToSlot(ctx, shelfID, testOwnerID, slot, uuid.New()); err != nil {
+			t.Fatalf("assign item: %v", err)
+		}
+	}
+	if _, err := shelfRepo.UpsertUnplaced(ctx, shelfID, testOwnerID, uuid.New()); err != nil {
+		t.Fatalf("add unplaced item: %v", err)
+	}
+
+	stats, err := svc.Shelves(ctx, testOwnerID)
+	if err != nil {
+		t.Fatalf("shelves: %v", err)
+	}
+	if len(stats.Shelves) != 1 || stats.FillRate != 0.5 {
+		t.Fatalf("unexpected shelf stats %+v", stats)
+	}
+	fill := stats.Shelves[0]
+	if fill.SlotCount != 4 || fill.OccupiedSlots != 2 || fill.ItemCount != 4 || fill.PlacedCount != 3 {
+		t.Fatalf("unexpected shelf fill %+v", fill)
+	}
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE public.items
+    ADD COLUMN metadata_source text DEFAULT ''::text NOT NULL,
+    ADD COLUMN metadata_source_id text DEFAULT ''::text NOT NULL;
+
+UPDATE public.items
+SET metadata_source = 'google_books',
+    metadata_source_id = google_volume_id
+WHERE google_volume_id <> ''::text;
+
+-- +goose Down
+ALTER TABLE public.items
+    DROP COLUMN IF EXISTS metadata_source_id,
+    DROP COLUMN IF EXISTS metadata_source;
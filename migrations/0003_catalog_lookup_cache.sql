@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE public.catalog_lookup_cache (
+    provider text NOT NULL,
+    lookup_key text NOT NULL,
+    results jsonb DEFAULT '[]'::jsonb NOT NULL,
+    not_found boolean DEFAULT false NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT catalog_lookup_cache_pkey PRIMARY KEY (provider, lookup_key)
+);
+
+CREATE INDEX idx_catalog_lookup_cache_expires_at ON public.catalog_lookup_cache USING btree (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS public.catalog_lookup_cache;
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE public.items
+    ADD COLUMN tags text[] DEFAULT '{}'::text[] NOT NULL;
+
+CREATE INDEX idx_items_tags ON public.items USING gin (tags);
+
+CREATE TABLE public.tags (
+    owner_id uuid NOT NULL,
+    name text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT tags_pkey PRIMARY KEY (owner_id, name)
+);
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.tags;
+
+DROP INDEX IF EXISTS public.idx_items_tags;
+
+ALTER TABLE public.items
+    DROP COLUMN IF EXISTS tags;
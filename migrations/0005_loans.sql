@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE TABLE public.borrowers (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    name text NOT NULL,
+    contact text DEFAULT ''::text NOT NULL,
+    notes text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT borrowers_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX uq_borrowers_owner_name ON public.borrowers USING btree (owner_id, lower(name));
+
+ALTER TABLE ONLY public.borrowers
+    ADD CONSTRAINT borrowers_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+CREATE TABLE public.item_loans (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    borrower_id uuid,
+    borrower_name text NOT NULL,
+    lent_at timestamp with time zone NOT NULL,
+    due_at timestamp with time zone,
+    returned_at timestamp with time zone,
+    notes text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT item_loans_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_item_loans_owner_id ON public.item_loans USING btree (owner_id);
+
+CREATE INDEX idx_item_loans_item_id ON public.item_loans USING btree (item_id);
+
+CREATE UNIQUE INDEX uq_item_loans_active_item ON public.item_loans USING btree (item_id) WHERE (returned_at IS NULL);
+
+ALTER TABLE ONLY public.item_loans
+    ADD CONSTRAINT item_loans_item_id_fkey FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.item_loans
+    ADD CONSTRAINT item_loans_borrower_id_fkey FOREIGN KEY (borrower_id) REFERENCES public.borrowers(id) ON DELETE SET NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.item_loans;
+DROP TABLE IF EXISTS public.borrowers;
@@ -0,0 +1,52 @@
+-- +goose Up
+CREATE TABLE public.read_throughs (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    read_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT read_throughs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_read_throughs_item_id ON public.read_throughs USING btree (item_id);
+
+CREATE UNIQUE INDEX uq_read_throughs_open_item ON public.read_throughs USING btree (item_id) WHERE (read_at IS NULL);
+
+ALTER TABLE ONLY public.read_throughs
+    ADD CONSTRAINT read_throughs_item_id_fkey FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
+
+CREATE TABLE public.reading_sessions (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    read_through_id uuid NOT NULL,
+    session_date timestamp with time zone NOT NULL,
+    start_page integer NOT NULL,
+    end_page integer NOT NULL,
+    minutes integer,
+    notes text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT reading_sessions_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_reading_sessions_item_id ON public.reading_sessions USING btree (item_id);
+
+CREATE INDEX idx_reading_sessions_owner_date ON public.reading_sessions USING btree (owner_id, session_date);
+
+ALTER TABLE ONLY public.reading_sessions
+    ADD CONSTRAINT reading_sessions_item_id_fkey FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.reading_sessions
+    ADD CONSTRAINT reading_sessions_read_through_id_fkey FOREIGN KEY (read_through_id) REFERENCES public.read_throughs(id) ON DELETE CASCADE;
+
+-- Seed a completed read-through for books already marked as read so history starts populated.
+INSERT INTO public.read_throughs (id, owner_id, item_id, started_at, read_at)
+SELECT md5(random()::text || i.id::text)::uuid, i.owner_id, i.id, i.read_at, i.read_at
+FROM public.items i
+WHERE i.item_type = 'book' AND i.reading_status = 'read' AND i.read_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.reading_sessions;
+DROP TABLE IF EXISTS public.read_throughs;
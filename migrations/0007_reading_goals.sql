@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE public.reading_goals (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    year integer NOT NULL,
+    metric text NOT NULL,
+    target integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT reading_goals_pkey PRIMARY KEY (id),
+    CONSTRAINT reading_goals_target_check CHECK (target > 0)
+);
+
+CREATE UNIQUE INDEX uq_reading_goals_owner_year_metric ON public.reading_goals USING btree (owner_id, year, metric);
+
+ALTER TABLE ONLY public.reading_goals
+    ADD CONSTRAINT reading_goals_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.reading_goals;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE public.queue_entries (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    position integer NOT NULL,
+    status text DEFAULT 'queued'::text NOT NULL,
+    added_at timestamp with time zone DEFAULT now() NOT NULL,
+    started_at timestamp with time zone,
+    CONSTRAINT queue_entries_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX uq_queue_entries_item_id ON public.queue_entries USING btree (item_id);
+
+CREATE INDEX idx_queue_entries_owner_position ON public.queue_entries USING btree (owner_id, status, position);
+
+ALTER TABLE ONLY public.queue_entries
+    ADD CONSTRAINT queue_entries_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.queue_entries
+    ADD CONSTRAINT queue_entries_item_id_fkey FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.queue_entries;
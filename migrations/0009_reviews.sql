@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE public.reviews (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    title text DEFAULT ''::text NOT NULL,
+    body text DEFAULT ''::text NOT NULL,
+    sections jsonb DEFAULT '[]'::jsonb NOT NULL,
+    read_on date,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT reviews_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_reviews_owner_created ON public.reviews USING btree (owner_id, created_at DESC);
+
+CREATE INDEX idx_reviews_item_id ON public.reviews USING btree (item_id);
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_item_id_fkey FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.reviews;
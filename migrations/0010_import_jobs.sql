@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE public.import_jobs (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    filename text DEFAULT ''::text NOT NULL,
+    status text NOT NULL,
+    total_rows integer DEFAULT 0 NOT NULL,
+    processed_rows integer DEFAULT 0 NOT NULL,
+    summary jsonb DEFAULT '{}'::jsonb NOT NULL,
+    error text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT import_jobs_pkey PRIMARY KEY (id),
+    CONSTRAINT import_jobs_status_check CHECK (status IN ('queued', 'running', 'completed', 'cancelled', 'failed'))
+);
+
+CREATE INDEX idx_import_jobs_owner_created ON public.import_jobs USING btree (owner_id, created_at DESC);
+
+CREATE INDEX idx_import_jobs_unfinished ON public.import_jobs USING btree (status) WHERE status IN ('queued', 'running');
+
+ALTER TABLE ONLY public.import_jobs
+    ADD CONSTRAINT import_jobs_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.import_jobs;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE public.import_mappings (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    name text NOT NULL,
+    spec jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT import_mappings_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_import_mappings_owner_name ON public.import_mappings USING btree (owner_id, lower(name));
+
+ALTER TABLE ONLY public.import_mappings
+    ADD CONSTRAINT import_mappings_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.import_mappings;
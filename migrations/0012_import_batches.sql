@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE public.import_batches (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    label text DEFAULT ''::text NOT NULL,
+    items jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    rolled_back_at timestamp with time zone,
+    CONSTRAINT import_batches_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_import_batches_owner_created ON public.import_batches USING btree (owner_id, created_at DESC);
+
+ALTER TABLE ONLY public.import_batches
+    ADD CONSTRAINT import_batches_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.import_batches;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE public.user_identities (
+    user_id uuid NOT NULL,
+    provider text NOT NULL,
+    subject text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT user_identities_pkey PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON public.user_identities USING btree (user_id);
+
+ALTER TABLE ONLY public.user_identities
+    ADD CONSTRAINT user_identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+INSERT INTO public.user_identities (user_id, provider, subject, created_at)
+SELECT id, oauth_provider, oauth_provider_id, created_at
+FROM public.users;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.user_identities;
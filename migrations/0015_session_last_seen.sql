@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE public.user_sessions ADD COLUMN last_seen_at timestamp with time zone;
+
+UPDATE public.user_sessions SET last_seen_at = created_at;
+
+ALTER TABLE public.user_sessions
+    ALTER COLUMN last_seen_at SET DEFAULT now(),
+    ALTER COLUMN last_seen_at SET NOT NULL;
+
+-- +goose Down
+ALTER TABLE public.user_sessions DROP COLUMN IF EXISTS last_seen_at;
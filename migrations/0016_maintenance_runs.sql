@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE public.maintenance_runs (
+    id uuid NOT NULL,
+    job text NOT NULL,
+    status text NOT NULL,
+    affected bigint DEFAULT 0 NOT NULL,
+    error text DEFAULT ''::text NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone NOT NULL,
+    CONSTRAINT maintenance_runs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_maintenance_runs_job_started ON public.maintenance_runs USING btree (job, started_at DESC);
+
+CREATE INDEX idx_maintenance_runs_started ON public.maintenance_runs USING btree (started_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS public.maintenance_runs;
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE public.library_shares (
+    id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    shelf_id uuid,
+    email text NOT NULL,
+    grantee_id uuid,
+    role text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    accepted_at timestamp with time zone,
+    CONSTRAINT library_shares_pkey PRIMARY KEY (id),
+    CONSTRAINT library_shares_role_check CHECK (role IN ('viewer', 'editor'))
+);
+
+CREATE UNIQUE INDEX idx_library_shares_owner_email_shelf ON public.library_shares USING btree (owner_id, email, COALESCE(shelf_id, '00000000-0000-0000-0000-000000000000'::uuid));
+
+CREATE INDEX idx_library_shares_email ON public.library_shares USING btree (email);
+
+CREATE INDEX idx_library_shares_grantee_id ON public.library_shares USING btree (grantee_id);
+
+ALTER TABLE ONLY public.library_shares
+    ADD CONSTRAINT library_shares_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.library_shares
+    ADD CONSTRAINT library_shares_grantee_id_fkey FOREIGN KEY (grantee_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.library_shares
+    ADD CONSTRAINT library_shares_shelf_id_fkey FOREIGN KEY (shelf_id) REFERENCES public.shelves(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS public.library_shares;
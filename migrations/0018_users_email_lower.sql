@@ -0,0 +1,12 @@
+-- +goose Up
+-- Email addresses are matched without case when linking identities, so keep
+-- them unique the same way. This fails if two users already share an address
+-- in different case; merge those accounts first.
+DROP INDEX IF EXISTS public.uq_users_email;
+
+CREATE UNIQUE INDEX uq_users_email_lower ON public.users USING btree (lower(email));
+
+-- +goose Down
+DROP INDEX IF EXISTS public.uq_users_email_lower;
+
+CREATE UNIQUE INDEX uq_users_email ON public.users USING btree (email);
@@ -3,6 +3,7 @@ export interface CsvImportSummary {
     imported: number;
     skippedDuplicates: CsvImportDuplicate[];
     failed: CsvImportFailure[];
+    delayed?: CsvImportDelayed[];
 }
 
 export interface CsvImportDuplicate {
@@ -18,3 +19,11 @@ export interface CsvImportFailure {
     identifier?: string;
     error: string;
 }
+
+export interface CsvImportDelayed {
+    row: number;
+    title?: string;
+    identifier?: string;
+    retries: number;
+    waitedMs: number;
+}
@@ -20,6 +20,8 @@ export interface Item {
     rating?: number | null;
     retailPriceUsd?: number | null;
     googleVolumeId?: string;
+    metadataSource?: string;
+    metadataSourceId?: string;
     platform?: string;
     ageGroup?: string;
     playerCount?: string;
@@ -29,9 +31,11 @@ export interface Item {
     seriesName?: string;
     volumeNumber?: number | null;
     totalVolumes?: number | null;
+    tags?: string[];
     createdAt: string;
     updatedAt: string;
     shelfPlacement?: ShelfPlacementSummary;
+    activeLoan?: ActiveLoanSummary;
 }
 
 export interface ItemForm {
@@ -59,6 +63,13 @@ export interface ItemForm {
     seriesName?: string;
     volumeNumber?: number | null;
     totalVolumes?: number | null;
+    tags?: string[];
+}
+
+export interface Tag {
+    name: string;
+    itemCount: number;
+    createdAt: string;
 }
 
 export interface ShelfPlacementSummary {
@@ -68,3 +79,11 @@ export interface ShelfPlacementSummary {
     rowIndex: number;
     colIndex: number;
 }
+
+export interface ActiveLoanSummary {
+    loanId: string;
+    borrowerId?: string;
+    borrowerName: string;
+    lentAt: string;
+    dueAt?: string;
+}
@@ -8,6 +8,7 @@ import {
     computed,
     signal,
 } from '@angular/core';
+import { DecimalPipe } from '@angular/common';
 import { MatButtonModule } from '@angular/material/button';
 import { MatIconModule } from '@angular/material/icon';
 import { MatProgressSpinnerModule } from '@angular/material/progress-spinner';
@@ -29,7 +30,7 @@ interface CsvImportStatus {
 @Component({
     selector: 'app-csv-import',
     standalone: true,
-    imports: [MatButtonModule, MatIconModule, MatProgressSpinnerModule],
+    imports: [DecimalPipe, MatButtonModule, MatIconModule, MatProgressSpinnerModule],
     templateUrl: './csv-import.component.html',
     styleUrl: './csv-import.component.scss',
 })